@@ -1,41 +1,301 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
+	"maps"
+	"math/big"
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"syscall"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/igwedaniel/artizan/internal/adapters/chain"
 	"github.com/igwedaniel/artizan/internal/adapters/eventbus"
 	"github.com/igwedaniel/artizan/internal/adapters/http"
+	"github.com/igwedaniel/artizan/internal/adapters/mailer"
+	"github.com/igwedaniel/artizan/internal/adapters/pinning"
 	"github.com/igwedaniel/artizan/internal/adapters/repositories"
+	"github.com/igwedaniel/artizan/internal/adapters/signer"
 	"github.com/igwedaniel/artizan/internal/config"
 	"github.com/igwedaniel/artizan/internal/eventhandlers"
+	chainInterfaces "github.com/igwedaniel/artizan/internal/interfaces/chain"
+	busInterfaces "github.com/igwedaniel/artizan/internal/interfaces/eventbus"
+	signerInterfaces "github.com/igwedaniel/artizan/internal/interfaces/signer"
+	"github.com/igwedaniel/artizan/internal/models"
 	"github.com/igwedaniel/artizan/internal/services"
+	"github.com/igwedaniel/artizan/pkg/ignition"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
+// main runs the API server, with the indexer command only the chain indexer,
+// with reconcile compares indexed balances with balanceOf and exits, and with
+// import-deployments checks Ignition deployments on chain and prints the
+// chains file entries for them:
+//
+//	artizan [serve]
+//	artizan indexer
+//	artizan reconcile [-chain id] [-fix]
+//	artizan import-deployments [-dir contracts/ignition/deployments]
 func main() {
+	command := "serve"
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
+	if command != "serve" && command != "indexer" && command != "reconcile" && command != "import-deployments" {
+		log.Fatalf("unknown command %q, expected serve, indexer, reconcile or import-deployments", command)
+	}
+
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
 	}
+	chainConfigs, err := cfg.Chains()
+	if err != nil {
+		log.Fatalf("failed to load chains: %v", err)
+	}
+	if command == "import-deployments" {
+		importDeployments(chainConfigs, cfg.IgnitionDeploymentsDir, os.Args[2:])
+		return
+	}
 
-	db, err := gorm.Open(postgres.Open(cfg.DbUrl), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(cfg.DbUrl), &gorm.Config{TranslateError: true})
 	if err != nil {
 		log.Fatalf("failed to connect to database: %v", err)
 	}
 
+	if err := db.AutoMigrate(
+		&models.User{},
+		&models.Follow{},
+		&models.AuthNonce{},
+		&models.Notification{},
+		&models.NotificationPreference{},
+		&models.EmailMessage{},
+		&models.EmailUnsubscribe{},
+		&models.Collection{},
+		&models.Drop{},
+		&models.DropEvent{},
+		&models.DropSubscription{},
+		&models.Allowlist{},
+		&models.AllowlistEntry{},
+		&models.DropReservation{},
+		&models.NFT{},
+		&models.Voucher{},
+		&models.SignerKey{},
+		&models.SignatureAudit{},
+		&models.Asset{},
+		&models.ImportJob{},
+		&models.TokenTransfer{},
+		&models.ChainCheckpoint{},
+		&models.IndexedBlock{},
+		&models.TokenBalance{},
+		&models.Order{},
+		&models.Sale{},
+		&models.Offer{},
+		&models.RelayTransaction{},
+		&models.RelayNonce{},
+	); err != nil {
+		log.Fatalf("failed to migrate database: %v", err)
+	}
+	if err := migrateCollectionChains(db, cfg.ChainId); err != nil {
+		log.Fatalf("failed to migrate collection chains: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	chains, err := openChains(ctx, chainConfigs, cfg.IgnitionDeploymentsDir)
+	if err != nil {
+		log.Fatalf("failed to open chains: %v", err)
+	}
+
 	userRepo := repositories.NewGormUserRepository(db)
+	followRepo := repositories.NewGormFollowRepository(db)
 	authNonceRepo := repositories.NewGormAuthNonceRepository(db)
+	notificationRepo := repositories.NewGormNotificationRepository(db)
+	emailRepo := repositories.NewGormEmailRepository(db)
+	collectionRepo := repositories.NewGormCollectionRepository(db)
+	nftRepo := repositories.NewGormNFTRepository(db)
+	dropRepo := repositories.NewGormDropRepository(db)
+	allowlistRepo := repositories.NewGormAllowlistRepository(db)
+	reservationRepo := repositories.NewGormDropReservationRepository(db)
+	assetRepo := repositories.NewGormAssetRepository(db)
+	voucherRepo := repositories.NewGormVoucherRepository(db)
+	signerRepo := repositories.NewGormSignerRepository(db)
+	importRepo := repositories.NewGormImportJobRepository(db)
+	transferRepo := repositories.NewGormTokenTransferRepository(db)
+	checkpointRepo := repositories.NewGormChainCheckpointRepository(db)
+	balanceRepo := repositories.NewGormTokenBalanceRepository(db)
+	orderRepo := repositories.NewGormOrderRepository(db)
+	saleRepo := repositories.NewGormSaleRepository(db)
+	offerRepo := repositories.NewGormOfferRepository(db)
+	relayRepo := repositories.NewGormRelayTransactionRepository(db)
 	eventBus := eventbus.New()
 
+	smtpMailer, err := mailer.NewSMTPMailer(cfg.SmtpHost, cfg.SmtpPort, cfg.SmtpUsername, cfg.SmtpPassword, cfg.EmailFrom)
+	if err != nil {
+		log.Fatalf("failed to configure mailer: %v", err)
+	}
+	emailService, err := services.NewEmailService(cfg.JwtSecret, cfg.AppBaseUrl, emailRepo, userRepo, followRepo, dropRepo, smtpMailer)
+	if err != nil {
+		log.Fatalf("failed to create email service: %v", err)
+	}
+
+	// every signature made with these keys is audited
+	signerService := services.NewSignerService(signerRepo)
+	deployerSigner, err := openSigner(ctx, signerService, models.SignerPurposeDeployer, signer.Options{
+		Backend:          cfg.DeployerSignerBackend,
+		PrivateKey:       cfg.DeployerPrivateKey,
+		KeystorePath:     cfg.DeployerKeystorePath,
+		KeystorePassword: cfg.DeployerKeystorePassword,
+		RemoteURL:        cfg.DeployerRemoteSignerUrl,
+		RemoteToken:      cfg.DeployerRemoteSignerToken,
+	})
+	if err != nil {
+		log.Fatalf("failed to open deployer signer: %v", err)
+	}
+	voucherSigner, err := openSigner(ctx, signerService, models.SignerPurposeVoucher, signer.Options{
+		Backend:          cfg.VoucherSignerBackend,
+		PrivateKey:       cfg.VoucherSignerPrivateKey,
+		KeystorePath:     cfg.VoucherSignerKeystorePath,
+		KeystorePassword: cfg.VoucherSignerKeystorePassword,
+		RemoteURL:        cfg.VoucherRemoteSignerUrl,
+		RemoteToken:      cfg.VoucherRemoteSignerToken,
+	})
+	if err != nil {
+		log.Fatalf("failed to open voucher signer: %v", err)
+	}
+
+	assetService := services.NewAssetService(assetRepo, pinning.NewKuboPinner(cfg.IpfsApiUrl, cfg.IpfsApiToken), cfg.AssetMaxBytes)
+	var voucherAssets *services.AssetService
+	if cfg.VoucherMetadata == "ipfs" {
+		voucherAssets = assetService
+	}
+
+	allowlistService := services.NewAllowlistService(dropRepo, allowlistRepo)
+	supplyService := services.NewSupplyService(reservationRepo, cfg.DropReservationTTL)
+	voucherService := services.NewVoucherService(nftRepo, voucherRepo, collectionRepo, voucherSigner,
+		cfg.VoucherTTL, cfg.AppBaseUrl, voucherAssets, allowlistService, supplyService)
+	deploymentService := services.NewDeploymentService(collectionRepo, chains, eventBus, deployerSigner,
+		voucherService.SignerAddress())
+	tokenIDAllocator := services.NewTokenIDAllocator(dropRepo)
+
 	svcs := &http.Services{
-		AuthService: services.NewAuthService(cfg.JwtSecret, userRepo, authNonceRepo),
-		UserService: services.NewUserService(userRepo),
+		AuthService:         services.NewAuthService(cfg.JwtSecret, userRepo, authNonceRepo),
+		UserService:         services.NewUserService(userRepo, followRepo, eventBus),
+		NotificationService: services.NewNotificationService(notificationRepo),
+		EmailService:        emailService,
+		ChainService:        services.NewChainService(chains),
+		CollectionService:   services.NewCollectionService(collectionRepo, chains),
+		DropService:         services.NewDropService(dropRepo, collectionRepo, eventBus),
+		AllowlistService:    allowlistService,
+		SupplyService:       supplyService,
+		DeploymentService:   deploymentService,
+		VoucherService:      voucherService,
+		SignerService:       signerService,
+		NFTService:          services.NewNFTService(nftRepo, dropRepo, voucherRepo, tokenIDAllocator),
+		AssetService:        assetService,
+		ImportService:       services.NewImportService(importRepo, nftRepo, dropRepo, tokenIDAllocator, assetService, cfg.ImportMaxBytes),
+		OwnershipService:    services.NewOwnershipService(balanceRepo, userRepo, nftRepo, collectionRepo, checkpointRepo, chains),
+		ListingService: services.NewListingService(nftRepo, voucherService, chains,
+			cfg.MarketplaceFeeRecipient, cfg.MarketplaceFeeBasisPoints, cfg.ListingTTL),
+		OrderService: services.NewOrderService(orderRepo, nftRepo, chains),
+		SaleService: services.NewSaleService(saleRepo, orderRepo, offerRepo, nftRepo, collectionRepo, userRepo, eventBus,
+			cfg.MarketplaceFeeRecipient),
+		OfferService: services.NewOfferService(offerRepo, nftRepo, collectionRepo, balanceRepo, userRepo, eventBus, chains,
+			cfg.MarketplaceFeeRecipient, cfg.MarketplaceFeeBasisPoints, cfg.OfferTTL),
+	}
+	if cfg.RelayerEnabled && command == "serve" {
+		relayerSigner, err := openSigner(ctx, signerService, models.SignerPurposeRelayer, signer.Options{
+			Backend:          cfg.RelayerSignerBackend,
+			PrivateKey:       cfg.RelayerPrivateKey,
+			KeystorePath:     cfg.RelayerKeystorePath,
+			KeystorePassword: cfg.RelayerKeystorePassword,
+			RemoteURL:        cfg.RelayerRemoteSignerUrl,
+			RemoteToken:      cfg.RelayerRemoteSignerToken,
+		})
+		if err != nil {
+			log.Fatalf("failed to open relayer signer: %v", err)
+		}
+		svcs.RelayerService = services.NewRelayerService(relayRepo, nftRepo, voucherRepo, chains, relayerSigner,
+			services.RelayPolicy{
+				CreatorBudget:  weiLimit("RELAYER_CREATOR_BUDGET", cfg.RelayerCreatorBudget),
+				UserBudget:     weiLimit("RELAYER_USER_BUDGET", cfg.RelayerUserBudget),
+				BudgetWindow:   cfg.RelayerBudgetWindow,
+				StuckAfter:     cfg.RelayerStuckAfter,
+				FeeBumpPercent: cfg.RelayerFeeBumpPercent,
+				MaxFeeCap:      weiLimit("RELAYER_MAX_FEE_CAP", cfg.RelayerMaxFeeCap),
+			})
+	}
+	if command == "reconcile" {
+		reconcile(ctx, svcs.OwnershipService, os.Args[2:])
+		return
 	}
 
 	// Example: subscribe to a user.created event
-	eventBus.Subscribe(eventhandlers.EventUserCreated, eventhandlers.NewHandleUserCreatedEvent(svcs.UserService))
+	eventBus.Subscribe(busInterfaces.EventUserCreated, eventhandlers.NewHandleUserCreatedEvent(svcs.UserService))
+
+	eventBus.Subscribe(busInterfaces.EventSaleCompleted, eventhandlers.NewHandleSaleCompletedNotification(svcs.NotificationService))
+	eventBus.Subscribe(busInterfaces.EventOfferReceived, eventhandlers.NewHandleOfferReceivedNotification(svcs.NotificationService))
+	eventBus.Subscribe(busInterfaces.EventDropStarted, eventhandlers.NewHandleDropStartedNotification(svcs.NotificationService))
+	eventBus.Subscribe(busInterfaces.EventUserFollowed, eventhandlers.NewHandleUserFollowedNotification(svcs.NotificationService))
+	eventBus.Subscribe(busInterfaces.EventCreatorApproved, eventhandlers.NewHandleCreatorApprovedNotification(svcs.NotificationService))
+	eventBus.Subscribe(busInterfaces.EventSaleCompleted, eventhandlers.NewHandleSaleCompletedEmail(svcs.EmailService))
+	eventBus.Subscribe(busInterfaces.EventDropStarted, eventhandlers.NewHandleDropStartedEmail(svcs.EmailService))
+	eventBus.Subscribe(busInterfaces.EventCollectionSignerUpdated, eventhandlers.NewHandleCollectionSignerUpdatedVouchers(svcs.VoucherService))
+
+	// one indexer follows each chain
+	indexers := make([]*services.IndexerService, len(chainConfigs))
+	for i, chainCfg := range chainConfigs {
+		network, err := chains.Network(chainCfg.ChainID)
+		if err != nil {
+			log.Fatalf("failed to create indexer: %v", err)
+		}
+		indexers[i], err = services.NewIndexerService(network.Client, collectionRepo, nftRepo, voucherRepo, transferRepo, checkpointRepo,
+			eventBus, network.ChainID, chainCfg.IndexerStartBlock, chainCfg.IndexerConfirmations, cfg.IndexerBlockRange)
+		if err != nil {
+			log.Fatalf("failed to create indexer for chain %d: %v", network.ChainID, err)
+		}
+		indexers[i].AddLogWatcher(svcs.SaleService.Watcher(network))
+		indexers[i].AddRollbackHook(svcs.SaleService.Rollback)
+	}
+	if command == "indexer" {
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		var wg sync.WaitGroup
+		for i, indexer := range indexers {
+			log.Printf("indexing chain %d", chainConfigs[i].ChainID)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				indexer.Run(ctx)
+			}()
+		}
+		wg.Wait()
+		return
+	}
+
+	go svcs.EmailService.Run(ctx)
+	go svcs.DeploymentService.Run(ctx)
+	go svcs.DropService.Run(ctx)
+	go svcs.SupplyService.Run(ctx)
+	go svcs.AssetService.Run(ctx)
+	go svcs.ImportService.Run(ctx)
+	go svcs.OrderService.Run(ctx)
+	go svcs.OfferService.Run(ctx)
+	if svcs.RelayerService != nil {
+		go svcs.RelayerService.Run(ctx)
+	}
+	if cfg.IndexerEnabled {
+		for _, indexer := range indexers {
+			go indexer.Run(ctx)
+		}
+	}
 
 	e := http.NewServer(svcs)
 
@@ -43,3 +303,136 @@ func main() {
 		log.Fatalf("failed to start server: %v", err)
 	}
 }
+
+// openChains connects to the RPC endpoint of every chain and, with a
+// deployments folder, registers the contracts Ignition deployed on them
+func openChains(ctx context.Context, chainConfigs []config.ChainConfig, deploymentsDir string) (chainInterfaces.Registry, error) {
+	networks := make([]*chainInterfaces.Network, len(chainConfigs))
+	for i, chainCfg := range chainConfigs {
+		networks[i] = &chainInterfaces.Network{
+			ChainID:       chainCfg.ChainID,
+			Name:          chainCfg.Name,
+			Confirmations: chainCfg.Confirmations,
+			NativeCurrency: chainInterfaces.NativeCurrency{
+				Name:     chainCfg.NativeCurrency.Name,
+				Symbol:   chainCfg.NativeCurrency.Symbol,
+				Decimals: chainCfg.NativeCurrency.Decimals,
+			},
+			Seaport:       common.HexToAddress(chainCfg.SeaportAddress),
+			ConduitKey:    common.HexToHash(chainCfg.SeaportConduitKey),
+			Zone:          common.HexToAddress(chainCfg.ZoneAddress),
+			Factory:       common.HexToAddress(chainCfg.FactoryAddress),
+			WrappedNative: common.HexToAddress(chainCfg.WrappedNativeAddress),
+		}
+		if err := chain.Dial(ctx, chainCfg.RpcUrl, networks[i]); err != nil {
+			return nil, err
+		}
+	}
+	registry, err := chain.NewRegistry(networks...)
+	if err != nil || deploymentsDir == "" {
+		return registry, err
+	}
+
+	deployments, err := ignition.LoadAll(deploymentsDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, deployment := range deployments {
+		network, err := registry.Network(deployment.ChainID)
+		if err != nil {
+			log.Printf("skipping Ignition deployment of chain %d, which is not configured", deployment.ChainID)
+			continue
+		}
+		if err := chain.RegisterDeployment(ctx, network, deployment); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+// importDeployments checks the Ignition deployments against every chain and
+// prints the chains, with the deployed zones, as a CHAINS_FILE
+func importDeployments(chainConfigs []config.ChainConfig, deploymentsDir string, args []string) {
+	flags := flag.NewFlagSet("import-deployments", flag.ExitOnError)
+	dir := flags.String("dir", deploymentsDir, "Hardhat Ignition deployments folder")
+	flags.Parse(args)
+	if *dir == "" {
+		log.Fatal("-dir or IGNITION_DEPLOYMENTS_DIR is required")
+	}
+
+	ctx := context.Background()
+	chains, err := openChains(ctx, chainConfigs, *dir)
+	if err != nil {
+		log.Fatalf("failed to import deployments: %v", err)
+	}
+	for i, network := range chains.Networks() {
+		if len(network.Contracts) == 0 {
+			log.Printf("chain %d: no deployment", network.ChainID)
+			continue
+		}
+		for _, name := range slices.Sorted(maps.Keys(network.Contracts)) {
+			log.Printf("chain %d: %s at %s verified", network.ChainID, name, network.Contracts[name].Address.Hex())
+		}
+		chainConfigs[i].ZoneAddress = network.Zone.Hex()
+	}
+	out, err := json.MarshalIndent(chainConfigs, "", "  ")
+	if err != nil {
+		log.Fatalf("failed to encode chains: %v", err)
+	}
+	fmt.Println(string(out))
+}
+
+// migrateCollectionChains puts collections created before chains were
+// configurable on the default chain and drops the contract index that
+// ignored the chain
+func migrateCollectionChains(db *gorm.DB, defaultChainID int64) error {
+	if err := db.Model(&models.Collection{}).Where("chain_id = 0").Update("chain_id", defaultChainID).Error; err != nil {
+		return err
+	}
+	if db.Migrator().HasIndex(&models.Collection{}, "idx_collections_contract_address") {
+		return db.Migrator().DropIndex(&models.Collection{}, "idx_collections_contract_address")
+	}
+	return nil
+}
+
+// openSigner opens a key backend and registers it as the active key for purpose
+func openSigner(ctx context.Context, signerService *services.SignerService, purpose string, opts signer.Options) (signerInterfaces.Signer, error) {
+	s, err := signer.Open(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return signerService.Activate(purpose, opts.Backend, s)
+}
+
+// weiLimit parses an optional wei amount from the config; empty is no limit
+func weiLimit(name, value string) *big.Int {
+	if value == "" {
+		return nil
+	}
+	limit, ok := new(big.Int).SetString(value, 10)
+	if !ok || limit.Sign() < 0 {
+		log.Fatalf("%s must be an amount in wei", name)
+	}
+	return limit
+}
+
+// reconcile prints the balances that disagree with the chain, fixing them with -fix
+func reconcile(ctx context.Context, ownershipService *services.OwnershipService, args []string) {
+	flags := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	chainID := flags.Int64("chain", 0, "chain to reconcile, the default chain when 0")
+	fix := flags.Bool("fix", false, "overwrite stored balances with the on-chain value")
+	flags.Parse(args)
+
+	report, err := ownershipService.Reconcile(ctx, *chainID, *fix)
+	if err != nil {
+		log.Fatalf("failed to reconcile balances: %v", err)
+	}
+	for _, m := range report.Mismatches {
+		fmt.Printf("%s #%s %s: stored %s, on chain %s\n", m.ContractAddress, m.TokenID, m.OwnerAddress, m.Stored, m.OnChain)
+	}
+	fmt.Printf("checked %d balances at block %d on chain %d: %d mismatched, %d fixed\n",
+		report.Checked, report.Block, report.ChainID, len(report.Mismatches), report.Fixed)
+	if len(report.Mismatches) > report.Fixed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,54 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	chainInterfaces "github.com/igwedaniel/artizan/internal/interfaces/chain"
+	"github.com/igwedaniel/artizan/pkg/ignition"
+)
+
+// the contracts every Ignition deployment must provide
+var requiredContracts = []string{chainInterfaces.ContractLazyMint1155, chainInterfaces.ContractLazyMintZone}
+
+// RegisterDeployment checks that the latest LazyMint1155 and LazyMintZone of
+// an Ignition deployment have the artifact's code on network and registers
+// them. A zone configured for network must be the deployed one; an unset
+// zone is taken from the deployment.
+func RegisterDeployment(ctx context.Context, network *chainInterfaces.Network, deployment *ignition.Deployment) error {
+	if deployment.ChainID != network.ChainID {
+		return fmt.Errorf("deployment of chain %d cannot be registered on chain %d", deployment.ChainID, network.ChainID)
+	}
+	contracts := make(map[string]*chainInterfaces.Contract, len(requiredContracts))
+	for _, name := range requiredContracts {
+		deployed := deployment.Latest(name)
+		if deployed == nil {
+			return fmt.Errorf("chain %d has no %s deployment in %s", network.ChainID, name, deployment.Dir)
+		}
+		code, err := network.Client.CodeAt(ctx, deployed.Address, nil)
+		if err != nil {
+			return fmt.Errorf("failed to read code of %s on chain %d: %w", deployed.FutureID, network.ChainID, err)
+		}
+		if len(code) == 0 {
+			return fmt.Errorf("%s on chain %d: no code at %s", deployed.FutureID, network.ChainID, deployed.Address.Hex())
+		}
+		if !deployed.MatchesCode(code) {
+			return fmt.Errorf("%s on chain %d: code at %s does not match the artifact", deployed.FutureID, network.ChainID, deployed.Address.Hex())
+		}
+		contracts[name] = &chainInterfaces.Contract{Address: deployed.Address, ABI: deployed.ABI}
+	}
+
+	zone := contracts[chainInterfaces.ContractLazyMintZone].Address
+	if network.Zone != (common.Address{}) && network.Zone != zone {
+		return fmt.Errorf("chain %d is configured with zone %s but %s was deployed", network.ChainID, network.Zone.Hex(), zone.Hex())
+	}
+	network.Zone = zone
+	if network.Contracts == nil {
+		network.Contracts = make(map[string]*chainInterfaces.Contract, len(contracts))
+	}
+	for name, contract := range contracts {
+		network.Contracts[name] = contract
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/igwedaniel/artizan/internal/adapters/signer"
+	chainInterfaces "github.com/igwedaniel/artizan/internal/interfaces/chain"
+	"github.com/igwedaniel/artizan/pkg/contracts"
+	"github.com/igwedaniel/artizan/pkg/ignition"
+)
+
+// the Hardhat artifacts the bindings of pkg/contracts are generated from;
+// lazyMintZone#LazyMintZone is an earlier build of the zone
+const artifactsDir = "../../../../contracts/ignition/deployments/chain-97/artifacts"
+
+type ignitionTest struct {
+	network    *chainInterfaces.Network
+	collection common.Address
+	zone       common.Address
+}
+
+// newIgnitionTest deploys the collection and zone bindings on a simulated
+// chain
+func newIgnitionTest(t *testing.T) *ignitionTest {
+	t.Helper()
+	deployer, err := signer.GenerateMemorySigner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := simulated.NewBackend(types.GenesisAlloc{deployer.Address(): {Balance: new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18))}})
+	t.Cleanup(func() { backend.Close() })
+
+	opts := &bind.TransactOpts{
+		From: deployer.Address(),
+		Signer: func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return deployer.SignTx(context.Background(), tx, big.NewInt(1337))
+		},
+	}
+	collection, _, _, err := contracts.DeployLazyMint1155(opts, backend.Client(), deployer.Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	zone, _, _, err := contracts.DeployLazyMintZone(opts, backend.Client(), deployer.Address(), collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend.Commit()
+	return &ignitionTest{
+		network:    &chainInterfaces.Network{ChainID: 1337, Name: "simulated", Client: backend.Client(), Confirmations: 1},
+		collection: collection,
+		zone:       zone,
+	}
+}
+
+// load writes a chain-1337 deployment of the collection and of the zone
+// built as zoneFuture, completed in that order, and loads it
+func (it *ignitionTest) load(t *testing.T, zoneFuture string) *ignition.Deployment {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "chain-1337")
+	if err := os.MkdirAll(filepath.Join(dir, "artifacts"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	futures := []string{"LazyMint1155ModuleV1#LazyMint1155", zoneFuture}
+	addresses := map[string]string{futures[0]: it.collection.Hex(), futures[1]: it.zone.Hex()}
+	journal := []string{`{"chainId":1337,"type":"DEPLOYMENT_INITIALIZE"}`}
+	for _, futureID := range futures {
+		raw, err := os.ReadFile(filepath.Join(artifactsDir, futureID+".json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "artifacts", futureID+".json"), raw, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		journal = append(journal, fmt.Sprintf(`{"futureId":%q,"result":{"type":"SUCCESS"},"type":"DEPLOYMENT_EXECUTION_STATE_COMPLETE"}`, futureID))
+	}
+	raw, err := json.Marshal(addresses)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "deployed_addresses.json"), raw, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "journal.jsonl"), []byte(strings.Join(journal, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	deployment, err := ignition.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return deployment
+}
+
+func TestRegisterDeployment(t *testing.T) {
+	it := newIgnitionTest(t)
+	deployment := it.load(t, "lazyMintZoneV3#LazyMintZone")
+
+	if err := RegisterDeployment(context.Background(), it.network, deployment); err != nil {
+		t.Fatal(err)
+	}
+	if it.network.Zone != it.zone {
+		t.Fatalf("zone = %s, want the deployed %s", it.network.Zone.Hex(), it.zone.Hex())
+	}
+	for name, want := range map[string]common.Address{
+		chainInterfaces.ContractLazyMint1155: it.collection,
+		chainInterfaces.ContractLazyMintZone: it.zone,
+	} {
+		if c := it.network.Contracts[name]; c == nil || c.Address != want || len(c.ABI.Methods) == 0 {
+			t.Fatalf("%s registered as %+v, want %s with its abi", name, c, want.Hex())
+		}
+	}
+}
+
+func TestRegisterDeploymentFailsOnMismatchedCode(t *testing.T) {
+	it := newIgnitionTest(t)
+	deployment := it.load(t, "lazyMintZone#LazyMintZone")
+
+	err := RegisterDeployment(context.Background(), it.network, deployment)
+	if err == nil || !strings.Contains(err.Error(), "does not match the artifact") {
+		t.Fatalf("err = %v, want the zone's code rejected", err)
+	}
+	if it.network.Contracts != nil || it.network.Zone != (common.Address{}) {
+		t.Fatalf("registered %v with zone %s from a deployment that failed its check", it.network.Contracts, it.network.Zone.Hex())
+	}
+}
+
+func TestRegisterDeploymentChecksTheNetwork(t *testing.T) {
+	it := newIgnitionTest(t)
+	deployment := it.load(t, "lazyMintZoneV3#LazyMintZone")
+	ctx := context.Background()
+
+	other := *it.network
+	other.ChainID = 97
+	if err := RegisterDeployment(ctx, &other, deployment); err == nil {
+		t.Fatal("registered the deployment of chain 1337 on chain 97")
+	}
+
+	configured := *it.network
+	configured.Zone = common.HexToAddress("0x00000000000000000000000000000000000000f1")
+	if err := RegisterDeployment(ctx, &configured, deployment); err == nil || !strings.Contains(err.Error(), "configured with zone") {
+		t.Fatalf("err = %v, want the configured zone to disagree with the deployed one", err)
+	}
+}
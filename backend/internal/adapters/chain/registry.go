@@ -0,0 +1,66 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	chainInterfaces "github.com/igwedaniel/artizan/internal/interfaces/chain"
+)
+
+type registry struct {
+	networks []*chainInterfaces.Network
+	byID     map[int64]*chainInterfaces.Network
+}
+
+// NewRegistry serves networks by chain ID. The first network is the default.
+func NewRegistry(networks ...*chainInterfaces.Network) (chainInterfaces.Registry, error) {
+	if len(networks) == 0 {
+		return nil, fmt.Errorf("no chains configured")
+	}
+	r := &registry{byID: make(map[int64]*chainInterfaces.Network, len(networks))}
+	for _, network := range networks {
+		if _, ok := r.byID[network.ChainID]; ok {
+			return nil, fmt.Errorf("chain %d is configured twice", network.ChainID)
+		}
+		r.byID[network.ChainID] = network
+		r.networks = append(r.networks, network)
+	}
+	return r, nil
+}
+
+func (r *registry) Network(chainID int64) (*chainInterfaces.Network, error) {
+	network, ok := r.byID[chainID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", chainInterfaces.ErrUnknownChain, chainID)
+	}
+	return network, nil
+}
+
+func (r *registry) Default() *chainInterfaces.Network {
+	return r.networks[0]
+}
+
+func (r *registry) Networks() []*chainInterfaces.Network {
+	return r.networks
+}
+
+// Dial connects network to rpcURL and checks the endpoint serves the chain
+// it is configured as
+func Dial(ctx context.Context, rpcURL string, network *chainInterfaces.Network) error {
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to chain %d: %w", network.ChainID, err)
+	}
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		client.Close()
+		return fmt.Errorf("failed to read chain id of chain %d: %w", network.ChainID, err)
+	}
+	if !chainID.IsInt64() || chainID.Int64() != network.ChainID {
+		client.Close()
+		return fmt.Errorf("rpc endpoint of chain %d serves chain %s", network.ChainID, chainID)
+	}
+	network.Client = client
+	return nil
+}
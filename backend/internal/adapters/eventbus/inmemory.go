@@ -1,6 +1,8 @@
 package eventbus
 
 import (
+	"errors"
+	"log"
 	"sync"
 
 	interfaces "github.com/igwedaniel/artizan/internal/interfaces/eventbus"
@@ -32,6 +34,31 @@ func (b *inMemoryBus) Publish(eventType string, data interface{}) {
 		Data:      data,
 	}
 	for _, h := range handlers {
-		go h(event)
+		go func() {
+			if err := h(event); err != nil {
+				log.Printf("failed to handle %s: %v", eventType, err)
+			}
+		}()
 	}
 }
+
+func (b *inMemoryBus) PublishAndWait(eventType string, data interface{}) error {
+	b.mu.RLock()
+	handlers := b.handlers[eventType]
+	b.mu.RUnlock()
+	event := interfaces.Event{
+		EventType: eventType,
+		Data:      data,
+	}
+	errs := make([]error, len(handlers))
+	var wg sync.WaitGroup
+	for i, h := range handlers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = h(event)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
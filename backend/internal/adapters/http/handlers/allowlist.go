@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/igwedaniel/artizan/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+type AllowlistHandler struct {
+	AllowlistService *services.AllowlistService
+}
+
+// NewAllowlistHandler creates a new AllowlistHandler
+func NewAllowlistHandler(allowlistService *services.AllowlistService) *AllowlistHandler {
+	return &AllowlistHandler{
+		AllowlistService: allowlistService,
+	}
+}
+
+// POST /drops/:id/allowlist (protected)
+func (h *AllowlistHandler) Upload(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	dropID, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid drop id"})
+	}
+	var req services.UploadAllowlistInput
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+	allowlist, err := h.AllowlistService.Upload(user, dropID, req)
+	if err != nil {
+		return c.JSON(allowlistErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusCreated, allowlist)
+}
+
+// GET /drops/:id/allowlist
+func (h *AllowlistHandler) Current(c echo.Context) error {
+	dropID, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid drop id"})
+	}
+	allowlist, err := h.AllowlistService.Current(dropID)
+	if err != nil {
+		return c.JSON(allowlistErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, allowlist)
+}
+
+// GET /drops/:id/allowlist/versions (protected)
+func (h *AllowlistHandler) ListVersions(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	dropID, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid drop id"})
+	}
+	versions, err := h.AllowlistService.ListVersions(user, dropID)
+	if err != nil {
+		return c.JSON(allowlistErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, versions)
+}
+
+// GET /drops/:id/allowlist/proof?wallet=0x...
+func (h *AllowlistHandler) Proof(c echo.Context) error {
+	dropID, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid drop id"})
+	}
+	proof, err := h.AllowlistService.Proof(dropID, c.QueryParam("wallet"))
+	if err != nil {
+		return c.JSON(allowlistErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, proof)
+}
+
+func allowlistErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrAllowlistNotFound),
+		errors.Is(err, services.ErrNotAllowlisted):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrDropNotPrivate),
+		errors.Is(err, services.ErrDropClosed):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrInvalidAllowlist),
+		errors.Is(err, services.ErrAllowlistTooLarge),
+		errors.Is(err, services.ErrInvalidAllowlistWallet):
+		return http.StatusBadRequest
+	}
+	return dropErrorStatus(err)
+}
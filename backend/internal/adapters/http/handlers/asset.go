@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/igwedaniel/artizan/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+type AssetHandler struct {
+	AssetService *services.AssetService
+}
+
+// NewAssetHandler creates a new AssetHandler
+func NewAssetHandler(assetService *services.AssetService) *AssetHandler {
+	return &AssetHandler{
+		AssetService: assetService,
+	}
+}
+
+// POST /assets (protected), multipart form with a "file" field
+func (h *AssetHandler) Upload(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	header, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "file is required"})
+	}
+	file, err := header.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid file"})
+	}
+	defer file.Close()
+
+	asset, err := h.AssetService.Upload(c.Request().Context(), user, header.Filename, header.Header.Get("Content-Type"), file)
+	if err != nil {
+		return c.JSON(assetErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusCreated, asset)
+}
+
+// GET /assets/:cid
+func (h *AssetHandler) Get(c echo.Context) error {
+	asset, err := h.AssetService.GetByCID(c.Param("cid"))
+	if err != nil {
+		return c.JSON(assetErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, asset)
+}
+
+func assetErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrAssetNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrEmptyAsset):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrAssetTooLarge):
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusInternalServerError
+}
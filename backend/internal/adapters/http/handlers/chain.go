@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/igwedaniel/artizan/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+type ChainHandler struct {
+	ChainService *services.ChainService
+}
+
+// NewChainHandler creates a new ChainHandler
+func NewChainHandler(chainService *services.ChainService) *ChainHandler {
+	return &ChainHandler{
+		ChainService: chainService,
+	}
+}
+
+// GET /chains
+func (h *ChainHandler) List(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.ChainService.List())
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/igwedaniel/artizan/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+type CollectionHandler struct {
+	CollectionService *services.CollectionService
+}
+
+// NewCollectionHandler creates a new CollectionHandler
+func NewCollectionHandler(collectionService *services.CollectionService) *CollectionHandler {
+	return &CollectionHandler{
+		CollectionService: collectionService,
+	}
+}
+
+// POST /collections (protected)
+func (h *CollectionHandler) Create(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	var req services.CreateCollectionInput
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+	collection, err := h.CollectionService.Create(user, req)
+	if err != nil {
+		return c.JSON(collectionErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusCreated, collection)
+}
+
+// GET /collections/:id
+func (h *CollectionHandler) Get(c echo.Context) error {
+	id, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid collection id"})
+	}
+	collection, err := h.CollectionService.GetByID(id)
+	if err != nil {
+		return c.JSON(collectionErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, collection)
+}
+
+// GET /collections?creator_id=1&include_archived=true
+func (h *CollectionHandler) ListByCreator(c echo.Context) error {
+	creatorID, err := strconv.ParseUint(c.QueryParam("creator_id"), 10, 64)
+	if err != nil || creatorID == 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "creator_id is required"})
+	}
+	collections, err := h.CollectionService.ListByCreator(uint(creatorID), c.QueryParam("include_archived") == "true")
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, collections)
+}
+
+// PATCH /collections/:id (protected)
+func (h *CollectionHandler) Update(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	id, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid collection id"})
+	}
+	var req services.UpdateCollectionInput
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+	collection, err := h.CollectionService.Update(user, id, req)
+	if err != nil {
+		return c.JSON(collectionErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, collection)
+}
+
+// POST /collections/:id/archive (protected)
+func (h *CollectionHandler) Archive(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	id, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid collection id"})
+	}
+	collection, err := h.CollectionService.Archive(user, id)
+	if err != nil {
+		return c.JSON(collectionErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, collection)
+}
+
+func collectionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrCollectionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrNotCollectionOwner),
+		errors.Is(err, services.ErrNotCreator):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrCollectionNameTaken),
+		errors.Is(err, services.ErrCollectionChainImmutable),
+		errors.Is(err, services.ErrCollectionArchived):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrInvalidCollectionName),
+		errors.Is(err, services.ErrUnsupportedChain):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/igwedaniel/artizan/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+type DeploymentHandler struct {
+	DeploymentService *services.DeploymentService
+}
+
+// NewDeploymentHandler creates a new DeploymentHandler
+func NewDeploymentHandler(deploymentService *services.DeploymentService) *DeploymentHandler {
+	return &DeploymentHandler{
+		DeploymentService: deploymentService,
+	}
+}
+
+// POST /collections/:id/publish (protected)
+func (h *DeploymentHandler) Publish(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	id, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid collection id"})
+	}
+	var req struct {
+		WithZone bool `json:"with_zone"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+	collection, err := h.DeploymentService.Publish(c.Request().Context(), user, id, req.WithZone)
+	if err != nil {
+		if errors.Is(err, services.ErrCollectionAlreadyDeployed) {
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(collectionErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusAccepted, collection)
+}
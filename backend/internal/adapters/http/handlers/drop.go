@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/igwedaniel/artizan/internal/models"
+	"github.com/igwedaniel/artizan/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+type DropHandler struct {
+	DropService *services.DropService
+}
+
+// NewDropHandler creates a new DropHandler
+func NewDropHandler(dropService *services.DropService) *DropHandler {
+	return &DropHandler{
+		DropService: dropService,
+	}
+}
+
+// POST /collections/:id/drops (protected)
+func (h *DropHandler) Create(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	collectionID, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid collection id"})
+	}
+	var req services.CreateDropInput
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+	drop, err := h.DropService.Create(user, collectionID, req)
+	if err != nil {
+		return c.JSON(dropErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusCreated, drop)
+}
+
+// GET /collections/:id/drops
+func (h *DropHandler) List(c echo.Context) error {
+	collectionID, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid collection id"})
+	}
+	drops, err := h.DropService.List(collectionID)
+	if err != nil {
+		return c.JSON(dropErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, drops)
+}
+
+// GET /collections/:id/drops/drafts (protected)
+func (h *DropHandler) ListDrafts(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	collectionID, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid collection id"})
+	}
+	drops, err := h.DropService.ListDrafts(user, collectionID)
+	if err != nil {
+		return c.JSON(dropErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, drops)
+}
+
+// GET /collections/:id/drops/:dropId
+func (h *DropHandler) Get(c echo.Context) error {
+	collectionID, dropID, ok := dropParams(c)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid drop id"})
+	}
+	drop, err := h.DropService.Get(collectionID, dropID)
+	if err != nil {
+		return c.JSON(dropErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, drop)
+}
+
+// PATCH /collections/:id/drops/:dropId (protected)
+func (h *DropHandler) Update(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	collectionID, dropID, ok := dropParams(c)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid drop id"})
+	}
+	var req services.UpdateDropInput
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+	drop, err := h.DropService.Update(user, collectionID, dropID, req)
+	if err != nil {
+		return c.JSON(dropErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, drop)
+}
+
+// POST /collections/:id/drops/:dropId/schedule (protected)
+func (h *DropHandler) Schedule(c echo.Context) error {
+	return h.transition(c, h.DropService.Schedule)
+}
+
+// POST /collections/:id/drops/:dropId/end (protected)
+func (h *DropHandler) End(c echo.Context) error {
+	return h.transition(c, h.DropService.End)
+}
+
+// POST /collections/:id/drops/:dropId/archive (protected)
+func (h *DropHandler) Archive(c echo.Context) error {
+	return h.transition(c, h.DropService.Archive)
+}
+
+// POST /collections/:id/drops/:dropId/subscription (protected)
+func (h *DropHandler) Subscribe(c echo.Context) error {
+	return h.subscription(c, h.DropService.Subscribe)
+}
+
+// DELETE /collections/:id/drops/:dropId/subscription (protected)
+func (h *DropHandler) Unsubscribe(c echo.Context) error {
+	return h.subscription(c, h.DropService.Unsubscribe)
+}
+
+func (h *DropHandler) subscription(c echo.Context, change func(*models.User, uint, uint) error) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	collectionID, dropID, ok := dropParams(c)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid drop id"})
+	}
+	if err := change(user, collectionID, dropID); err != nil {
+		return c.JSON(dropErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *DropHandler) transition(c echo.Context, move func(*models.User, uint, uint) (*models.Drop, error)) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	collectionID, dropID, ok := dropParams(c)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid drop id"})
+	}
+	drop, err := move(user, collectionID, dropID)
+	if err != nil {
+		return c.JSON(dropErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, drop)
+}
+
+func dropParams(c echo.Context) (uint, uint, bool) {
+	collectionID, ok := paramUint(c, "id")
+	if !ok {
+		return 0, 0, false
+	}
+	dropID, ok := paramUint(c, "dropId")
+	return collectionID, dropID, ok
+}
+
+func dropErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrDropNotEditable),
+		errors.Is(err, services.ErrDropNotUpcoming),
+		errors.Is(err, services.ErrInvalidDropTransition):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrInvalidDropType),
+		errors.Is(err, services.ErrInvalidDropPrice),
+		errors.Is(err, services.ErrPrivateDropPriced),
+		errors.Is(err, services.ErrInvalidDropSupply),
+		errors.Is(err, services.ErrInvalidDropWalletCap),
+		errors.Is(err, services.ErrInvalidDropTimes),
+		errors.Is(err, services.ErrDropStartPassed):
+		return http.StatusBadRequest
+	}
+	return nftErrorStatus(err)
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/igwedaniel/artizan/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+type EmailHandler struct {
+	EmailService *services.EmailService
+}
+
+// NewEmailHandler creates a new EmailHandler
+func NewEmailHandler(emailService *services.EmailService) *EmailHandler {
+	return &EmailHandler{
+		EmailService: emailService,
+	}
+}
+
+// POST /me/email (protected)
+func (h *EmailHandler) RequestVerification(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	var req struct {
+		Email string `json:"email"`
+	}
+	if err := c.Bind(&req); err != nil || req.Email == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+	if err := h.EmailService.RequestVerification(user, req.Email); err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidEmail), errors.Is(err, services.ErrEmailAlreadyVerified):
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case errors.Is(err, services.ErrEmailTaken):
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusAccepted, map[string]string{"status": "verification email sent"})
+}
+
+// GET /email/verify?token=...
+func (h *EmailHandler) Verify(c echo.Context) error {
+	token := c.QueryParam("token")
+	if token == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+	user, err := h.EmailService.VerifyEmail(token)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidEmailToken):
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case errors.Is(err, services.ErrEmailTaken):
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, user)
+}
+
+// GET or POST /email/unsubscribe?token=..., POST is used by one-click unsubscribe
+func (h *EmailHandler) Unsubscribe(c echo.Context) error {
+	token := c.QueryParam("token")
+	if token == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+	category, err := h.EmailService.Unsubscribe(token)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidEmailToken) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]string{"unsubscribed": category})
+}
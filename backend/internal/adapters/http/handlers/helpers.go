@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/igwedaniel/artizan/internal/models"
+	"github.com/labstack/echo/v4"
+)
+
+// currentUser returns the user stored in the context by AuthMiddleware
+func currentUser(c echo.Context) (*models.User, bool) {
+	user, ok := c.Get("user").(*models.User)
+	return user, ok && user != nil
+}
+
+// queryInt parses an integer query parameter, falling back to def
+func queryInt(c echo.Context, name string, def int) int {
+	v, err := strconv.Atoi(c.QueryParam(name))
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// paramUint parses a numeric path parameter such as :id
+func paramUint(c echo.Context, name string) (uint, bool) {
+	v, err := strconv.ParseUint(c.Param(name), 10, 64)
+	if err != nil || v == 0 {
+		return 0, false
+	}
+	return uint(v), true
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/igwedaniel/artizan/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+type ImportHandler struct {
+	ImportService *services.ImportService
+}
+
+// NewImportHandler creates a new ImportHandler
+func NewImportHandler(importService *services.ImportService) *ImportHandler {
+	return &ImportHandler{
+		ImportService: importService,
+	}
+}
+
+// POST /drops/:id/imports (protected), multipart form with a ZIP "archive"
+// field and an optional CSV or JSON "manifest" field
+func (h *ImportHandler) Create(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	id, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid drop id"})
+	}
+	archiveHeader, err := c.FormFile("archive")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "archive is required"})
+	}
+	archive, err := archiveHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid archive"})
+	}
+	defer archive.Close()
+
+	var manifest io.Reader
+	var manifestName string
+	if manifestHeader, err := c.FormFile("manifest"); err == nil {
+		file, err := manifestHeader.Open()
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid manifest"})
+		}
+		defer file.Close()
+		manifest, manifestName = file, manifestHeader.Filename
+	}
+
+	job, created, err := h.ImportService.Create(user, id, archive, manifestName, manifest)
+	if err != nil {
+		var validation *services.ImportValidationError
+		if errors.As(err, &validation) {
+			return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+				"error":      err.Error(),
+				"row_errors": validation.Rows,
+			})
+		}
+		return c.JSON(importErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	if !created {
+		return c.JSON(http.StatusOK, job)
+	}
+	return c.JSON(http.StatusAccepted, job)
+}
+
+// GET /imports/:id (protected)
+func (h *ImportHandler) Get(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	id, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid import id"})
+	}
+	job, err := h.ImportService.GetByID(user, id)
+	if err != nil {
+		return c.JSON(importErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, job)
+}
+
+func importErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrImportNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrImportTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, services.ErrInvalidImportArchive),
+		errors.Is(err, services.ErrInvalidImportManifest),
+		errors.Is(err, services.ErrImportManifestMissing),
+		errors.Is(err, services.ErrImportTooManyRows):
+		return http.StatusBadRequest
+	}
+	return nftErrorStatus(err)
+}
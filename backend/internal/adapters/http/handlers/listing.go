@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/igwedaniel/artizan/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+type ListingHandler struct {
+	ListingService *services.ListingService
+}
+
+// NewListingHandler creates a new ListingHandler
+func NewListingHandler(listingService *services.ListingService) *ListingHandler {
+	return &ListingHandler{
+		ListingService: listingService,
+	}
+}
+
+// POST /nfts/:id/listings/build (protected), body: {"price": "1000000000000000000", "quantity": 1, "fees": [...]}
+func (h *ListingHandler) Build(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	id, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid nft id"})
+	}
+	var req services.BuildListingInput
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+	order, err := h.ListingService.BuildListing(c.Request().Context(), user, id, req)
+	if err != nil {
+		return c.JSON(listingErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, order)
+}
+
+func listingErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrNotCollectionOwner):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrCollectionHasNoZone),
+		errors.Is(err, services.ErrListingNotApproved):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrInvalidListingPrice),
+		errors.Is(err, services.ErrInvalidListingCurrency),
+		errors.Is(err, services.ErrInvalidListingQuantity),
+		errors.Is(err, services.ErrInvalidListingFees),
+		errors.Is(err, services.ErrInvalidListingExpiry):
+		return http.StatusBadRequest
+	}
+	return voucherErrorStatus(err)
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/igwedaniel/artizan/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+// metadata may still be edited, so caches revalidate every few minutes
+const metadataCacheControl = "public, max-age=300"
+
+type MetadataHandler struct {
+	NFTService *services.NFTService
+}
+
+// NewMetadataHandler creates a new MetadataHandler
+func NewMetadataHandler(nftService *services.NFTService) *MetadataHandler {
+	return &MetadataHandler{
+		NFTService: nftService,
+	}
+}
+
+// GET /metadata/:chain/:contract/:id.json, :id being the 64 hex character
+// token ID
+func (h *MetadataHandler) Get(c echo.Context) error {
+	chainID, err := strconv.ParseInt(c.Param("chain"), 10, 64)
+	tokenID, ok := strings.CutSuffix(c.Param("file"), ".json")
+	if err != nil || !ok {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": services.ErrNFTNotFound.Error()})
+	}
+	metadata, err := h.NFTService.Metadata(chainID, c.Param("contract"), tokenID)
+	if err != nil {
+		return c.JSON(nftErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	body, err := json.Marshal(metadata)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	header := c.Response().Header()
+	header.Set("ETag", etag)
+	header.Set("Cache-Control", metadataCacheControl)
+	header.Set("Access-Control-Allow-Origin", "*")
+	if etagMatches(c.Request().Header.Get("If-None-Match"), etag) {
+		return c.NoContent(http.StatusNotModified)
+	}
+	return c.Blob(http.StatusOK, echo.MIMEApplicationJSON, body)
+}
+
+// etagMatches evaluates an If-None-Match header against etag, ignoring weak
+// validator prefixes
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/igwedaniel/artizan/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+type NFTHandler struct {
+	NFTService *services.NFTService
+}
+
+// NewNFTHandler creates a new NFTHandler
+func NewNFTHandler(nftService *services.NFTService) *NFTHandler {
+	return &NFTHandler{
+		NFTService: nftService,
+	}
+}
+
+// POST /drops/:id/nfts (protected)
+func (h *NFTHandler) Create(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	id, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid drop id"})
+	}
+	var req services.CreateNFTInput
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+	nft, err := h.NFTService.Create(user, id, req)
+	if err != nil {
+		return c.JSON(nftErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusCreated, nft)
+}
+
+// PATCH /nfts/:id (protected)
+func (h *NFTHandler) Update(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	id, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid nft id"})
+	}
+	var req services.UpdateNFTInput
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+	nft, err := h.NFTService.Update(user, id, req)
+	if err != nil {
+		return c.JSON(nftErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, nft)
+}
+
+// GET /nfts/:id
+func (h *NFTHandler) Get(c echo.Context) error {
+	id, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid nft id"})
+	}
+	nft, err := h.NFTService.GetByID(id)
+	if err != nil {
+		return c.JSON(nftErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, nft)
+}
+
+func nftErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrDropNotFound),
+		errors.Is(err, services.ErrNFTNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidMetadataURI),
+		errors.Is(err, services.ErrInvalidNFTName),
+		errors.Is(err, services.ErrInvalidNFTAttributes),
+		errors.Is(err, services.ErrInvalidEditionSize):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrEditionSizeLocked):
+		return http.StatusConflict
+	}
+	return collectionErrorStatus(err)
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/igwedaniel/artizan/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+type OfferHandler struct {
+	OfferService *services.OfferService
+}
+
+// NewOfferHandler creates a new OfferHandler
+func NewOfferHandler(offerService *services.OfferService) *OfferHandler {
+	return &OfferHandler{
+		OfferService: offerService,
+	}
+}
+
+// POST /offers/build (protected), body: {"nft_id": 1, "price": "1000000000000000000", "quantity": 1}
+// or {"collection_id": 1, ...} for an offer on any token of the collection
+func (h *OfferHandler) Build(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	var req services.BuildOfferInput
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+	order, err := h.OfferService.BuildOffer(c.Request().Context(), user, req)
+	if err != nil {
+		return c.JSON(offerErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, order)
+}
+
+// POST /offers (protected), body: {"order": {...}, "signature": "0x...", "order_hash": "0x..."}
+func (h *OfferHandler) Submit(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	var req services.SubmitOrderInput
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+	offer, err := h.OfferService.Submit(c.Request().Context(), user, req)
+	if err != nil {
+		return c.JSON(offerErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusCreated, offer)
+}
+
+// GET /offers?nft_id=&collection_id=&maker=&status=active&limit=20&offset=0
+func (h *OfferHandler) List(c echo.Context) error {
+	offers, err := h.OfferService.List(services.ListOffersInput{
+		NFTID:        uint(queryInt(c, "nft_id", 0)),
+		CollectionID: uint(queryInt(c, "collection_id", 0)),
+		Maker:        c.QueryParam("maker"),
+		Status:       c.QueryParam("status"),
+		Limit:        queryInt(c, "limit", 0),
+		Offset:       queryInt(c, "offset", 0),
+	})
+	if err != nil {
+		return c.JSON(offerErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, offers)
+}
+
+// GET /offers/:hash
+func (h *OfferHandler) Get(c echo.Context) error {
+	offer, err := h.OfferService.GetByHash(c.Param("hash"))
+	if err != nil {
+		return c.JSON(offerErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, offer)
+}
+
+// GET /offers/:hash/fulfillment?nft_id=&quantity= (protected)
+func (h *OfferHandler) Fulfillment(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	fulfillment, err := h.OfferService.Fulfillment(c.Request().Context(), user, c.Param("hash"), services.AcceptOfferInput{
+		NFTID:    uint(queryInt(c, "nft_id", 0)),
+		Quantity: uint64(queryInt(c, "quantity", 0)),
+	})
+	if err != nil {
+		return c.JSON(offerErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, fulfillment)
+}
+
+func offerErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrOfferNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrOfferNotBidder):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrOfferNotActive),
+		errors.Is(err, services.ErrOfferTokenNotMinted):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrInvalidOfferTarget),
+		errors.Is(err, services.ErrInvalidOfferPrice),
+		errors.Is(err, services.ErrInvalidOfferCurrency),
+		errors.Is(err, services.ErrInvalidOfferQuantity),
+		errors.Is(err, services.ErrInvalidOfferExpiry),
+		errors.Is(err, services.ErrUnsupportedOffer),
+		errors.Is(err, services.ErrOfferTokenMismatch):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrOfferInsufficientBalance),
+		errors.Is(err, services.ErrOfferInsufficientAllowance),
+		errors.Is(err, services.ErrInsufficientTokens):
+		return http.StatusUnprocessableEntity
+	}
+	if status := orderErrorStatus(err); status != http.StatusInternalServerError {
+		return status
+	}
+	return nftErrorStatus(err)
+}
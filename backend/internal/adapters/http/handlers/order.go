@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/igwedaniel/artizan/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+type OrderHandler struct {
+	OrderService *services.OrderService
+}
+
+// NewOrderHandler creates a new OrderHandler
+func NewOrderHandler(orderService *services.OrderService) *OrderHandler {
+	return &OrderHandler{
+		OrderService: orderService,
+	}
+}
+
+// POST /orders (protected), body: {"order": {...}, "signature": "0x...", "extra_data": "0x...", "order_hash": "0x..."}
+func (h *OrderHandler) Submit(c echo.Context) error {
+	var req services.SubmitOrderInput
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+	order, err := h.OrderService.Submit(c.Request().Context(), req)
+	if err != nil {
+		return c.JSON(orderErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusCreated, order)
+}
+
+// GET /orders?nft_id=&collection_id=&maker=&currency=&status=active&sort=price&limit=20&offset=0
+func (h *OrderHandler) List(c echo.Context) error {
+	orders, err := h.OrderService.List(services.ListOrdersInput{
+		NFTID:        uint(queryInt(c, "nft_id", 0)),
+		CollectionID: uint(queryInt(c, "collection_id", 0)),
+		Maker:        c.QueryParam("maker"),
+		Currency:     c.QueryParam("currency"),
+		Status:       c.QueryParam("status"),
+		Sort:         c.QueryParam("sort"),
+		Limit:        queryInt(c, "limit", 0),
+		Offset:       queryInt(c, "offset", 0),
+	})
+	if err != nil {
+		return c.JSON(orderErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, orders)
+}
+
+// GET /orders/:hash
+func (h *OrderHandler) Get(c echo.Context) error {
+	order, err := h.OrderService.GetByHash(c.Param("hash"))
+	if err != nil {
+		return c.JSON(orderErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, order)
+}
+
+// GET /nfts/:id/orders/best?currency=
+func (h *OrderHandler) Best(c echo.Context) error {
+	id, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid nft id"})
+	}
+	order, err := h.OrderService.GetBest(id, c.QueryParam("currency"))
+	if err != nil {
+		return c.JSON(orderErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, order)
+}
+
+func orderErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrOrderNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrOrderAlreadySubmitted):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrInvalidOrder),
+		errors.Is(err, services.ErrUnsupportedOrder),
+		errors.Is(err, services.ErrInvalidOrderMaker),
+		errors.Is(err, services.ErrInvalidOrderStatus),
+		errors.Is(err, services.ErrInvalidListingCurrency),
+		errors.Is(err, services.ErrUnsupportedChain):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrOrderTokenUnknown),
+		errors.Is(err, services.ErrOrderZoneMismatch),
+		errors.Is(err, services.ErrOrderHashMismatch),
+		errors.Is(err, services.ErrInvalidOrderSignature),
+		errors.Is(err, services.ErrOrderCounterMismatch),
+		errors.Is(err, services.ErrOrderExpired),
+		errors.Is(err, services.ErrOrderVoucherMismatch):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/igwedaniel/artizan/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+type OwnershipHandler struct {
+	OwnershipService *services.OwnershipService
+}
+
+// NewOwnershipHandler creates a new OwnershipHandler
+func NewOwnershipHandler(ownershipService *services.OwnershipService) *OwnershipHandler {
+	return &OwnershipHandler{
+		OwnershipService: ownershipService,
+	}
+}
+
+// GET /users/:handle/nfts?chain_id=97&limit=20&offset=0
+func (h *OwnershipHandler) Portfolio(c echo.Context) error {
+	balances, err := h.OwnershipService.ListPortfolio(c.Param("handle"), int64(queryInt(c, "chain_id", 0)),
+		queryInt(c, "limit", 0), queryInt(c, "offset", 0))
+	if err != nil {
+		return c.JSON(ownershipErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, balances)
+}
+
+// GET /nfts/:id/holders?limit=20&offset=0
+func (h *OwnershipHandler) Holders(c echo.Context) error {
+	id, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid nft id"})
+	}
+	balances, err := h.OwnershipService.ListHolders(id, queryInt(c, "limit", 0), queryInt(c, "offset", 0))
+	if err != nil {
+		return c.JSON(ownershipErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, balances)
+}
+
+// GET /collections/:id/holders
+func (h *OwnershipHandler) CollectionHolders(c echo.Context) error {
+	id, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid collection id"})
+	}
+	holders, err := h.OwnershipService.CountCollectionHolders(id)
+	if err != nil {
+		return c.JSON(ownershipErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, holders)
+}
+
+func ownershipErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidHandle),
+		errors.Is(err, services.ErrUnsupportedChain):
+		return http.StatusBadRequest
+	}
+	return nftErrorStatus(err)
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/igwedaniel/artizan/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+type RelayerHandler struct {
+	RelayerService *services.RelayerService
+}
+
+// NewRelayerHandler creates a new RelayerHandler
+func NewRelayerHandler(relayerService *services.RelayerService) *RelayerHandler {
+	return &RelayerHandler{
+		RelayerService: relayerService,
+	}
+}
+
+// POST /nfts/:id/mint/relay (protected), redeems the caller's voucher with a
+// transaction the platform pays for
+func (h *RelayerHandler) Sponsor(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	id, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid nft id"})
+	}
+	relayTx, err := h.RelayerService.Sponsor(c.Request().Context(), user, id)
+	if err != nil {
+		return c.JSON(relayerErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusAccepted, relayTx)
+}
+
+// GET /transactions/:id (protected)
+func (h *RelayerHandler) Get(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	id, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid transaction id"})
+	}
+	relayTx, err := h.RelayerService.Get(user, id)
+	if err != nil {
+		return c.JSON(relayerErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, relayTx)
+}
+
+func relayerErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrRelayTransactionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrNoSponsorableVoucher),
+		errors.Is(err, services.ErrMintAlreadyRelaying),
+		errors.Is(err, services.ErrRelayWouldRevert):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrCreatorSponsorshipExhausted),
+		errors.Is(err, services.ErrUserSponsorshipExhausted):
+		return http.StatusPaymentRequired
+	case errors.Is(err, services.ErrRelayFeesTooHigh):
+		return http.StatusServiceUnavailable
+	}
+	return voucherErrorStatus(err)
+}
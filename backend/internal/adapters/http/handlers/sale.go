@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/igwedaniel/artizan/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+type SaleHandler struct {
+	SaleService *services.SaleService
+}
+
+// NewSaleHandler creates a new SaleHandler
+func NewSaleHandler(saleService *services.SaleService) *SaleHandler {
+	return &SaleHandler{
+		SaleService: saleService,
+	}
+}
+
+// GET /nfts/:id/sales?limit=20&offset=0
+func (h *SaleHandler) ListForNFT(c echo.Context) error {
+	id, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid nft id"})
+	}
+	sales, err := h.SaleService.ListByNFT(id, queryInt(c, "limit", 0), queryInt(c, "offset", 0))
+	if err != nil {
+		return c.JSON(nftErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, sales)
+}
+
+// GET /collections/:id/sales?limit=20&offset=0
+func (h *SaleHandler) ListForCollection(c echo.Context) error {
+	id, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid collection id"})
+	}
+	sales, err := h.SaleService.ListByCollection(id, queryInt(c, "limit", 0), queryInt(c, "offset", 0))
+	if err != nil {
+		return c.JSON(nftErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, sales)
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/igwedaniel/artizan/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+type SignerHandler struct {
+	SignerService *services.SignerService
+}
+
+// NewSignerHandler creates a new SignerHandler
+func NewSignerHandler(signerService *services.SignerService) *SignerHandler {
+	return &SignerHandler{
+		SignerService: signerService,
+	}
+}
+
+// GET /admin/signers?purpose=voucher (admin)
+func (h *SignerHandler) ListKeys(c echo.Context) error {
+	keys, err := h.SignerService.ListKeys(c.QueryParam("purpose"))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, keys)
+}
+
+// GET /admin/signers/:address/signatures?limit=20&offset=0 (admin)
+func (h *SignerHandler) ListSignatures(c echo.Context) error {
+	audits, err := h.SignerService.ListSignatures(c.Param("address"), queryInt(c, "limit", 20), queryInt(c, "offset", 0))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSignerAddress) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, audits)
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/igwedaniel/artizan/internal/services"
@@ -26,3 +27,54 @@ func (h *UserHandler) GetCurrentUser(c echo.Context) error {
 	}
 	return c.JSON(http.StatusOK, user)
 }
+
+// POST /users/:handle/follow (protected)
+func (h *UserHandler) Follow(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	followee, err := h.UserService.Follow(user, c.Param("handle"))
+	if err != nil {
+		return c.JSON(userErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, followee)
+}
+
+// DELETE /users/:handle/follow (protected)
+func (h *UserHandler) Unfollow(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	if err := h.UserService.Unfollow(user, c.Param("handle")); err != nil {
+		return c.JSON(userErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// POST /admin/users/:id/creator (admin)
+func (h *UserHandler) ApproveCreator(c echo.Context) error {
+	id, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid user id"})
+	}
+	user, err := h.UserService.ApproveCreator(id)
+	if err != nil {
+		return c.JSON(userErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, user)
+}
+
+func userErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidHandle),
+		errors.Is(err, services.ErrCannotFollowSelf):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrUserIsAdmin):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
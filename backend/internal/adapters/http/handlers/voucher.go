@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+
+	"github.com/igwedaniel/artizan/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+type VoucherHandler struct {
+	VoucherService *services.VoucherService
+}
+
+// NewVoucherHandler creates a new VoucherHandler
+func NewVoucherHandler(voucherService *services.VoucherService) *VoucherHandler {
+	return &VoucherHandler{
+		VoucherService: voucherService,
+	}
+}
+
+// POST /nfts/:id/voucher (protected), body: {"amount": "1"}
+func (h *VoucherHandler) Issue(c echo.Context) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+	id, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid nft id"})
+	}
+	var req struct {
+		Amount string `json:"amount"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+	amount := big.NewInt(1)
+	if req.Amount != "" {
+		if _, ok := amount.SetString(req.Amount, 10); !ok {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": services.ErrInvalidVoucherAmount.Error()})
+		}
+	}
+
+	voucher, err := h.VoucherService.IssueVoucher(c.Request().Context(), user, id, amount)
+	if err != nil {
+		return c.JSON(voucherErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, voucher)
+}
+
+// GET /admin/nfts/:id/vouchers (admin)
+func (h *VoucherHandler) ListForNFT(c echo.Context) error {
+	id, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid nft id"})
+	}
+	vouchers, err := h.VoucherService.ListForNFT(id)
+	if err != nil {
+		return c.JSON(voucherErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, vouchers)
+}
+
+// POST /admin/vouchers/:id/revoke (admin), body: {"reason": "..."}
+func (h *VoucherHandler) Revoke(c echo.Context) error {
+	id, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid voucher id"})
+	}
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+	voucher, err := h.VoucherService.Revoke(id, req.Reason)
+	if err != nil {
+		return c.JSON(voucherErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, voucher)
+}
+
+// POST /admin/nfts/:id/vouchers/revoke (admin), body: {"reason": "..."}
+func (h *VoucherHandler) RevokeForNFT(c echo.Context) error {
+	id, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid nft id"})
+	}
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+	voucher, err := h.VoucherService.RevokeForNFT(id, req.Reason)
+	if err != nil {
+		return c.JSON(voucherErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, voucher)
+}
+
+// POST /admin/collections/:id/vouchers/reissue (admin)
+func (h *VoucherHandler) ReissueOutstanding(c echo.Context) error {
+	id, ok := paramUint(c, "id")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid collection id"})
+	}
+	vouchers, err := h.VoucherService.ReissueOutstanding(c.Request().Context(), id)
+	if err != nil {
+		return c.JSON(voucherErrorStatus(err), map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"reissued": vouchers})
+}
+
+func voucherErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrNFTNotFound),
+		errors.Is(err, services.ErrVoucherNotFound),
+		errors.Is(err, services.ErrCollectionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrNotAllowlisted),
+		errors.Is(err, services.ErrDropPriced):
+		return http.StatusForbidden
+	case errors.Is(err, services.ErrNFTAlreadyMinted),
+		errors.Is(err, services.ErrCollectionNotDeployed),
+		errors.Is(err, services.ErrNFTVoucherOutstanding),
+		errors.Is(err, services.ErrNFTVoucherRevoked),
+		errors.Is(err, services.ErrVoucherNotOutstanding),
+		errors.Is(err, services.ErrCollectionSignerRotating),
+		errors.Is(err, services.ErrAllowlistQuotaExceeded),
+		errors.Is(err, services.ErrDropSoldOut),
+		errors.Is(err, services.ErrDropWalletLimitReached),
+		errors.Is(err, services.ErrDropNotLive):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrInvalidVoucherAmount),
+		errors.Is(err, services.ErrVoucherExceedsEdition):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
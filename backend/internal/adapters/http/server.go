@@ -3,13 +3,33 @@ package http
 import (
 	"github.com/igwedaniel/artizan/internal/adapters/http/handlers"
 	"github.com/igwedaniel/artizan/internal/adapters/http/middleware"
+	"github.com/igwedaniel/artizan/internal/models"
 	"github.com/igwedaniel/artizan/internal/services"
 	"github.com/labstack/echo/v4"
 )
 
 type Services struct {
-	AuthService *services.AuthService
-	UserService *services.UserService
+	AuthService         *services.AuthService
+	UserService         *services.UserService
+	NotificationService *services.NotificationService
+	EmailService        *services.EmailService
+	ChainService        *services.ChainService
+	CollectionService   *services.CollectionService
+	DropService         *services.DropService
+	AllowlistService    *services.AllowlistService
+	SupplyService       *services.SupplyService
+	DeploymentService   *services.DeploymentService
+	VoucherService      *services.VoucherService
+	SignerService       *services.SignerService
+	NFTService          *services.NFTService
+	AssetService        *services.AssetService
+	ImportService       *services.ImportService
+	OwnershipService    *services.OwnershipService
+	ListingService      *services.ListingService
+	OrderService        *services.OrderService
+	SaleService         *services.SaleService
+	OfferService        *services.OfferService
+	RelayerService      *services.RelayerService // nil when the relayer is disabled
 	// Add more services here as needed
 }
 
@@ -19,6 +39,24 @@ func NewServer(svcs *Services) *echo.Echo {
 	e := echo.New()
 	authHandler := handlers.NewAuthHandler(svcs.AuthService)
 	userHandler := handlers.NewUserHandler(svcs.UserService)
+	notificationHandler := handlers.NewNotificationHandler(svcs.NotificationService)
+	emailHandler := handlers.NewEmailHandler(svcs.EmailService)
+	chainHandler := handlers.NewChainHandler(svcs.ChainService)
+	collectionHandler := handlers.NewCollectionHandler(svcs.CollectionService)
+	dropHandler := handlers.NewDropHandler(svcs.DropService)
+	allowlistHandler := handlers.NewAllowlistHandler(svcs.AllowlistService)
+	deploymentHandler := handlers.NewDeploymentHandler(svcs.DeploymentService)
+	voucherHandler := handlers.NewVoucherHandler(svcs.VoucherService)
+	signerHandler := handlers.NewSignerHandler(svcs.SignerService)
+	nftHandler := handlers.NewNFTHandler(svcs.NFTService)
+	metadataHandler := handlers.NewMetadataHandler(svcs.NFTService)
+	assetHandler := handlers.NewAssetHandler(svcs.AssetService)
+	importHandler := handlers.NewImportHandler(svcs.ImportService)
+	ownershipHandler := handlers.NewOwnershipHandler(svcs.OwnershipService)
+	listingHandler := handlers.NewListingHandler(svcs.ListingService)
+	orderHandler := handlers.NewOrderHandler(svcs.OrderService)
+	saleHandler := handlers.NewSaleHandler(svcs.SaleService)
+	offerHandler := handlers.NewOfferHandler(svcs.OfferService)
 
 	// Public routes
 	e.GET("/health", func(c echo.Context) error {
@@ -27,10 +65,82 @@ func NewServer(svcs *Services) *echo.Echo {
 	e.POST("/auth/nonce", authHandler.GetNonce)
 	e.POST("/auth/login", authHandler.Authenticate)
 	e.POST("/auth/refresh", authHandler.RefreshToken)
+	e.GET("/email/verify", emailHandler.Verify)
+	e.GET("/email/unsubscribe", emailHandler.Unsubscribe)
+	e.POST("/email/unsubscribe", emailHandler.Unsubscribe)
+	e.GET("/chains", chainHandler.List)
+	e.GET("/collections", collectionHandler.ListByCreator)
+	e.GET("/collections/:id", collectionHandler.Get)
+	e.GET("/collections/:id/holders", ownershipHandler.CollectionHolders)
+	e.GET("/collections/:id/sales", saleHandler.ListForCollection)
+	e.GET("/collections/:id/drops", dropHandler.List)
+	e.GET("/collections/:id/drops/:dropId", dropHandler.Get)
+	e.GET("/drops/:id/allowlist", allowlistHandler.Current)
+	e.GET("/drops/:id/allowlist/proof", allowlistHandler.Proof)
+	e.GET("/nfts/:id", nftHandler.Get)
+	e.GET("/nfts/:id/holders", ownershipHandler.Holders)
+	e.GET("/nfts/:id/orders/best", orderHandler.Best)
+	e.GET("/nfts/:id/sales", saleHandler.ListForNFT)
+	e.GET("/users/:handle/nfts", ownershipHandler.Portfolio)
+	e.GET("/orders", orderHandler.List)
+	e.GET("/orders/:hash", orderHandler.Get)
+	e.GET("/offers", offerHandler.List)
+	e.GET("/offers/:hash", offerHandler.Get)
+	e.GET("/metadata/:chain/:contract/:file", metadataHandler.Get)
+	e.GET("/assets/:cid", assetHandler.Get)
 
 	// Protected routes
 	g := e.Group("", middleware.AuthMiddleware(svcs.AuthService))
 	g.GET("/me", userHandler.GetCurrentUser)
+	g.POST("/users/:handle/follow", userHandler.Follow)
+	g.DELETE("/users/:handle/follow", userHandler.Unfollow)
+	g.POST("/me/email", emailHandler.RequestVerification)
+	g.GET("/notifications", notificationHandler.List)
+	g.GET("/notifications/unread-count", notificationHandler.UnreadCount)
+	g.POST("/notifications/read", notificationHandler.MarkRead)
+	g.POST("/notifications/read-all", notificationHandler.MarkAllRead)
+	g.GET("/notifications/preferences", notificationHandler.GetPreferences)
+	g.PUT("/notifications/preferences", notificationHandler.UpdatePreferences)
+	g.POST("/collections", collectionHandler.Create)
+	g.PATCH("/collections/:id", collectionHandler.Update)
+	g.POST("/collections/:id/archive", collectionHandler.Archive)
+	g.POST("/collections/:id/publish", deploymentHandler.Publish)
+	g.POST("/collections/:id/drops", dropHandler.Create)
+	g.GET("/collections/:id/drops/drafts", dropHandler.ListDrafts)
+	g.PATCH("/collections/:id/drops/:dropId", dropHandler.Update)
+	g.POST("/collections/:id/drops/:dropId/schedule", dropHandler.Schedule)
+	g.POST("/collections/:id/drops/:dropId/end", dropHandler.End)
+	g.POST("/collections/:id/drops/:dropId/archive", dropHandler.Archive)
+	g.POST("/collections/:id/drops/:dropId/subscription", dropHandler.Subscribe)
+	g.DELETE("/collections/:id/drops/:dropId/subscription", dropHandler.Unsubscribe)
+	g.POST("/assets", assetHandler.Upload)
+	g.POST("/drops/:id/nfts", nftHandler.Create)
+	g.POST("/drops/:id/imports", importHandler.Create)
+	g.POST("/drops/:id/allowlist", allowlistHandler.Upload)
+	g.GET("/drops/:id/allowlist/versions", allowlistHandler.ListVersions)
+	g.GET("/imports/:id", importHandler.Get)
+	g.PATCH("/nfts/:id", nftHandler.Update)
+	g.POST("/nfts/:id/voucher", voucherHandler.Issue)
+	g.POST("/nfts/:id/listings/build", listingHandler.Build)
+	g.POST("/orders", orderHandler.Submit)
+	g.POST("/offers/build", offerHandler.Build)
+	g.POST("/offers", offerHandler.Submit)
+	g.GET("/offers/:hash/fulfillment", offerHandler.Fulfillment)
+	if svcs.RelayerService != nil {
+		relayerHandler := handlers.NewRelayerHandler(svcs.RelayerService)
+		g.POST("/nfts/:id/mint/relay", relayerHandler.Sponsor)
+		g.GET("/transactions/:id", relayerHandler.Get)
+	}
+
+	// Admin routes
+	admin := g.Group("/admin", middleware.RequireRole(models.RoleAdmin))
+	admin.POST("/users/:id/creator", userHandler.ApproveCreator)
+	admin.GET("/nfts/:id/vouchers", voucherHandler.ListForNFT)
+	admin.POST("/nfts/:id/vouchers/revoke", voucherHandler.RevokeForNFT)
+	admin.POST("/vouchers/:id/revoke", voucherHandler.Revoke)
+	admin.POST("/collections/:id/vouchers/reissue", voucherHandler.ReissueOutstanding)
+	admin.GET("/signers", signerHandler.ListKeys)
+	admin.GET("/signers/:address/signatures", signerHandler.ListSignatures)
 
 	return e
 }
@@ -0,0 +1,143 @@
+package mailer
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+)
+
+// ReceivedMail is a message accepted by LocalSMTPServer.
+type ReceivedMail struct {
+	From string
+	To   []string
+	Data string
+}
+
+// LocalSMTPServer is a minimal in-process SMTP server that accepts every
+// message and keeps it in memory. It stands in for a real relay in tests and
+// local development; point NewSMTPMailer at Host() and Port().
+type LocalSMTPServer struct {
+	listener net.Listener
+	mu       sync.Mutex
+	messages []ReceivedMail
+	wg       sync.WaitGroup
+}
+
+// NewLocalSMTPServer starts a server on a random loopback port
+func NewLocalSMTPServer() (*LocalSMTPServer, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &LocalSMTPServer{listener: l}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+func (s *LocalSMTPServer) Host() string {
+	return s.listener.Addr().(*net.TCPAddr).IP.String()
+}
+
+func (s *LocalSMTPServer) Port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// Messages returns a copy of every message received so far
+func (s *LocalSMTPServer) Messages() []ReceivedMail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ReceivedMail(nil), s.messages...)
+}
+
+func (s *LocalSMTPServer) Close() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
+
+func (s *LocalSMTPServer) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+		}()
+	}
+}
+
+func (s *LocalSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(format string, args ...interface{}) {
+		fmt.Fprintf(conn, format+"\r\n", args...)
+	}
+
+	var current ReceivedMail
+	reply("220 localhost ESMTP ready")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		verb := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(verb, "EHLO"):
+			reply("250-localhost")
+			reply("250 8BITMIME")
+		case strings.HasPrefix(verb, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(verb, "MAIL FROM:"):
+			current = ReceivedMail{From: trimAddress(line[len("MAIL FROM:"):])}
+			reply("250 OK")
+		case strings.HasPrefix(verb, "RCPT TO:"):
+			current.To = append(current.To, trimAddress(line[len("RCPT TO:"):]))
+			reply("250 OK")
+		case verb == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" || l == ".\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(l, "."))
+			}
+			current.Data = data.String()
+			s.mu.Lock()
+			s.messages = append(s.messages, current)
+			s.mu.Unlock()
+			current = ReceivedMail{}
+			reply("250 OK: queued")
+		case verb == "RSET":
+			current = ReceivedMail{}
+			reply("250 OK")
+		case verb == "NOOP":
+			reply("250 OK")
+		case verb == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func trimAddress(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.Index(s, " "); i >= 0 {
+		s = s[:i]
+	}
+	return strings.Trim(s, "<>")
+}
@@ -0,0 +1,111 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	interfaces "github.com/igwedaniel/artizan/internal/interfaces/mailer"
+)
+
+func TestSMTPMailerSend(t *testing.T) {
+	server, err := NewLocalSMTPServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	m, err := NewSMTPMailer(server.Host(), server.Port(), "", "", "Artizan <noreply@artizan.test>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = m.Send(context.Background(), &interfaces.Message{
+		To:      "Ada <ada@example.com>",
+		Subject: "A drop is live",
+		Text:    "plain body",
+		HTML:    "<p>html body</p>",
+		Headers: map[string]string{"List-Unsubscribe": "<https://artizan.test/email/unsubscribe?token=t>"},
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	received := server.Messages()
+	if len(received) != 1 {
+		t.Fatalf("server received %d messages, want 1", len(received))
+	}
+	got := received[0]
+	if got.From != "noreply@artizan.test" {
+		t.Errorf("MAIL FROM = %q", got.From)
+	}
+	if len(got.To) != 1 || got.To[0] != "ada@example.com" {
+		t.Errorf("RCPT TO = %v", got.To)
+	}
+	for _, want := range []string{
+		"Subject: A drop is live",
+		"List-Unsubscribe: <https://artizan.test/email/unsubscribe?token=t>",
+		"multipart/alternative",
+		"plain body",
+		"<p>html body</p>",
+	} {
+		if !strings.Contains(got.Data, want) {
+			t.Errorf("message lacks %q:\n%s", want, got.Data)
+		}
+	}
+}
+
+func TestSMTPMailerSendInvalidRecipient(t *testing.T) {
+	m, err := NewSMTPMailer("127.0.0.1", 1, "", "", "noreply@artizan.test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Send(context.Background(), &interfaces.Message{To: "not an address"}); err == nil {
+		t.Fatal("Send accepted an invalid recipient")
+	}
+}
+
+// A relay that never answers must not keep Send, or its connection, around
+// once the context is cancelled.
+func TestSMTPMailerSendCancelled(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	closed := make(chan struct{})
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// never greet; a read returns once the client drops the connection
+		conn.Read(make([]byte, 1))
+		close(closed)
+	}()
+
+	addr := l.Addr().(*net.TCPAddr)
+	m, err := NewSMTPMailer(addr.IP.String(), addr.Port, "", "", "noreply@artizan.test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = m.Send(ctx, &interfaces.Message{To: "ada@example.com", Subject: "s", Text: "t"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Send = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("Send returned after %s", elapsed)
+	}
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("connection to the relay was left open")
+	}
+}
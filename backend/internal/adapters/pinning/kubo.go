@@ -0,0 +1,136 @@
+package pinning
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	pinningInterfaces "github.com/igwedaniel/artizan/internal/interfaces/pinning"
+)
+
+// add options matching what pkg/cid computes
+const kuboAddQuery = "cid-version=1&raw-leaves=true&chunker=size-262144&pin=true&quieter=true"
+
+const kuboTimeout = 2 * time.Minute
+
+type kuboAddResponse struct {
+	Name string `json:"Name"`
+	Hash string `json:"Hash"`
+	Size string `json:"Size"`
+}
+
+type kuboErrorResponse struct {
+	Message string `json:"Message"`
+}
+
+// kuboPinner talks to the RPC API (/api/v0) of a Kubo node or of any pinning
+// service that exposes it
+type kuboPinner struct {
+	apiURL string
+	token  string
+	client *http.Client
+}
+
+// NewKuboPinner creates a Pinner for the RPC API at apiURL, e.g.
+// http://127.0.0.1:5001. token, when set, is sent as a bearer token.
+func NewKuboPinner(apiURL, token string) pinningInterfaces.Pinner {
+	return &kuboPinner{
+		apiURL: strings.TrimRight(apiURL, "/"),
+		token:  token,
+		client: &http.Client{Timeout: kuboTimeout},
+	}
+}
+
+func (p *kuboPinner) Add(ctx context.Context, name string, data []byte) (string, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", name)
+	if err != nil {
+		return "", err
+	}
+	if _, err := part.Write(data); err != nil {
+		return "", err
+	}
+	if err := form.Close(); err != nil {
+		return "", err
+	}
+
+	res, err := p.post(ctx, "/api/v0/add?"+kuboAddQuery, form.FormDataContentType(), &body)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	// the response is one JSON object per line; the last one is the root
+	var last kuboAddResponse
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+			if err := json.Unmarshal(line, &last); err != nil {
+				return "", fmt.Errorf("ipfs add: invalid response: %w", err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	if last.Hash == "" {
+		return "", errors.New("ipfs add: empty response")
+	}
+	return last.Hash, nil
+}
+
+func (p *kuboPinner) IsPinned(ctx context.Context, cid string) (bool, error) {
+	res, err := p.post(ctx, "/api/v0/pin/ls?type=recursive&arg="+url.QueryEscape(cid), "", nil)
+	if err != nil {
+		if strings.Contains(err.Error(), "not pinned") {
+			return false, nil
+		}
+		return false, err
+	}
+	defer res.Body.Close()
+	var pins struct {
+		Keys map[string]json.RawMessage `json:"Keys"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&pins); err != nil {
+		return false, fmt.Errorf("ipfs pin ls: invalid response: %w", err)
+	}
+	_, ok := pins.Keys[cid]
+	return ok, nil
+}
+
+// post calls an RPC endpoint; Kubo only accepts POST
+func (p *kuboPinner) post(ctx context.Context, path, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.apiURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+	res, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		var failure kuboErrorResponse
+		if json.NewDecoder(io.LimitReader(res.Body, 1<<16)).Decode(&failure) == nil && failure.Message != "" {
+			return nil, fmt.Errorf("ipfs: %s (status %d)", failure.Message, res.StatusCode)
+		}
+		return nil, fmt.Errorf("ipfs: unexpected status %d", res.StatusCode)
+	}
+	return res, nil
+}
@@ -0,0 +1,79 @@
+package pinning
+
+import (
+	"context"
+	"testing"
+
+	"github.com/igwedaniel/artizan/pkg/cid"
+)
+
+func startLocalIPFS(t *testing.T) *LocalIPFSAPI {
+	t.Helper()
+	node, err := NewLocalIPFSAPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { node.Close() })
+	return node
+}
+
+func TestKuboPinnerAdd(t *testing.T) {
+	node := startLocalIPFS(t)
+	pinner := NewKuboPinner(node.URL(), "token")
+	ctx := context.Background()
+
+	data := []byte(`{"name":"Sunrise"}`)
+	got, err := pinner.Add(ctx, "metadata.json", data)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if want := cid.Sum(data).String(); got != want {
+		t.Fatalf("Add = %s, want %s", got, want)
+	}
+	if pinned := node.Pinned(); len(pinned) != 1 || pinned[0] != got {
+		t.Fatalf("node pinned %v, want [%s]", pinned, got)
+	}
+
+	pinned, err := pinner.IsPinned(ctx, got)
+	if err != nil || !pinned {
+		t.Fatalf("IsPinned(%s) = %v, %v", got, pinned, err)
+	}
+	other := cid.Sum([]byte("never added")).String()
+	pinned, err = pinner.IsPinned(ctx, other)
+	if err != nil || pinned {
+		t.Fatalf("IsPinned(%s) = %v, %v; want false", other, pinned, err)
+	}
+}
+
+func TestKuboPinnerFailingNode(t *testing.T) {
+	node := startLocalIPFS(t)
+	pinner := NewKuboPinner(node.URL(), "")
+	ctx := context.Background()
+
+	node.SetFailing(true)
+	if got, err := pinner.Add(ctx, "a.txt", []byte("a")); err == nil {
+		t.Fatalf("Add = %s on a failing node", got)
+	}
+	if len(node.Pinned()) != 0 {
+		t.Fatalf("failing node pinned %v", node.Pinned())
+	}
+	c := cid.Sum([]byte("a")).String()
+	if pinned, err := pinner.IsPinned(ctx, c); err == nil {
+		t.Fatalf("IsPinned = %v on a failing node", pinned)
+	}
+
+	node.SetFailing(false)
+	if got, err := pinner.Add(ctx, "a.txt", []byte("a")); err != nil || got != c {
+		t.Fatalf("Add = %s, %v after the node recovered", got, err)
+	}
+}
+
+func TestKuboPinnerUnreachable(t *testing.T) {
+	node := startLocalIPFS(t)
+	url := node.URL()
+	node.Close()
+
+	if _, err := NewKuboPinner(url, "").Add(context.Background(), "a.txt", []byte("a")); err == nil {
+		t.Fatal("Add succeeded against a closed node")
+	}
+}
@@ -0,0 +1,134 @@
+package pinning
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/igwedaniel/artizan/pkg/cid"
+)
+
+// LocalIPFSAPI serves the subset of the Kubo RPC API the backend uses (add,
+// pin/ls and cat) from memory. It stands in for an IPFS node in tests and
+// local development; point NewKuboPinner at URL().
+type LocalIPFSAPI struct {
+	listener net.Listener
+	server   *http.Server
+	mu       sync.Mutex
+	blobs    map[string][]byte
+	failing  bool
+}
+
+// NewLocalIPFSAPI starts a server on a random loopback port
+func NewLocalIPFSAPI() (*LocalIPFSAPI, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &LocalIPFSAPI{listener: l, blobs: map[string][]byte{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v0/add", s.handleAdd)
+	mux.HandleFunc("POST /api/v0/pin/ls", s.handlePinLs)
+	mux.HandleFunc("POST /api/v0/cat", s.handleCat)
+	s.server = &http.Server{Handler: mux}
+	go s.server.Serve(l)
+	return s, nil
+}
+
+func (s *LocalIPFSAPI) URL() string {
+	return "http://" + s.listener.Addr().String()
+}
+
+// SetFailing makes every request fail, to simulate an unreachable node
+func (s *LocalIPFSAPI) SetFailing(failing bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failing = failing
+}
+
+// Pinned returns the CIDs pinned so far
+func (s *LocalIPFSAPI) Pinned() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cids := make([]string, 0, len(s.blobs))
+	for c := range s.blobs {
+		cids = append(cids, c)
+	}
+	return cids
+}
+
+func (s *LocalIPFSAPI) Close() error {
+	return s.server.Shutdown(context.Background())
+}
+
+func (s *LocalIPFSAPI) isFailing() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.failing
+}
+
+func (s *LocalIPFSAPI) handleAdd(w http.ResponseWriter, r *http.Request) {
+	if s.isFailing() {
+		writeIPFSError(w, http.StatusServiceUnavailable, "node unavailable")
+		return
+	}
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writeIPFSError(w, http.StatusBadRequest, "file argument 'data' is required")
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		writeIPFSError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	c := cid.Sum(data).String()
+	s.mu.Lock()
+	s.blobs[c] = data
+	s.mu.Unlock()
+	writeIPFSJSON(w, kuboAddResponse{Name: header.Filename, Hash: c, Size: strconv.Itoa(len(data))})
+}
+
+func (s *LocalIPFSAPI) handlePinLs(w http.ResponseWriter, r *http.Request) {
+	if s.isFailing() {
+		writeIPFSError(w, http.StatusServiceUnavailable, "node unavailable")
+		return
+	}
+	c := r.URL.Query().Get("arg")
+	s.mu.Lock()
+	_, ok := s.blobs[c]
+	s.mu.Unlock()
+	if !ok {
+		writeIPFSError(w, http.StatusInternalServerError, "path '"+c+"' is not pinned")
+		return
+	}
+	writeIPFSJSON(w, map[string]interface{}{"Keys": map[string]interface{}{c: map[string]string{"Type": "recursive"}}})
+}
+
+func (s *LocalIPFSAPI) handleCat(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	data, ok := s.blobs[r.URL.Query().Get("arg")]
+	s.mu.Unlock()
+	if !ok {
+		writeIPFSError(w, http.StatusInternalServerError, "block was not found locally (offline)")
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Write(data)
+}
+
+func writeIPFSJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeIPFSError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{"Message": message, "Code": 0, "Type": "error"})
+}
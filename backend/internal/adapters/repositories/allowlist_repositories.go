@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"errors"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// entries inserted per statement
+const allowlistEntryBatchSize = 500
+
+type gormAllowlistRepository struct {
+	db *gorm.DB
+}
+
+func NewGormAllowlistRepository(db *gorm.DB) repoInterfaces.AllowlistRepository {
+	return &gormAllowlistRepository{db: db}
+}
+
+// the drop row lock serialises uploads, so versions are numbered without gaps
+func (r *gormAllowlistRepository) CreateVersion(allowlist *models.Allowlist, entries []*models.AllowlistEntry) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var drop models.Drop
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", allowlist.DropID).First(&drop).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return repoInterfaces.ErrRecordNotFound
+			}
+			return err
+		}
+		var latest uint
+		if err := tx.Model(&models.Allowlist{}).Where("drop_id = ?", allowlist.DropID).
+			Select("COALESCE(MAX(version), 0)").Scan(&latest).Error; err != nil {
+			return err
+		}
+		allowlist.Version = latest + 1
+		if err := tx.Create(allowlist).Error; err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			entry.AllowlistID = allowlist.ID
+		}
+		return tx.CreateInBatches(entries, allowlistEntryBatchSize).Error
+	})
+}
+
+func (r *gormAllowlistRepository) GetLatest(dropID uint) (*models.Allowlist, error) {
+	var allowlist models.Allowlist
+	if err := r.db.Where("drop_id = ?", dropID).Order("version DESC").First(&allowlist).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repoInterfaces.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &allowlist, nil
+}
+
+func (r *gormAllowlistRepository) ListVersions(dropID uint) ([]*models.Allowlist, error) {
+	var allowlists []*models.Allowlist
+	if err := r.db.Where("drop_id = ?", dropID).Order("version DESC").Find(&allowlists).Error; err != nil {
+		return nil, err
+	}
+	return allowlists, nil
+}
+
+func (r *gormAllowlistRepository) GetEntry(allowlistID uint, address string) (*models.AllowlistEntry, error) {
+	var entry models.AllowlistEntry
+	if err := r.db.Where("allowlist_id = ? AND address = ?", allowlistID, address).First(&entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repoInterfaces.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"gorm.io/gorm"
+)
+
+type gormAssetRepository struct {
+	db *gorm.DB
+}
+
+func NewGormAssetRepository(db *gorm.DB) repoInterfaces.AssetRepository {
+	return &gormAssetRepository{db: db}
+}
+
+func (r *gormAssetRepository) Create(asset *models.Asset) error {
+	if err := r.db.Create(asset).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return repoInterfaces.ErrDuplicateRecord
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *gormAssetRepository) GetByCID(cid string) (*models.Asset, error) {
+	var asset models.Asset
+	if err := r.db.Where("cid = ?", cid).First(&asset).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repoInterfaces.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &asset, nil
+}
+
+func (r *gormAssetRepository) Update(asset *models.Asset) error {
+	return r.db.Save(asset).Error
+}
+
+func (r *gormAssetRepository) ListDuePins(now time.Time, limit int) ([]*models.Asset, error) {
+	var assets []*models.Asset
+	err := r.db.Where("pin_status IN ? AND next_pin_attempt_at <= ?", []string{models.AssetPinStatusPending, models.AssetPinStatusFailed}, now).
+		Order("next_pin_attempt_at").Limit(limit).Find(&assets).Error
+	if err != nil {
+		return nil, err
+	}
+	return assets, nil
+}
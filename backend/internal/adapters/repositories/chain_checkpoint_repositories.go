@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"errors"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormChainCheckpointRepository struct {
+	db *gorm.DB
+}
+
+func NewGormChainCheckpointRepository(db *gorm.DB) repoInterfaces.ChainCheckpointRepository {
+	return &gormChainCheckpointRepository{db: db}
+}
+
+func (r *gormChainCheckpointRepository) GetCheckpoint(chainID int64) (*models.ChainCheckpoint, error) {
+	var checkpoint models.ChainCheckpoint
+	if err := r.db.Where("chain_id = ?", chainID).First(&checkpoint).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repoInterfaces.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+func (r *gormChainCheckpointRepository) Advance(checkpoint *models.ChainCheckpoint, blocks []*models.IndexedBlock) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if len(blocks) > 0 {
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "chain_id"}, {Name: "number"}},
+				DoUpdates: clause.AssignmentColumns([]string{"hash", "parent_hash"}),
+			}).Create(blocks).Error
+			if err != nil {
+				return err
+			}
+		}
+		return saveCheckpoint(tx, checkpoint)
+	})
+}
+
+func (r *gormChainCheckpointRepository) ListBlocks(chainID int64, fromBlock uint64) ([]*models.IndexedBlock, error) {
+	var blocks []*models.IndexedBlock
+	if err := r.db.Where("chain_id = ? AND number >= ?", chainID, fromBlock).Order("number DESC").Find(&blocks).Error; err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+func (r *gormChainCheckpointRepository) Rewind(chainID int64, ancestor *models.IndexedBlock) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("chain_id = ? AND number > ?", chainID, ancestor.Number).Delete(&models.IndexedBlock{}).Error; err != nil {
+			return err
+		}
+		return saveCheckpoint(tx, &models.ChainCheckpoint{ChainID: chainID, BlockNumber: ancestor.Number, BlockHash: ancestor.Hash})
+	})
+}
+
+func (r *gormChainCheckpointRepository) PruneBlocks(chainID int64, beforeBlock uint64) error {
+	return r.db.Where("chain_id = ? AND number < ?", chainID, beforeBlock).Delete(&models.IndexedBlock{}).Error
+}
+
+// saveCheckpoint upserts the single checkpoint row of a chain
+func saveCheckpoint(tx *gorm.DB, checkpoint *models.ChainCheckpoint) error {
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chain_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"block_number", "block_hash", "updated_at"}),
+	}).Create(checkpoint).Error
+}
@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"gorm.io/gorm"
+)
+
+type gormCollectionRepository struct {
+	db *gorm.DB
+}
+
+func NewGormCollectionRepository(db *gorm.DB) repoInterfaces.CollectionRepository {
+	return &gormCollectionRepository{db: db}
+}
+
+func (r *gormCollectionRepository) Create(collection *models.Collection) error {
+	if err := r.db.Create(collection).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return repoInterfaces.ErrDuplicateRecord
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *gormCollectionRepository) GetByID(id uint) (*models.Collection, error) {
+	var collection models.Collection
+	if err := r.db.Where("id = ?", id).First(&collection).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repoInterfaces.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &collection, nil
+}
+
+func (r *gormCollectionRepository) GetByContractAddress(chainID int64, contractAddress string) (*models.Collection, error) {
+	var collection models.Collection
+	if err := r.db.Where("chain_id = ? AND contract_address = ?", chainID, contractAddress).First(&collection).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repoInterfaces.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// get a creator's collection by name, case insensitive search
+func (r *gormCollectionRepository) GetByCreatorAndName(creatorID uint, name string) (*models.Collection, error) {
+	var collection models.Collection
+	if err := r.db.Where("creator_id = ? AND LOWER(name) = LOWER(?)", creatorID, name).First(&collection).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repoInterfaces.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &collection, nil
+}
+
+func (r *gormCollectionRepository) Update(collection *models.Collection) error {
+	return r.db.Save(collection).Error
+}
+
+func (r *gormCollectionRepository) UpdateDetails(collection *models.Collection) error {
+	err := r.db.Model(collection).Select("name", "description", "chain_id", "archived_at").Updates(collection).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return repoInterfaces.ErrDuplicateRecord
+	}
+	return err
+}
+
+// list a creator's collections, newest first
+func (r *gormCollectionRepository) ListByCreator(creatorID uint, includeArchived bool) ([]*models.Collection, error) {
+	var collections []*models.Collection
+	q := r.db.Where("creator_id = ?", creatorID)
+	if !includeArchived {
+		q = q.Where("archived_at IS NULL")
+	}
+	if err := q.Order("created_at DESC").Find(&collections).Error; err != nil {
+		return nil, err
+	}
+	return collections, nil
+}
+
+func (r *gormCollectionRepository) ClaimDeployment(id uint, withZone bool) (bool, error) {
+	res := r.db.Model(&models.Collection{}).
+		Where("id = ? AND contract_address IS NULL AND deployment_status IN ?", id, []string{models.DeploymentStatusNone, models.DeploymentStatusFailed}).
+		Updates(map[string]interface{}{
+			"deployment_status": models.DeploymentStatusDeploying,
+			"deployment_error":  "",
+			"with_zone":         withZone,
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+func (r *gormCollectionRepository) ListByDeploymentStatus(statuses ...string) ([]*models.Collection, error) {
+	var collections []*models.Collection
+	if err := r.db.Where("deployment_status IN ?", statuses).Order("id").Find(&collections).Error; err != nil {
+		return nil, err
+	}
+	return collections, nil
+}
+
+func (r *gormCollectionRepository) ListSignerOutdated(signerAddress string, now time.Time) ([]*models.Collection, error) {
+	var collections []*models.Collection
+	err := r.db.Where("deployment_status = ? AND contract_address IS NOT NULL", models.DeploymentStatusDeployed).
+		Where("signer_address IS NULL OR signer_address <> ? OR signer_tx_hash <> ''", signerAddress).
+		Where("signer_tx_hash <> '' OR pending_signer_address IS NULL OR pending_signer_address <> ? OR (signer_failed_at IS NULL AND (signer_retry_at IS NULL OR signer_retry_at <= ?))",
+			signerAddress, now).
+		Order("id").Find(&collections).Error
+	if err != nil {
+		return nil, err
+	}
+	return collections, nil
+}
+
+func (r *gormCollectionRepository) ListWithContract(chainID int64) ([]*models.Collection, error) {
+	var collections []*models.Collection
+	if err := r.db.Where("chain_id = ? AND contract_address IS NOT NULL", chainID).Order("id").Find(&collections).Error; err != nil {
+		return nil, err
+	}
+	return collections, nil
+}
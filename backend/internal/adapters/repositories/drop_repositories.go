@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormDropRepository struct {
+	db *gorm.DB
+}
+
+func NewGormDropRepository(db *gorm.DB) repoInterfaces.DropRepository {
+	return &gormDropRepository{db: db}
+}
+
+func (r *gormDropRepository) Create(drop *models.Drop) error {
+	return r.db.Create(drop).Error
+}
+
+func (r *gormDropRepository) GetByID(id uint) (*models.Drop, error) {
+	var drop models.Drop
+	if err := r.db.Preload("Collection").Where("id = ?", id).First(&drop).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repoInterfaces.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &drop, nil
+}
+
+func (r *gormDropRepository) Update(drop *models.Drop) error {
+	return r.db.Model(drop).Select("drop_type", "start_time", "end_time", "price", "supply", "max_per_wallet").Updates(drop).Error
+}
+
+func (r *gormDropRepository) ListByCollection(collectionID uint, statuses ...string) ([]*models.Drop, error) {
+	var drops []*models.Drop
+	q := r.db.Where("collection_id = ?", collectionID)
+	if len(statuses) > 0 {
+		q = q.Where("status IN ?", statuses)
+	}
+	if err := q.Order("start_time, id").Find(&drops).Error; err != nil {
+		return nil, err
+	}
+	return drops, nil
+}
+
+// the status guard makes concurrent transitions of one drop race safely
+func (r *gormDropRepository) Transition(drop *models.Drop, from string, event *models.DropEvent) (bool, error) {
+	moved := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(drop).Where("status = ?", from).
+			Select("status", "scheduled_at", "started_at", "ended_at", "archived_at").
+			Updates(drop)
+		if res.Error != nil || res.RowsAffected != 1 {
+			return res.Error
+		}
+		moved = true
+		if event == nil {
+			return nil
+		}
+		event.DropID = drop.ID
+		return tx.Create(event).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return moved, nil
+}
+
+func (r *gormDropRepository) ListDue(now time.Time) ([]*models.Drop, error) {
+	var drops []*models.Drop
+	err := r.db.Preload("Collection").
+		Where("(status = ? AND start_time <= ?) OR (status = ? AND end_time <= ?)",
+			models.DropStatusScheduled, now.Unix(), models.DropStatusLive, now.Unix()).
+		Order("start_time, id").Find(&drops).Error
+	if err != nil {
+		return nil, err
+	}
+	return drops, nil
+}
+
+func (r *gormDropRepository) ListSoldOut() ([]*models.Drop, error) {
+	var drops []*models.Drop
+	err := r.db.Preload("Collection").
+		Where("status = ? AND supply > 0", models.DropStatusLive).
+		Where("(SELECT COUNT(*) FROM nfts WHERE nfts.drop_id = drops.id AND nfts.is_minted AND nfts.deleted_at IS NULL) >= drops.supply").
+		Find(&drops).Error
+	if err != nil {
+		return nil, err
+	}
+	return drops, nil
+}
+
+// SKIP LOCKED lets several processes deliver events side by side
+func (r *gormDropRepository) ClaimNextEvent(now, lockUntil time.Time) (*models.DropEvent, error) {
+	var event models.DropEvent
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("delivered_at IS NULL AND (locked_until IS NULL OR locked_until <= ?)", now).
+			Order("id").First(&event).Error
+		if err != nil {
+			return err
+		}
+		event.Attempts++
+		event.LockedUntil = &lockUntil
+		return tx.Model(&event).Select("attempts", "locked_until").Updates(&event).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *gormDropRepository) MarkEventDelivered(id uint, at time.Time) error {
+	return r.db.Model(&models.DropEvent{}).Where("id = ?", id).Update("delivered_at", at).Error
+}
+
+// the row lock taken by the UPDATE serialises concurrent reservations
+func (r *gormDropRepository) ReserveTokenSequences(dropID uint, n uint64) (uint64, error) {
+	var last uint64
+	res := r.db.Raw("UPDATE drops SET token_sequence = token_sequence + ? WHERE id = ? AND deleted_at IS NULL RETURNING token_sequence", n, dropID).Scan(&last)
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return 0, repoInterfaces.ErrRecordNotFound
+	}
+	return last, nil
+}
+
+func (r *gormDropRepository) Subscribe(dropID, userID uint) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.DropSubscription{DropID: dropID, UserID: userID}).Error
+}
+
+func (r *gormDropRepository) Unsubscribe(dropID, userID uint) error {
+	return r.db.Where("drop_id = ? AND user_id = ?", dropID, userID).Delete(&models.DropSubscription{}).Error
+}
+
+func (r *gormDropRepository) ListSubscriberIDs(dropID uint) ([]uint, error) {
+	var ids []uint
+	if err := r.db.Model(&models.DropSubscription{}).Where("drop_id = ?", dropID).Order("id").Pluck("user_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
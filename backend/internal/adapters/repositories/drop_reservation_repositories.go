@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormDropReservationRepository struct {
+	db *gorm.DB
+}
+
+func NewGormDropReservationRepository(db *gorm.DB) repoInterfaces.DropReservationRepository {
+	return &gormDropReservationRepository{db: db}
+}
+
+// redeemableVoucher matches a voucher of the NFT a reservation holds a slot
+// for that the contract still accepts: one signed by the collection's
+// current signer and neither redeemed nor superseded, issued to a collector
+// or, in a priced drop, to the creator for a listing. The contract ignores
+// expiry and revocation, so a hold backing one keeps counting past its
+// ExpiresAt until the signer is rotated.
+var redeemableVoucher = fmt.Sprintf(`EXISTS (SELECT 1 FROM vouchers v
+	JOIN drops d ON d.id = drop_reservations.drop_id
+	JOIN collections c ON c.id = d.collection_id
+	WHERE v.nft_id = drop_reservations.nft_id AND (NOT v.for_creator OR d.price > 0)
+		AND v.status IN ('%s', '%s', '%s') AND v.signer_address = c.signer_address)`,
+	models.VoucherStatusIssued, models.VoucherStatusExpired, models.VoucherStatusRevoked)
+
+// liveHold matches the held reservations that count at the first argument
+var liveHold = "drop_reservations.status = '" + models.DropReservationHeld + "' AND " +
+	"(drop_reservations.expires_at > ? OR " + redeemableVoucher + ")"
+
+// the drop row lock serialises reservations of a drop, so each one counts
+// the slots and copies every earlier one committed. A new voucher for an NFT
+// that already holds a slot takes that slot over: only one of its vouchers
+// can ever mint. Another wallet has to wait for the hold to expire, its own
+// holder need not.
+func (r *gormDropReservationRepository) Reserve(reservation *models.DropReservation, quota *uint64, now time.Time) (bool, error) {
+	reserved := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var drop models.Drop
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "supply").
+			Where("id = ?", reservation.DropID).First(&drop).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return repoInterfaces.ErrRecordNotFound
+			}
+			return err
+		}
+		var held models.DropReservation
+		err = tx.Where("nft_id = ? AND status = ?", reservation.NFTID, models.DropReservationHeld).First(&held).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if held.ID != 0 && held.ExpiresAt.After(now) && !strings.EqualFold(held.OwnerAddress, reservation.OwnerAddress) {
+			return repoInterfaces.ErrDuplicateRecord
+		}
+
+		if quota != nil {
+			var allocated uint64
+			err := tx.Model(&models.DropReservation{}).Select("COALESCE(SUM(amount), 0)").
+				Where("drop_id = ? AND LOWER(owner_address) = LOWER(?) AND id <> ?", drop.ID, reservation.OwnerAddress, held.ID).
+				Where("status = ? OR ("+liveHold+")", models.DropReservationSold, now).
+				Scan(&allocated).Error
+			if err != nil {
+				return err
+			}
+			if allocated+reservation.Amount > *quota {
+				return repoInterfaces.ErrQuotaExceeded
+			}
+		}
+		if drop.Supply > 0 {
+			var minted, holds int64
+			err := tx.Model(&models.NFT{}).Where("drop_id = ? AND is_minted", drop.ID).Count(&minted).Error
+			if err != nil {
+				return err
+			}
+			err = tx.Model(&models.DropReservation{}).
+				Joins("JOIN nfts n ON n.id = drop_reservations.nft_id").
+				Where("drop_reservations.drop_id = ? AND drop_reservations.id <> ? AND NOT n.is_minted", drop.ID, held.ID).
+				Where(liveHold, now).Count(&holds).Error
+			if err != nil {
+				return err
+			}
+			if minted+holds >= drop.Supply {
+				return nil
+			}
+		}
+
+		reservation.Status = models.DropReservationHeld
+		if held.ID != 0 {
+			reservation.ID = held.ID
+			reservation.CreatedAt = held.CreatedAt
+			if err := tx.Model(&held).Select("owner_address", "amount", "expires_at").Updates(reservation).Error; err != nil {
+				return err
+			}
+		} else if err := tx.Create(reservation).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return repoInterfaces.ErrDuplicateRecord
+			}
+			return err
+		}
+		reserved = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return reserved, nil
+}
+
+func (r *gormDropReservationRepository) Release(nftID uint, at time.Time) (bool, error) {
+	res := r.db.Model(&models.DropReservation{}).
+		Where("nft_id = ? AND status = ? AND NOT "+redeemableVoucher, nftID, models.DropReservationHeld).
+		Updates(map[string]interface{}{"status": models.DropReservationReleased, "released_at": at})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *gormDropReservationRepository) ReleaseExpired(now time.Time) (int64, error) {
+	res := r.db.Model(&models.DropReservation{}).
+		Where("status = ? AND expires_at <= ? AND NOT "+redeemableVoucher, models.DropReservationHeld, now).
+		Updates(map[string]interface{}{"status": models.DropReservationReleased, "released_at": now})
+	return res.RowsAffected, res.Error
+}
@@ -0,0 +1,272 @@
+package repositories
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	testSigner   = "0x00000000000000000000000000000000000000a1"
+	testContract = "0x00000000000000000000000000000000000000b1"
+	testChainID  = 1337
+)
+
+// openTestDB connects to the Postgres in TEST_DATABASE_URL with a schema of
+// its own that is dropped after the test
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	suffix := make([]byte, 6)
+	if _, err := rand.Read(suffix); err != nil {
+		t.Fatal(err)
+	}
+	schema := "test_" + hex.EncodeToString(suffix)
+
+	config := &gorm.Config{TranslateError: true, Logger: logger.Discard}
+	admin, err := gorm.Open(postgres.Open(dsn), config)
+	if err != nil {
+		t.Fatalf("failed to connect to the test database: %v", err)
+	}
+	if err := admin.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+		if sqlDB, err := admin.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	if strings.Contains(dsn, "://") {
+		separator := "?"
+		if strings.Contains(dsn, "?") {
+			separator = "&"
+		}
+		dsn += separator + "search_path=" + schema
+	} else {
+		dsn += " search_path=" + schema
+	}
+	db, err := gorm.Open(postgres.Open(dsn), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	if err := db.AutoMigrate(&models.User{}, &models.Collection{}, &models.Drop{}, &models.NFT{}, &models.Voucher{}, &models.DropReservation{}); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// seedDrop stores a live drop of supply copies with nfts NFTs, on a
+// collection of chainID whose contract, at the same address on every chain,
+// accepts testSigner
+func seedDrop(t *testing.T, db *gorm.DB, chainID int64, supply int64, nfts int) (*models.Collection, *models.Drop, []*models.NFT) {
+	t.Helper()
+	creator := &models.User{WalletAddress: fmt.Sprintf("0x%040x", 0xc000+chainID), Username: fmt.Sprintf("creator%d", chainID), Role: models.RoleCreator}
+	if err := db.Create(creator).Error; err != nil {
+		t.Fatal(err)
+	}
+	contract, signer := testContract, testSigner
+	collection := &models.Collection{CreatorID: creator.ID, Name: "Drops", ChainID: chainID, ContractAddress: &contract, SignerAddress: &signer}
+	if err := db.Create(collection).Error; err != nil {
+		t.Fatal(err)
+	}
+	drop := &models.Drop{CollectionID: collection.ID, DropType: models.DropTypePublic, Supply: supply, Status: models.DropStatusLive}
+	if err := db.Create(drop).Error; err != nil {
+		t.Fatal(err)
+	}
+	created := make([]*models.NFT, nfts)
+	for i := range created {
+		tokenID := new(big.Int).Lsh(big.NewInt(int64(drop.ID)), 128)
+		created[i] = &models.NFT{DropID: drop.ID, TokenID: models.NewUint256(tokenID.Add(tokenID, big.NewInt(int64(i+1))))}
+		if err := db.Create(created[i]).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	return collection, drop, created
+}
+
+func wallet(i int) string {
+	return fmt.Sprintf("0x%040x", 0xd000+i)
+}
+
+// reserveAll asks for a slot of every nft at once, one goroutine each,
+// and counts the slots granted
+func reserveAll(t *testing.T, repo repoInterfaces.DropReservationRepository, drop *models.Drop, nfts []*models.NFT, owner func(int) string, quota *uint64) int {
+	t.Helper()
+	now := time.Now()
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		reserved int
+		errs     []error
+	)
+	start := make(chan struct{})
+	for i, nft := range nfts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			ok, err := repo.Reserve(&models.DropReservation{
+				DropID:       drop.ID,
+				NFTID:        nft.ID,
+				OwnerAddress: owner(i),
+				Amount:       1,
+				ExpiresAt:    now.Add(time.Minute),
+			}, quota, now)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil && ok:
+				reserved++
+			case err != nil && !errors.Is(err, repoInterfaces.ErrQuotaExceeded):
+				errs = append(errs, err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		t.Fatal(err)
+	}
+	return reserved
+}
+
+func TestReserveNeverOversellsUnderConcurrency(t *testing.T) {
+	db := openTestDB(t)
+	_, drop, nfts := seedDrop(t, db, testChainID, 5, 60)
+	repo := NewGormDropReservationRepository(db)
+
+	if reserved := reserveAll(t, repo, drop, nfts, wallet, nil); reserved != 5 {
+		t.Fatalf("%d slots reserved of a supply of 5", reserved)
+	}
+	var held int64
+	if err := db.Model(&models.DropReservation{}).Where("status = ?", models.DropReservationHeld).Count(&held).Error; err != nil {
+		t.Fatal(err)
+	}
+	if held != 5 {
+		t.Fatalf("%d holds stored, want 5", held)
+	}
+}
+
+func TestReserveKeepsWalletQuotaUnderConcurrency(t *testing.T) {
+	db := openTestDB(t)
+	_, drop, nfts := seedDrop(t, db, testChainID, 100, 40)
+	repo := NewGormDropReservationRepository(db)
+
+	quota := uint64(3)
+	sameWallet := func(int) string { return wallet(1) }
+	if reserved := reserveAll(t, repo, drop, nfts, sameWallet, &quota); reserved != 3 {
+		t.Fatalf("one wallet reserved %d slots with a quota of 3", reserved)
+	}
+}
+
+// The contract ignores voucher expiry, so a lapsed hold whose voucher can
+// still mint keeps its slot until the collection's signer is rotated.
+func TestExpiredHoldCountsWhileItsVoucherCanMint(t *testing.T) {
+	db := openTestDB(t)
+	collection, drop, nfts := seedDrop(t, db, testChainID, 1, 2)
+	repo := NewGormDropReservationRepository(db)
+	now := time.Now()
+
+	lapsed := &models.DropReservation{DropID: drop.ID, NFTID: nfts[0].ID, OwnerAddress: wallet(1), Amount: 1, ExpiresAt: now.Add(time.Minute)}
+	if ok, err := repo.Reserve(lapsed, nil, now); err != nil || !ok {
+		t.Fatalf("reserve = %v, %v", ok, err)
+	}
+	voucher := &models.Voucher{NFTID: nfts[0].ID, ChainID: testChainID, ContractAddress: *collection.ContractAddress, OwnerAddress: wallet(1),
+		TokenID: nfts[0].TokenID, Amount: "1", URI: "ipfs://metadata", Signature: "0x", SignerAddress: testSigner, Digest: "0x",
+		IssuedAt: now, ExpiresAt: now.Add(time.Minute), Status: models.VoucherStatusRevoked}
+	if err := db.Create(voucher).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	later := now.Add(time.Hour)
+	other := &models.DropReservation{DropID: drop.ID, NFTID: nfts[1].ID, OwnerAddress: wallet(2), Amount: 1, ExpiresAt: later.Add(time.Minute)}
+	if ok, err := repo.Reserve(other, nil, later); err != nil || ok {
+		t.Fatalf("reserve = %v, %v; want the drop sold out", ok, err)
+	}
+	if released, err := repo.ReleaseExpired(later); err != nil || released != 0 {
+		t.Fatalf("released %d, %v; want the hold kept", released, err)
+	}
+	if released, err := repo.Release(nfts[0].ID, later); err != nil || released {
+		t.Fatalf("released = %v, %v; want the hold kept", released, err)
+	}
+
+	// a new voucher for the same NFT takes its slot over
+	takeover := &models.DropReservation{DropID: drop.ID, NFTID: nfts[0].ID, OwnerAddress: wallet(3), Amount: 1, ExpiresAt: later.Add(time.Minute)}
+	if ok, err := repo.Reserve(takeover, nil, later); err != nil || !ok || takeover.ID != lapsed.ID {
+		t.Fatalf("reserve = %v, %v with id %d; want the slot of hold %d", ok, err, takeover.ID, lapsed.ID)
+	}
+
+	rotated := "0x00000000000000000000000000000000000000a2"
+	if err := db.Model(collection).Update("signer_address", rotated).Error; err != nil {
+		t.Fatal(err)
+	}
+	muchLater := later.Add(time.Hour)
+	if released, err := repo.ReleaseExpired(muchLater); err != nil || released != 1 {
+		t.Fatalf("released %d, %v; want the hold freed once the signer was rotated", released, err)
+	}
+	other.ExpiresAt = muchLater.Add(time.Minute)
+	if ok, err := repo.Reserve(other, nil, muchLater); err != nil || !ok {
+		t.Fatalf("reserve = %v, %v; want the freed slot", ok, err)
+	}
+}
+
+// a priced drop sells through the creator's listings, whose vouchers mint
+// as surely as a collector's
+func TestListingVoucherHoldsASlotOfAPricedDrop(t *testing.T) {
+	db := openTestDB(t)
+	collection, drop, nfts := seedDrop(t, db, testChainID, 1, 2)
+	if err := db.Model(drop).Update("price", 1000).Error; err != nil {
+		t.Fatal(err)
+	}
+	repo := NewGormDropReservationRepository(db)
+	creator := fmt.Sprintf("0x%040x", 0xc000+testChainID)
+	now := time.Now()
+
+	listed := &models.DropReservation{DropID: drop.ID, NFTID: nfts[0].ID, OwnerAddress: creator, Amount: 5, ExpiresAt: now.Add(time.Minute)}
+	if ok, err := repo.Reserve(listed, nil, now); err != nil || !ok {
+		t.Fatalf("reserve = %v, %v", ok, err)
+	}
+	// the creator relists before the hold expires
+	relisted := &models.DropReservation{DropID: drop.ID, NFTID: nfts[0].ID, OwnerAddress: creator, Amount: 3, ExpiresAt: now.Add(time.Minute)}
+	if ok, err := repo.Reserve(relisted, nil, now); err != nil || !ok || relisted.ID != listed.ID {
+		t.Fatalf("reserve = %v, %v with id %d; want the slot of hold %d", ok, err, relisted.ID, listed.ID)
+	}
+	voucher := &models.Voucher{NFTID: nfts[0].ID, ChainID: testChainID, ContractAddress: *collection.ContractAddress, OwnerAddress: creator,
+		TokenID: nfts[0].TokenID, Amount: "3", URI: "ipfs://metadata", Signature: "0x", SignerAddress: testSigner, Digest: "0x",
+		IssuedAt: now, ExpiresAt: now.Add(24 * time.Hour), Status: models.VoucherStatusIssued, ForCreator: true}
+	if err := db.Create(voucher).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	later := now.Add(time.Hour)
+	if released, err := repo.ReleaseExpired(later); err != nil || released != 0 {
+		t.Fatalf("released %d, %v; want the listing's hold kept", released, err)
+	}
+	other := &models.DropReservation{DropID: drop.ID, NFTID: nfts[1].ID, OwnerAddress: creator, Amount: 1, ExpiresAt: later.Add(time.Minute)}
+	if ok, err := repo.Reserve(other, nil, later); err != nil || ok {
+		t.Fatalf("reserve = %v, %v; want the drop sold out", ok, err)
+	}
+}
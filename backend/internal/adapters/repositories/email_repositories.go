@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormEmailRepository struct {
+	db *gorm.DB
+}
+
+func NewGormEmailRepository(db *gorm.DB) repoInterfaces.EmailRepository {
+	return &gormEmailRepository{db: db}
+}
+
+func (r *gormEmailRepository) CreateMessage(message *models.EmailMessage) error {
+	if err := r.db.Create(message).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return repoInterfaces.ErrDuplicateRecord
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *gormEmailRepository) UpdateMessage(message *models.EmailMessage) error {
+	return r.db.Save(message).Error
+}
+
+// list pending messages whose next attempt is due, oldest first
+func (r *gormEmailRepository) ListDueMessages(now time.Time, limit int) ([]*models.EmailMessage, error) {
+	var messages []*models.EmailMessage
+	err := r.db.Where("status = ? AND next_attempt_at <= ?", models.EmailStatusPending, now).
+		Order("next_attempt_at").Limit(limit).Find(&messages).Error
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (r *gormEmailRepository) IsUnsubscribed(userID uint, category string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.EmailUnsubscribe{}).Where("user_id = ? AND category = ?", userID, category).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *gormEmailRepository) Unsubscribe(userID uint, category string) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.EmailUnsubscribe{UserID: userID, Category: category}).Error
+}
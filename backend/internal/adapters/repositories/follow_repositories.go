@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"errors"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"gorm.io/gorm"
+)
+
+type gormFollowRepository struct {
+	db *gorm.DB
+}
+
+func NewGormFollowRepository(db *gorm.DB) repoInterfaces.FollowRepository {
+	return &gormFollowRepository{db: db}
+}
+
+func (r *gormFollowRepository) Create(follow *models.Follow) error {
+	if err := r.db.Create(follow).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return repoInterfaces.ErrDuplicateRecord
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *gormFollowRepository) Delete(followerID, followeeID uint) (bool, error) {
+	res := r.db.Where("follower_id = ? AND followee_id = ?", followerID, followeeID).Delete(&models.Follow{})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *gormFollowRepository) ListFollowerIDs(followeeID uint) ([]uint, error) {
+	var ids []uint
+	if err := r.db.Model(&models.Follow{}).Where("followee_id = ?", followeeID).Order("id").Pluck("follower_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormImportJobRepository struct {
+	db *gorm.DB
+}
+
+func NewGormImportJobRepository(db *gorm.DB) repoInterfaces.ImportJobRepository {
+	return &gormImportJobRepository{db: db}
+}
+
+func (r *gormImportJobRepository) Create(job *models.ImportJob) error {
+	if err := r.db.Create(job).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return repoInterfaces.ErrDuplicateRecord
+		}
+		return err
+	}
+	return nil
+}
+
+// the archive stays in the database; callers never need it
+func (r *gormImportJobRepository) GetByID(id uint) (*models.ImportJob, error) {
+	var job models.ImportJob
+	if err := r.db.Omit("archive", "manifest").Where("id = ?", id).First(&job).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repoInterfaces.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *gormImportJobRepository) GetByDropAndHash(dropID uint, archiveHash string) (*models.ImportJob, error) {
+	var job models.ImportJob
+	err := r.db.Omit("archive", "manifest").Where("drop_id = ? AND archive_hash = ?", dropID, archiveHash).First(&job).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repoInterfaces.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *gormImportJobRepository) Update(job *models.ImportJob) error {
+	return r.db.Omit("Drop").Save(job).Error
+}
+
+func (r *gormImportJobRepository) UpdateProgress(job *models.ImportJob) error {
+	return r.db.Model(job).Select("processed_rows", "skipped_rows", "failed_rows", "errors", "results", "updated_at").Updates(job).Error
+}
+
+func (r *gormImportJobRepository) ClaimNext(staleBefore time.Time) (*models.ImportJob, error) {
+	var job models.ImportJob
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND updated_at < ?)", models.ImportStatusQueued, models.ImportStatusProcessing, staleBefore).
+			Order("id").First(&job).Error
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		job.Status = models.ImportStatusProcessing
+		if job.StartedAt == nil {
+			job.StartedAt = &now
+		}
+		return tx.Model(&job).Select("status", "started_at", "updated_at").Updates(&job).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
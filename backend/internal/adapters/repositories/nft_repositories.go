@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"gorm.io/gorm"
+)
+
+type gormNFTRepository struct {
+	db *gorm.DB
+}
+
+func NewGormNFTRepository(db *gorm.DB) repoInterfaces.NFTRepository {
+	return &gormNFTRepository{db: db}
+}
+
+func (r *gormNFTRepository) Create(nft *models.NFT) error {
+	if err := r.db.Create(nft).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return repoInterfaces.ErrDuplicateRecord
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *gormNFTRepository) GetByID(id uint) (*models.NFT, error) {
+	var nft models.NFT
+	if err := r.db.Preload("Drop.Collection").Where("id = ?", id).First(&nft).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repoInterfaces.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &nft, nil
+}
+
+func (r *gormNFTRepository) Update(nft *models.NFT) error {
+	return r.db.Omit("Drop").Save(nft).Error
+}
+
+func (r *gormNFTRepository) GetByContractAndTokenID(chainID int64, contractAddress string, tokenID models.Uint256) (*models.NFT, error) {
+	var nft models.NFT
+	err := r.db.Joins("JOIN drops ON drops.id = nfts.drop_id AND drops.deleted_at IS NULL").
+		Joins("JOIN collections ON collections.id = drops.collection_id AND collections.deleted_at IS NULL").
+		Where("collections.chain_id = ? AND collections.contract_address = ? AND nfts.token_id = ?", chainID, contractAddress, tokenID).
+		First(&nft).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repoInterfaces.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &nft, nil
+}
+
+func (r *gormNFTRepository) MarkMinted(id uint, block uint64, txHash, uri string, mintedAt time.Time) (bool, error) {
+	minted := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.NFT{}).Where("id = ? AND is_minted = ?", id, false).Updates(map[string]interface{}{
+			"is_minted":    true,
+			"mint_block":   block,
+			"mint_tx_hash": txHash,
+			"minted_at":    mintedAt,
+			"minted_uri":   uri,
+		})
+		if res.Error != nil || res.RowsAffected != 1 {
+			return res.Error
+		}
+		minted = true
+		return tx.Model(&models.DropReservation{}).
+			Where("id = (SELECT id FROM drop_reservations WHERE nft_id = ? AND status <> ? ORDER BY id DESC LIMIT 1)",
+				id, models.DropReservationSold).
+			Updates(map[string]interface{}{"status": models.DropReservationSold, "sold_at": mintedAt, "mint_tx_hash": txHash}).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return minted, nil
+}
+
+// a restored voucher gets back the status its other fields imply, and the
+// drop slot the mint sold is held for it again
+func (r *gormNFTRepository) RevertMintsFromBlock(chainID int64, fromBlock uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		reverted := func() *gorm.DB {
+			return tx.Model(&models.NFT{}).Select("nfts.id").
+				Joins("JOIN drops ON drops.id = nfts.drop_id").
+				Joins("JOIN collections ON collections.id = drops.collection_id").
+				Where("collections.chain_id = ? AND nfts.mint_block >= ?", chainID, fromBlock)
+		}
+		err := tx.Model(&models.Voucher{}).
+			Where("status = ? AND redeem_tx_hash <> ''", models.VoucherStatusRedeemed).
+			Where("nft_id IN (?)", reverted().Where("nfts.mint_tx_hash = vouchers.redeem_tx_hash")).
+			Updates(map[string]interface{}{
+				"status": gorm.Expr("CASE WHEN superseded_by_id IS NOT NULL THEN ? WHEN revoked_at IS NOT NULL THEN ? WHEN expires_at <= NOW() THEN ? ELSE ? END",
+					models.VoucherStatusSuperseded, models.VoucherStatusRevoked, models.VoucherStatusExpired, models.VoucherStatusIssued),
+				"redeemed_at":    nil,
+				"redeem_tx_hash": "",
+			}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&models.DropReservation{}).
+			Where("status = ?", models.DropReservationSold).
+			Where("nft_id IN (?)", reverted().Where("nfts.mint_tx_hash = drop_reservations.mint_tx_hash")).
+			Where("NOT EXISTS (SELECT 1 FROM drop_reservations h WHERE h.nft_id = drop_reservations.nft_id AND h.status = ?)", models.DropReservationHeld).
+			Updates(map[string]interface{}{
+				"status":       models.DropReservationHeld,
+				"sold_at":      nil,
+				"mint_tx_hash": "",
+			}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&models.NFT{}).Where("id IN (?)", reverted()).Updates(map[string]interface{}{
+			"is_minted":    false,
+			"mint_block":   nil,
+			"mint_tx_hash": nil,
+			"minted_at":    nil,
+			"minted_uri":   "",
+		}).Error
+	})
+}
+
+func (r *gormNFTRepository) ListByImportKeys(dropID uint, keys []string) ([]*models.NFT, error) {
+	var nfts []*models.NFT
+	if len(keys) == 0 {
+		return nfts, nil
+	}
+	if err := r.db.Where("drop_id = ? AND import_key IN ?", dropID, keys).Find(&nfts).Error; err != nil {
+		return nil, err
+	}
+	return nfts, nil
+}
@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"gorm.io/gorm"
+)
+
+// seedMint mints nft at block with a voucher it redeemed and the drop slot
+// it sold
+func seedMint(t *testing.T, db *gorm.DB, drop *models.Drop, nft *models.NFT, chainID int64, block uint64, txHash string) {
+	t.Helper()
+	now := time.Now()
+	held := &models.DropReservation{DropID: drop.ID, NFTID: nft.ID, OwnerAddress: wallet(1), Amount: 1,
+		Status: models.DropReservationHeld, ExpiresAt: now}
+	if err := db.Create(held).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewGormNFTRepository(db).MarkMinted(nft.ID, block, txHash, "ipfs://metadata", now); err != nil {
+		t.Fatal(err)
+	}
+	voucher := &models.Voucher{NFTID: nft.ID, ChainID: chainID, ContractAddress: testContract, OwnerAddress: wallet(1),
+		TokenID: nft.TokenID, Amount: "1", URI: "ipfs://metadata", Signature: "0x", SignerAddress: testSigner, Digest: "0x",
+		IssuedAt: now, ExpiresAt: now.Add(time.Hour), Status: models.VoucherStatusRedeemed, RedeemedAt: &now, RedeemTxHash: txHash}
+	if err := db.Create(voucher).Error; err != nil {
+		t.Fatal(err)
+	}
+}
+
+// the slot is sold with the mint, not by a later event a crash could lose
+func TestMarkMintedSellsTheDropSlot(t *testing.T) {
+	db := openTestDB(t)
+	_, drop, nfts := seedDrop(t, db, testChainID, 10, 1)
+	seedMint(t, db, drop, nfts[0], testChainID, 5, "0xaa")
+
+	var reservation models.DropReservation
+	if err := db.Where("nft_id = ?", nfts[0].ID).First(&reservation).Error; err != nil {
+		t.Fatal(err)
+	}
+	if reservation.Status != models.DropReservationSold || reservation.MintTxHash != "0xaa" || reservation.SoldAt == nil {
+		t.Fatalf("reservation = %s sold in %q, want sold in 0xaa", reservation.Status, reservation.MintTxHash)
+	}
+	minted, err := NewGormNFTRepository(db).MarkMinted(nfts[0].ID, 6, "0xbb", "ipfs://metadata", time.Now())
+	if err != nil || minted {
+		t.Fatalf("minted again = %v, %v; want false", minted, err)
+	}
+	if err := db.First(&reservation, reservation.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if reservation.MintTxHash != "0xaa" {
+		t.Fatalf("reservation sold in %q, want it left at 0xaa", reservation.MintTxHash)
+	}
+}
+
+func TestRevertMintsFromBlockStaysOnItsChain(t *testing.T) {
+	db := openTestDB(t)
+	const otherChainID = 97
+	_, dropA, nftsA := seedDrop(t, db, testChainID, 10, 1)
+	_, dropB, nftsB := seedDrop(t, db, otherChainID, 10, 1)
+	// the same block and transaction hash on both chains
+	seedMint(t, db, dropA, nftsA[0], testChainID, 5, "0xaa")
+	seedMint(t, db, dropB, nftsB[0], otherChainID, 5, "0xaa")
+
+	if err := NewGormNFTRepository(db).RevertMintsFromBlock(testChainID, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(nft *models.NFT, minted bool, voucherStatus, reservationStatus string) {
+		t.Helper()
+		var got models.NFT
+		if err := db.First(&got, nft.ID).Error; err != nil {
+			t.Fatal(err)
+		}
+		if got.IsMinted != minted || (got.MintBlock != nil) != minted {
+			t.Fatalf("nft %d minted = %v at %v, want %v", nft.ID, got.IsMinted, got.MintBlock, minted)
+		}
+		var voucher models.Voucher
+		if err := db.Where("nft_id = ?", nft.ID).First(&voucher).Error; err != nil {
+			t.Fatal(err)
+		}
+		if voucher.Status != voucherStatus || (voucher.RedeemTxHash != "") != minted {
+			t.Fatalf("voucher of nft %d = %s redeemed in %q, want %s", nft.ID, voucher.Status, voucher.RedeemTxHash, voucherStatus)
+		}
+		var reservation models.DropReservation
+		if err := db.Where("nft_id = ?", nft.ID).First(&reservation).Error; err != nil {
+			t.Fatal(err)
+		}
+		if reservation.Status != reservationStatus || (reservation.SoldAt != nil) != minted {
+			t.Fatalf("reservation of nft %d = %s, want %s", nft.ID, reservation.Status, reservationStatus)
+		}
+	}
+	check(nftsA[0], false, models.VoucherStatusIssued, models.DropReservationHeld)
+	check(nftsB[0], true, models.VoucherStatusRedeemed, models.DropReservationSold)
+}
+
+func TestRevertMintsFromBlockKeepsEarlierMints(t *testing.T) {
+	db := openTestDB(t)
+	_, drop, nfts := seedDrop(t, db, testChainID, 10, 2)
+	seedMint(t, db, drop, nfts[0], testChainID, 2, "0xaa")
+	seedMint(t, db, drop, nfts[1], testChainID, 6, "0xbb")
+
+	if err := NewGormNFTRepository(db).RevertMintsFromBlock(testChainID, 4); err != nil {
+		t.Fatal(err)
+	}
+	var minted []uint
+	if err := db.Model(&models.NFT{}).Where("is_minted").Pluck("id", &minted).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(minted) != 1 || minted[0] != nfts[0].ID {
+		t.Fatalf("minted nfts %v, want only %d", minted, nfts[0].ID)
+	}
+	var sold int64
+	if err := db.Model(&models.DropReservation{}).Where("status = ?", models.DropReservationSold).Count(&sold).Error; err != nil {
+		t.Fatal(err)
+	}
+	if sold != 1 {
+		t.Fatalf("%d sold reservations, want 1", sold)
+	}
+}
+
+func TestGetByContractAndTokenIDStaysOnItsChain(t *testing.T) {
+	db := openTestDB(t)
+	const otherChainID = 97
+	_, _, nftsA := seedDrop(t, db, testChainID, 10, 1)
+	_, _, nftsB := seedDrop(t, db, otherChainID, 10, 1)
+	repo := NewGormNFTRepository(db)
+
+	for _, tt := range []struct {
+		chainID int64
+		nft     *models.NFT
+	}{{testChainID, nftsA[0]}, {otherChainID, nftsB[0]}} {
+		got, err := repo.GetByContractAndTokenID(tt.chainID, testContract, tt.nft.TokenID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.ID != tt.nft.ID {
+			t.Fatalf("chain %d resolved nft %d, want %d", tt.chainID, got.ID, tt.nft.ID)
+		}
+	}
+	// the contract on the other chain never minted this token
+	if _, err := repo.GetByContractAndTokenID(otherChainID, testContract, nftsA[0].TokenID); !errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+		t.Fatalf("err = %v, want %v", err, repoInterfaces.ErrRecordNotFound)
+	}
+}
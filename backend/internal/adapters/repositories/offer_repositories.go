@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"gorm.io/gorm"
+)
+
+type gormOfferRepository struct {
+	db *gorm.DB
+}
+
+func NewGormOfferRepository(db *gorm.DB) repoInterfaces.OfferRepository {
+	return &gormOfferRepository{db: db}
+}
+
+func (r *gormOfferRepository) Create(offer *models.Offer) error {
+	if err := r.db.Create(offer).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return repoInterfaces.ErrDuplicateRecord
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *gormOfferRepository) GetByHash(orderHash string) (*models.Offer, error) {
+	var offer models.Offer
+	if err := r.db.Where("order_hash = ?", orderHash).First(&offer).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repoInterfaces.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &offer, nil
+}
+
+func (r *gormOfferRepository) List(filter repoInterfaces.OfferFilter) ([]*models.Offer, error) {
+	query := r.db.Model(&models.Offer{})
+	switch {
+	case filter.NFTID != 0 && filter.IncludeCollection:
+		query = query.Where("nft_id = ? OR (nft_id IS NULL AND collection_id = ?)", filter.NFTID, filter.CollectionID)
+	case filter.NFTID != 0:
+		query = query.Where("nft_id = ?", filter.NFTID)
+	case filter.CollectionID != 0:
+		query = query.Where("collection_id = ?", filter.CollectionID)
+	}
+	if filter.Maker != "" {
+		query = query.Where("maker = ?", filter.Maker)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.LiveAt != nil {
+		query = query.Where("status = ? AND start_time <= ? AND end_time > ?", models.OrderStatusActive, *filter.LiveAt, *filter.LiveAt)
+	}
+	var offers []*models.Offer
+	if err := query.Order("unit_price DESC, id ASC").Limit(filter.Limit).Offset(filter.Offset).Find(&offers).Error; err != nil {
+		return nil, err
+	}
+	return offers, nil
+}
+
+func (r *gormOfferRepository) ExpireEnded(now time.Time) (int64, error) {
+	res := r.db.Model(&models.Offer{}).
+		Where("status = ? AND end_time <= ?", models.OrderStatusActive, now).
+		Updates(map[string]interface{}{"status": models.OrderStatusExpired, "updated_at": now})
+	return res.RowsAffected, res.Error
+}
+
+// expired offers can still be cancelled so they do not come back after a reorg
+func (r *gormOfferRepository) MarkCancelled(chainID int64, orderHash string, at time.Time, block uint64) (bool, error) {
+	res := r.db.Model(&models.Offer{}).
+		Where("chain_id = ? AND order_hash = ? AND status IN ?", chainID, orderHash, []string{models.OrderStatusActive, models.OrderStatusExpired}).
+		Updates(map[string]interface{}{"status": models.OrderStatusCancelled, "cancelled_at": at, "cancelled_block": block})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *gormOfferRepository) CancelBelowCounter(chainID int64, maker string, counter models.Uint256, at time.Time, block uint64) (int64, error) {
+	res := r.db.Model(&models.Offer{}).
+		Where("chain_id = ? AND maker = ? AND counter < ? AND status IN ?", chainID, maker, counter, []string{models.OrderStatusActive, models.OrderStatusExpired}).
+		Updates(map[string]interface{}{"status": models.OrderStatusCancelled, "cancelled_at": at, "cancelled_block": block})
+	return res.RowsAffected, res.Error
+}
+
+func (r *gormOfferRepository) RevertCancellationsFromBlock(chainID int64, fromBlock uint64) error {
+	return r.db.Model(&models.Offer{}).
+		Where("chain_id = ? AND cancelled_block >= ?", chainID, fromBlock).
+		Updates(map[string]interface{}{
+			"status":          gorm.Expr("CASE WHEN end_time <= NOW() THEN ? ELSE ? END", models.OrderStatusExpired, models.OrderStatusActive),
+			"cancelled_at":    nil,
+			"cancelled_block": nil,
+		}).Error
+}
+
+func (r *gormOfferRepository) ListActiveMakers(chainID int64) ([]string, error) {
+	var makers []string
+	if err := r.db.Model(&models.Offer{}).Where("chain_id = ? AND status = ?", chainID, models.OrderStatusActive).Distinct().Pluck("maker", &makers).Error; err != nil {
+		return nil, err
+	}
+	return makers, nil
+}
@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"gorm.io/gorm"
+)
+
+type gormOrderRepository struct {
+	db *gorm.DB
+}
+
+func NewGormOrderRepository(db *gorm.DB) repoInterfaces.OrderRepository {
+	return &gormOrderRepository{db: db}
+}
+
+func (r *gormOrderRepository) Create(order *models.Order) error {
+	if err := r.db.Create(order).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return repoInterfaces.ErrDuplicateRecord
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *gormOrderRepository) GetByHash(orderHash string) (*models.Order, error) {
+	var order models.Order
+	if err := r.db.Where("order_hash = ?", orderHash).First(&order).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repoInterfaces.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (r *gormOrderRepository) List(filter repoInterfaces.OrderFilter) ([]*models.Order, error) {
+	query := r.db.Model(&models.Order{})
+	if filter.NFTID != 0 {
+		query = query.Where("nft_id = ?", filter.NFTID)
+	}
+	if filter.CollectionID != 0 {
+		query = query.Where("collection_id = ?", filter.CollectionID)
+	}
+	if filter.Maker != "" {
+		query = query.Where("maker = ?", filter.Maker)
+	}
+	if filter.Currency != "" {
+		query = query.Where("currency = ?", filter.Currency)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.LiveAt != nil {
+		query = query.Where("status = ? AND start_time <= ? AND end_time > ?", models.OrderStatusActive, *filter.LiveAt, *filter.LiveAt)
+	}
+	if filter.SortBy == "price" {
+		query = query.Order("unit_price ASC, id ASC")
+	} else {
+		query = query.Order("id DESC")
+	}
+	var orders []*models.Order
+	if err := query.Limit(filter.Limit).Offset(filter.Offset).Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (r *gormOrderRepository) GetBest(nftID uint, currency string, now time.Time) (*models.Order, error) {
+	var order models.Order
+	err := r.db.Where("nft_id = ? AND currency = ? AND status = ? AND start_time <= ? AND end_time > ?",
+		nftID, currency, models.OrderStatusActive, now, now).
+		Order("unit_price ASC, id ASC").
+		First(&order).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repoInterfaces.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (r *gormOrderRepository) ExpireEnded(now time.Time) (int64, error) {
+	res := r.db.Model(&models.Order{}).
+		Where("status = ? AND end_time <= ?", models.OrderStatusActive, now).
+		Updates(map[string]interface{}{"status": models.OrderStatusExpired, "updated_at": now})
+	return res.RowsAffected, res.Error
+}
+
+// expired orders can still be cancelled so they do not come back after a reorg
+func (r *gormOrderRepository) MarkCancelled(chainID int64, orderHash string, at time.Time, block uint64) (bool, error) {
+	res := r.db.Model(&models.Order{}).
+		Where("chain_id = ? AND order_hash = ? AND status IN ?", chainID, orderHash, []string{models.OrderStatusActive, models.OrderStatusExpired}).
+		Updates(map[string]interface{}{"status": models.OrderStatusCancelled, "cancelled_at": at, "cancelled_block": block})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *gormOrderRepository) CancelBelowCounter(chainID int64, maker string, counter models.Uint256, at time.Time, block uint64) (int64, error) {
+	res := r.db.Model(&models.Order{}).
+		Where("chain_id = ? AND maker = ? AND counter < ? AND status IN ?", chainID, maker, counter, []string{models.OrderStatusActive, models.OrderStatusExpired}).
+		Updates(map[string]interface{}{"status": models.OrderStatusCancelled, "cancelled_at": at, "cancelled_block": block})
+	return res.RowsAffected, res.Error
+}
+
+func (r *gormOrderRepository) RevertCancellationsFromBlock(chainID int64, fromBlock uint64) error {
+	return r.db.Model(&models.Order{}).
+		Where("chain_id = ? AND cancelled_block >= ?", chainID, fromBlock).
+		Updates(map[string]interface{}{
+			"status":          gorm.Expr("CASE WHEN end_time <= NOW() THEN ? ELSE ? END", models.OrderStatusExpired, models.OrderStatusActive),
+			"cancelled_at":    nil,
+			"cancelled_block": nil,
+		}).Error
+}
+
+func (r *gormOrderRepository) ListActiveMakers(chainID int64) ([]string, error) {
+	var makers []string
+	if err := r.db.Model(&models.Order{}).Where("chain_id = ? AND status = ?", chainID, models.OrderStatusActive).Distinct().Pluck("maker", &makers).Error; err != nil {
+		return nil, err
+	}
+	return makers, nil
+}
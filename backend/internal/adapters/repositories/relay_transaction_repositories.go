@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"errors"
+	"math/big"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormRelayTransactionRepository struct {
+	db *gorm.DB
+}
+
+func NewGormRelayTransactionRepository(db *gorm.DB) repoInterfaces.RelayTransactionRepository {
+	return &gormRelayTransactionRepository{db: db}
+}
+
+// the sender's nonce row is locked for the whole transaction, which also
+// serializes the budget checks
+func (r *gormRelayTransactionRepository) Reserve(relayTx *models.RelayTransaction, chainNonce uint64, budget repoInterfaces.SponsorshipBudget) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		nonce := models.RelayNonce{ChainID: relayTx.ChainID, Address: relayTx.From}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&nonce).Error; err != nil {
+			return err
+		}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("chain_id = ? AND address = ?", relayTx.ChainID, relayTx.From).First(&nonce).Error
+		if err != nil {
+			return err
+		}
+
+		var open int64
+		err = tx.Model(&models.RelayTransaction{}).
+			Where("nft_id = ? AND status IN ?", relayTx.NFTID, []string{models.RelayStatusQueued, models.RelayStatusPending}).
+			Count(&open).Error
+		if err != nil {
+			return err
+		}
+		if open > 0 {
+			return repoInterfaces.ErrDuplicateRecord
+		}
+
+		if budget.Creator != nil {
+			spent, err := relaySpend(tx, "creator_id = ?", relayTx.CreatorID, budget)
+			if err != nil {
+				return err
+			}
+			if spent.Add(spent, relayTx.Cost.Big()).Cmp(budget.Creator) > 0 {
+				return repoInterfaces.ErrCreatorBudgetExceeded
+			}
+		}
+		if budget.User != nil {
+			spent, err := relaySpend(tx, "requester_id = ?", relayTx.RequesterID, budget)
+			if err != nil {
+				return err
+			}
+			if spent.Add(spent, relayTx.Cost.Big()).Cmp(budget.User) > 0 {
+				return repoInterfaces.ErrUserBudgetExceeded
+			}
+		}
+
+		relayTx.Nonce = max(nonce.Next, chainNonce)
+		relayTx.Status = models.RelayStatusQueued
+		if err := tx.Create(relayTx).Error; err != nil {
+			return err
+		}
+		return tx.Model(&nonce).Where("chain_id = ? AND address = ?", nonce.ChainID, nonce.Address).
+			Update("next", relayTx.Nonce+1).Error
+	})
+}
+
+// relaySpend sums the cost of the relay transactions matching a column
+// within the budget window
+func relaySpend(tx *gorm.DB, query string, id uint, budget repoInterfaces.SponsorshipBudget) (*big.Int, error) {
+	var spent models.Uint256
+	err := tx.Model(&models.RelayTransaction{}).Select("COALESCE(SUM(cost), 0)").
+		Where(query, id).Where("created_at >= ?", budget.Since).Row().Scan(&spent)
+	if err != nil {
+		return nil, err
+	}
+	return spent.Big(), nil
+}
+
+func (r *gormRelayTransactionRepository) GetByID(id uint) (*models.RelayTransaction, error) {
+	var relayTx models.RelayTransaction
+	if err := r.db.Where("id = ?", id).First(&relayTx).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repoInterfaces.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &relayTx, nil
+}
+
+func (r *gormRelayTransactionRepository) Update(relayTx *models.RelayTransaction) error {
+	return r.db.Save(relayTx).Error
+}
+
+func (r *gormRelayTransactionRepository) ListOpen(chainID int64, from string) ([]*models.RelayTransaction, error) {
+	var relayTxs []*models.RelayTransaction
+	err := r.db.Where("chain_id = ? AND from_address = ? AND status IN ?", chainID, from,
+		[]string{models.RelayStatusQueued, models.RelayStatusPending}).
+		Order("nonce").Find(&relayTxs).Error
+	if err != nil {
+		return nil, err
+	}
+	return relayTxs, nil
+}
@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormSaleRepository struct {
+	db *gorm.DB
+}
+
+func NewGormSaleRepository(db *gorm.DB) repoInterfaces.SaleRepository {
+	return &gormSaleRepository{db: db}
+}
+
+func (r *gormSaleRepository) Create(sale *models.Sale) (bool, error) {
+	created := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(sale)
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		created = true
+		if sale.OrderID != nil {
+			if err := addFill(tx, &models.Order{}, *sale.OrderID, sale); err != nil {
+				return err
+			}
+		}
+		if sale.OfferID != nil {
+			return addFill(tx, &models.Offer{}, *sale.OfferID, sale)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return created, nil
+}
+
+// rolled back sales are deleted for good so the same log can be indexed again
+func (r *gormSaleRepository) DeleteFromBlock(chainID int64, fromBlock uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var orderIDs, offerIDs []uint
+		orphaned := func() *gorm.DB {
+			return tx.Model(&models.Sale{}).Unscoped().Where("chain_id = ? AND block_number >= ?", chainID, fromBlock)
+		}
+		if err := orphaned().Where("order_id IS NOT NULL").Distinct().Pluck("order_id", &orderIDs).Error; err != nil {
+			return err
+		}
+		if err := orphaned().Where("offer_id IS NOT NULL").Distinct().Pluck("offer_id", &offerIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("chain_id = ? AND block_number >= ?", chainID, fromBlock).Delete(&models.Sale{}).Error; err != nil {
+			return err
+		}
+		if err := recountFills(tx, &models.Order{}, "orders", "order_id", orderIDs); err != nil {
+			return err
+		}
+		return recountFills(tx, &models.Offer{}, "offers", "offer_id", offerIDs)
+	})
+}
+
+func (r *gormSaleRepository) ListByNFT(nftID uint, limit, offset int) ([]*models.Sale, error) {
+	var sales []*models.Sale
+	if err := r.db.Where("nft_id = ?", nftID).Order("block_number DESC, log_index DESC").Limit(limit).Offset(offset).Find(&sales).Error; err != nil {
+		return nil, err
+	}
+	return sales, nil
+}
+
+func (r *gormSaleRepository) ListByCollection(collectionID uint, limit, offset int) ([]*models.Sale, error) {
+	var sales []*models.Sale
+	if err := r.db.Where("collection_id = ?", collectionID).Order("block_number DESC, log_index DESC").Limit(limit).Offset(offset).Find(&sales).Error; err != nil {
+		return nil, err
+	}
+	return sales, nil
+}
+
+// addFill adds a sale to the filled quantity of the order or offer it
+// filled, marking it filled once nothing is left
+func addFill(tx *gorm.DB, model interface{}, id uint, sale *models.Sale) error {
+	return tx.Model(model).Where("id = ?", id).Updates(map[string]interface{}{
+		"filled_quantity": gorm.Expr("filled_quantity + ?", sale.Quantity),
+		"status":          gorm.Expr("CASE WHEN filled_quantity + ? >= quantity THEN ? ELSE status END", sale.Quantity, models.OrderStatusFilled),
+		"filled_at":       gorm.Expr("CASE WHEN filled_quantity + ? >= quantity THEN ?::timestamptz ELSE filled_at END", sale.Quantity, sale.BlockTime),
+	}).Error
+}
+
+// recountFills sums the remaining sales of orders or offers and reopens
+// the ones no longer filled
+func recountFills(tx *gorm.DB, model interface{}, table, column string, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	err := tx.Model(model).Where("id IN ?", ids).
+		Update("filled_quantity", gorm.Expr("COALESCE((SELECT SUM(quantity) FROM sales WHERE sales."+column+" = "+table+".id AND sales.deleted_at IS NULL), 0)")).Error
+	if err != nil {
+		return err
+	}
+	return tx.Model(model).
+		Where("id IN ? AND status = ? AND filled_quantity < quantity", ids, models.OrderStatusFilled).
+		Updates(map[string]interface{}{
+			"status":    gorm.Expr("CASE WHEN end_time <= NOW() THEN ? ELSE ? END", models.OrderStatusExpired, models.OrderStatusActive),
+			"filled_at": nil,
+		}).Error
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormSignerRepository struct {
+	db *gorm.DB
+}
+
+func NewGormSignerRepository(db *gorm.DB) repoInterfaces.SignerRepository {
+	return &gormSignerRepository{db: db}
+}
+
+func (r *gormSignerRepository) GetActiveKey(purpose string) (*models.SignerKey, error) {
+	var key models.SignerKey
+	if err := r.db.Where("purpose = ? AND status = ?", purpose, models.SignerKeyStatusActive).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repoInterfaces.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *gormSignerRepository) ActivateKey(key *models.SignerKey) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Model(&models.SignerKey{}).
+			Where("purpose = ? AND status = ? AND address <> ?", key.Purpose, models.SignerKeyStatusActive, key.Address).
+			Updates(map[string]interface{}{"status": models.SignerKeyStatusRetired, "retired_at": now}).Error
+		if err != nil {
+			return err
+		}
+		key.Status = models.SignerKeyStatusActive
+		key.ActivatedAt = now
+		key.RetiredAt = nil
+		// a key that comes back from retirement is reactivated in place
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "purpose"}, {Name: "address"}},
+			DoUpdates: clause.AssignmentColumns([]string{"backend", "status", "activated_at", "retired_at", "updated_at"}),
+		}).Create(key).Error
+	})
+}
+
+// list a purpose's keys, newest first
+func (r *gormSignerRepository) ListKeys(purpose string) ([]*models.SignerKey, error) {
+	var keys []*models.SignerKey
+	q := r.db.Order("activated_at DESC")
+	if purpose != "" {
+		q = q.Where("purpose = ?", purpose)
+	}
+	if err := q.Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *gormSignerRepository) CreateAudit(audit *models.SignatureAudit) error {
+	return r.db.Create(audit).Error
+}
+
+// list the signatures of a key, newest first
+func (r *gormSignerRepository) ListAudits(signerAddress string, limit, offset int) ([]*models.SignatureAudit, error) {
+	var audits []*models.SignatureAudit
+	err := r.db.Where("signer_address = ?", signerAddress).
+		Order("id DESC").Limit(limit).Offset(offset).Find(&audits).Error
+	if err != nil {
+		return nil, err
+	}
+	return audits, nil
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"math/big"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// zeroAddress is the checksummed form the indexer stores for mints and burns
+const zeroAddress = "0x0000000000000000000000000000000000000000"
+
+type gormTokenBalanceRepository struct {
+	db *gorm.DB
+}
+
+func NewGormTokenBalanceRepository(db *gorm.DB) repoInterfaces.TokenBalanceRepository {
+	return &gormTokenBalanceRepository{db: db}
+}
+
+func (r *gormTokenBalanceRepository) ListByOwner(chainID int64, ownerAddress string, limit, offset int) ([]*models.TokenBalance, error) {
+	var balances []*models.TokenBalance
+	err := r.db.Preload("NFT").
+		Where("chain_id = ? AND owner_address = ?", chainID, ownerAddress).
+		Order("updated_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&balances).Error
+	if err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+func (r *gormTokenBalanceRepository) ListHolders(chainID int64, contractAddress string, tokenID models.Uint256, limit, offset int) ([]*models.TokenBalance, error) {
+	var balances []*models.TokenBalance
+	err := r.db.Where("chain_id = ? AND contract_address = ? AND token_id = ?", chainID, contractAddress, tokenID).
+		Order("balance DESC, id").
+		Limit(limit).Offset(offset).
+		Find(&balances).Error
+	if err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+func (r *gormTokenBalanceRepository) CountHolders(chainID int64, contractAddress string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.TokenBalance{}).
+		Where("chain_id = ? AND contract_address = ?", chainID, contractAddress).
+		Distinct("owner_address").
+		Count(&count).Error
+	return count, err
+}
+
+func (r *gormTokenBalanceRepository) ListAfter(chainID int64, afterID uint, limit int) ([]*models.TokenBalance, error) {
+	var balances []*models.TokenBalance
+	if err := r.db.Where("chain_id = ? AND id > ?", chainID, afterID).Order("id").Limit(limit).Find(&balances).Error; err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+func (r *gormTokenBalanceRepository) Set(balance *models.TokenBalance) error {
+	if balance.Balance.Big().Sign() == 0 {
+		return r.db.Where("chain_id = ? AND contract_address = ? AND token_id = ? AND owner_address = ?",
+			balance.ChainID, balance.ContractAddress, balance.TokenID, balance.OwnerAddress).
+			Delete(&models.TokenBalance{}).Error
+	}
+	return r.db.Omit("NFT").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "chain_id"}, {Name: "contract_address"}, {Name: "token_id"}, {Name: "owner_address"}},
+		DoUpdates: clause.AssignmentColumns([]string{"balance", "updated_at"}),
+	}).Create(balance).Error
+}
+
+// applyTransfer moves the amount of a transfer between the balances of its
+// sender and recipient, or back again when reverse is set. Mints and burns
+// have the zero address on one side, which holds no balance. A balance never
+// goes below zero: a sender whose earlier transfers predate the indexer start
+// is left at zero until reconciled.
+func applyTransfer(tx *gorm.DB, transfer *models.TokenTransfer, reverse bool) error {
+	amount := transfer.Amount.Big()
+	from, to := transfer.FromAddress, transfer.ToAddress
+	if reverse {
+		from, to = to, from
+	}
+	if err := addBalance(tx, transfer, from, new(big.Int).Neg(amount)); err != nil {
+		return err
+	}
+	return addBalance(tx, transfer, to, amount)
+}
+
+func addBalance(tx *gorm.DB, transfer *models.TokenTransfer, owner string, delta *big.Int) error {
+	if owner == zeroAddress || delta.Sign() == 0 {
+		return nil
+	}
+	err := tx.Exec(`INSERT INTO token_balances (chain_id, contract_address, token_id, owner_address, nft_id, balance, updated_at)
+		VALUES (?, ?, ?, ?, ?, GREATEST(?::numeric, 0), NOW())
+		ON CONFLICT (chain_id, contract_address, token_id, owner_address) DO UPDATE
+		SET balance = GREATEST(token_balances.balance + ?::numeric, 0),
+			nft_id = COALESCE(EXCLUDED.nft_id, token_balances.nft_id),
+			updated_at = EXCLUDED.updated_at`,
+		transfer.ChainID, transfer.ContractAddress, transfer.TokenID, owner, transfer.NFTID, delta.String(), delta.String()).Error
+	if err != nil {
+		return err
+	}
+	return tx.Where("chain_id = ? AND contract_address = ? AND token_id = ? AND owner_address = ? AND balance = 0",
+		transfer.ChainID, transfer.ContractAddress, transfer.TokenID, owner).
+		Delete(&models.TokenBalance{}).Error
+}
@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormTokenTransferRepository struct {
+	db *gorm.DB
+}
+
+func NewGormTokenTransferRepository(db *gorm.DB) repoInterfaces.TokenTransferRepository {
+	return &gormTokenTransferRepository{db: db}
+}
+
+// the balances move in the same transaction, and only the first time a log is stored
+func (r *gormTokenTransferRepository) Create(transfer *models.TokenTransfer) (bool, error) {
+	created := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(transfer)
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		created = true
+		return applyTransfer(tx, transfer, false)
+	})
+	if err != nil {
+		return false, err
+	}
+	return created, nil
+}
+
+// rolled back rows are deleted for good so the same log can be indexed again,
+// after their amounts are moved back
+func (r *gormTokenTransferRepository) DeleteFromBlock(chainID int64, fromBlock uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var transfers []*models.TokenTransfer
+		err := tx.Unscoped().Where("chain_id = ? AND block_number >= ?", chainID, fromBlock).
+			Order("block_number DESC, log_index DESC, batch_index DESC").
+			Find(&transfers).Error
+		if err != nil {
+			return err
+		}
+		for _, transfer := range transfers {
+			if err := applyTransfer(tx, transfer, true); err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Where("chain_id = ? AND block_number >= ?", chainID, fromBlock).Delete(&models.TokenTransfer{}).Error
+	})
+}
@@ -48,6 +48,30 @@ func (r *gormUserRepository) GetUserByWalletAddress(walletAddress string) (*mode
 	return &user, nil
 }
 
+// get user by username, case insensitive search
+func (r *gormUserRepository) GetUserByUsername(username string) (*models.User, error) {
+	var user models.User
+	if err := r.db.Where("LOWER(username) = LOWER(?)", username).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repoInterfaces.ErrRecordNotFound // Return a specific error if record not found
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// get user by verified email, case insensitive search
+func (r *gormUserRepository) GetUserByEmail(email string) (*models.User, error) {
+	var user models.User
+	if err := r.db.Where("LOWER(email) = LOWER(?)", email).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repoInterfaces.ErrRecordNotFound // Return a specific error if record not found
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
 // update user by id
 func (r *gormUserRepository) UpdateUserByID(id string, user *models.User) error {
 	if err := r.db.Model(&models.User{}).Where("id = ?", id).Updates(user).Error; err != nil {
@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormVoucherRepository struct {
+	db *gorm.DB
+}
+
+func NewGormVoucherRepository(db *gorm.DB) repoInterfaces.VoucherRepository {
+	return &gormVoucherRepository{db: db}
+}
+
+func (r *gormVoucherRepository) Create(voucher *models.Voucher) error {
+	if err := r.db.Create(voucher).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return repoInterfaces.ErrDuplicateRecord
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *gormVoucherRepository) GetByID(id uint) (*models.Voucher, error) {
+	var voucher models.Voucher
+	if err := r.db.Where("id = ?", id).First(&voucher).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repoInterfaces.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &voucher, nil
+}
+
+func (r *gormVoucherRepository) Update(voucher *models.Voucher) error {
+	return r.db.Save(voucher).Error
+}
+
+// list every voucher ever issued for an NFT, newest first
+func (r *gormVoucherRepository) ListByNFT(nftID uint) ([]*models.Voucher, error) {
+	var vouchers []*models.Voucher
+	if err := r.db.Where("nft_id = ?", nftID).Order("issued_at DESC").Find(&vouchers).Error; err != nil {
+		return nil, err
+	}
+	return vouchers, nil
+}
+
+func (r *gormVoucherRepository) GetIssuedByNFT(nftID uint) (*models.Voucher, error) {
+	var voucher models.Voucher
+	if err := r.db.Where("nft_id = ? AND status = ? AND NOT for_creator", nftID, models.VoucherStatusIssued).First(&voucher).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repoInterfaces.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &voucher, nil
+}
+
+func (r *gormVoucherRepository) GetIssuedForOwner(nftID uint, ownerAddress string) (*models.Voucher, error) {
+	var voucher models.Voucher
+	err := r.db.Where("nft_id = ? AND status = ? AND LOWER(owner_address) = LOWER(?)", nftID, models.VoucherStatusIssued, ownerAddress).
+		Order("id DESC").First(&voucher).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repoInterfaces.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &voucher, nil
+}
+
+// list unexpired issued vouchers of a contract
+func (r *gormVoucherRepository) ListIssuedByContract(chainID int64, contractAddress string, now time.Time) ([]*models.Voucher, error) {
+	var vouchers []*models.Voucher
+	err := r.db.Where("chain_id = ? AND contract_address = ? AND status = ? AND expires_at > ?", chainID, contractAddress, models.VoucherStatusIssued, now).
+		Order("id").Find(&vouchers).Error
+	if err != nil {
+		return nil, err
+	}
+	return vouchers, nil
+}
+
+func (r *gormVoucherRepository) HasRevokedBySigner(nftID uint, signerAddress string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Voucher{}).
+		Where("nft_id = ? AND status = ? AND signer_address = ?", nftID, models.VoucherStatusRevoked, signerAddress).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *gormVoucherRepository) Supersede(old, replacement *models.Voucher) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		old.Status = models.VoucherStatusSuperseded
+		if err := tx.Save(old).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(replacement).Error; err != nil {
+			return err
+		}
+		old.SupersededByID = &replacement.ID
+		return tx.Model(old).Update("superseded_by_id", replacement.ID).Error
+	})
+}
+
+// a revoked or expired voucher can still be redeemed on-chain, so every
+// status but redeemed is a candidate
+func (r *gormVoucherRepository) MarkRedeemed(nftID uint, ownerAddress, amount, txHash string, redeemedAt time.Time) (*models.Voucher, error) {
+	var voucher models.Voucher
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("nft_id = ? AND LOWER(owner_address) = LOWER(?) AND amount = ? AND status <> ?", nftID, ownerAddress, amount, models.VoucherStatusRedeemed).
+			Order("CASE WHEN status = 'issued' THEN 0 ELSE 1 END, id DESC").First(&voucher).Error
+		if err != nil {
+			return err
+		}
+		voucher.Status = models.VoucherStatusRedeemed
+		voucher.RedeemedAt = &redeemedAt
+		voucher.RedeemTxHash = txHash
+		return tx.Model(&voucher).Select("status", "redeemed_at", "redeem_tx_hash").Updates(&voucher).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repoInterfaces.ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return &voucher, nil
+}
@@ -0,0 +1,23 @@
+package signer
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	signerInterfaces "github.com/igwedaniel/artizan/internal/interfaces/signer"
+)
+
+// NewKeystoreSigner decrypts a go-ethereum keystore (Web3 Secret Storage)
+// file. The decrypted key is only held in memory.
+func NewKeystoreSigner(path, passphrase string) (signerInterfaces.Signer, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore file: %w", err)
+	}
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore file %s: %w", path, err)
+	}
+	return NewMemorySigner(key.PrivateKey), nil
+}
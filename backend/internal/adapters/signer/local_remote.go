@@ -0,0 +1,129 @@
+package signer
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	signerInterfaces "github.com/igwedaniel/artizan/internal/interfaces/signer"
+)
+
+// LocalRemoteSigner serves the remote signer protocol in process, signing
+// with another Signer. It stands in for a real signing service in tests and
+// local development; point NewRemoteSigner at URL().
+type LocalRemoteSigner struct {
+	signer   signerInterfaces.Signer
+	token    string
+	listener net.Listener
+	server   *http.Server
+}
+
+// NewLocalRemoteSigner starts a server on a random loopback port that only
+// accepts requests bearing token
+func NewLocalRemoteSigner(signer signerInterfaces.Signer, token string) (*LocalRemoteSigner, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &LocalRemoteSigner{signer: signer, token: token, listener: l}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+remoteAddressPath, s.handleAddress)
+	mux.HandleFunc("POST "+remoteSignHashPath, s.handleSignHash)
+	mux.HandleFunc("POST "+remoteSignTransactionPath, s.handleSignTransaction)
+	s.server = &http.Server{Handler: s.authorize(mux)}
+	go s.server.Serve(l)
+	return s, nil
+}
+
+func (s *LocalRemoteSigner) URL() string {
+	return "http://" + s.listener.Addr().String()
+}
+
+func (s *LocalRemoteSigner) Close() error {
+	return s.server.Shutdown(context.Background())
+}
+
+func (s *LocalRemoteSigner) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expected := "Bearer " + s.token
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte(expected)) != 1 {
+			writeRemoteError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *LocalRemoteSigner) handleAddress(w http.ResponseWriter, r *http.Request) {
+	writeRemoteJSON(w, http.StatusOK, remoteAddressResponse{Address: s.signer.Address().Hex()})
+}
+
+func (s *LocalRemoteSigner) handleSignHash(w http.ResponseWriter, r *http.Request) {
+	var req remoteSignHashRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeRemoteError(w, http.StatusBadRequest, errors.New("invalid request"))
+		return
+	}
+	hash, err := hexutil.Decode(req.Hash)
+	if err != nil || len(hash) != common.HashLength {
+		writeRemoteError(w, http.StatusBadRequest, errors.New("hash must be 32 bytes"))
+		return
+	}
+	signature, err := s.signer.SignHash(r.Context(), common.BytesToHash(hash))
+	if err != nil {
+		writeRemoteError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeRemoteJSON(w, http.StatusOK, remoteSignHashResponse{Signature: hexutil.Encode(signature)})
+}
+
+func (s *LocalRemoteSigner) handleSignTransaction(w http.ResponseWriter, r *http.Request) {
+	var req remoteSignTransactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeRemoteError(w, http.StatusBadRequest, errors.New("invalid request"))
+		return
+	}
+	chainID, ok := new(big.Int).SetString(req.ChainID, 10)
+	if !ok {
+		writeRemoteError(w, http.StatusBadRequest, errors.New("invalid chain_id"))
+		return
+	}
+	raw, err := hexutil.Decode(req.Transaction)
+	if err != nil {
+		writeRemoteError(w, http.StatusBadRequest, errors.New("invalid transaction"))
+		return
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		writeRemoteError(w, http.StatusBadRequest, errors.New("invalid transaction"))
+		return
+	}
+	signed, err := s.signer.SignTx(r.Context(), tx, chainID)
+	if err != nil {
+		writeRemoteError(w, http.StatusInternalServerError, err)
+		return
+	}
+	signedRaw, err := signed.MarshalBinary()
+	if err != nil {
+		writeRemoteError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeRemoteJSON(w, http.StatusOK, remoteSignTransactionResponse{Transaction: hexutil.Encode(signedRaw)})
+}
+
+func writeRemoteJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeRemoteError(w http.ResponseWriter, status int, err error) {
+	writeRemoteJSON(w, status, remoteErrorResponse{Error: err.Error()})
+}
@@ -0,0 +1,56 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	signerInterfaces "github.com/igwedaniel/artizan/internal/interfaces/signer"
+)
+
+// keySigner signs with a private key held in process memory
+type keySigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+// NewMemorySigner wraps a private key that lives in process memory. It is
+// meant for tests and local development.
+func NewMemorySigner(key *ecdsa.PrivateKey) signerInterfaces.Signer {
+	return &keySigner{key: key, address: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+// NewMemorySignerFromHex parses a hex encoded private key, with or without 0x
+func NewMemorySignerFromHex(hexKey string) (signerInterfaces.Signer, error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(hexKey), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	return NewMemorySigner(key), nil
+}
+
+// GenerateMemorySigner creates a signer for a fresh random key
+func GenerateMemorySigner() (signerInterfaces.Signer, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	return NewMemorySigner(key), nil
+}
+
+func (s *keySigner) Address() common.Address {
+	return s.address
+}
+
+func (s *keySigner) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	return crypto.Sign(hash.Bytes(), s.key)
+}
+
+func (s *keySigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key)
+}
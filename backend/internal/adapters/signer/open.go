@@ -0,0 +1,48 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+
+	signerInterfaces "github.com/igwedaniel/artizan/internal/interfaces/signer"
+)
+
+// key backends a Signer can be opened from
+const (
+	BackendMemory   = "memory"
+	BackendKeystore = "keystore"
+	BackendRemote   = "remote"
+)
+
+// Options selects and configures a key backend. Only the fields of the chosen
+// backend are read.
+type Options struct {
+	Backend          string
+	PrivateKey       string // memory
+	KeystorePath     string // keystore
+	KeystorePassword string // keystore
+	RemoteURL        string // remote
+	RemoteToken      string // remote
+}
+
+// Open creates the Signer described by opts
+func Open(ctx context.Context, opts Options) (signerInterfaces.Signer, error) {
+	switch opts.Backend {
+	case BackendMemory:
+		if opts.PrivateKey == "" {
+			return nil, fmt.Errorf("the memory signer backend needs a private key")
+		}
+		return NewMemorySignerFromHex(opts.PrivateKey)
+	case BackendKeystore:
+		if opts.KeystorePath == "" {
+			return nil, fmt.Errorf("the keystore signer backend needs a keystore path")
+		}
+		return NewKeystoreSigner(opts.KeystorePath, opts.KeystorePassword)
+	case BackendRemote:
+		if opts.RemoteURL == "" {
+			return nil, fmt.Errorf("the remote signer backend needs a url")
+		}
+		return NewRemoteSigner(ctx, opts.RemoteURL, opts.RemoteToken)
+	}
+	return nil, fmt.Errorf("unknown signer backend %q", opts.Backend)
+}
@@ -0,0 +1,170 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	signerInterfaces "github.com/igwedaniel/artizan/internal/interfaces/signer"
+)
+
+// Remote signer protocol. Every request carries "Authorization: Bearer
+// <token>" and every error response is {"error": "..."} with a non 2xx status.
+//
+//	GET  /v1/address           -> {"address": "0x..."}
+//	POST /v1/sign/hash         {"hash": "0x<32 bytes>"} -> {"signature": "0x<65 bytes, v in {0,1}>"}
+//	POST /v1/sign/transaction  {"chain_id": "97", "transaction": "0x<unsigned tx, binary encoding>"}
+//	                           -> {"transaction": "0x<signed tx, binary encoding>"}
+const (
+	remoteAddressPath         = "/v1/address"
+	remoteSignHashPath        = "/v1/sign/hash"
+	remoteSignTransactionPath = "/v1/sign/transaction"
+)
+
+const remoteSignerTimeout = 10 * time.Second
+
+type remoteAddressResponse struct {
+	Address string `json:"address"`
+}
+
+type remoteSignHashRequest struct {
+	Hash string `json:"hash"`
+}
+
+type remoteSignHashResponse struct {
+	Signature string `json:"signature"`
+}
+
+type remoteSignTransactionRequest struct {
+	ChainID     string `json:"chain_id"`
+	Transaction string `json:"transaction"`
+}
+
+type remoteSignTransactionResponse struct {
+	Transaction string `json:"transaction"`
+}
+
+type remoteErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// remoteSigner asks a signing service for every signature and checks that
+// what comes back was produced by the expected key
+type remoteSigner struct {
+	baseURL string
+	token   string
+	client  *http.Client
+	address common.Address
+}
+
+// NewRemoteSigner connects to a remote signing service and asks it for its
+// address
+func NewRemoteSigner(ctx context.Context, baseURL, token string) (signerInterfaces.Signer, error) {
+	s := &remoteSigner{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: remoteSignerTimeout},
+	}
+	var res remoteAddressResponse
+	if err := s.do(ctx, http.MethodGet, remoteAddressPath, nil, &res); err != nil {
+		return nil, fmt.Errorf("failed to fetch remote signer address: %w", err)
+	}
+	if !common.IsHexAddress(res.Address) {
+		return nil, fmt.Errorf("remote signer returned an invalid address %q", res.Address)
+	}
+	s.address = common.HexToAddress(res.Address)
+	return s, nil
+}
+
+func (s *remoteSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *remoteSigner) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	var res remoteSignHashResponse
+	if err := s.do(ctx, http.MethodPost, remoteSignHashPath, remoteSignHashRequest{Hash: hash.Hex()}, &res); err != nil {
+		return nil, err
+	}
+	signature, err := hexutil.Decode(res.Signature)
+	if err != nil || len(signature) != crypto.SignatureLength {
+		return nil, errors.New("remote signer returned a malformed signature")
+	}
+	pub, err := crypto.SigToPub(hash.Bytes(), signature)
+	if err != nil || crypto.PubkeyToAddress(*pub) != s.address {
+		return nil, errors.New("remote signer returned a signature from another key")
+	}
+	return signature, nil
+}
+
+func (s *remoteSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	req := remoteSignTransactionRequest{ChainID: chainID.String(), Transaction: hexutil.Encode(raw)}
+	var res remoteSignTransactionResponse
+	if err := s.do(ctx, http.MethodPost, remoteSignTransactionPath, req, &res); err != nil {
+		return nil, err
+	}
+	signedRaw, err := hexutil.Decode(res.Transaction)
+	if err != nil {
+		return nil, errors.New("remote signer returned a malformed transaction")
+	}
+	signed := new(types.Transaction)
+	if err := signed.UnmarshalBinary(signedRaw); err != nil {
+		return nil, fmt.Errorf("remote signer returned a malformed transaction: %w", err)
+	}
+	signer := types.LatestSignerForChainID(chainID)
+	if signer.Hash(signed) != signer.Hash(tx) {
+		return nil, errors.New("remote signer returned a different transaction")
+	}
+	sender, err := types.Sender(signer, signed)
+	if err != nil || sender != s.address {
+		return nil, errors.New("remote signer returned a transaction signed by another key")
+	}
+	return signed, nil
+}
+
+func (s *remoteSigner) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		var failure remoteErrorResponse
+		if json.NewDecoder(io.LimitReader(res.Body, 1<<16)).Decode(&failure) == nil && failure.Error != "" {
+			return fmt.Errorf("remote signer: %s (status %d)", failure.Error, res.StatusCode)
+		}
+		return fmt.Errorf("remote signer: unexpected status %d", res.StatusCode)
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
@@ -0,0 +1,226 @@
+package signer
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	signerInterfaces "github.com/igwedaniel/artizan/internal/interfaces/signer"
+)
+
+var testChainID = big.NewInt(1337)
+
+func generateSigner(t *testing.T) signerInterfaces.Signer {
+	t.Helper()
+	s, err := GenerateMemorySigner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func startRemote(t *testing.T, backing signerInterfaces.Signer, token string) *LocalRemoteSigner {
+	t.Helper()
+	server, err := NewLocalRemoteSigner(backing, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { server.Close() })
+	return server
+}
+
+func unsignedTx() *types.Transaction {
+	to := common.HexToAddress("0x00000000000000000000000000000000000000e1")
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   testChainID,
+		Nonce:     3,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(5),
+	})
+}
+
+// checkSigner signs a hash and a transaction with s and checks both
+// recover to want
+func checkSigner(t *testing.T, s signerInterfaces.Signer, want common.Address) {
+	t.Helper()
+	ctx := context.Background()
+	if s.Address() != want {
+		t.Fatalf("address = %s, want %s", s.Address().Hex(), want.Hex())
+	}
+
+	hash := crypto.Keccak256Hash([]byte("voucher"))
+	signature, err := s.SignHash(ctx, hash)
+	if err != nil {
+		t.Fatalf("SignHash: %v", err)
+	}
+	pub, err := crypto.SigToPub(hash.Bytes(), signature)
+	if err != nil || crypto.PubkeyToAddress(*pub) != want {
+		t.Fatalf("hash signature recovers to another key: %v", err)
+	}
+
+	tx := unsignedTx()
+	signed, err := s.SignTx(ctx, tx, testChainID)
+	if err != nil {
+		t.Fatalf("SignTx: %v", err)
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(testChainID), signed)
+	if err != nil || sender != want {
+		t.Fatalf("transaction sender = %s, %v; want %s", sender.Hex(), err, want.Hex())
+	}
+	if signed.Nonce() != tx.Nonce() || signed.Value().Cmp(tx.Value()) != 0 {
+		t.Fatal("signed transaction differs from the one sent")
+	}
+}
+
+func TestRemoteSigner(t *testing.T) {
+	backing := generateSigner(t)
+	server := startRemote(t, backing, "secret")
+
+	s, err := NewRemoteSigner(context.Background(), server.URL()+"/", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSigner(t, s, backing.Address())
+}
+
+func TestRemoteSignerRejectsWrongToken(t *testing.T) {
+	server := startRemote(t, generateSigner(t), "secret")
+
+	_, err := NewRemoteSigner(context.Background(), server.URL(), "guess")
+	if err == nil || !strings.Contains(err.Error(), "unauthorized") {
+		t.Fatalf("err = %v, want unauthorized", err)
+	}
+}
+
+// impostorSigner claims one address and signs with another key
+type impostorSigner struct {
+	signerInterfaces.Signer
+	claimed common.Address
+}
+
+func (s *impostorSigner) Address() common.Address { return s.claimed }
+
+// tamperingSigner signs a different transaction than the one asked for
+type tamperingSigner struct {
+	signerInterfaces.Signer
+}
+
+func (s *tamperingSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	to := common.HexToAddress("0x00000000000000000000000000000000000000ee")
+	other := types.NewTx(&types.DynamicFeeTx{
+		ChainID: chainID, Nonce: tx.Nonce(), GasTipCap: tx.GasTipCap(), GasFeeCap: tx.GasFeeCap(),
+		Gas: tx.Gas(), To: &to, Value: tx.Value(),
+	})
+	return s.Signer.SignTx(ctx, other, chainID)
+}
+
+func TestRemoteSignerRejectsSignaturesFromAnotherKey(t *testing.T) {
+	claimed := generateSigner(t).Address()
+	server := startRemote(t, &impostorSigner{Signer: generateSigner(t), claimed: claimed}, "secret")
+	ctx := context.Background()
+
+	s, err := NewRemoteSigner(ctx, server.URL(), "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Address() != claimed {
+		t.Fatalf("address = %s, want %s", s.Address().Hex(), claimed.Hex())
+	}
+	if _, err := s.SignHash(ctx, crypto.Keccak256Hash([]byte("voucher"))); err == nil || !strings.Contains(err.Error(), "another key") {
+		t.Fatalf("SignHash err = %v, want a signature from another key", err)
+	}
+	if _, err := s.SignTx(ctx, unsignedTx(), testChainID); err == nil || !strings.Contains(err.Error(), "another key") {
+		t.Fatalf("SignTx err = %v, want a transaction signed by another key", err)
+	}
+}
+
+func TestRemoteSignerRejectsAnotherTransaction(t *testing.T) {
+	server := startRemote(t, &tamperingSigner{Signer: generateSigner(t)}, "secret")
+	ctx := context.Background()
+
+	s, err := NewRemoteSigner(ctx, server.URL(), "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.SignTx(ctx, unsignedTx(), testChainID); err == nil || !strings.Contains(err.Error(), "different transaction") {
+		t.Fatalf("SignTx err = %v, want a different transaction", err)
+	}
+}
+
+// writeKeystore stores a fresh key in a keystore file with light scrypt
+// parameters and returns its path
+func writeKeystore(t *testing.T, passphrase string) (string, common.Address) {
+	t.Helper()
+	account, err := keystore.StoreKey(t.TempDir(), passphrase, keystore.LightScryptN, keystore.LightScryptP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return account.URL.Path, account.Address
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	path, address := writeKeystore(t, "hunter2")
+
+	s, err := NewKeystoreSigner(path, "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSigner(t, s, address)
+
+	if _, err := NewKeystoreSigner(path, "wrong"); err == nil {
+		t.Fatal("decrypted the keystore with a wrong passphrase")
+	}
+	if _, err := NewKeystoreSigner(filepath.Join(t.TempDir(), "missing.json"), "hunter2"); err == nil {
+		t.Fatal("opened a missing keystore file")
+	}
+}
+
+func TestOpen(t *testing.T) {
+	ctx := context.Background()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyAddress := crypto.PubkeyToAddress(key.PublicKey)
+	keystorePath, keystoreAddress := writeKeystore(t, "hunter2")
+	backing := generateSigner(t)
+	server := startRemote(t, backing, "secret")
+
+	for _, tt := range []struct {
+		opts Options
+		want common.Address
+	}{
+		{Options{Backend: BackendMemory, PrivateKey: "0x" + common.Bytes2Hex(crypto.FromECDSA(key))}, keyAddress},
+		{Options{Backend: BackendKeystore, KeystorePath: keystorePath, KeystorePassword: "hunter2"}, keystoreAddress},
+		{Options{Backend: BackendRemote, RemoteURL: server.URL(), RemoteToken: "secret"}, backing.Address()},
+	} {
+		s, err := Open(ctx, tt.opts)
+		if err != nil {
+			t.Fatalf("Open %s: %v", tt.opts.Backend, err)
+		}
+		if s.Address() != tt.want {
+			t.Fatalf("%s signer address = %s, want %s", tt.opts.Backend, s.Address().Hex(), tt.want.Hex())
+		}
+	}
+
+	for _, opts := range []Options{
+		{Backend: BackendMemory},
+		{Backend: BackendMemory, PrivateKey: "0x1234"},
+		{Backend: BackendKeystore},
+		{Backend: BackendRemote},
+		{Backend: "hsm"},
+	} {
+		if _, err := Open(ctx, opts); err == nil {
+			t.Fatalf("Open(%+v) succeeded", opts)
+		}
+	}
+}
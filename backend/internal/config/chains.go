@@ -0,0 +1,110 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ChainConfig describes one chain of CHAINS_FILE. Settings left out fall back
+// to the single-chain environment variables, except the RPC URL and chain ID.
+//
+//	[{"chain_id": 97, "name": "bsc-testnet", "rpc_url": "https://...",
+//	  "confirmations": 3, "native_currency": {"name": "BNB", "symbol": "tBNB", "decimals": 18},
+//	  "seaport_address": "0x...", "zone_address": "0x...", "wrapped_native_address": "0x..."}]
+type ChainConfig struct {
+	ChainID        int64                `json:"chain_id"`
+	Name           string               `json:"name"`
+	RpcUrl         string               `json:"rpc_url"`
+	Confirmations  uint64               `json:"confirmations"`
+	NativeCurrency NativeCurrencyConfig `json:"native_currency"`
+	SeaportAddress string               `json:"seaport_address"`
+	// zero has Seaport itself transfer the tokens
+	SeaportConduitKey string `json:"seaport_conduit_key"`
+	ZoneAddress       string `json:"zone_address"`
+	FactoryAddress    string `json:"factory_address"`
+	// ERC-20 offers bid when they name no currency
+	WrappedNativeAddress string `json:"wrapped_native_address"`
+	IndexerStartBlock    uint64 `json:"indexer_start_block"`
+	IndexerConfirmations uint64 `json:"indexer_confirmations"`
+}
+
+type NativeCurrencyConfig struct {
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Decimals uint8  `json:"decimals"`
+}
+
+// Chains returns the chains the backend serves, the default chain first
+func (c Config) Chains() ([]ChainConfig, error) {
+	if c.ChainsFile == "" {
+		if c.RpcUrl == "" {
+			return nil, errors.New("RPC_URL is required without CHAINS_FILE")
+		}
+		return []ChainConfig{c.withDefaults(ChainConfig{
+			ChainID:              c.ChainId,
+			Name:                 c.ChainName,
+			RpcUrl:               c.RpcUrl,
+			ZoneAddress:          c.ZoneAddress,
+			FactoryAddress:       c.FactoryAddress,
+			WrappedNativeAddress: c.OfferCurrency,
+			IndexerStartBlock:    c.IndexerStartBlock,
+		})}, nil
+	}
+
+	data, err := os.ReadFile(c.ChainsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chains file: %w", err)
+	}
+	var chains []ChainConfig
+	if err := json.Unmarshal(data, &chains); err != nil {
+		return nil, fmt.Errorf("failed to parse chains file: %w", err)
+	}
+	ordered := make([]ChainConfig, 0, len(chains))
+	seen := make(map[int64]bool, len(chains))
+	for _, chain := range chains {
+		if chain.ChainID <= 0 || chain.RpcUrl == "" {
+			return nil, errors.New("every chain needs a chain_id and an rpc_url")
+		}
+		if seen[chain.ChainID] {
+			return nil, fmt.Errorf("chain %d is configured twice", chain.ChainID)
+		}
+		seen[chain.ChainID] = true
+		chain = c.withDefaults(chain)
+		if chain.ChainID == c.ChainId {
+			ordered = append([]ChainConfig{chain}, ordered...)
+		} else {
+			ordered = append(ordered, chain)
+		}
+	}
+	if !seen[c.ChainId] {
+		return nil, fmt.Errorf("CHAIN_ID %d is not in the chains file", c.ChainId)
+	}
+	return ordered, nil
+}
+
+func (c Config) withDefaults(chain ChainConfig) ChainConfig {
+	if chain.Name == "" {
+		chain.Name = fmt.Sprintf("chain-%d", chain.ChainID)
+	}
+	if chain.Confirmations == 0 {
+		chain.Confirmations = c.ChainConfirmations
+	}
+	if chain.IndexerConfirmations == 0 {
+		chain.IndexerConfirmations = c.IndexerConfirmations
+	}
+	if chain.NativeCurrency.Symbol == "" {
+		chain.NativeCurrency = NativeCurrencyConfig{Name: c.NativeCurrencyName, Symbol: c.NativeCurrencySymbol}
+	}
+	if chain.NativeCurrency.Decimals == 0 {
+		chain.NativeCurrency.Decimals = 18
+	}
+	if chain.SeaportAddress == "" {
+		chain.SeaportAddress = c.SeaportAddress
+	}
+	if chain.SeaportConduitKey == "" {
+		chain.SeaportConduitKey = c.SeaportConduitKey
+	}
+	return chain
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"reflect"
+	"time"
 
 	"github.com/caarlos0/env"
 	"github.com/joho/godotenv"
@@ -13,6 +14,105 @@ type Config struct {
 	JwtSecret string `env:"JWT_SECRET,required"`
 	Env       string `env:"ENVIRONMENT,required"`
 	DbUrl     string `env:"DATABASE_URL,required"`
+
+	// Public URL of this API, used to build links sent to users
+	AppBaseUrl string `env:"APP_BASE_URL" envDefault:"http://localhost:8080"`
+
+	SmtpHost     string `env:"SMTP_HOST" envDefault:"localhost"`
+	SmtpPort     int    `env:"SMTP_PORT" envDefault:"25"`
+	SmtpUsername string `env:"SMTP_USERNAME"`
+	SmtpPassword string `env:"SMTP_PASSWORD"`
+	EmailFrom    string `env:"EMAIL_FROM" envDefault:"Artizan <no-reply@artizan.app>"`
+
+	// Chains the backend serves, as a JSON list of ChainConfig. Without it
+	// the single chain described by RPC_URL and the settings below is used.
+	ChainsFile string `env:"CHAINS_FILE"`
+	// Chain new collections are created on
+	ChainId              int64  `env:"CHAIN_ID" envDefault:"97"`
+	RpcUrl               string `env:"RPC_URL"`
+	ChainName            string `env:"CHAIN_NAME" envDefault:"bsc-testnet"`
+	ChainConfirmations   uint64 `env:"CHAIN_CONFIRMATIONS" envDefault:"3"`
+	NativeCurrencyName   string `env:"NATIVE_CURRENCY_NAME" envDefault:"BNB"`
+	NativeCurrencySymbol string `env:"NATIVE_CURRENCY_SYMBOL" envDefault:"tBNB"`
+	// Shared LazyMintZone and collection factory, when deployed
+	ZoneAddress    string `env:"ZONE_ADDRESS"`
+	FactoryAddress string `env:"FACTORY_ADDRESS"`
+	// Hardhat Ignition deployments folder, such as contracts/ignition/deployments;
+	// when set, its LazyMint1155 and LazyMintZone are checked on chain at startup
+	IgnitionDeploymentsDir string `env:"IGNITION_DEPLOYMENTS_DIR"`
+
+	// Run the chain indexer inside the server; disable when `artizan indexer` runs separately
+	IndexerEnabled bool `env:"INDEXER_ENABLED" envDefault:"true"`
+	// First block to index before a checkpoint exists; 0 starts at the confirmed head
+	IndexerStartBlock uint64 `env:"INDEXER_START_BLOCK" envDefault:"0"`
+	// Blocks per eth_getLogs request
+	IndexerBlockRange uint64 `env:"INDEXER_BLOCK_RANGE" envDefault:"2000"`
+	// Blocks the indexer stays behind the head, counting the block itself;
+	// deeper reorgs are detected and rolled back
+	IndexerConfirmations uint64 `env:"INDEXER_CONFIRMATIONS" envDefault:"3"`
+
+	// Key that deploys and owns collection contracts. The backend is one of
+	// memory (raw private key), keystore (encrypted keystore file) or remote.
+	DeployerSignerBackend     string `env:"DEPLOYER_SIGNER_BACKEND" envDefault:"memory"`
+	DeployerPrivateKey        string `env:"DEPLOYER_PRIVATE_KEY"`
+	DeployerKeystorePath      string `env:"DEPLOYER_KEYSTORE_PATH"`
+	DeployerKeystorePassword  string `env:"DEPLOYER_KEYSTORE_PASSWORD"`
+	DeployerRemoteSignerUrl   string `env:"DEPLOYER_REMOTE_SIGNER_URL"`
+	DeployerRemoteSignerToken string `env:"DEPLOYER_REMOTE_SIGNER_TOKEN"`
+
+	// Key whose signatures LazyMint1155 accepts for mint vouchers. Changing
+	// it rotates the signer of every deployed collection.
+	VoucherSignerBackend          string `env:"VOUCHER_SIGNER_BACKEND" envDefault:"memory"`
+	VoucherSignerPrivateKey       string `env:"VOUCHER_SIGNER_PRIVATE_KEY"`
+	VoucherSignerKeystorePath     string `env:"VOUCHER_SIGNER_KEYSTORE_PATH"`
+	VoucherSignerKeystorePassword string `env:"VOUCHER_SIGNER_KEYSTORE_PASSWORD"`
+	VoucherRemoteSignerUrl        string `env:"VOUCHER_REMOTE_SIGNER_URL"`
+	VoucherRemoteSignerToken      string `env:"VOUCHER_REMOTE_SIGNER_TOKEN"`
+	// How long the backend honours an issued voucher
+	VoucherTTL time.Duration `env:"VOUCHER_TTL" envDefault:"24h"`
+	// Longest a voucher holds a slot of its drop's supply; vouchers of a
+	// drop expire with their slot
+	DropReservationTTL time.Duration `env:"DROP_RESERVATION_TTL" envDefault:"15m"`
+	// Where voucher URIs point: "ipfs" pins the metadata, "hosted" uses /metadata
+	VoucherMetadata string `env:"VOUCHER_METADATA" envDefault:"ipfs"`
+
+	// Seaport 1.6 contract listings are signed for, and the conduit sellers
+	// approve; the zero key has Seaport itself transfer the tokens. Without
+	// CHAINS_FILE these and OFFER_CURRENCY apply to the single chain.
+	SeaportAddress    string `env:"SEAPORT_ADDRESS" envDefault:"0x0000000000000068F116a894984e2DB1123eB395"`
+	SeaportConduitKey string `env:"SEAPORT_CONDUIT_KEY" envDefault:"0x0000000000000000000000000000000000000000000000000000000000000000"`
+	// Platform share of every listing, in basis points; off without a recipient
+	MarketplaceFeeRecipient   string        `env:"MARKETPLACE_FEE_RECIPIENT"`
+	MarketplaceFeeBasisPoints uint          `env:"MARKETPLACE_FEE_BASIS_POINTS" envDefault:"250"`
+	ListingTTL                time.Duration `env:"LISTING_TTL" envDefault:"720h"`
+	// ERC-20 offers bid when they name no currency, usually the wrapped
+	// native coin; empty makes the currency required
+	OfferCurrency string        `env:"OFFER_CURRENCY"`
+	OfferTTL      time.Duration `env:"OFFER_TTL" envDefault:"168h"`
+
+	// Hot wallet that pays for sponsored mints; the relayer routes are off
+	// unless it is enabled. Budgets are in wei per RELAYER_BUDGET_WINDOW,
+	// empty for no limit.
+	RelayerEnabled           bool          `env:"RELAYER_ENABLED" envDefault:"false"`
+	RelayerSignerBackend     string        `env:"RELAYER_SIGNER_BACKEND" envDefault:"memory"`
+	RelayerPrivateKey        string        `env:"RELAYER_PRIVATE_KEY"`
+	RelayerKeystorePath      string        `env:"RELAYER_KEYSTORE_PATH"`
+	RelayerKeystorePassword  string        `env:"RELAYER_KEYSTORE_PASSWORD"`
+	RelayerRemoteSignerUrl   string        `env:"RELAYER_REMOTE_SIGNER_URL"`
+	RelayerRemoteSignerToken string        `env:"RELAYER_REMOTE_SIGNER_TOKEN"`
+	RelayerCreatorBudget     string        `env:"RELAYER_CREATOR_BUDGET" envDefault:"100000000000000000"`
+	RelayerUserBudget        string        `env:"RELAYER_USER_BUDGET" envDefault:"10000000000000000"`
+	RelayerBudgetWindow      time.Duration `env:"RELAYER_BUDGET_WINDOW" envDefault:"24h"`
+	RelayerStuckAfter        time.Duration `env:"RELAYER_STUCK_AFTER" envDefault:"3m"`
+	RelayerFeeBumpPercent    uint          `env:"RELAYER_FEE_BUMP_PERCENT" envDefault:"20"`
+	RelayerMaxFeeCap         string        `env:"RELAYER_MAX_FEE_CAP" envDefault:"500000000000"`
+
+	// Kubo RPC API used to pin assets
+	IpfsApiUrl    string `env:"IPFS_API_URL" envDefault:"http://127.0.0.1:5001"`
+	IpfsApiToken  string `env:"IPFS_API_TOKEN"`
+	AssetMaxBytes int64  `env:"ASSET_MAX_BYTES" envDefault:"52428800"`
+	// Largest ZIP accepted by bulk NFT imports
+	ImportMaxBytes int64 `env:"IMPORT_MAX_BYTES" envDefault:"268435456"`
 }
 
 func LoadConfig() (Config, error) {
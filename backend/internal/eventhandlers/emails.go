@@ -0,0 +1,35 @@
+package eventhandlers
+
+import (
+	"fmt"
+
+	interfaces "github.com/igwedaniel/artizan/internal/interfaces/eventbus"
+	"github.com/igwedaniel/artizan/internal/services"
+)
+
+func NewHandleSaleCompletedEmail(emailService *services.EmailService) func(interfaces.Event) error {
+	return func(e interfaces.Event) error {
+		data, ok := e.Data.(interfaces.SaleCompletedEvent)
+		if !ok {
+			return unexpectedPayload(e)
+		}
+		receipt := services.SaleReceipt{SaleID: data.SaleID, NFTID: data.NFTID, Price: data.Price, Currency: data.Currency}
+		if err := emailService.SendSaleReceipt(data.BuyerID, receipt); err != nil {
+			return fmt.Errorf("failed to queue sale receipt for sale %d: %w", data.SaleID, err)
+		}
+		return nil
+	}
+}
+
+func NewHandleDropStartedEmail(emailService *services.EmailService) func(interfaces.Event) error {
+	return func(e interfaces.Event) error {
+		data, ok := e.Data.(interfaces.DropStartedEvent)
+		if !ok {
+			return unexpectedPayload(e)
+		}
+		if err := emailService.SendDropReminders(data.CreatorID, data.DropID, dropEventKey(data.EventID)); err != nil {
+			return fmt.Errorf("failed to queue drop reminders for drop %d: %w", data.DropID, err)
+		}
+		return nil
+	}
+}
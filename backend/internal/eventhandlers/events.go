@@ -1,5 +0,0 @@
-package eventhandlers
-
-const (
-	EventUserCreated = "user.created"
-)
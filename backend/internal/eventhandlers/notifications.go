@@ -0,0 +1,106 @@
+package eventhandlers
+
+import (
+	"errors"
+	"fmt"
+
+	interfaces "github.com/igwedaniel/artizan/internal/interfaces/eventbus"
+	"github.com/igwedaniel/artizan/internal/models"
+	"github.com/igwedaniel/artizan/internal/services"
+)
+
+// The bus runs every handler on its own goroutine, so the handlers below
+// never hold up the publisher. They return their failures, which the bus
+// logs, or hands back to publishers that wait, such as the drop event
+// outbox, so the event is delivered again.
+
+func NewHandleSaleCompletedNotification(notificationService *services.NotificationService) func(interfaces.Event) error {
+	return func(e interfaces.Event) error {
+		data, ok := e.Data.(interfaces.SaleCompletedEvent)
+		if !ok {
+			return unexpectedPayload(e)
+		}
+		payload := models.NotificationData{"sale_id": data.SaleID, "nft_id": data.NFTID, "price": data.Price, "currency": data.Currency}
+		return errors.Join(
+			notify(notificationService, data.SellerID, models.NotificationTypeSaleCompleted,
+				"Your NFT sold", fmt.Sprintf("Your NFT sold for %s %s.", data.Price, data.Currency), payload),
+			notify(notificationService, data.BuyerID, models.NotificationTypeSaleCompleted,
+				"Purchase complete", "Your purchase went through and the NFT is now in your wallet.", payload),
+		)
+	}
+}
+
+func NewHandleOfferReceivedNotification(notificationService *services.NotificationService) func(interfaces.Event) error {
+	return func(e interfaces.Event) error {
+		data, ok := e.Data.(interfaces.OfferReceivedEvent)
+		if !ok {
+			return unexpectedPayload(e)
+		}
+		payload := models.NotificationData{"offer_id": data.OfferID, "collection_id": data.CollectionID, "nft_id": data.NFTID, "price": data.Price, "currency": data.Currency}
+		var errs []error
+		for _, recipientID := range data.RecipientIDs {
+			if recipientID == data.BidderID {
+				continue
+			}
+			errs = append(errs, notify(notificationService, recipientID, models.NotificationTypeOfferReceived,
+				"New offer", fmt.Sprintf("You received an offer of %s %s.", data.Price, data.Currency), payload))
+		}
+		return errors.Join(errs...)
+	}
+}
+
+func NewHandleDropStartedNotification(notificationService *services.NotificationService) func(interfaces.Event) error {
+	return func(e interfaces.Event) error {
+		data, ok := e.Data.(interfaces.DropStartedEvent)
+		if !ok {
+			return unexpectedPayload(e)
+		}
+		err := notificationService.NotifyOnce(dropEventKey(data.EventID), data.CreatorID, models.NotificationTypeDropStarted,
+			"Your drop is live", "Your drop has started and collectors can now mint.",
+			models.NotificationData{"drop_id": data.DropID, "collection_id": data.CollectionID})
+		if err != nil {
+			return fmt.Errorf("failed to notify the creator of drop %d: %w", data.DropID, err)
+		}
+		return nil
+	}
+}
+
+func NewHandleUserFollowedNotification(notificationService *services.NotificationService) func(interfaces.Event) error {
+	return func(e interfaces.Event) error {
+		data, ok := e.Data.(interfaces.UserFollowedEvent)
+		if !ok {
+			return unexpectedPayload(e)
+		}
+		return notify(notificationService, data.FolloweeID, models.NotificationTypeNewFollower,
+			"New follower", "Someone started following you.",
+			models.NotificationData{"follower_id": data.FollowerID})
+	}
+}
+
+func NewHandleCreatorApprovedNotification(notificationService *services.NotificationService) func(interfaces.Event) error {
+	return func(e interfaces.Event) error {
+		data, ok := e.Data.(interfaces.CreatorApprovedEvent)
+		if !ok {
+			return unexpectedPayload(e)
+		}
+		return notify(notificationService, data.UserID, models.NotificationTypeCreatorApproved,
+			"You're a creator", "Your creator application was approved. You can now create collections and drops.", nil)
+	}
+}
+
+func notify(notificationService *services.NotificationService, userID uint, notificationType, title, body string, data models.NotificationData) error {
+	if err := notificationService.Notify(userID, notificationType, title, body, data); err != nil {
+		return fmt.Errorf("failed to notify user %d (%s): %w", userID, notificationType, err)
+	}
+	return nil
+}
+
+// dropEventKey is the dedupe key of the side effects of a drop event, the
+// same on every delivery of the event
+func dropEventKey(eventID uint) string {
+	return fmt.Sprintf("drop_event:%d", eventID)
+}
+
+func unexpectedPayload(e interfaces.Event) error {
+	return fmt.Errorf("unexpected payload %T for event %s", e.Data, e.EventType)
+}
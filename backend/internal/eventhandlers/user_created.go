@@ -5,8 +5,9 @@ import (
 	"github.com/igwedaniel/artizan/internal/services"
 )
 
-func NewHandleUserCreatedEvent(UserService *services.UserService) func(interfaces.Event) {
-	return func(e interfaces.Event) {
+func NewHandleUserCreatedEvent(UserService *services.UserService) func(interfaces.Event) error {
+	return func(e interfaces.Event) error {
 		// handler logic using userRepo
+		return nil
 	}
 }
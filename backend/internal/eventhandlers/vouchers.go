@@ -0,0 +1,29 @@
+package eventhandlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	interfaces "github.com/igwedaniel/artizan/internal/interfaces/eventbus"
+	"github.com/igwedaniel/artizan/internal/services"
+)
+
+// NewHandleCollectionSignerUpdatedVouchers re-signs the outstanding vouchers
+// of a collection once its contract accepts the new signer
+func NewHandleCollectionSignerUpdatedVouchers(voucherService *services.VoucherService) func(interfaces.Event) error {
+	return func(e interfaces.Event) error {
+		data, ok := e.Data.(interfaces.CollectionSignerUpdatedEvent)
+		if !ok {
+			return unexpectedPayload(e)
+		}
+		reissued, err := voucherService.ReissueOutstanding(context.Background(), data.CollectionID)
+		if err != nil {
+			return fmt.Errorf("failed to reissue vouchers of collection %d: %w", data.CollectionID, err)
+		}
+		if len(reissued) > 0 {
+			log.Printf("reissued %d vouchers of collection %d for signer %s", len(reissued), data.CollectionID, data.SignerAddress)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// Client is the subset of an Ethereum JSON-RPC client the backend uses. It is
+// satisfied by *ethclient.Client and by go-ethereum's simulated backend.
+type Client interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	ethereum.ChainIDReader
+	ethereum.BlockNumberReader
+	ethereum.ChainStateReader
+	ethereum.TransactionReader
+}
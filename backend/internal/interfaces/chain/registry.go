@@ -0,0 +1,55 @@
+package interfaces
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrUnknownChain = errors.New("chain is not configured")
+
+// names of the platform contracts a Network registers
+const (
+	ContractLazyMint1155 = "LazyMint1155"
+	ContractLazyMintZone = "LazyMintZone"
+)
+
+// Network is a chain the backend serves and the platform contracts on it
+type Network struct {
+	ChainID        int64
+	Name           string
+	Client         Client
+	Confirmations  uint64 // blocks a transaction needs before it counts, the block itself included
+	NativeCurrency NativeCurrency
+	Seaport        common.Address
+	ConduitKey     common.Hash    // zero has Seaport itself transfer the tokens
+	Zone           common.Address // shared LazyMintZone, zero when each collection deploys its own
+	Factory        common.Address // collection factory, zero when collections are deployed directly
+	WrappedNative  common.Address // ERC-20 offers bid it when they name no currency; zero makes the currency required
+	// platform contracts by name, registered from deployment artifacts
+	Contracts map[string]*Contract
+}
+
+// Contract is a deployed contract whose code was checked on chain
+type Contract struct {
+	Address common.Address
+	ABI     abi.ABI
+}
+
+// NativeCurrency is the coin a chain pays gas and native listings in
+type NativeCurrency struct {
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Decimals uint8  `json:"decimals"`
+}
+
+// Registry resolves the chains the backend is configured for
+type Registry interface {
+	// Network returns the chain with chainID, or ErrUnknownChain
+	Network(chainID int64) (*Network, error)
+	// Default is the chain new collections are created on
+	Default() *Network
+	// Networks lists every configured chain, the default first
+	Networks() []*Network
+}
@@ -5,9 +5,15 @@ type Event struct {
 	Data      interface{}
 }
 
-type HandlerFunc func(Event)
+// HandlerFunc handles an event and reports a failure to handle it
+type HandlerFunc func(Event) error
 
 type EventBus interface {
 	Subscribe(event string, handler HandlerFunc)
+	// Publish runs every handler of event in the background and logs their
+	// failures
 	Publish(event string, data interface{})
+	// PublishAndWait runs every handler of event and returns their failures
+	// once they all have, for callers that must know an event was handled
+	PublishAndWait(event string, data interface{}) error
 }
@@ -0,0 +1,106 @@
+package interfaces
+
+// Event names published on the bus. They live next to the bus interface so
+// that services can publish them without importing the handlers package.
+const (
+	EventUserCreated     = "user.created"
+	EventUserFollowed    = "user.followed"
+	EventCreatorApproved = "creator.approved"
+	EventSaleCompleted   = "sale.completed"
+	EventOfferReceived   = "offer.received"
+	EventDropScheduled   = "drop.scheduled"
+	EventDropStarted     = "drop.started"
+	EventDropEnded       = "drop.ended"
+
+	EventCollectionDeployed      = "collection.deployed"
+	EventCollectionSignerUpdated = "collection.signer_updated"
+	EventNFTMinted               = "nft.minted"
+)
+
+// SaleCompletedEvent is the payload of EventSaleCompleted.
+type SaleCompletedEvent struct {
+	SaleID   uint   `json:"sale_id"`
+	NFTID    uint   `json:"nft_id"`
+	SellerID uint   `json:"seller_id"` // zero when the seller has no account
+	BuyerID  uint   `json:"buyer_id"`  // zero when the buyer has no account
+	Price    string `json:"price"`     // amount in the smallest unit of Currency
+	Currency string `json:"currency"`
+}
+
+// OfferReceivedEvent is the payload of EventOfferReceived.
+type OfferReceivedEvent struct {
+	OfferID      uint   `json:"offer_id"`
+	BidderID     uint   `json:"bidder_id"`
+	RecipientIDs []uint `json:"recipient_ids"`
+	CollectionID uint   `json:"collection_id"`
+	NFTID        uint   `json:"nft_id"` // zero for collection-wide offers
+	Price        string `json:"price"`
+	Currency     string `json:"currency"`
+}
+
+// DropScheduledEvent is the payload of EventDropScheduled. Drop events are
+// delivered at least once; EventID, the same on every delivery, lets
+// handlers skip the work an earlier delivery already did.
+type DropScheduledEvent struct {
+	EventID      uint   `json:"event_id"`
+	DropID       uint   `json:"drop_id"`
+	CollectionID uint   `json:"collection_id"`
+	CreatorID    uint   `json:"creator_id"`
+	StartTime    int64  `json:"start_time"`
+	EndTime      *int64 `json:"end_time"`
+}
+
+// DropStartedEvent is the payload of EventDropStarted.
+type DropStartedEvent struct {
+	EventID      uint `json:"event_id"`
+	DropID       uint `json:"drop_id"`
+	CollectionID uint `json:"collection_id"`
+	CreatorID    uint `json:"creator_id"`
+}
+
+// DropEndedEvent is the payload of EventDropEnded.
+type DropEndedEvent struct {
+	EventID      uint   `json:"event_id"`
+	DropID       uint   `json:"drop_id"`
+	CollectionID uint   `json:"collection_id"`
+	CreatorID    uint   `json:"creator_id"`
+	Status       string `json:"status"` // sold_out or ended
+}
+
+// UserFollowedEvent is the payload of EventUserFollowed.
+type UserFollowedEvent struct {
+	FollowerID uint `json:"follower_id"`
+	FolloweeID uint `json:"followee_id"`
+}
+
+// CreatorApprovedEvent is the payload of EventCreatorApproved.
+type CreatorApprovedEvent struct {
+	UserID uint `json:"user_id"`
+}
+
+// CollectionDeployedEvent is the payload of EventCollectionDeployed.
+type CollectionDeployedEvent struct {
+	CollectionID    uint   `json:"collection_id"`
+	CreatorID       uint   `json:"creator_id"`
+	ContractAddress string `json:"contract_address"`
+	ZoneAddress     string `json:"zone_address,omitempty"`
+}
+
+// CollectionSignerUpdatedEvent is the payload of EventCollectionSignerUpdated.
+type CollectionSignerUpdatedEvent struct {
+	CollectionID  uint   `json:"collection_id"`
+	SignerAddress string `json:"signer_address"`
+}
+
+// NFTMintedEvent is the payload of EventNFTMinted.
+type NFTMintedEvent struct {
+	NFTID           uint   `json:"nft_id"`
+	CollectionID    uint   `json:"collection_id"`
+	CreatorID       uint   `json:"creator_id"`
+	ContractAddress string `json:"contract_address"`
+	TokenID         string `json:"token_id"`
+	OwnerAddress    string `json:"owner_address"`
+	Amount          string `json:"amount"`
+	VoucherID       uint   `json:"voucher_id"` // zero when no recorded voucher matches the mint
+	TxHash          string `json:"tx_hash"`
+}
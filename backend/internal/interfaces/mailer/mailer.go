@@ -0,0 +1,17 @@
+package interfaces
+
+import "context"
+
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+	Headers map[string]string
+}
+
+// Mailer delivers a single message. Implementations should return an error
+// for any failure so the caller can retry.
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
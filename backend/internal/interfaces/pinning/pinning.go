@@ -0,0 +1,10 @@
+package interfaces
+
+import "context"
+
+// Pinner stores blobs on IPFS and keeps them pinned
+type Pinner interface {
+	// Add uploads data, pins it and returns the CID the node assigned to it
+	Add(ctx context.Context, name string, data []byte) (string, error)
+	IsPinned(ctx context.Context, cid string) (bool, error)
+}
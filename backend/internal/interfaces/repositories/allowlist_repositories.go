@@ -0,0 +1,15 @@
+package interfaces
+
+import "github.com/igwedaniel/artizan/internal/models"
+
+type AllowlistRepository interface {
+	// CreateVersion stores allowlist and its entries as the next version of
+	// its drop, setting Version
+	CreateVersion(allowlist *models.Allowlist, entries []*models.AllowlistEntry) error
+	// GetLatest returns the newest version of a drop's allowlist
+	GetLatest(dropID uint) (*models.Allowlist, error)
+	// ListVersions lists a drop's allowlist versions, newest first
+	ListVersions(dropID uint) ([]*models.Allowlist, error)
+	// GetEntry finds the entry of a checksummed address in an allowlist version
+	GetEntry(allowlistID uint, address string) (*models.AllowlistEntry, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+type AssetRepository interface {
+	// Create fails with ErrDuplicateRecord when an asset with the same CID exists
+	Create(asset *models.Asset) error
+	GetByCID(cid string) (*models.Asset, error)
+	Update(asset *models.Asset) error
+	// ListDuePins lists unpinned assets whose next pin attempt is due
+	ListDuePins(now time.Time, limit int) ([]*models.Asset, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import "github.com/igwedaniel/artizan/internal/models"
+
+type ChainCheckpointRepository interface {
+	// GetCheckpoint returns ErrRecordNotFound before the chain was first indexed
+	GetCheckpoint(chainID int64) (*models.ChainCheckpoint, error)
+	// Advance stores blocks and moves the checkpoint in one transaction
+	Advance(checkpoint *models.ChainCheckpoint, blocks []*models.IndexedBlock) error
+	// ListBlocks lists the indexed blocks of a chain from fromBlock up, newest first
+	ListBlocks(chainID int64, fromBlock uint64) ([]*models.IndexedBlock, error)
+	// Rewind forgets the blocks above ancestor and makes it the checkpoint
+	Rewind(chainID int64, ancestor *models.IndexedBlock) error
+	// PruneBlocks forgets blocks below beforeBlock
+	PruneBlocks(chainID int64, beforeBlock uint64) error
+}
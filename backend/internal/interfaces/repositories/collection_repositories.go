@@ -0,0 +1,34 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+type CollectionRepository interface {
+	// Create stores a collection, or returns ErrDuplicateRecord when the
+	// creator already has a collection with its name
+	Create(collection *models.Collection) error
+	GetByID(id uint) (*models.Collection, error)
+	// GetByContractAddress finds a collection of a chain by its checksummed
+	// contract address
+	GetByContractAddress(chainID int64, contractAddress string) (*models.Collection, error)
+	GetByCreatorAndName(creatorID uint, name string) (*models.Collection, error)
+	Update(collection *models.Collection) error
+	// UpdateDetails saves the fields a creator edits, leaving the deployment
+	// columns DeploymentService owns untouched
+	UpdateDetails(collection *models.Collection) error
+	ListByCreator(creatorID uint, includeArchived bool) ([]*models.Collection, error)
+	// ClaimDeployment marks an undeployed collection as deploying and reports
+	// whether this call won the claim
+	ClaimDeployment(id uint, withZone bool) (bool, error)
+	ListByDeploymentStatus(statuses ...string) ([]*models.Collection, error)
+	// ListSignerOutdated lists deployed collections whose contract does not
+	// accept signerAddress yet, skipping those backing off from a failed
+	// update to it at now or that gave up on it
+	ListSignerOutdated(signerAddress string, now time.Time) ([]*models.Collection, error)
+	// ListWithContract lists every collection of a chain with a contract
+	// address, archived or not
+	ListWithContract(chainID int64) ([]*models.Collection, error)
+}
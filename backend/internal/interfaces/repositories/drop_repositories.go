@@ -0,0 +1,41 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+type DropRepository interface {
+	Create(drop *models.Drop) error
+	// GetByID returns the drop with its collection loaded
+	GetByID(id uint) (*models.Drop, error)
+	// Update saves the editable fields of a drop; the status only moves through Transition
+	Update(drop *models.Drop) error
+	// ListByCollection lists a collection's drops in the given statuses, or
+	// all of them, by start time
+	ListByCollection(collectionID uint, statuses ...string) ([]*models.Drop, error)
+	// Transition saves the status and stage times of drop if its stored
+	// status is still from, and reports whether it was. A non-nil event is
+	// added to the outbox in the same transaction.
+	Transition(drop *models.Drop, from string, event *models.DropEvent) (bool, error)
+	// ListDue lists scheduled drops whose start time has come and live drops
+	// whose end time has, with their collections loaded
+	ListDue(now time.Time) ([]*models.Drop, error)
+	// ListSoldOut lists live drops with at least Supply minted NFTs
+	ListSoldOut() ([]*models.Drop, error)
+	// ClaimNextEvent locks the oldest undelivered event whose lock ran out by
+	// now until lockUntil, or returns nil when there is none
+	ClaimNextEvent(now, lockUntil time.Time) (*models.DropEvent, error)
+	// MarkEventDelivered records that every handler of an event has run
+	MarkEventDelivered(id uint, at time.Time) error
+	// ReserveTokenSequences atomically advances the drop's token sequence by n
+	// and returns the last sequence reserved
+	ReserveTokenSequences(dropID uint, n uint64) (uint64, error)
+	// Subscribe asks for a reminder of a drop for userID; subscribing twice
+	// is a no-op
+	Subscribe(dropID, userID uint) error
+	Unsubscribe(dropID, userID uint) error
+	// ListSubscriberIDs lists the users subscribed to a drop
+	ListSubscriberIDs(dropID uint) ([]uint, error)
+}
@@ -0,0 +1,30 @@
+package interfaces
+
+import (
+	"errors"
+	"time"
+
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+var ErrQuotaExceeded = errors.New("wallet quota exceeded")
+
+type DropReservationRepository interface {
+	// Reserve holds a slot of the drop for reservation.NFTID and reports
+	// false when minted NFTs and live holds already fill the drop's supply,
+	// or ErrDuplicateRecord when the NFT holds a slot of another owner
+	// unexpired at now. A hold is live while it is unexpired or a voucher
+	// the contract accepts still backs it; an expired hold of the NFT, or
+	// one of the same owner, is taken over.
+	// With a quota it fails with ErrQuotaExceeded when the copies of the
+	// owner's live holds and purchases in the drop would pass it. Concurrent calls
+	// for one drop are serialised, so neither the supply nor a quota is ever
+	// over-reserved.
+	Reserve(reservation *models.DropReservation, quota *uint64, now time.Time) (bool, error)
+	// Release frees the slot an NFT holds unless a voucher the contract
+	// accepts backs it, reporting whether it freed one
+	Release(nftID uint, at time.Time) (bool, error)
+	// ReleaseExpired frees every slot held past its expiry at now that no
+	// voucher the contract accepts backs any more
+	ReleaseExpired(now time.Time) (int64, error)
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+type EmailRepository interface {
+	// CreateMessage fails with ErrDuplicateRecord when a message with the
+	// same dedupe key was queued
+	CreateMessage(message *models.EmailMessage) error
+	UpdateMessage(message *models.EmailMessage) error
+	ListDueMessages(now time.Time, limit int) ([]*models.EmailMessage, error)
+	IsUnsubscribed(userID uint, category string) (bool, error)
+	Unsubscribe(userID uint, category string) error
+}
@@ -3,5 +3,6 @@ package interfaces
 import "errors"
 
 var (
-	ErrRecordNotFound = errors.New("record not found")
+	ErrRecordNotFound  = errors.New("record not found")
+	ErrDuplicateRecord = errors.New("record already exists")
 )
@@ -0,0 +1,12 @@
+package interfaces
+
+import "github.com/igwedaniel/artizan/internal/models"
+
+type FollowRepository interface {
+	// Create stores a follow, or returns ErrDuplicateRecord when it exists
+	Create(follow *models.Follow) error
+	// Delete removes a follow, reporting whether there was one
+	Delete(followerID, followeeID uint) (bool, error)
+	// ListFollowerIDs lists the users following followeeID
+	ListFollowerIDs(followeeID uint) ([]uint, error)
+}
@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+type ImportJobRepository interface {
+	// Create fails with ErrDuplicateRecord when the drop already has a job for the same archive
+	Create(job *models.ImportJob) error
+	GetByID(id uint) (*models.ImportJob, error)
+	GetByDropAndHash(dropID uint, archiveHash string) (*models.ImportJob, error)
+	Update(job *models.ImportJob) error
+	// UpdateProgress saves the counters, errors and results of a running job
+	UpdateProgress(job *models.ImportJob) error
+	// ClaimNext marks the oldest queued job, or a processing job that has not
+	// progressed since staleBefore, as processing and returns it. It returns
+	// nil when there is nothing to do.
+	ClaimNext(staleBefore time.Time) (*models.ImportJob, error)
+}
@@ -0,0 +1,29 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+type NFTRepository interface {
+	// Create fails with ErrDuplicateRecord when the drop already has an NFT with the same import key
+	Create(nft *models.NFT) error
+	// GetByID returns the NFT with its drop and collection loaded
+	GetByID(id uint) (*models.NFT, error)
+	Update(nft *models.NFT) error
+	// GetByContractAndTokenID finds an NFT by the collection contract it
+	// mints on. Contracts deployed at the same address on several chains
+	// are told apart by chainID.
+	GetByContractAndTokenID(chainID int64, contractAddress string, tokenID models.Uint256) (*models.NFT, error)
+	// MarkMinted records the mint of an NFT and, in the same transaction,
+	// converts its latest drop reservation to a sale. It reports false when
+	// the mint was already marked.
+	MarkMinted(id uint, block uint64, txHash, uri string, mintedAt time.Time) (bool, error)
+	// RevertMintsFromBlock clears the mints indexed on chainID from fromBlock
+	// up, restores the vouchers they redeemed and holds the drop slots they
+	// sold again
+	RevertMintsFromBlock(chainID int64, fromBlock uint64) error
+	// ListByImportKeys returns the NFTs of a drop created from the given manifest row keys
+	ListByImportKeys(dropID uint, keys []string) ([]*models.NFT, error)
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import "github.com/igwedaniel/artizan/internal/models"
+
+type NotificationRepository interface {
+	// Create fails with ErrDuplicateRecord when a notification with the same
+	// dedupe key was stored
+	Create(notification *models.Notification) error
+	ListByUser(userID uint, unreadOnly bool, limit, offset int) ([]*models.Notification, error)
+	CountUnread(userID uint) (int64, error)
+	MarkRead(userID uint, ids []uint) error
+	MarkAllRead(userID uint) error
+	GetPreferences(userID uint) ([]*models.NotificationPreference, error)
+	UpsertPreference(preference *models.NotificationPreference) error
+}
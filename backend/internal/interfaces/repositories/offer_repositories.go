@@ -0,0 +1,38 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+// OfferFilter selects offers. With NFTID and IncludeCollection set, the
+// collection-wide offers of CollectionID match too.
+type OfferFilter struct {
+	NFTID             uint
+	CollectionID      uint
+	IncludeCollection bool
+	Maker             string
+	Status            string
+	LiveAt            *time.Time // active offers between their start and end time
+	Limit             int
+	Offset            int
+}
+
+type OfferRepository interface {
+	// Create stores an offer; a second offer with the same hash is ErrDuplicateRecord
+	Create(offer *models.Offer) error
+	GetByHash(orderHash string) (*models.Offer, error)
+	// List returns the offers matching filter, highest unit price first
+	List(filter OfferFilter) ([]*models.Offer, error)
+	// ExpireEnded marks active offers whose end time has passed expired
+	ExpireEnded(now time.Time) (int64, error)
+	// MarkCancelled cancels an unfilled offer and reports false when there was none
+	MarkCancelled(chainID int64, orderHash string, at time.Time, block uint64) (bool, error)
+	// CancelBelowCounter cancels the unfilled offers a maker signed under an older counter
+	CancelBelowCounter(chainID int64, maker string, counter models.Uint256, at time.Time, block uint64) (int64, error)
+	// RevertCancellationsFromBlock restores the offers of a chain cancelled from fromBlock up
+	RevertCancellationsFromBlock(chainID int64, fromBlock uint64) error
+	// ListActiveMakers returns the makers with active offers on a chain
+	ListActiveMakers(chainID int64) ([]string, error)
+}
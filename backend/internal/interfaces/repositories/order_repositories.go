@@ -0,0 +1,40 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+// OrderFilter narrows an order listing; zero fields match everything
+type OrderFilter struct {
+	NFTID        uint
+	CollectionID uint
+	Maker        string
+	Currency     string
+	Status       string
+	// live orders are active and not past their end time at this instant
+	LiveAt *time.Time
+	SortBy string // "price" for cheapest first, otherwise newest first
+	Limit  int
+	Offset int
+}
+
+type OrderRepository interface {
+	// Create fails with ErrDuplicateRecord when the order hash is already stored
+	Create(order *models.Order) error
+	GetByHash(orderHash string) (*models.Order, error)
+	List(filter OrderFilter) ([]*models.Order, error)
+	// GetBest returns the live order with the lowest unit price for an NFT in a currency
+	GetBest(nftID uint, currency string, now time.Time) (*models.Order, error)
+	// ExpireEnded marks active orders whose end time has passed expired
+	ExpireEnded(now time.Time) (int64, error)
+	// MarkCancelled cancels an unfilled order and reports false when there was none
+	MarkCancelled(chainID int64, orderHash string, at time.Time, block uint64) (bool, error)
+	// CancelBelowCounter cancels the unfilled orders a maker signed under an older counter
+	CancelBelowCounter(chainID int64, maker string, counter models.Uint256, at time.Time, block uint64) (int64, error)
+	// RevertCancellationsFromBlock restores the orders of a chain cancelled from fromBlock up
+	RevertCancellationsFromBlock(chainID int64, fromBlock uint64) error
+	// ListActiveMakers returns the makers with active orders on a chain
+	ListActiveMakers(chainID int64) ([]string, error)
+}
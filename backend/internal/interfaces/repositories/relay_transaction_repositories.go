@@ -0,0 +1,36 @@
+package interfaces
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+var (
+	ErrCreatorBudgetExceeded = errors.New("creator sponsorship budget exceeded")
+	ErrUserBudgetExceeded    = errors.New("user sponsorship budget exceeded")
+)
+
+// SponsorshipBudget caps the cost of the relay transactions created since
+// Since, per creator and per requester. A nil cap is unlimited.
+type SponsorshipBudget struct {
+	Since   time.Time
+	Creator *big.Int
+	User    *big.Int
+}
+
+type RelayTransactionRepository interface {
+	// Reserve stores relayTx as queued with the next nonce of its sender,
+	// never lower than chainNonce. Reservations for a sender are serialized,
+	// so concurrent callers get distinct nonces and see each other's spend.
+	// It fails with ErrDuplicateRecord while the NFT has an open relay
+	// transaction, and with ErrCreatorBudgetExceeded or ErrUserBudgetExceeded
+	// when relayTx.Cost does not fit the budget.
+	Reserve(relayTx *models.RelayTransaction, chainNonce uint64, budget SponsorshipBudget) error
+	GetByID(id uint) (*models.RelayTransaction, error)
+	Update(relayTx *models.RelayTransaction) error
+	// ListOpen returns the queued and pending transactions of a sender by nonce
+	ListOpen(chainID int64, from string) ([]*models.RelayTransaction, error)
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import "github.com/igwedaniel/artizan/internal/models"
+
+type SaleRepository interface {
+	// Create stores a sale, adds its quantity to the filled quantity of its
+	// order or offer and reports false when the same log was already indexed
+	Create(sale *models.Sale) (bool, error)
+	// DeleteFromBlock removes the sales of a chain indexed from fromBlock up
+	// and recounts the fills of their orders and offers
+	DeleteFromBlock(chainID int64, fromBlock uint64) error
+	ListByNFT(nftID uint, limit, offset int) ([]*models.Sale, error)
+	ListByCollection(collectionID uint, limit, offset int) ([]*models.Sale, error)
+}
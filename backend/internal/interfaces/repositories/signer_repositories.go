@@ -0,0 +1,13 @@
+package interfaces
+
+import "github.com/igwedaniel/artizan/internal/models"
+
+type SignerRepository interface {
+	GetActiveKey(purpose string) (*models.SignerKey, error)
+	// ActivateKey makes key the active key of its purpose and retires the
+	// previously active one
+	ActivateKey(key *models.SignerKey) error
+	ListKeys(purpose string) ([]*models.SignerKey, error)
+	CreateAudit(audit *models.SignatureAudit) error
+	ListAudits(signerAddress string, limit, offset int) ([]*models.SignatureAudit, error)
+}
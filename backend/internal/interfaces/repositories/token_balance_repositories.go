@@ -0,0 +1,16 @@
+package interfaces
+
+import "github.com/igwedaniel/artizan/internal/models"
+
+type TokenBalanceRepository interface {
+	// ListByOwner returns the tokens a wallet holds with their NFTs loaded
+	ListByOwner(chainID int64, ownerAddress string, limit, offset int) ([]*models.TokenBalance, error)
+	// ListHolders returns the wallets holding a token, largest balance first
+	ListHolders(chainID int64, contractAddress string, tokenID models.Uint256, limit, offset int) ([]*models.TokenBalance, error)
+	// CountHolders counts the distinct wallets holding any token of a contract
+	CountHolders(chainID int64, contractAddress string) (int64, error)
+	// ListAfter pages through the balances of a chain by ID
+	ListAfter(chainID int64, afterID uint, limit int) ([]*models.TokenBalance, error)
+	// Set overwrites a balance, removing the row when it is zero
+	Set(balance *models.TokenBalance) error
+}
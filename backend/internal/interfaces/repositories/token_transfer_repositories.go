@@ -0,0 +1,12 @@
+package interfaces
+
+import "github.com/igwedaniel/artizan/internal/models"
+
+type TokenTransferRepository interface {
+	// Create stores a transfer, moves the token balances it changes and
+	// reports false when the same log was already indexed
+	Create(transfer *models.TokenTransfer) (bool, error)
+	// DeleteFromBlock removes the transfers of a chain indexed from fromBlock up
+	// and undoes their balance changes
+	DeleteFromBlock(chainID int64, fromBlock uint64) error
+}
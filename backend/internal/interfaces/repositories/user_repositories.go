@@ -6,6 +6,8 @@ type UserRepository interface {
 	Create(user *models.User) error
 	GetUserByWalletAddress(walletAddress string) (*models.User, error)
 	GetByID(id string) (*models.User, error)
+	GetUserByUsername(username string) (*models.User, error)
+	GetUserByEmail(email string) (*models.User, error)
 	UpdateUserByID(id string, user *models.User) error
 	DeleteUserByID(id string) error
 }
@@ -0,0 +1,29 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+type VoucherRepository interface {
+	// Create fails with ErrDuplicateRecord when the NFT already has an issued
+	// collector voucher
+	Create(voucher *models.Voucher) error
+	GetByID(id uint) (*models.Voucher, error)
+	Update(voucher *models.Voucher) error
+	ListByNFT(nftID uint) ([]*models.Voucher, error)
+	// GetIssuedByNFT returns the collector voucher in the issued status,
+	// expired or not
+	GetIssuedByNFT(nftID uint) (*models.Voucher, error)
+	// GetIssuedForOwner returns the latest voucher in the issued status that
+	// lets ownerAddress mint an NFT, expired or not
+	GetIssuedForOwner(nftID uint, ownerAddress string) (*models.Voucher, error)
+	ListIssuedByContract(chainID int64, contractAddress string, now time.Time) ([]*models.Voucher, error)
+	HasRevokedBySigner(nftID uint, signerAddress string) (bool, error)
+	// MarkRedeemed marks the latest voucher of an NFT matching the minted owner
+	// and amount as redeemed and returns it, or ErrRecordNotFound
+	MarkRedeemed(nftID uint, ownerAddress, amount, txHash string, redeemedAt time.Time) (*models.Voucher, error)
+	// Supersede marks old as superseded by replacement and stores replacement
+	Supersede(old, replacement *models.Voucher) error
+}
@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Signer holds an Ethereum key on behalf of the backend. Implementations may
+// keep the key in memory, in an encrypted keystore file or behind a remote
+// signing service; callers never see the key itself.
+type Signer interface {
+	Address() common.Address
+	// SignHash signs a 32 byte digest and returns a 65 byte [R || S || V]
+	// signature with V in {0, 1}
+	SignHash(ctx context.Context, hash common.Hash) ([]byte, error)
+	SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Allowlist is one version of the wallets allowed to mint from a private
+// drop. Every upload adds a version with its own Merkle root; the latest
+// version is the one enforced.
+type Allowlist struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	DropID      uint      `json:"drop_id" gorm:"not null;uniqueIndex:idx_allowlist_drop_version"`
+	Version     uint      `json:"version" gorm:"not null;uniqueIndex:idx_allowlist_drop_version"`
+	Root        string    `json:"root" gorm:"not null;type:varchar(66)"` // StandardMerkleTree root of [address, quota] leaves
+	Size        int       `json:"size" gorm:"not null"`
+	CreatedByID uint      `json:"created_by_id" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// AllowlistEntry is a wallet of an allowlist version, the copies it may
+// mint from the drop and its Merkle proof
+type AllowlistEntry struct {
+	ID          uint           `json:"-" gorm:"primaryKey"`
+	AllowlistID uint           `json:"-" gorm:"not null;uniqueIndex:idx_allowlist_entry"`
+	Address     string         `json:"address" gorm:"not null;type:varchar(42);uniqueIndex:idx_allowlist_entry"` // checksummed
+	Quota       uint64         `json:"quota" gorm:"not null"`
+	Leaf        string         `json:"leaf" gorm:"not null;type:varchar(66)"`
+	Proof       AllowlistProof `json:"proof" gorm:"type:jsonb"`
+}
+
+type AllowlistProof []string
+
+// Scan implements the Scanner interface.
+func (p *AllowlistProof) Scan(value interface{}) error {
+	if value == nil {
+		*p = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, p)
+}
+
+// Value implements the Valuer interface.
+func (p AllowlistProof) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return json.Marshal(p)
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	AssetKindFile     = "file"
+	AssetKindMetadata = "metadata" // token metadata JSON rendered by the backend
+)
+
+const (
+	AssetPinStatusPending = "pending"
+	AssetPinStatusPinned  = "pinned"
+	AssetPinStatusFailed  = "failed" // retried in the background
+)
+
+// Asset is a blob stored on IPFS under its CIDv1. The content is kept in the
+// database until the blob is pinned so failed pins can be retried.
+type Asset struct {
+	gorm.Model
+	OwnerID          *uint      `json:"owner_id" gorm:"index"` // nil for assets the backend generated
+	Owner            *User      `json:"-" gorm:"foreignKey:OwnerID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	CID              string     `json:"cid" gorm:"not null;uniqueIndex"`
+	Kind             string     `json:"kind" gorm:"not null"`
+	Filename         string     `json:"filename"`
+	ContentType      string     `json:"content_type"`
+	Size             int64      `json:"size" gorm:"not null"`
+	Content          []byte     `json:"-" gorm:"type:bytea"`
+	PinStatus        string     `json:"pin_status" gorm:"not null;index"`
+	PinAttempts      int        `json:"pin_attempts" gorm:"not null;default:0"`
+	PinError         string     `json:"pin_error,omitempty"`
+	NextPinAttemptAt time.Time  `json:"-" gorm:"not null;index"`
+	PinnedAt         *time.Time `json:"pinned_at"`
+}
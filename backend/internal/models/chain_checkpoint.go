@@ -0,0 +1,23 @@
+package models
+
+import "gorm.io/gorm"
+
+// ChainCheckpoint is the last block the indexer has fully processed on a
+// chain. The next block must have it as its parent, or the chain reorganised.
+type ChainCheckpoint struct {
+	gorm.Model
+	ChainID     int64  `json:"chain_id" gorm:"not null;uniqueIndex"`
+	BlockNumber uint64 `json:"block_number" gorm:"not null"`
+	BlockHash   string `json:"block_hash" gorm:"not null;type:varchar(66)"`
+}
+
+// IndexedBlock remembers the hash of a recent block the indexer wrote rows
+// from, and of every checkpoint, so that after a reorg it can find the
+// newest block that is still canonical and roll back everything above it
+type IndexedBlock struct {
+	ID         uint   `json:"id" gorm:"primarykey"`
+	ChainID    int64  `json:"chain_id" gorm:"not null;uniqueIndex:idx_indexed_block"`
+	Number     uint64 `json:"number" gorm:"not null;uniqueIndex:idx_indexed_block"`
+	Hash       string `json:"hash" gorm:"not null;type:varchar(66)"`
+	ParentHash string `json:"parent_hash" gorm:"not null;type:varchar(66)"`
+}
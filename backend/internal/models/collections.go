@@ -1,12 +1,45 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	DeploymentStatusNone          = ""
+	DeploymentStatusDeploying     = "deploying"      // LazyMint1155 transaction sent
+	DeploymentStatusDeployingZone = "deploying_zone" // LazyMintZone transaction sent
+	DeploymentStatusDeployed      = "deployed"
+	DeploymentStatusFailed        = "failed"
+)
 
 type Collection struct {
 	gorm.Model
-	CreatorID       uint   `json:"creator_id" gorm:"not null"`
-	Creator         *User  `json:"creator" gorm:"foreignKey:CreatorID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	Name            string `json:"name" gorm:"not null"`
-	Description     string `json:"description" gorm:"not null"`
-	ContractAddress string `json:"contract_address" gorm:"not null;uniqueIndex;type:varchar(42)"` // Ethereum address format
+	CreatorID        uint       `json:"creator_id" gorm:"not null;uniqueIndex:idx_collection_creator_name"`
+	Creator          *User      `json:"creator" gorm:"foreignKey:CreatorID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Name             string     `json:"name" gorm:"not null;uniqueIndex:idx_collection_creator_name"`
+	Description      string     `json:"description" gorm:"not null"`
+	ChainID          int64      `json:"chain_id" gorm:"not null;default:0;index;uniqueIndex:idx_collection_chain_contract"`
+	ContractAddress  *string    `json:"contract_address" gorm:"uniqueIndex:idx_collection_chain_contract;type:varchar(42)"` // Ethereum address format, immutable once set
+	ArchivedAt       *time.Time `json:"archived_at"`
+	DeploymentStatus string     `json:"deployment_status" gorm:"not null;default:'';index"`
+	DeploymentError  string     `json:"deployment_error,omitempty"`
+	DeployTxHash     string     `json:"deploy_tx_hash,omitempty" gorm:"type:varchar(66)"`
+	WithZone         bool       `json:"with_zone" gorm:"not null;default:false"` // also deploy a LazyMintZone
+	ZoneAddress      *string    `json:"zone_address" gorm:"type:varchar(42)"`
+	ZoneTxHash       string     `json:"zone_tx_hash,omitempty" gorm:"type:varchar(66)"`
+	// voucher signer the contract accepts; a pending setSigner moves it to PendingSignerAddress
+	SignerAddress        *string `json:"signer_address" gorm:"type:varchar(42)"`
+	PendingSignerAddress *string `json:"pending_signer_address,omitempty" gorm:"type:varchar(42)"`
+	SignerTxHash         string  `json:"signer_tx_hash,omitempty" gorm:"type:varchar(66)"`
+	// failed setSigner attempts for PendingSignerAddress; retries back off until SignerFailedAt
+	SignerAttempts int        `json:"signer_attempts,omitempty" gorm:"not null;default:0"`
+	SignerRetryAt  *time.Time `json:"signer_retry_at,omitempty"`
+	SignerFailedAt *time.Time `json:"signer_failed_at,omitempty"`
+	SignerError    string     `json:"signer_error,omitempty"`
+	// signed deployer transaction in flight, kept so it can be broadcast again or replaced
+	PendingTx             string     `json:"-" gorm:"type:text"`
+	PendingTxSentAt       *time.Time `json:"-"`
+	PendingTxReplacements int        `json:"-" gorm:"not null;default:0"`
 }
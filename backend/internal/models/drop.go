@@ -1,16 +1,69 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	DropTypePublic  = "public"
+	DropTypePrivate = "private"
+)
+
+// Drop statuses move draft → scheduled → live → sold_out or ended →
+// archived; a draft can also be archived straight away
+const (
+	DropStatusDraft     = "draft"     // editable, hidden from the public
+	DropStatusScheduled = "scheduled" // goes live at StartTime
+	DropStatusLive      = "live"
+	DropStatusSoldOut   = "sold_out" // every copy of Supply minted
+	DropStatusEnded     = "ended"    // reached EndTime or ended by the creator
+	DropStatusArchived  = "archived"
+)
+
+// lifecycle events a drop publishes, each once per stage
+const (
+	DropEventScheduled = "scheduled"
+	DropEventStarted   = "started"
+	DropEventEnded     = "ended"
+)
 
 /*
-id , collection_id , drop_type , start_time , price , supply
+id , collection_id , drop_type , start_time , end_time , price , supply , status
 */
 type Drop struct {
 	gorm.Model
-	CollectionID uint        `json:"collection_id" gorm:"not null"`
+	CollectionID uint        `json:"collection_id" gorm:"not null;index"`
 	Collection   *Collection `json:"collection" gorm:"foreignKey:CollectionID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 	DropType     string      `json:"drop_type" gorm:"not null"`  // e.g., "public", "private"
 	StartTime    int64       `json:"start_time" gorm:"not null"` // Unix timestamp for the start time
+	EndTime      *int64      `json:"end_time"`                   // Unix timestamp the drop ends at; nil runs until sold out
 	Price        int64       `json:"price" gorm:"not null"`      // Price in the smallest currency unit (e.g., cents for USD)
 	Supply       int64       `json:"supply" gorm:"not null"`     // Total supply of NFTs in this drop
+	Status       string      `json:"status" gorm:"not null;default:'draft';index"`
+	// copies one wallet may claim, on top of any allowlist quota; 0 is unlimited
+	MaxPerWallet int64 `json:"max_per_wallet" gorm:"not null;default:0"`
+	// when the drop entered each stage, set by DropRepository.Transition
+	ScheduledAt *time.Time `json:"scheduled_at"`
+	StartedAt   *time.Time `json:"started_at"`
+	EndedAt     *time.Time `json:"ended_at"`
+	ArchivedAt  *time.Time `json:"archived_at"`
+	// last token sequence handed out, only ever moved by DropRepository.ReserveTokenSequences
+	TokenSequence uint64 `json:"-" gorm:"not null;default:0"`
+}
+
+// DropEvent is a lifecycle event of a drop in the outbox. It is stored in
+// the same transaction as the stage it announces and marked delivered once
+// every handler has run; an event whose delivery was cut short is delivered
+// again when its lock runs out.
+type DropEvent struct {
+	gorm.Model
+	DropID      uint       `json:"drop_id" gorm:"not null;uniqueIndex:idx_drop_event"`
+	Drop        *Drop      `json:"-" gorm:"foreignKey:DropID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Event       string     `json:"event" gorm:"not null;uniqueIndex:idx_drop_event"`
+	Status      string     `json:"status" gorm:"not null"` // the status the drop moved to
+	Attempts    int        `json:"attempts" gorm:"not null;default:0"`
+	LockedUntil *time.Time `json:"locked_until"` // claimed by a deliverer until then
+	DeliveredAt *time.Time `json:"delivered_at" gorm:"index"`
 }
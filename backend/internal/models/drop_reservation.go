@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+const (
+	DropReservationHeld     = "held"     // a voucher for the slot is out
+	DropReservationReleased = "released" // no voucher for the slot can mint any more; it is free again
+	DropReservationSold     = "sold"     // the voucher was minted
+)
+
+// DropReservation holds one slot of a drop's Supply for the voucher of an
+// NFT while its buyer signs the mint. Vouchers carry no expiry on-chain and
+// revoking one does not stop the contract accepting it, so a hold past its
+// ExpiresAt keeps counting until the collection's signer is rotated away
+// from every voucher of the NFT; only then is the slot released.
+type DropReservation struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	DropID       uint       `json:"drop_id" gorm:"not null;index:idx_drop_reservation_drop"`
+	NFTID        uint       `json:"nft_id" gorm:"not null;index;uniqueIndex:idx_drop_reservation_held,where:status = 'held'"`
+	OwnerAddress string     `json:"owner_address" gorm:"not null;type:varchar(42)"`
+	Amount       uint64     `json:"amount" gorm:"not null;default:1"` // copies the voucher mints, counted against the owner's quota
+	Status       string     `json:"status" gorm:"not null;index:idx_drop_reservation_drop"`
+	ExpiresAt    time.Time  `json:"expires_at" gorm:"not null;index"`
+	CreatedAt    time.Time  `json:"created_at"`
+	ReleasedAt   *time.Time `json:"released_at"`
+	SoldAt       *time.Time `json:"sold_at"`
+	MintTxHash   string     `json:"mint_tx_hash,omitempty"`
+}
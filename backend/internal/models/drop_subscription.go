@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// DropSubscription asks for a reminder when a scheduled drop starts
+type DropSubscription struct {
+	ID        uint      `json:"-" gorm:"primaryKey"`
+	DropID    uint      `json:"drop_id" gorm:"not null;uniqueIndex:idx_drop_subscription"`
+	Drop      *Drop     `json:"-" gorm:"foreignKey:DropID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	UserID    uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_drop_subscription;index"`
+	User      *User     `json:"-" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	EmailCategorySaleReceipts  = "sale_receipts"
+	EmailCategoryDropReminders = "drop_reminders"
+	EmailCategorySecurity      = "security"
+	EmailCategoryAccount       = "account" // verification mails, never unsubscribable
+)
+
+const (
+	EmailStatusPending = "pending"
+	EmailStatusSent    = "sent"
+	EmailStatusFailed  = "failed"
+)
+
+// EmailMessage is a rendered email waiting in the outbox. Sending is retried
+// with backoff until it succeeds or runs out of attempts.
+type EmailMessage struct {
+	gorm.Model
+	UserID        *uint      `json:"user_id" gorm:"index"`
+	To            string     `json:"to" gorm:"not null"`
+	Category      string     `json:"category" gorm:"not null"`
+	Subject       string     `json:"subject" gorm:"not null"`
+	TextBody      string     `json:"-" gorm:"not null"`
+	HTMLBody      string     `json:"-" gorm:"not null"`
+	Unsubscribe   string     `json:"-"` // unsubscribe URL for the List-Unsubscribe header
+	Status        string     `json:"status" gorm:"not null;index:idx_email_status_next"`
+	Attempts      int        `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"not null;index:idx_email_status_next"`
+	LastError     string     `json:"last_error"`
+	SentAt        *time.Time `json:"sent_at"`
+	// DedupeKey is set on messages that must be queued once even when the
+	// event behind them is handled again
+	DedupeKey *string `json:"-" gorm:"uniqueIndex"`
+}
+
+type EmailUnsubscribe struct {
+	gorm.Model
+	UserID   uint   `json:"user_id" gorm:"not null;uniqueIndex:idx_email_unsubscribe_user_category"`
+	User     *User  `json:"-" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Category string `json:"category" gorm:"not null;uniqueIndex:idx_email_unsubscribe_user_category"`
+}
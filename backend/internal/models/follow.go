@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// Follow records that a user follows another, usually a creator
+type Follow struct {
+	ID         uint      `json:"-" gorm:"primaryKey"`
+	FollowerID uint      `json:"follower_id" gorm:"not null;uniqueIndex:idx_follow"`
+	Follower   *User     `json:"-" gorm:"foreignKey:FollowerID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	FolloweeID uint      `json:"followee_id" gorm:"not null;uniqueIndex:idx_follow;index"`
+	Followee   *User     `json:"-" gorm:"foreignKey:FolloweeID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	ImportStatusQueued     = "queued"
+	ImportStatusProcessing = "processing"
+	ImportStatusCompleted  = "completed" // check FailedRows for rows that did not import
+	ImportStatusFailed     = "failed"
+)
+
+// ImportJob is a bulk NFT import into a drop from a ZIP of media and a
+// manifest. Uploading the same archive and manifest again returns the same
+// job, and rows whose key already exists in the drop are skipped.
+type ImportJob struct {
+	gorm.Model
+	DropID        uint            `json:"drop_id" gorm:"not null;uniqueIndex:idx_import_drop_hash"`
+	Drop          *Drop           `json:"-" gorm:"foreignKey:DropID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	CreatorID     uint            `json:"creator_id" gorm:"not null;index"`
+	ArchiveHash   string          `json:"archive_hash" gorm:"not null;type:varchar(64);uniqueIndex:idx_import_drop_hash"` // sha256 of archive and manifest
+	ManifestName  string          `json:"manifest_name" gorm:"not null"`
+	Archive       []byte          `json:"-" gorm:"type:bytea"` // dropped once the job finishes
+	Manifest      []byte          `json:"-" gorm:"type:bytea"` // nil when the manifest is inside the archive
+	Status        string          `json:"status" gorm:"not null;index"`
+	TotalRows     int             `json:"total_rows" gorm:"not null"`
+	ProcessedRows int             `json:"processed_rows" gorm:"not null;default:0"` // including skipped and failed rows
+	SkippedRows   int             `json:"skipped_rows" gorm:"not null;default:0"`   // already imported
+	FailedRows    int             `json:"failed_rows" gorm:"not null;default:0"`
+	Errors        ImportRowErrors `json:"errors" gorm:"type:jsonb"`
+	Results       ImportResults   `json:"results" gorm:"type:jsonb"`
+	Error         string          `json:"error,omitempty"`
+	StartedAt     *time.Time      `json:"started_at"`
+	CompletedAt   *time.Time      `json:"completed_at"`
+}
+
+// ImportRowError is a problem with one manifest row; Row counts from 1
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Key     string `json:"key,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportResult is the NFT a manifest row became
+type ImportResult struct {
+	Row         int     `json:"row"`
+	Key         string  `json:"key"`
+	NFTID       uint    `json:"nft_id"`
+	TokenID     Uint256 `json:"token_id"`
+	Image       string  `json:"image"`
+	MetadataURI string  `json:"metadata_uri"`
+}
+
+type ImportRowErrors []ImportRowError
+
+// Scan implements the Scanner interface.
+func (e *ImportRowErrors) Scan(value interface{}) error {
+	if value == nil {
+		*e = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, e)
+}
+
+// Value implements the Valuer interface.
+func (e ImportRowErrors) Value() (driver.Value, error) {
+	if e == nil {
+		return nil, nil
+	}
+	return json.Marshal(e)
+}
+
+type ImportResults []ImportResult
+
+// Scan implements the Scanner interface.
+func (r *ImportResults) Scan(value interface{}) error {
+	if value == nil {
+		*r = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, r)
+}
+
+// Value implements the Valuer interface.
+func (r ImportResults) Value() (driver.Value, error) {
+	if r == nil {
+		return nil, nil
+	}
+	return json.Marshal(r)
+}
@@ -1,6 +1,13 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
 
 /*
 nfts
@@ -9,9 +16,55 @@ id , drop_id , token_id , metadata_uri , lazy_minted
 
 type NFT struct {
 	gorm.Model
-	DropID      uint   `json:"drop_id" gorm:"not null"`
-	Drop        *Drop  `json:"drop" gorm:"foreignKey:DropID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	TokenID     string `json:"token_id" gorm:"not null;uniqueIndex"` // Unique identifier for the NFT
-	MetadataURI string `json:"metadata_uri" gorm:"not null"`         // URI pointing to the NFT's metadata
-	IsMinted    bool   `json:"is_minted" gorm:"default:false"`       // Indicates if the NFT is lazy minted
+	DropID      uint    `json:"drop_id" gorm:"not null;uniqueIndex:idx_nft_drop_import_key"`
+	Drop        *Drop   `json:"drop" gorm:"foreignKey:DropID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	TokenID     Uint256 `json:"token_id" gorm:"not null;uniqueIndex"` // drop ID in the high 128 bits, drop sequence in the low 128
+	MetadataURI string  `json:"metadata_uri" gorm:"not null"`         // externally hosted metadata; empty when served by /metadata
+	IsMinted    bool    `json:"is_minted" gorm:"default:false"`       // Indicates if the NFT is lazy minted
+
+	// set by the indexer from the mint's TransferSingle and URI logs
+	MintTxHash *string    `json:"mint_tx_hash" gorm:"type:varchar(66)"`
+	MintBlock  *uint64    `json:"mint_block" gorm:"index"`
+	MintedAt   *time.Time `json:"minted_at"`
+	MintedURI  string     `json:"minted_uri" gorm:"not null;default:''"` // the uri the voucher carried on-chain
+
+	// fields rendered into the hosted ERC-1155 metadata JSON
+	Name         string        `json:"name" gorm:"not null;default:''"`
+	Description  string        `json:"description" gorm:"not null;default:''"`
+	Image        string        `json:"image" gorm:"not null;default:''"`
+	AnimationURL string        `json:"animation_url" gorm:"not null;default:''"`
+	Attributes   NFTAttributes `json:"attributes" gorm:"type:jsonb"`
+
+	EditionSize uint64  `json:"edition_size" gorm:"not null;default:1"`                          // copies a voucher may mint
+	ImportKey   *string `json:"import_key,omitempty" gorm:"uniqueIndex:idx_nft_drop_import_key"` // manifest row key of imported NFTs
+}
+
+// NFTAttribute is one trait in the OpenSea attributes format
+type NFTAttribute struct {
+	TraitType   string      `json:"trait_type,omitempty"`
+	Value       interface{} `json:"value"`
+	DisplayType string      `json:"display_type,omitempty"`
+}
+
+type NFTAttributes []NFTAttribute
+
+// Scan implements the Scanner interface.
+func (a *NFTAttributes) Scan(value interface{}) error {
+	if value == nil {
+		*a = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, a)
+}
+
+// Value implements the Valuer interface.
+func (a NFTAttributes) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return json.Marshal(a)
 }
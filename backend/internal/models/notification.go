@@ -0,0 +1,77 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	NotificationTypeSaleCompleted   = "sale_completed"
+	NotificationTypeOfferReceived   = "offer_received"
+	NotificationTypeDropStarted     = "drop_started"
+	NotificationTypeNewFollower     = "new_follower"
+	NotificationTypeCreatorApproved = "creator_approved"
+)
+
+// NotificationTypes lists every notification type a user can receive.
+var NotificationTypes = []string{
+	NotificationTypeSaleCompleted,
+	NotificationTypeOfferReceived,
+	NotificationTypeDropStarted,
+	NotificationTypeNewFollower,
+	NotificationTypeCreatorApproved,
+}
+
+type Notification struct {
+	gorm.Model
+	UserID uint             `json:"user_id" gorm:"not null;index:idx_notification_user_read"`
+	User   *User            `json:"-" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Type   string           `json:"type" gorm:"not null"`
+	Title  string           `json:"title" gorm:"not null"`
+	Body   string           `json:"body" gorm:"not null"`
+	Data   NotificationData `json:"data" gorm:"type:jsonb"`
+	ReadAt *time.Time       `json:"read_at" gorm:"index:idx_notification_user_read"`
+	// DedupeKey is set on notifications that must be stored once even when
+	// the event behind them is handled again
+	DedupeKey *string `json:"-" gorm:"uniqueIndex"`
+}
+
+// NotificationPreference toggles a single notification type for a user.
+// Types without a stored preference are enabled.
+type NotificationPreference struct {
+	gorm.Model
+	UserID  uint   `json:"user_id" gorm:"not null;uniqueIndex:idx_notification_pref_user_type"`
+	User    *User  `json:"-" gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Type    string `json:"type" gorm:"not null;uniqueIndex:idx_notification_pref_user_type"`
+	Enabled bool   `json:"enabled" gorm:"not null"`
+}
+
+type NotificationData map[string]interface{}
+
+// Scan implements the Scanner interface.
+func (d *NotificationData) Scan(value interface{}) error {
+	if value == nil {
+		*d = nil
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, d)
+}
+
+// Value implements the Valuer interface.
+func (d NotificationData) Value() (driver.Value, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(d)
+}
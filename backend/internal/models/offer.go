@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Offer is a signed Seaport order bidding an ERC-20 for copies of one of
+// our tokens, or for copies of any token of a collection when NFTID is nil.
+// Prices are in the smallest unit of Currency; Status takes the
+// OrderStatus values.
+type Offer struct {
+	gorm.Model
+	ChainID         int64           `json:"chain_id" gorm:"not null"`
+	OrderHash       string          `json:"order_hash" gorm:"not null;uniqueIndex;type:varchar(66)"`
+	Seaport         string          `json:"seaport" gorm:"not null;type:varchar(42)"`
+	BidderID        uint            `json:"bidder_id" gorm:"not null;index"`
+	Maker           string          `json:"maker" gorm:"not null;index;type:varchar(42)"`
+	CollectionID    uint            `json:"collection_id" gorm:"not null;index"`
+	NFTID           *uint           `json:"nft_id" gorm:"index"` // nil for collection-wide offers
+	ContractAddress string          `json:"contract_address" gorm:"not null;type:varchar(42)"`
+	TokenID         *Uint256        `json:"token_id"`
+	Quantity        uint64          `json:"quantity" gorm:"not null"`
+	Currency        string          `json:"currency" gorm:"not null;type:varchar(42)"`
+	Price           Uint256         `json:"price" gorm:"not null"`            // everything the bidder pays for Quantity copies
+	UnitPrice       Uint256         `json:"unit_price" gorm:"not null;index"` // Price / Quantity, rounded down
+	Fees            Uint256         `json:"fees" gorm:"not null"`             // taken from Price before it reaches the seller
+	Counter         Uint256         `json:"counter" gorm:"not null"`
+	StartTime       time.Time       `json:"start_time" gorm:"not null"`
+	EndTime         time.Time       `json:"end_time" gorm:"not null;index"`
+	Components      OrderComponents `json:"order" gorm:"type:jsonb;not null"`
+	Signature       string          `json:"signature" gorm:"not null"`
+	Status          string          `json:"status" gorm:"not null;index"`
+
+	// set by the indexer from Seaport's OrderFulfilled, OrderCancelled and
+	// CounterIncremented logs
+	FilledQuantity uint64     `json:"filled_quantity" gorm:"not null;default:0"`
+	FilledAt       *time.Time `json:"filled_at"`
+	CancelledAt    *time.Time `json:"cancelled_at"`
+	CancelledBlock *uint64    `json:"cancelled_block" gorm:"index"`
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	OrderStatusActive    = "active"
+	OrderStatusFilled    = "filled"
+	OrderStatusCancelled = "cancelled"
+	OrderStatusExpired   = "expired"
+)
+
+// Order is a signed Seaport listing selling copies of one token. Prices are
+// in the smallest unit of Currency, the zero address for the native coin.
+type Order struct {
+	gorm.Model
+	ChainID         int64           `json:"chain_id" gorm:"not null"`
+	OrderHash       string          `json:"order_hash" gorm:"not null;uniqueIndex;type:varchar(66)"`
+	Seaport         string          `json:"seaport" gorm:"not null;type:varchar(42)"`
+	Maker           string          `json:"maker" gorm:"not null;index;type:varchar(42)"`
+	CollectionID    uint            `json:"collection_id" gorm:"not null;index"`
+	NFTID           uint            `json:"nft_id" gorm:"not null;index"`
+	ContractAddress string          `json:"contract_address" gorm:"not null;type:varchar(42)"`
+	TokenID         Uint256         `json:"token_id" gorm:"not null"`
+	Quantity        uint64          `json:"quantity" gorm:"not null"`
+	Currency        string          `json:"currency" gorm:"not null;type:varchar(42)"`
+	Price           Uint256         `json:"price" gorm:"not null"`            // everything the buyer pays for Quantity copies
+	UnitPrice       Uint256         `json:"unit_price" gorm:"not null;index"` // Price / Quantity, rounded down
+	Counter         Uint256         `json:"counter" gorm:"not null"`
+	StartTime       time.Time       `json:"start_time" gorm:"not null"`
+	EndTime         time.Time       `json:"end_time" gorm:"not null;index"`
+	Components      OrderComponents `json:"order" gorm:"type:jsonb;not null"`
+	Signature       string          `json:"signature" gorm:"not null"`
+	ExtraData       string          `json:"extra_data"` // LazyMintZone voucher for unminted tokens
+	Status          string          `json:"status" gorm:"not null;index"`
+
+	// set by the indexer from Seaport's OrderFulfilled, OrderCancelled and
+	// CounterIncremented logs
+	FilledQuantity uint64     `json:"filled_quantity" gorm:"not null;default:0"`
+	FilledAt       *time.Time `json:"filled_at"`
+	CancelledAt    *time.Time `json:"cancelled_at"`
+	CancelledBlock *uint64    `json:"cancelled_block" gorm:"index"`
+}
+
+// OrderComponents holds the signed Seaport order components as JSON
+type OrderComponents json.RawMessage
+
+// Scan implements the Scanner interface.
+func (c *OrderComponents) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+	case []byte:
+		*c = append((*c)[:0], v...)
+	case string:
+		*c = OrderComponents(v)
+	default:
+		return errors.New("type assertion to []byte failed")
+	}
+	return nil
+}
+
+// Value implements the Valuer interface.
+func (c OrderComponents) Value() (driver.Value, error) {
+	if c == nil {
+		return nil, nil
+	}
+	return []byte(c), nil
+}
+
+func (c OrderComponents) MarshalJSON() ([]byte, error) {
+	if c == nil {
+		return []byte("null"), nil
+	}
+	return c, nil
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	RelayStatusQueued    = "queued"  // nonce reserved, not accepted by the node yet
+	RelayStatusPending   = "pending" // broadcast, waiting for a confirmed receipt
+	RelayStatusConfirmed = "confirmed"
+	RelayStatusFailed    = "failed" // reverted, or its nonce was used by another transaction
+)
+
+// RelayTransaction is a mintIfNotExists call the relayer pays for on behalf
+// of a user. Every fee bump re-signs the same nonce, so any of TxHashes can
+// be the one that gets mined.
+//
+// Cost is charged to the requester's and the creator's sponsorship budgets:
+// the most the transaction can cost while it is open, then what it cost.
+type RelayTransaction struct {
+	gorm.Model
+	ChainID      int64       `json:"chain_id" gorm:"not null;uniqueIndex:idx_relay_nonce"`
+	RequesterID  uint        `json:"requester_id" gorm:"not null;index"`
+	CreatorID    uint        `json:"creator_id" gorm:"not null;index"`
+	CollectionID uint        `json:"collection_id" gorm:"not null"`
+	NFTID        uint        `json:"nft_id" gorm:"not null;index"`
+	VoucherID    uint        `json:"voucher_id" gorm:"not null"`
+	From         string      `json:"from" gorm:"column:from_address;not null;type:varchar(42);uniqueIndex:idx_relay_nonce"`
+	To           string      `json:"to" gorm:"column:to_address;not null;type:varchar(42)"`
+	Data         string      `json:"-" gorm:"not null"` // hex calldata
+	Nonce        uint64      `json:"nonce" gorm:"not null;uniqueIndex:idx_relay_nonce"`
+	GasLimit     uint64      `json:"gas_limit" gorm:"not null"`
+	GasTipCap    Uint256     `json:"gas_tip_cap" gorm:"not null"`
+	GasFeeCap    Uint256     `json:"gas_fee_cap" gorm:"not null"`
+	Cost         Uint256     `json:"cost" gorm:"not null"`
+	Status       string      `json:"status" gorm:"not null;index"`
+	Error        string      `json:"error,omitempty"`
+	TxHash       string      `json:"tx_hash,omitempty" gorm:"type:varchar(66)"` // latest broadcast, or the one mined
+	TxHashes     RelayHashes `json:"tx_hashes" gorm:"type:jsonb"`
+	Attempts     uint        `json:"attempts" gorm:"not null;default:0"`
+	BroadcastAt  *time.Time  `json:"broadcast_at"`
+	GasUsed      uint64      `json:"gas_used,omitempty"`
+	BlockNumber  *uint64     `json:"block_number"`
+	ConfirmedAt  *time.Time  `json:"confirmed_at"`
+}
+
+// RelayNonce is the next nonce the relayer hands out for an account
+type RelayNonce struct {
+	ChainID   int64  `gorm:"primaryKey;autoIncrement:false"`
+	Address   string `gorm:"primaryKey;type:varchar(42)"`
+	Next      uint64 `gorm:"not null"`
+	UpdatedAt time.Time
+}
+
+type RelayHashes []string
+
+// Scan implements the Scanner interface.
+func (h *RelayHashes) Scan(value interface{}) error {
+	if value == nil {
+		*h = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, h)
+}
+
+// Value implements the Valuer interface.
+func (h RelayHashes) Value() (driver.Value, error) {
+	if h == nil {
+		return nil, nil
+	}
+	return json.Marshal(h)
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Sale is a Seaport fill that traded one of our tokens, decoded from an
+// OrderFulfilled log. Sales are never edited; a reorg deletes them.
+//
+// Price is everything the buyer paid. It splits into SellerProceeds, the
+// marketplace Fee and Royalties, which covers every other recipient.
+type Sale struct {
+	gorm.Model
+	ChainID         int64        `json:"chain_id" gorm:"not null"`
+	OrderHash       string       `json:"order_hash" gorm:"not null;index;type:varchar(66)"`
+	OrderID         *uint        `json:"order_id" gorm:"index"` // the listing filled, when it is in our order book
+	OfferID         *uint        `json:"offer_id" gorm:"index"` // the offer accepted, when it is in our order book
+	NFTID           uint         `json:"nft_id" gorm:"not null;index"`
+	CollectionID    uint         `json:"collection_id" gorm:"not null;index"`
+	ContractAddress string       `json:"contract_address" gorm:"not null;type:varchar(42)"`
+	TokenID         Uint256      `json:"token_id" gorm:"not null"`
+	Quantity        uint64       `json:"quantity" gorm:"not null"`
+	Seller          string       `json:"seller" gorm:"not null;index;type:varchar(42)"`
+	Buyer           string       `json:"buyer" gorm:"not null;index;type:varchar(42)"`
+	Currency        string       `json:"currency" gorm:"not null;type:varchar(42)"` // zero address for the native coin
+	Price           Uint256      `json:"price" gorm:"not null"`
+	SellerProceeds  Uint256      `json:"seller_proceeds" gorm:"not null"`
+	Fee             Uint256      `json:"fee" gorm:"not null"`
+	Royalties       Uint256      `json:"royalties" gorm:"not null"`
+	Payments        SalePayments `json:"payments" gorm:"type:jsonb"`
+	TxHash          string       `json:"tx_hash" gorm:"not null;type:varchar(66);uniqueIndex:idx_sale_log"`
+	LogIndex        uint         `json:"log_index" gorm:"not null;uniqueIndex:idx_sale_log"`
+	BlockNumber     uint64       `json:"block_number" gorm:"not null;index"`
+	BlockHash       string       `json:"block_hash" gorm:"not null;type:varchar(66)"`
+	BlockTime       time.Time    `json:"block_time" gorm:"not null;index"`
+}
+
+// Kinds of sale payment
+const (
+	SalePaymentSeller  = "seller"
+	SalePaymentFee     = "fee"
+	SalePaymentRoyalty = "royalty"
+)
+
+// SalePayment is one currency transfer of a sale
+type SalePayment struct {
+	Recipient string `json:"recipient"`
+	Amount    string `json:"amount"`
+	Kind      string `json:"kind"`
+}
+
+type SalePayments []SalePayment
+
+// Scan implements the Scanner interface.
+func (p *SalePayments) Scan(value interface{}) error {
+	if value == nil {
+		*p = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, p)
+}
+
+// Value implements the Valuer interface.
+func (p SalePayments) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return json.Marshal(p)
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// purposes a signer key can serve
+const (
+	SignerPurposeVoucher  = "voucher"
+	SignerPurposeDeployer = "deployer"
+	SignerPurposeRelayer  = "relayer"
+)
+
+const (
+	SignerKeyStatusActive  = "active"
+	SignerKeyStatusRetired = "retired"
+)
+
+const (
+	SignatureKindHash        = "hash"
+	SignatureKindTransaction = "transaction"
+)
+
+// SignerKey is a key the backend has signed with for a purpose. Only one key
+// per purpose is active; the others are kept for the audit trail.
+type SignerKey struct {
+	gorm.Model
+	Purpose     string     `json:"purpose" gorm:"not null;index;uniqueIndex:idx_signer_key_purpose_address"`
+	Address     string     `json:"address" gorm:"not null;type:varchar(42);uniqueIndex:idx_signer_key_purpose_address"`
+	Backend     string     `json:"backend" gorm:"not null"` // memory, keystore or remote
+	Status      string     `json:"status" gorm:"not null;index"`
+	ActivatedAt time.Time  `json:"activated_at" gorm:"not null"`
+	RetiredAt   *time.Time `json:"retired_at"`
+}
+
+// SignatureAudit records a signature produced by one of the backend's keys
+type SignatureAudit struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	CreatedAt     time.Time `json:"created_at" gorm:"index"`
+	SignerAddress string    `json:"signer_address" gorm:"not null;type:varchar(42);index"`
+	Purpose       string    `json:"purpose" gorm:"not null"`
+	Kind          string    `json:"kind" gorm:"not null"`
+	Digest        string    `json:"digest" gorm:"not null;type:varchar(66);index"` // the hash that was signed
+	Signature     string    `json:"signature" gorm:"not null"`
+	ChainID       *int64    `json:"chain_id,omitempty"`
+	TxHash        string    `json:"tx_hash,omitempty" gorm:"type:varchar(66)"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// TokenBalance is how many copies of a token a wallet holds, kept up to date
+// from indexed transfers. Rows are removed when the balance drops to zero.
+type TokenBalance struct {
+	ID              uint      `json:"-" gorm:"primarykey"`
+	ChainID         int64     `json:"chain_id" gorm:"not null;uniqueIndex:idx_balance_holder"`
+	ContractAddress string    `json:"contract_address" gorm:"not null;type:varchar(42);uniqueIndex:idx_balance_holder;index:idx_balance_token"`
+	TokenID         Uint256   `json:"token_id" gorm:"not null;uniqueIndex:idx_balance_holder;index:idx_balance_token"`
+	OwnerAddress    string    `json:"owner_address" gorm:"not null;type:varchar(42);uniqueIndex:idx_balance_holder;index"`
+	NFTID           *uint     `json:"nft_id" gorm:"index"` // nil for tokens the backend did not create
+	NFT             *NFT      `json:"nft,omitempty" gorm:"foreignKey:NFTID;constraint:OnDelete:SET NULL"`
+	Balance         Uint256   `json:"balance" gorm:"not null"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
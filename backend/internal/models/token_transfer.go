@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TokenTransfer is one token movement decoded from a TransferSingle or
+// TransferBatch log. A batch log yields a row per token, told apart by
+// BatchIndex. Mints come from the zero address, burns go to it.
+type TokenTransfer struct {
+	gorm.Model
+	ChainID         int64     `json:"chain_id" gorm:"not null"`
+	ContractAddress string    `json:"contract_address" gorm:"not null;type:varchar(42);index:idx_transfer_token"`
+	TokenID         Uint256   `json:"token_id" gorm:"not null;index:idx_transfer_token"`
+	NFTID           *uint     `json:"nft_id" gorm:"index"` // nil for tokens the backend did not create
+	Operator        string    `json:"operator" gorm:"not null;type:varchar(42)"`
+	FromAddress     string    `json:"from_address" gorm:"not null;type:varchar(42);index"`
+	ToAddress       string    `json:"to_address" gorm:"not null;type:varchar(42);index"`
+	Amount          Uint256   `json:"amount" gorm:"not null"`
+	BlockNumber     uint64    `json:"block_number" gorm:"not null;index"`
+	BlockHash       string    `json:"block_hash" gorm:"not null;type:varchar(66)"`
+	TxHash          string    `json:"tx_hash" gorm:"not null;type:varchar(66);uniqueIndex:idx_transfer_log"`
+	LogIndex        uint      `json:"log_index" gorm:"not null;uniqueIndex:idx_transfer_log"`
+	BatchIndex      uint      `json:"batch_index" gorm:"not null;uniqueIndex:idx_transfer_log"`
+	Timestamp       time.Time `json:"timestamp" gorm:"not null"`
+}
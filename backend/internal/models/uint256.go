@@ -0,0 +1,128 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var maxUint256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+// Uint256 is a Solidity uint256 stored as numeric(78,0), the smallest
+// Postgres numeric that holds every uint256 value. It is written to JSON as
+// a decimal string so clients never round it through a float.
+type Uint256 struct {
+	v *big.Int
+}
+
+// NewUint256 copies v; it does not check the range
+func NewUint256(v *big.Int) Uint256 {
+	return Uint256{v: new(big.Int).Set(v)}
+}
+
+// ParseUint256 parses a decimal or 0x prefixed hex string
+func ParseUint256(s string) (Uint256, error) {
+	s = strings.TrimSpace(s)
+	var v *big.Int
+	var ok bool
+	if hex, found := strings.CutPrefix(strings.ToLower(s), "0x"); found {
+		v, ok = new(big.Int).SetString(hex, 16)
+	} else {
+		v, ok = new(big.Int).SetString(s, 10)
+	}
+	if !ok {
+		return Uint256{}, fmt.Errorf("invalid uint256 %q", s)
+	}
+	u := Uint256{v: v}
+	if err := u.check(); err != nil {
+		return Uint256{}, err
+	}
+	return u, nil
+}
+
+// Big returns a copy of the value; the zero Uint256 is 0
+func (u Uint256) Big() *big.Int {
+	if u.v == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(u.v)
+}
+
+func (u Uint256) String() string {
+	return u.Big().String()
+}
+
+// Hex is the 64 character lowercase hex form ERC-1155 uses for {id}
+func (u Uint256) Hex() string {
+	return fmt.Sprintf("%064x", u.Big())
+}
+
+func (u Uint256) Cmp(other Uint256) int {
+	return u.Big().Cmp(other.Big())
+}
+
+func (u Uint256) check() error {
+	v := u.Big()
+	if v.Sign() < 0 || v.Cmp(maxUint256) > 0 {
+		return fmt.Errorf("%s is out of uint256 range", v)
+	}
+	return nil
+}
+
+// GormDataType sets the column type used by AutoMigrate
+func (Uint256) GormDataType() string {
+	return "numeric(78,0)"
+}
+
+// Scan implements the Scanner interface.
+func (u *Uint256) Scan(value interface{}) error {
+	var s string
+	switch v := value.(type) {
+	case nil:
+		return errors.New("cannot scan NULL into Uint256")
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	case int64:
+		u.v = big.NewInt(v)
+		return u.check()
+	default:
+		return fmt.Errorf("cannot scan %T into Uint256", value)
+	}
+	parsed, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return fmt.Errorf("invalid numeric value %q", s)
+	}
+	u.v = parsed
+	return u.check()
+}
+
+// Value implements the Valuer interface.
+func (u Uint256) Value() (driver.Value, error) {
+	if err := u.check(); err != nil {
+		return nil, err
+	}
+	return u.String(), nil
+}
+
+func (u Uint256) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.String())
+}
+
+// UnmarshalJSON accepts a decimal or hex string, or a JSON number
+func (u *Uint256) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		s = string(data)
+	}
+	parsed, err := ParseUint256(s)
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
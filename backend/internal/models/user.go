@@ -1,12 +1,23 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	RoleCreator = "creator"
+	RoleAdmin   = "admin"
+)
 
 type User struct {
 	gorm.Model
-	WalletAddress string `json:"wallet_address" gorm:"uniqueIndex;not null"`
-	Username      string `json:"username" gorm:"uniqueIndex;not null"`
-	Role          string `json:"role" gorm:"not null"`
-	Bio           string `json:"bio"`
-	AvatarURL     string `json:"avatar_url"`
+	WalletAddress   string     `json:"wallet_address" gorm:"uniqueIndex;not null"`
+	Username        string     `json:"username" gorm:"uniqueIndex;not null"`
+	Role            string     `json:"role" gorm:"not null"`
+	Bio             string     `json:"bio"`
+	AvatarURL       string     `json:"avatar_url"`
+	Email           *string    `json:"email,omitempty" gorm:"uniqueIndex"` // only set once verified
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
 }
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	VoucherStatusIssued     = "issued"
+	VoucherStatusRedeemed   = "redeemed"
+	VoucherStatusRevoked    = "revoked"
+	VoucherStatusExpired    = "expired"
+	VoucherStatusSuperseded = "superseded" // re-signed by a newer signer
+)
+
+// Voucher records a signed LazyMint1155 voucher. The contract never expires
+// vouchers, so ExpiresAt is only enforced by the backend; a signature stays
+// redeemable on-chain until its signer is rotated away. An NFT has at most
+// one issued collector voucher at a time; vouchers of the collection's
+// creator, who lists the NFT, do not count against it.
+type Voucher struct {
+	gorm.Model
+	NFTID           uint       `json:"nft_id" gorm:"not null;index;uniqueIndex:idx_voucher_collector_outstanding,where:status = 'issued' AND NOT for_creator"`
+	NFT             *NFT       `json:"-" gorm:"foreignKey:NFTID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ChainID         int64      `json:"chain_id" gorm:"not null"`
+	ContractAddress string     `json:"contract_address" gorm:"not null;index;type:varchar(42)"`
+	OwnerAddress    string     `json:"owner_address" gorm:"not null;index;type:varchar(42)"`
+	ForCreator      bool       `json:"for_creator" gorm:"not null;default:false"`
+	TokenID         Uint256    `json:"token_id" gorm:"not null"`
+	Amount          string     `json:"amount" gorm:"not null"`
+	URI             string     `json:"uri" gorm:"not null"`
+	Signature       string     `json:"signature" gorm:"not null"`
+	SignerAddress   string     `json:"signer_address" gorm:"not null;type:varchar(42)"`
+	Digest          string     `json:"digest" gorm:"not null;type:varchar(66)"`
+	IssuedAt        time.Time  `json:"issued_at" gorm:"not null"`
+	ExpiresAt       time.Time  `json:"expires_at" gorm:"not null"`
+	Status          string     `json:"status" gorm:"not null;index"`
+	RevokedAt       *time.Time `json:"revoked_at"`
+	RevokedReason   string     `json:"revoked_reason,omitempty"`
+	SupersededByID  *uint      `json:"superseded_by_id"`
+	RedeemedAt      *time.Time `json:"redeemed_at"`
+	RedeemTxHash    string     `json:"redeem_tx_hash,omitempty"`
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"github.com/igwedaniel/artizan/pkg/merkle"
+)
+
+const maxAllowlistSize = 50000
+
+// AllowlistService keeps the allowlists of private drops. Each upload is
+// stored as a new version with the root of an OpenZeppelin StandardMerkleTree
+// over [address, quota] leaves and a proof per wallet; the latest version
+// decides which wallets vouchers are issued to, and for how many copies.
+type AllowlistService struct {
+	dropRepo      repoInterfaces.DropRepository
+	allowlistRepo repoInterfaces.AllowlistRepository
+}
+
+// NewAllowlistService creates a new AllowlistService instance
+func NewAllowlistService(
+	dropRepo repoInterfaces.DropRepository,
+	allowlistRepo repoInterfaces.AllowlistRepository,
+) *AllowlistService {
+	return &AllowlistService{
+		dropRepo:      dropRepo,
+		allowlistRepo: allowlistRepo,
+	}
+}
+
+// Upload replaces the allowlist of a private drop owned by actor with a new version
+func (s *AllowlistService) Upload(actor *models.User, dropID uint, input UploadAllowlistInput) (*models.Allowlist, error) {
+	drop, err := s.drop(dropID)
+	if err != nil {
+		return nil, err
+	}
+	if drop.Collection == nil || drop.Collection.CreatorID != actor.ID {
+		return nil, ErrNotCollectionOwner
+	}
+	if drop.Collection.ArchivedAt != nil {
+		return nil, ErrCollectionArchived
+	}
+	if drop.DropType != models.DropTypePrivate {
+		return nil, ErrDropNotPrivate
+	}
+	switch drop.Status {
+	case models.DropStatusSoldOut, models.DropStatusEnded, models.DropStatusArchived:
+		return nil, ErrDropClosed
+	}
+
+	if len(input.Entries) == 0 {
+		return nil, ErrInvalidAllowlist
+	}
+	if len(input.Entries) > maxAllowlistSize {
+		return nil, ErrAllowlistTooLarge
+	}
+	entries := make([]*models.AllowlistEntry, 0, len(input.Entries))
+	leaves := make([]common.Hash, 0, len(input.Entries))
+	seen := make(map[common.Address]bool, len(input.Entries))
+	for _, e := range input.Entries {
+		if !common.IsHexAddress(e.Address) || e.Quota == 0 {
+			return nil, ErrInvalidAllowlist
+		}
+		address := common.HexToAddress(e.Address)
+		if seen[address] {
+			return nil, ErrInvalidAllowlist
+		}
+		seen[address] = true
+		leaf := merkle.AddressAmountLeaf(address, new(big.Int).SetUint64(e.Quota))
+		leaves = append(leaves, leaf)
+		entries = append(entries, &models.AllowlistEntry{
+			Address: address.Hex(),
+			Quota:   e.Quota,
+			Leaf:    leaf.Hex(),
+		})
+	}
+
+	tree, err := merkle.New(leaves)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build allowlist tree: %w", err)
+	}
+	for i, entry := range entries {
+		proof, err := tree.Proof(leaves[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to prove allowlist entry: %w", err)
+		}
+		entry.Proof = make(models.AllowlistProof, len(proof))
+		for j, node := range proof {
+			entry.Proof[j] = node.Hex()
+		}
+	}
+
+	allowlist := &models.Allowlist{
+		DropID:      drop.ID,
+		Root:        tree.Root().Hex(),
+		Size:        len(entries),
+		CreatedByID: actor.ID,
+	}
+	if err := s.allowlistRepo.CreateVersion(allowlist, entries); err != nil {
+		return nil, fmt.Errorf("failed to store allowlist: %w", err)
+	}
+	return allowlist, nil
+}
+
+// Current returns the enforced allowlist version of a listed drop
+func (s *AllowlistService) Current(dropID uint) (*models.Allowlist, error) {
+	drop, err := s.listedDrop(dropID)
+	if err != nil {
+		return nil, err
+	}
+	return s.latest(drop)
+}
+
+// ListVersions lists every allowlist version of a drop owned by actor
+func (s *AllowlistService) ListVersions(actor *models.User, dropID uint) (*AllowlistVersions, error) {
+	drop, err := s.drop(dropID)
+	if err != nil {
+		return nil, err
+	}
+	if drop.Collection == nil || drop.Collection.CreatorID != actor.ID {
+		return nil, ErrNotCollectionOwner
+	}
+	versions, err := s.allowlistRepo.ListVersions(drop.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &AllowlistVersions{DropID: drop.ID, Versions: versions}, nil
+}
+
+// Proof returns the Merkle proof of wallet in the enforced allowlist version
+func (s *AllowlistService) Proof(dropID uint, wallet string) (*AllowlistProof, error) {
+	if !common.IsHexAddress(wallet) {
+		return nil, ErrInvalidAllowlistWallet
+	}
+	drop, err := s.listedDrop(dropID)
+	if err != nil {
+		return nil, err
+	}
+	allowlist, err := s.latest(drop)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.entry(allowlist, wallet)
+	if err != nil {
+		return nil, err
+	}
+	return &AllowlistProof{
+		DropID:  drop.ID,
+		Version: allowlist.Version,
+		Root:    allowlist.Root,
+		Address: entry.Address,
+		Quota:   entry.Quota,
+		Leaf:    entry.Leaf,
+		Proof:   entry.Proof,
+	}, nil
+}
+
+// entryFor returns the entry of wallet in the enforced allowlist of drop, or
+// nil when the drop is public and anyone may mint
+func (s *AllowlistService) entryFor(drop *models.Drop, wallet string) (*models.AllowlistEntry, error) {
+	if drop.DropType != models.DropTypePrivate {
+		return nil, nil
+	}
+	allowlist, err := s.latest(drop)
+	if err != nil {
+		if errors.Is(err, ErrAllowlistNotFound) {
+			return nil, ErrNotAllowlisted
+		}
+		return nil, err
+	}
+	return s.entry(allowlist, wallet)
+}
+
+func (s *AllowlistService) latest(drop *models.Drop) (*models.Allowlist, error) {
+	allowlist, err := s.allowlistRepo.GetLatest(drop.ID)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrAllowlistNotFound
+		}
+		return nil, err
+	}
+	return allowlist, nil
+}
+
+func (s *AllowlistService) entry(allowlist *models.Allowlist, wallet string) (*models.AllowlistEntry, error) {
+	entry, err := s.allowlistRepo.GetEntry(allowlist.ID, common.HexToAddress(wallet).Hex())
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrNotAllowlisted
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (s *AllowlistService) drop(id uint) (*models.Drop, error) {
+	drop, err := s.dropRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrDropNotFound
+		}
+		return nil, err
+	}
+	return drop, nil
+}
+
+// listedDrop returns a drop the public may see, hiding drafts
+func (s *AllowlistService) listedDrop(id uint) (*models.Drop, error) {
+	drop, err := s.drop(id)
+	if err != nil {
+		return nil, err
+	}
+	if drop.Status == models.DropStatusDraft {
+		return nil, ErrDropNotFound
+	}
+	return drop, nil
+}
@@ -0,0 +1,30 @@
+package services
+
+import "github.com/igwedaniel/artizan/internal/models"
+
+type AllowlistEntryInput struct {
+	Address string `json:"address"`
+	Quota   uint64 `json:"quota"` // copies the wallet may mint from the drop
+}
+
+type UploadAllowlistInput struct {
+	Entries []AllowlistEntryInput `json:"entries"`
+}
+
+// AllowlistProof lets a wallet prove its place on the current allowlist
+// version with MerkleProof.verify(proof, root, leaf)
+type AllowlistProof struct {
+	DropID  uint     `json:"drop_id"`
+	Version uint     `json:"version"`
+	Root    string   `json:"root"`
+	Address string   `json:"address"`
+	Quota   uint64   `json:"quota"`
+	Leaf    string   `json:"leaf"`
+	Proof   []string `json:"proof"`
+}
+
+// AllowlistVersions is the allowlist history of a drop, newest first
+type AllowlistVersions struct {
+	DropID   uint                `json:"drop_id"`
+	Versions []*models.Allowlist `json:"versions"`
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	pinningInterfaces "github.com/igwedaniel/artizan/internal/interfaces/pinning"
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"github.com/igwedaniel/artizan/pkg/cid"
+)
+
+const (
+	pinRetryBaseDelay = 30 * time.Second
+	pinRetryMaxDelay  = 6 * time.Hour
+	pinPollInterval   = 15 * time.Second
+	pinBatchSize      = 20
+	// the worker leaves a new asset alone while its first, inline pin runs
+	pinGracePeriod = time.Minute
+)
+
+// AssetService stores content-addressed blobs on IPFS. CIDs are computed
+// locally, so an asset's ipfs:// URI is known, and can be signed into a
+// voucher, before the pinning service has confirmed it.
+type AssetService struct {
+	assetRepo repoInterfaces.AssetRepository
+	pinner    pinningInterfaces.Pinner
+	maxSize   int64
+}
+
+// NewAssetService creates a new AssetService instance
+func NewAssetService(assetRepo repoInterfaces.AssetRepository, pinner pinningInterfaces.Pinner, maxSize int64) *AssetService {
+	return &AssetService{
+		assetRepo: assetRepo,
+		pinner:    pinner,
+		maxSize:   maxSize,
+	}
+}
+
+// Upload stores a file for owner. Uploading content that already exists
+// returns the existing asset.
+func (s *AssetService) Upload(ctx context.Context, owner *models.User, filename, contentType string, r io.Reader) (*models.Asset, error) {
+	data, err := io.ReadAll(io.LimitReader(r, s.maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	return s.StoreFile(ctx, owner.ID, filename, contentType, data)
+}
+
+// StoreFile stores a file already read into memory for the user ownerID
+func (s *AssetService) StoreFile(ctx context.Context, ownerID uint, filename, contentType string, data []byte) (*models.Asset, error) {
+	if len(data) == 0 {
+		return nil, ErrEmptyAsset
+	}
+	if int64(len(data)) > s.maxSize {
+		return nil, ErrAssetTooLarge
+	}
+	return s.store(ctx, &ownerID, models.AssetKindFile, filename, contentType, data)
+}
+
+// MaxSize is the largest file StoreFile accepts
+func (s *AssetService) MaxSize() int64 {
+	return s.maxSize
+}
+
+// StoreMetadata pins the JSON encoding of a token's metadata
+func (s *AssetService) StoreMetadata(ctx context.Context, metadata *TokenMetadata) (*models.Asset, error) {
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	return s.store(ctx, nil, models.AssetKindMetadata, "metadata.json", "application/json", data)
+}
+
+func (s *AssetService) GetByCID(c string) (*models.Asset, error) {
+	if _, err := cid.Parse(c); err != nil {
+		return nil, ErrAssetNotFound
+	}
+	asset, err := s.assetRepo.GetByCID(c)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrAssetNotFound
+		}
+		return nil, err
+	}
+	return asset, nil
+}
+
+func (s *AssetService) store(ctx context.Context, ownerID *uint, kind, filename, contentType string, data []byte) (*models.Asset, error) {
+	c := cid.Sum(data).String()
+	existing, err := s.assetRepo.GetByCID(c)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	asset := &models.Asset{
+		OwnerID:          ownerID,
+		CID:              c,
+		Kind:             kind,
+		Filename:         filename,
+		ContentType:      contentType,
+		Size:             int64(len(data)),
+		Content:          data,
+		PinStatus:        models.AssetPinStatusPending,
+		NextPinAttemptAt: time.Now().Add(pinGracePeriod),
+	}
+	if err := s.assetRepo.Create(asset); err != nil {
+		if errors.Is(err, repoInterfaces.ErrDuplicateRecord) {
+			return s.assetRepo.GetByCID(c)
+		}
+		return nil, fmt.Errorf("failed to store asset: %w", err)
+	}
+	// a failed pin is recorded on the asset and retried by Run
+	s.pin(ctx, asset)
+	return asset, nil
+}
+
+// Run retries failed pins until ctx is cancelled
+func (s *AssetService) Run(ctx context.Context) {
+	ticker := time.NewTicker(pinPollInterval)
+	defer ticker.Stop()
+	for {
+		s.pinDue(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *AssetService) pinDue(ctx context.Context) {
+	assets, err := s.assetRepo.ListDuePins(time.Now(), pinBatchSize)
+	if err != nil {
+		log.Printf("failed to load unpinned assets: %v", err)
+		return
+	}
+	for _, asset := range assets {
+		if ctx.Err() != nil {
+			return
+		}
+		s.pin(ctx, asset)
+	}
+}
+
+func (s *AssetService) pin(ctx context.Context, asset *models.Asset) {
+	asset.PinAttempts++
+	if err := s.pinContent(ctx, asset); err != nil {
+		asset.PinStatus = models.AssetPinStatusFailed
+		asset.PinError = err.Error()
+		asset.NextPinAttemptAt = time.Now().Add(pinRetryDelay(asset.PinAttempts))
+	} else {
+		now := time.Now()
+		asset.PinStatus = models.AssetPinStatusPinned
+		asset.PinError = ""
+		asset.PinnedAt = &now
+		asset.Content = nil
+	}
+	if err := s.assetRepo.Update(asset); err != nil {
+		log.Printf("failed to update pin status of asset %s: %v", asset.CID, err)
+	}
+}
+
+func (s *AssetService) pinContent(ctx context.Context, asset *models.Asset) error {
+	// an earlier attempt may have pinned the content before failing
+	if asset.PinAttempts > 1 {
+		if pinned, err := s.pinner.IsPinned(ctx, asset.CID); err == nil && pinned {
+			return nil
+		}
+	}
+	if asset.Content == nil {
+		return errors.New("asset content is no longer available")
+	}
+	got, err := s.pinner.Add(ctx, asset.Filename, asset.Content)
+	if err != nil {
+		return err
+	}
+	if got != asset.CID {
+		return fmt.Errorf("pinning service stored the content as %s, expected %s", got, asset.CID)
+	}
+	return nil
+}
+
+// pinRetryDelay doubles the delay after every failed attempt
+func pinRetryDelay(attempts int) time.Duration {
+	delay := pinRetryBaseDelay << (attempts - 1)
+	if delay <= 0 || delay > pinRetryMaxDelay {
+		return pinRetryMaxDelay
+	}
+	return delay
+}
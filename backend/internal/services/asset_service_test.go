@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/igwedaniel/artizan/internal/adapters/pinning"
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"github.com/igwedaniel/artizan/pkg/cid"
+)
+
+type memoryAssetRepo struct {
+	assets []*models.Asset
+}
+
+func (r *memoryAssetRepo) Create(asset *models.Asset) error {
+	for _, a := range r.assets {
+		if a.CID == asset.CID {
+			return repoInterfaces.ErrDuplicateRecord
+		}
+	}
+	asset.ID = uint(len(r.assets) + 1)
+	r.assets = append(r.assets, asset)
+	return nil
+}
+
+func (r *memoryAssetRepo) GetByCID(c string) (*models.Asset, error) {
+	for _, a := range r.assets {
+		if a.CID == c {
+			return a, nil
+		}
+	}
+	return nil, repoInterfaces.ErrRecordNotFound
+}
+
+func (r *memoryAssetRepo) Update(*models.Asset) error { return nil }
+
+func (r *memoryAssetRepo) ListDuePins(now time.Time, limit int) ([]*models.Asset, error) {
+	var due []*models.Asset
+	for _, a := range r.assets {
+		if a.PinStatus != models.AssetPinStatusPinned && !a.NextPinAttemptAt.After(now) && len(due) < limit {
+			due = append(due, a)
+		}
+	}
+	return due, nil
+}
+
+func newAssetTest(t *testing.T) (*AssetService, *memoryAssetRepo, *pinning.LocalIPFSAPI) {
+	t.Helper()
+	node, err := pinning.NewLocalIPFSAPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { node.Close() })
+	repo := &memoryAssetRepo{}
+	return NewAssetService(repo, pinning.NewKuboPinner(node.URL(), ""), 1<<20), repo, node
+}
+
+func TestAssetPinnedOnUpload(t *testing.T) {
+	s, _, node := newAssetTest(t)
+	data := []byte("artwork")
+
+	asset, err := s.StoreFile(context.Background(), 1, "art.png", "image/png", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if asset.CID != cid.Sum(data).String() {
+		t.Fatalf("cid = %s, want %s", asset.CID, cid.Sum(data))
+	}
+	if asset.PinStatus != models.AssetPinStatusPinned || asset.PinnedAt == nil || asset.Content != nil {
+		t.Fatalf("asset = %s with content %v, want pinned and the content dropped", asset.PinStatus, asset.Content != nil)
+	}
+	if pinned := node.Pinned(); len(pinned) != 1 || pinned[0] != asset.CID {
+		t.Fatalf("node pinned %v", pinned)
+	}
+}
+
+func TestAssetPinRetriedWithBackoff(t *testing.T) {
+	s, _, node := newAssetTest(t)
+	ctx := context.Background()
+	node.SetFailing(true)
+
+	before := time.Now()
+	asset, err := s.StoreFile(ctx, 1, "art.png", "image/png", []byte("artwork"))
+	if err != nil {
+		t.Fatalf("a failed pin must not fail the upload: %v", err)
+	}
+	if asset.PinStatus != models.AssetPinStatusFailed || asset.PinAttempts != 1 || asset.PinError == "" || asset.Content == nil {
+		t.Fatalf("asset = %s after %d attempts (%q), want failed with the content kept", asset.PinStatus, asset.PinAttempts, asset.PinError)
+	}
+	if asset.NextPinAttemptAt.Before(before.Add(pinRetryBaseDelay)) {
+		t.Fatalf("next attempt at %s, want at least %s later", asset.NextPinAttemptAt, pinRetryBaseDelay)
+	}
+
+	// not due yet
+	s.pinDue(ctx)
+	if asset.PinAttempts != 1 {
+		t.Fatalf("retried after %d attempts before the backoff elapsed", asset.PinAttempts)
+	}
+
+	asset.NextPinAttemptAt = time.Now().Add(-time.Second)
+	before = time.Now()
+	s.pinDue(ctx)
+	if asset.PinStatus != models.AssetPinStatusFailed || asset.PinAttempts != 2 {
+		t.Fatalf("asset = %s after %d attempts, want a second failure", asset.PinStatus, asset.PinAttempts)
+	}
+	if asset.NextPinAttemptAt.Before(before.Add(2 * pinRetryBaseDelay)) {
+		t.Fatalf("next attempt at %s, want the delay doubled", asset.NextPinAttemptAt)
+	}
+
+	node.SetFailing(false)
+	asset.NextPinAttemptAt = time.Now().Add(-time.Second)
+	s.pinDue(ctx)
+	if asset.PinStatus != models.AssetPinStatusPinned || asset.PinError != "" || asset.Content != nil {
+		t.Fatalf("asset = %s (%q), want pinned once the node recovered", asset.PinStatus, asset.PinError)
+	}
+	if pinned := node.Pinned(); len(pinned) != 1 || pinned[0] != asset.CID {
+		t.Fatalf("node pinned %v", pinned)
+	}
+}
+
+// A pin that reached the node before the attempt failed is found by the
+// retry even when the content is gone
+func TestAssetPinRetryFindsEarlierPin(t *testing.T) {
+	s, repo, node := newAssetTest(t)
+	ctx := context.Background()
+	data := []byte("artwork")
+	node.SetFailing(true)
+	asset, err := s.StoreFile(ctx, 1, "art.png", "image/png", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	node.SetFailing(false)
+	if _, err := pinning.NewKuboPinner(node.URL(), "").Add(ctx, "art.png", data); err != nil {
+		t.Fatal(err)
+	}
+
+	asset.Content = nil
+	asset.NextPinAttemptAt = time.Now().Add(-time.Second)
+	s.pinDue(ctx)
+	if asset.PinStatus != models.AssetPinStatusPinned {
+		t.Fatalf("asset = %s (%q), want pinned", asset.PinStatus, asset.PinError)
+	}
+	if len(repo.assets) != 1 {
+		t.Fatalf("%d assets stored", len(repo.assets))
+	}
+}
+
+func TestPinRetryDelayIsCapped(t *testing.T) {
+	for attempts, want := range map[int]time.Duration{
+		1:  pinRetryBaseDelay,
+		2:  2 * pinRetryBaseDelay,
+		5:  16 * pinRetryBaseDelay,
+		20: pinRetryMaxDelay,
+		80: pinRetryMaxDelay,
+	} {
+		if got := pinRetryDelay(attempts); got != want {
+			t.Errorf("pinRetryDelay(%d) = %s, want %s", attempts, got, want)
+		}
+	}
+}
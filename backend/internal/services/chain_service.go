@@ -0,0 +1,64 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	chainInterfaces "github.com/igwedaniel/artizan/internal/interfaces/chain"
+)
+
+// ChainService describes the chains the backend serves, so clients know
+// where collections can live and which contracts to talk to
+type ChainService struct {
+	chains chainInterfaces.Registry
+}
+
+// NewChainService creates a new ChainService instance
+func NewChainService(chains chainInterfaces.Registry) *ChainService {
+	return &ChainService{chains: chains}
+}
+
+// List returns every configured chain, the default first
+func (s *ChainService) List() []*ChainInfo {
+	networks := s.chains.Networks()
+	infos := make([]*ChainInfo, len(networks))
+	for i, network := range networks {
+		infos[i] = &ChainInfo{
+			ChainID:        network.ChainID,
+			Name:           network.Name,
+			Default:        i == 0,
+			Confirmations:  network.Confirmations,
+			NativeCurrency: network.NativeCurrency,
+			Seaport:        network.Seaport.Hex(),
+			ConduitKey:     network.ConduitKey.Hex(),
+			Zone:           optionalAddress(network.Zone),
+			Factory:        optionalAddress(network.Factory),
+			WrappedNative:  optionalAddress(network.WrappedNative),
+		}
+	}
+	return infos
+}
+
+// resolveNetwork looks up chainID, with 0 for the default chain
+func resolveNetwork(chains chainInterfaces.Registry, chainID int64) (*chainInterfaces.Network, error) {
+	if chainID == 0 {
+		return chains.Default(), nil
+	}
+	network, err := chains.Network(chainID)
+	if err != nil {
+		if errors.Is(err, chainInterfaces.ErrUnknownChain) {
+			return nil, ErrUnsupportedChain
+		}
+		return nil, err
+	}
+	return network, nil
+}
+
+// optionalAddress is nil for the zero address
+func optionalAddress(address common.Address) *string {
+	if address == (common.Address{}) {
+		return nil
+	}
+	hex := address.Hex()
+	return &hex
+}
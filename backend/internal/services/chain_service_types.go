@@ -0,0 +1,17 @@
+package services
+
+import chainInterfaces "github.com/igwedaniel/artizan/internal/interfaces/chain"
+
+// ChainInfo is a configured chain and the platform contracts on it
+type ChainInfo struct {
+	ChainID        int64                          `json:"chain_id"`
+	Name           string                         `json:"name"`
+	Default        bool                           `json:"default"`
+	Confirmations  uint64                         `json:"confirmations"`
+	NativeCurrency chainInterfaces.NativeCurrency `json:"native_currency"`
+	Seaport        string                         `json:"seaport_address"`
+	ConduitKey     string                         `json:"conduit_key"`
+	Zone           *string                        `json:"zone_address"`
+	Factory        *string                        `json:"factory_address"`
+	WrappedNative  *string                        `json:"wrapped_native_address"`
+}
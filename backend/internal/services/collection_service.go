@@ -0,0 +1,179 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	chainInterfaces "github.com/igwedaniel/artizan/internal/interfaces/chain"
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+const maxCollectionNameLength = 100
+
+type CollectionService struct {
+	collectionRepo repoInterfaces.CollectionRepository
+	chains         chainInterfaces.Registry
+}
+
+// NewCollectionService creates a new CollectionService instance
+func NewCollectionService(collectionRepo repoInterfaces.CollectionRepository, chains chainInterfaces.Registry) *CollectionService {
+	return &CollectionService{
+		collectionRepo: collectionRepo,
+		chains:         chains,
+	}
+}
+
+// Create creates a collection owned by creator, who must be an approved
+// creator. Its contract address is only ever set by DeploymentService.
+func (s *CollectionService) Create(creator *models.User, input CreateCollectionInput) (*models.Collection, error) {
+	if creator.Role != models.RoleCreator {
+		return nil, ErrNotCreator
+	}
+	name, err := validateCollectionName(input.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureNameAvailable(creator.ID, name, 0); err != nil {
+		return nil, err
+	}
+	chainID, err := s.chainID(input.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	collection := &models.Collection{
+		CreatorID:   creator.ID,
+		ChainID:     chainID,
+		Name:        name,
+		Description: strings.TrimSpace(input.Description),
+	}
+	if err := s.collectionRepo.Create(collection); err != nil {
+		if errors.Is(err, repoInterfaces.ErrDuplicateRecord) {
+			return nil, ErrCollectionNameTaken
+		}
+		return nil, fmt.Errorf("failed to create collection: %w", err)
+	}
+	return collection, nil
+}
+
+// get Collection by ID
+func (s *CollectionService) GetByID(id uint) (*models.Collection, error) {
+	collection, err := s.collectionRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrCollectionNotFound
+		}
+		return nil, err
+	}
+	return collection, nil
+}
+
+// Update changes the given fields of a collection owned by actor. The chain
+// can only change until a deployment starts.
+func (s *CollectionService) Update(actor *models.User, id uint, input UpdateCollectionInput) (*models.Collection, error) {
+	collection, err := s.getOwned(actor, id)
+	if err != nil {
+		return nil, err
+	}
+	if collection.ArchivedAt != nil {
+		return nil, ErrCollectionArchived
+	}
+
+	if input.Name != nil {
+		name, err := validateCollectionName(*input.Name)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.ensureNameAvailable(actor.ID, name, collection.ID); err != nil {
+			return nil, err
+		}
+		collection.Name = name
+	}
+	if input.Description != nil {
+		collection.Description = strings.TrimSpace(*input.Description)
+	}
+	if input.ChainID != nil && *input.ChainID != collection.ChainID {
+		if collection.ContractAddress != nil || collection.DeploymentStatus != models.DeploymentStatusNone {
+			return nil, ErrCollectionChainImmutable
+		}
+		if collection.ChainID, err = s.chainID(*input.ChainID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.collectionRepo.UpdateDetails(collection); err != nil {
+		if errors.Is(err, repoInterfaces.ErrDuplicateRecord) {
+			return nil, ErrCollectionNameTaken
+		}
+		return nil, fmt.Errorf("failed to update collection: %w", err)
+	}
+	return collection, nil
+}
+
+// Archive hides a collection owned by actor from public listings
+func (s *CollectionService) Archive(actor *models.User, id uint) (*models.Collection, error) {
+	collection, err := s.getOwned(actor, id)
+	if err != nil {
+		return nil, err
+	}
+	if collection.ArchivedAt != nil {
+		return collection, nil
+	}
+	now := time.Now()
+	collection.ArchivedAt = &now
+	if err := s.collectionRepo.UpdateDetails(collection); err != nil {
+		return nil, fmt.Errorf("failed to archive collection: %w", err)
+	}
+	return collection, nil
+}
+
+// list collections of a creator
+func (s *CollectionService) ListByCreator(creatorID uint, includeArchived bool) ([]*models.Collection, error) {
+	return s.collectionRepo.ListByCreator(creatorID, includeArchived)
+}
+
+func (s *CollectionService) getOwned(actor *models.User, id uint) (*models.Collection, error) {
+	collection, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if collection.CreatorID != actor.ID {
+		return nil, ErrNotCollectionOwner
+	}
+	return collection, nil
+}
+
+// chainID checks a requested chain is configured; 0 picks the default chain
+func (s *CollectionService) chainID(requested int64) (int64, error) {
+	network, err := resolveNetwork(s.chains, requested)
+	if err != nil {
+		return 0, err
+	}
+	return network.ChainID, nil
+}
+
+// ensureNameAvailable checks that no other collection of the creator uses name
+func (s *CollectionService) ensureNameAvailable(creatorID uint, name string, exceptID uint) error {
+	existing, err := s.collectionRepo.GetByCreatorAndName(creatorID, name)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if existing.ID != exceptID {
+		return ErrCollectionNameTaken
+	}
+	return nil
+}
+
+func validateCollectionName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxCollectionNameLength {
+		return "", ErrInvalidCollectionName
+	}
+	return name, nil
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	chainAdapters "github.com/igwedaniel/artizan/internal/adapters/chain"
+	chainInterfaces "github.com/igwedaniel/artizan/internal/interfaces/chain"
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+// memoryCollectionRepo enforces idx_collection_creator_name like Postgres does
+type memoryCollectionRepo struct {
+	repoInterfaces.CollectionRepository
+	collections map[uint]*models.Collection
+	updateErr   error
+}
+
+func (r *memoryCollectionRepo) nameTaken(c *models.Collection) bool {
+	for _, other := range r.collections {
+		if other.ID != c.ID && other.CreatorID == c.CreatorID && other.Name == c.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *memoryCollectionRepo) Create(c *models.Collection) error {
+	if r.nameTaken(c) {
+		return repoInterfaces.ErrDuplicateRecord
+	}
+	c.ID = uint(len(r.collections) + 1)
+	stored := *c
+	r.collections[c.ID] = &stored
+	return nil
+}
+
+func (r *memoryCollectionRepo) GetByID(id uint) (*models.Collection, error) {
+	c, ok := r.collections[id]
+	if !ok {
+		return nil, repoInterfaces.ErrRecordNotFound
+	}
+	copied := *c
+	return &copied, nil
+}
+
+// the service's own lookup misses, as it does when two requests race
+func (r *memoryCollectionRepo) GetByCreatorAndName(uint, string) (*models.Collection, error) {
+	return nil, repoInterfaces.ErrRecordNotFound
+}
+
+func (r *memoryCollectionRepo) UpdateDetails(c *models.Collection) error {
+	if r.nameTaken(c) {
+		return repoInterfaces.ErrDuplicateRecord
+	}
+	stored := r.collections[c.ID]
+	stored.Name, stored.Description, stored.ChainID, stored.ArchivedAt = c.Name, c.Description, c.ChainID, c.ArchivedAt
+	return nil
+}
+
+func newTestCollectionService(t *testing.T) (*CollectionService, *memoryCollectionRepo) {
+	t.Helper()
+	chains, err := chainAdapters.NewRegistry(&chainInterfaces.Network{ChainID: 31337, Name: "hardhat"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &memoryCollectionRepo{collections: map[uint]*models.Collection{}}
+	return NewCollectionService(repo, chains), repo
+}
+
+func testUser(id uint, role string) *models.User {
+	user := &models.User{Role: role}
+	user.ID = id
+	return user
+}
+
+func TestCreateCollectionRequiresCreatorRole(t *testing.T) {
+	s, _ := newTestCollectionService(t)
+	for _, role := range []string{"", models.RoleAdmin} {
+		if _, err := s.Create(testUser(1, role), CreateCollectionInput{Name: "Genesis"}); !errors.Is(err, ErrNotCreator) {
+			t.Errorf("role %q: Create = %v, want ErrNotCreator", role, err)
+		}
+	}
+	collection, err := s.Create(testUser(1, models.RoleCreator), CreateCollectionInput{Name: "Genesis"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if collection.ChainID != 31337 {
+		t.Errorf("collection is on chain %d, want the default chain", collection.ChainID)
+	}
+}
+
+func TestCollectionNameRaceIsAConflict(t *testing.T) {
+	s, _ := newTestCollectionService(t)
+	creator := testUser(1, models.RoleCreator)
+	if _, err := s.Create(creator, CreateCollectionInput{Name: "Genesis"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Create(creator, CreateCollectionInput{Name: "Genesis"}); !errors.Is(err, ErrCollectionNameTaken) {
+		t.Fatalf("duplicate Create = %v, want ErrCollectionNameTaken", err)
+	}
+	second, err := s.Create(creator, CreateCollectionInput{Name: "Second"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := "Genesis"
+	if _, err := s.Update(creator, second.ID, UpdateCollectionInput{Name: &name}); !errors.Is(err, ErrCollectionNameTaken) {
+		t.Fatalf("renaming onto a taken name = %v, want ErrCollectionNameTaken", err)
+	}
+}
+
+// Only DeploymentService sets contract addresses; clients sending one are ignored.
+func TestClientsCannotSetContractAddress(t *testing.T) {
+	s, repo := newTestCollectionService(t)
+	creator := testUser(1, models.RoleCreator)
+
+	var create CreateCollectionInput
+	if err := json.Unmarshal([]byte(`{"name":"Genesis","contract_address":"0x5FbDB2315678afecb367f032d93F642f64180aa3"}`), &create); err != nil {
+		t.Fatal(err)
+	}
+	collection, err := s.Create(creator, create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var update UpdateCollectionInput
+	if err := json.Unmarshal([]byte(`{"description":"d","contract_address":"0x5FbDB2315678afecb367f032d93F642f64180aa3"}`), &update); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Update(creator, collection.ID, update); err != nil {
+		t.Fatal(err)
+	}
+	if stored := repo.collections[collection.ID]; stored.ContractAddress != nil {
+		t.Fatalf("client set the contract address to %s", *stored.ContractAddress)
+	}
+}
@@ -0,0 +1,14 @@
+package services
+
+type CreateCollectionInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ChainID     int64  `json:"chain_id"` // 0 for the default chain
+}
+
+// UpdateCollectionInput holds the fields to change; nil fields are left as is
+type UpdateCollectionInput struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	ChainID     *int64  `json:"chain_id"`
+}
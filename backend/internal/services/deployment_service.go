@@ -0,0 +1,622 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	chainInterfaces "github.com/igwedaniel/artizan/internal/interfaces/chain"
+	busInterfaces "github.com/igwedaniel/artizan/internal/interfaces/eventbus"
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	signerInterfaces "github.com/igwedaniel/artizan/internal/interfaces/signer"
+	"github.com/igwedaniel/artizan/internal/models"
+	"github.com/igwedaniel/artizan/pkg/contracts"
+)
+
+const (
+	deploymentPollInterval = 5 * time.Second
+	// a deployer transaction left unmined this long is replaced with higher fees
+	stuckTxTimeout = 10 * time.Minute
+	// replacements of a stuck transaction before its collection gives up on it
+	maxTxReplacements = 3
+	// failed setSigner attempts before a contract is left on its old signer
+	// until the next rotation; retries wait signerRetryBackoff, doubling
+	maxSignerAttempts  = 5
+	signerRetryBackoff = time.Minute
+)
+
+var (
+	errTxDropped = errors.New("transaction was dropped")
+	errTxStuck   = errors.New("transaction was not mined")
+)
+
+// DeploymentService deploys a LazyMint1155, and optionally a LazyMintZone,
+// for a collection on its chain and follows the transactions until they are
+// confirmed. It also points deployed contracts at the current voucher signer
+// after a signer rotation. The deployer has the same address on every chain.
+type DeploymentService struct {
+	collectionRepo repoInterfaces.CollectionRepository
+	chains         chainInterfaces.Registry
+	eventBus       busInterfaces.EventBus
+	deployerSigner signerInterfaces.Signer
+	deployer       common.Address
+	voucherSigner  common.Address
+	sendMu         sync.Mutex       // one deployer transaction at a time keeps nonces in order
+	nonces         map[int64]uint64 // next deployer nonce by chain, guarded by sendMu
+}
+
+// NewDeploymentService creates a new DeploymentService instance
+func NewDeploymentService(
+	collectionRepo repoInterfaces.CollectionRepository,
+	chains chainInterfaces.Registry,
+	eventBus busInterfaces.EventBus,
+	deployerSigner signerInterfaces.Signer,
+	voucherSigner common.Address,
+) *DeploymentService {
+	return &DeploymentService{
+		collectionRepo: collectionRepo,
+		chains:         chains,
+		eventBus:       eventBus,
+		deployerSigner: deployerSigner,
+		deployer:       deployerSigner.Address(),
+		voucherSigner:  voucherSigner,
+		nonces:         make(map[int64]uint64),
+	}
+}
+
+// Publish starts the on-chain deployment of a collection owned by actor. The
+// returned collection is in the deploying state; Run finishes the job.
+func (s *DeploymentService) Publish(ctx context.Context, actor *models.User, collectionID uint, withZone bool) (*models.Collection, error) {
+	collection, err := s.collectionRepo.GetByID(collectionID)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrCollectionNotFound
+		}
+		return nil, err
+	}
+	if collection.CreatorID != actor.ID {
+		return nil, ErrNotCollectionOwner
+	}
+	if collection.ArchivedAt != nil {
+		return nil, ErrCollectionArchived
+	}
+	network, err := s.chains.Network(collection.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	claimed, err := s.collectionRepo.ClaimDeployment(collection.ID, withZone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim deployment: %w", err)
+	}
+	if !claimed {
+		return nil, ErrCollectionAlreadyDeployed
+	}
+	collection.DeploymentStatus = models.DeploymentStatusDeploying
+	collection.DeploymentError = ""
+	collection.WithZone = withZone
+
+	err = s.send(ctx, network, collection,
+		func(hash string) { collection.DeployTxHash = hash },
+		func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			_, tx, _, err := contracts.DeployLazyMint1155(opts, network.Client, s.voucherSigner)
+			return tx, err
+		})
+	if err != nil {
+		// nothing was broadcast
+		collection.DeployTxHash = ""
+		s.fail(collection, err)
+		return nil, fmt.Errorf("failed to send deployment transaction: %w", err)
+	}
+	return collection, nil
+}
+
+// Run follows pending deployments until ctx is cancelled
+func (s *DeploymentService) Run(ctx context.Context) {
+	ticker := time.NewTicker(deploymentPollInterval)
+	defer ticker.Stop()
+	for {
+		if err := s.TrackPending(ctx); err != nil {
+			log.Printf("failed to track collection deployments: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// TrackPending advances every collection whose deployment or signer update
+// is in flight
+func (s *DeploymentService) TrackPending(ctx context.Context) error {
+	collections, err := s.collectionRepo.ListByDeploymentStatus(models.DeploymentStatusDeploying, models.DeploymentStatusDeployingZone)
+	if err != nil {
+		return err
+	}
+	for _, collection := range collections {
+		if err := s.track(ctx, collection); err != nil {
+			log.Printf("failed to track deployment of collection %d: %v", collection.ID, err)
+		}
+	}
+
+	outdated, err := s.collectionRepo.ListSignerOutdated(s.voucherSigner.Hex(), time.Now())
+	if err != nil {
+		return err
+	}
+	for _, collection := range outdated {
+		if err := s.syncSigner(ctx, collection); err != nil {
+			log.Printf("failed to update voucher signer of collection %d: %v", collection.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *DeploymentService) track(ctx context.Context, collection *models.Collection) error {
+	network, err := s.chains.Network(collection.ChainID)
+	if err != nil {
+		return err
+	}
+	switch collection.DeploymentStatus {
+	case models.DeploymentStatusDeploying:
+		address, err := s.deployed(ctx, network, collection, &collection.DeployTxHash, "LazyMint1155")
+		if err != nil || address == (common.Address{}) {
+			return err
+		}
+		contract := address.Hex()
+		collection.ContractAddress = &contract
+		if !collection.WithZone {
+			return s.complete(collection)
+		}
+
+		// the zone is owned by the deployer and mints through the new contract;
+		// a failed send is retried on the next pass
+		err = s.send(ctx, network, collection,
+			func(hash string) {
+				collection.DeploymentStatus = models.DeploymentStatusDeployingZone
+				collection.ZoneTxHash = hash
+			},
+			func(opts *bind.TransactOpts) (*types.Transaction, error) {
+				_, tx, _, err := contracts.DeployLazyMintZone(opts, network.Client, s.deployer, address)
+				return tx, err
+			})
+		if err != nil {
+			return fmt.Errorf("failed to send LazyMintZone deployment: %w", err)
+		}
+		return nil
+
+	case models.DeploymentStatusDeployingZone:
+		address, err := s.deployed(ctx, network, collection, &collection.ZoneTxHash, "LazyMintZone")
+		if err != nil || address == (common.Address{}) {
+			return err
+		}
+		zone := address.Hex()
+		collection.ZoneAddress = &zone
+		return s.complete(collection)
+	}
+	return nil
+}
+
+// deployed follows the contract deployment recorded on collection under
+// txHash and returns the contract address once it is confirmed, or the zero
+// address while it is pending. A deployment that reverted, was dropped or got
+// stuck fails the collection.
+func (s *DeploymentService) deployed(ctx context.Context, network *chainInterfaces.Network, collection *models.Collection, txHash *string, contract string) (common.Address, error) {
+	receipt, err := s.await(ctx, network, collection, txHash)
+	switch {
+	case errors.Is(err, errTxDropped):
+		// a replaced deployment may have been mined under an earlier hash; the
+		// contract address only depends on the deployer and the nonce
+		tx, err := decodeTx(collection.PendingTx)
+		if err != nil {
+			return common.Address{}, err
+		}
+		address := crypto.CreateAddress(s.deployer, tx.Nonce())
+		block, err := s.confirmedBlock(ctx, network)
+		if err != nil {
+			return common.Address{}, err
+		}
+		code, err := network.Client.CodeAt(ctx, address, block)
+		if err != nil {
+			return common.Address{}, err
+		}
+		if len(code) > 0 {
+			return address, nil
+		}
+		s.fail(collection, fmt.Errorf("%s deployment %s was dropped", contract, *txHash))
+		return common.Address{}, nil
+	case errors.Is(err, errTxStuck):
+		s.resetNonce(network)
+		s.fail(collection, fmt.Errorf("%s deployment %s was not mined", contract, *txHash))
+		return common.Address{}, nil
+	case err != nil || receipt == nil:
+		return common.Address{}, err
+	case receipt.Status != types.ReceiptStatusSuccessful:
+		s.fail(collection, fmt.Errorf("%s deployment %s reverted", contract, *txHash))
+		return common.Address{}, nil
+	}
+	return receipt.ContractAddress, nil
+}
+
+// syncSigner moves a deployed contract to the current voucher signer with
+// setSigner. Once the change is confirmed, vouchers signed by the previous
+// signer can be reissued. Failed updates back off and are given up after
+// maxSignerAttempts.
+func (s *DeploymentService) syncSigner(ctx context.Context, collection *models.Collection) error {
+	contract := common.HexToAddress(*collection.ContractAddress)
+	network, err := s.chains.Network(collection.ChainID)
+	if err != nil {
+		return err
+	}
+
+	if collection.SignerTxHash != "" {
+		receipt, err := s.await(ctx, network, collection, &collection.SignerTxHash)
+		switch {
+		case errors.Is(err, errTxDropped):
+			// a replaced setSigner may have been mined under an earlier hash
+			block, err := s.confirmedBlock(ctx, network)
+			if err != nil {
+				return err
+			}
+			current, err := s.currentSigner(ctx, network, contract, block)
+			if err != nil {
+				return err
+			}
+			if collection.PendingSignerAddress == nil || current.Hex() != *collection.PendingSignerAddress {
+				return s.signerFailed(collection, fmt.Errorf("setSigner %s was dropped", collection.SignerTxHash))
+			}
+		case errors.Is(err, errTxStuck):
+			s.resetNonce(network)
+			return s.signerFailed(collection, fmt.Errorf("setSigner %s was not mined", collection.SignerTxHash))
+		case err != nil || receipt == nil:
+			return err
+		case receipt.Status != types.ReceiptStatusSuccessful:
+			return s.signerFailed(collection, fmt.Errorf("setSigner %s reverted", collection.SignerTxHash))
+		}
+		collection.SignerAddress = collection.PendingSignerAddress
+		collection.PendingSignerAddress = nil
+		collection.SignerTxHash = ""
+		collection.PendingTx = ""
+		collection.PendingTxSentAt = nil
+		collection.SignerAttempts = 0
+		collection.SignerRetryAt = nil
+		collection.SignerError = ""
+		if err := s.collectionRepo.Update(collection); err != nil {
+			return err
+		}
+		s.eventBus.Publish(busInterfaces.EventCollectionSignerUpdated, busInterfaces.CollectionSignerUpdatedEvent{
+			CollectionID:  collection.ID,
+			SignerAddress: *collection.SignerAddress,
+		})
+		return nil
+	}
+
+	// collections deployed before signers were tracked learn theirs from the chain
+	if collection.SignerAddress == nil {
+		current, err := s.currentSigner(ctx, network, contract, nil)
+		if err != nil {
+			return err
+		}
+		address := current.Hex()
+		collection.SignerAddress = &address
+		if current == s.voucherSigner {
+			return s.collectionRepo.Update(collection)
+		}
+	}
+
+	// a new rotation starts with a clean slate
+	target := s.voucherSigner.Hex()
+	if collection.PendingSignerAddress == nil || *collection.PendingSignerAddress != target {
+		collection.PendingSignerAddress = &target
+		collection.SignerAttempts = 0
+		collection.SignerRetryAt = nil
+		collection.SignerFailedAt = nil
+		collection.SignerError = ""
+	}
+	err = s.send(ctx, network, collection,
+		func(hash string) { collection.SignerTxHash = hash },
+		func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			transactor, err := contracts.NewLazyMint1155Transactor(contract, network.Client)
+			if err != nil {
+				return nil, err
+			}
+			return transactor.SetSigner(opts, s.voucherSigner)
+		})
+	if err != nil {
+		return s.signerFailed(collection, fmt.Errorf("failed to send setSigner: %w", err))
+	}
+	return nil
+}
+
+// signerFailed records a failed setSigner attempt and returns cause. The
+// next attempt waits twice as long as the previous one; after
+// maxSignerAttempts the contract keeps its old signer until the next rotation.
+func (s *DeploymentService) signerFailed(collection *models.Collection, cause error) error {
+	now := time.Now()
+	collection.SignerTxHash = ""
+	collection.PendingTx = ""
+	collection.PendingTxSentAt = nil
+	collection.SignerAttempts++
+	collection.SignerError = cause.Error()
+	if collection.SignerAttempts >= maxSignerAttempts {
+		collection.SignerRetryAt = nil
+		collection.SignerFailedAt = &now
+	} else {
+		retryAt := now.Add(signerRetryBackoff << (collection.SignerAttempts - 1))
+		collection.SignerRetryAt = &retryAt
+	}
+	if err := s.collectionRepo.Update(collection); err != nil {
+		return err
+	}
+	return cause
+}
+
+func (s *DeploymentService) currentSigner(ctx context.Context, network *chainInterfaces.Network, contract common.Address, block *big.Int) (common.Address, error) {
+	caller, err := contracts.NewLazyMint1155Caller(contract, network.Client)
+	if err != nil {
+		return common.Address{}, err
+	}
+	current, err := caller.Signer(&bind.CallOpts{Context: ctx, BlockNumber: block})
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to read signer: %w", err)
+	}
+	return current, nil
+}
+
+func (s *DeploymentService) complete(collection *models.Collection) error {
+	collection.DeploymentStatus = models.DeploymentStatusDeployed
+	collection.PendingTx = ""
+	collection.PendingTxSentAt = nil
+	signer := s.voucherSigner.Hex()
+	collection.SignerAddress = &signer
+	if err := s.collectionRepo.Update(collection); err != nil {
+		return err
+	}
+	event := busInterfaces.CollectionDeployedEvent{
+		CollectionID:    collection.ID,
+		CreatorID:       collection.CreatorID,
+		ContractAddress: *collection.ContractAddress,
+	}
+	if collection.ZoneAddress != nil {
+		event.ZoneAddress = *collection.ZoneAddress
+	}
+	s.eventBus.Publish(busInterfaces.EventCollectionDeployed, event)
+	return nil
+}
+
+// fail records a failed deployment so the creator can publish again
+func (s *DeploymentService) fail(collection *models.Collection, cause error) {
+	collection.DeploymentStatus = models.DeploymentStatusFailed
+	collection.DeploymentError = cause.Error()
+	collection.PendingTx = ""
+	collection.PendingTxSentAt = nil
+	if err := s.collectionRepo.Update(collection); err != nil {
+		log.Printf("failed to mark deployment of collection %d as failed: %v", collection.ID, err)
+	}
+}
+
+// await follows the deployer transaction recorded on collection under
+// txHash. It returns the receipt once the transaction has enough
+// confirmations and nil while it is pending. A transaction the node no longer
+// knows is broadcast again, and one left unmined for stuckTxTimeout is
+// replaced with higher fees, moving txHash to the replacement. errTxDropped
+// reports that another transaction, possibly an earlier version of this one,
+// used its nonce; errTxStuck that it was still unmined after every
+// replacement.
+func (s *DeploymentService) await(ctx context.Context, network *chainInterfaces.Network, collection *models.Collection, txHash *string) (*types.Receipt, error) {
+	hash := common.HexToHash(*txHash)
+	receipt, err := network.Client.TransactionReceipt(ctx, hash)
+	if err == nil {
+		return s.confirmedReceipt(ctx, network, receipt)
+	}
+	if !txNotFound(err) {
+		return nil, err
+	}
+	// transactions recorded before they were kept signed can only be waited on
+	if collection.PendingTx == "" {
+		return nil, nil
+	}
+	tx, err := decodeTx(collection.PendingTx)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := s.confirmedBlock(ctx, network)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := network.Client.NonceAt(ctx, s.deployer, block)
+	if err != nil {
+		return nil, err
+	}
+	if nonce > tx.Nonce() {
+		// the receipt may have landed since it was looked up
+		if _, err := network.Client.TransactionReceipt(ctx, hash); err == nil {
+			return nil, nil
+		}
+		return nil, errTxDropped
+	}
+
+	if collection.PendingTxSentAt != nil && time.Since(*collection.PendingTxSentAt) >= stuckTxTimeout {
+		if collection.PendingTxReplacements >= maxTxReplacements {
+			return nil, errTxStuck
+		}
+		return nil, s.replace(ctx, network, collection, txHash, tx)
+	}
+	if _, _, err := network.Client.TransactionByHash(ctx, hash); err != nil {
+		if !txNotFound(err) {
+			return nil, err
+		}
+		if err := network.Client.SendTransaction(ctx, tx); err != nil {
+			return nil, fmt.Errorf("failed to broadcast %s again: %w", *txHash, err)
+		}
+	}
+	return nil, nil
+}
+
+// confirmedReceipt returns receipt once it has enough confirmations, or nil
+// while it needs more
+func (s *DeploymentService) confirmedReceipt(ctx context.Context, network *chainInterfaces.Network, receipt *types.Receipt) (*types.Receipt, error) {
+	head, err := network.Client.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.BlockNumber == nil || head+1 < receipt.BlockNumber.Uint64()+max(network.Confirmations, 1) {
+		return nil, nil
+	}
+	return receipt, nil
+}
+
+// confirmedBlock returns the latest block with enough confirmations
+func (s *DeploymentService) confirmedBlock(ctx context.Context, network *chainInterfaces.Network) (*big.Int, error) {
+	head, err := network.Client.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	depth := max(network.Confirmations, 1) - 1
+	if head < depth {
+		return big.NewInt(0), nil
+	}
+	return new(big.Int).SetUint64(head - depth), nil
+}
+
+// send signs a deployer transaction on network, records it on collection
+// through record and stores the collection before the transaction is
+// broadcast, so a crash never loses a sent transaction. A failed broadcast
+// leaves the transaction recorded for await to send again.
+func (s *DeploymentService) send(ctx context.Context, network *chainInterfaces.Network, collection *models.Collection, record func(hash string), build func(*bind.TransactOpts) (*types.Transaction, error)) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	nonce, err := network.Client.PendingNonceAt(ctx, s.deployer)
+	if err != nil {
+		return fmt.Errorf("failed to read deployer nonce: %w", err)
+	}
+	// transactions recorded but not yet accepted by the node still hold their nonce
+	if next, ok := s.nonces[network.ChainID]; ok && next > nonce {
+		nonce = next
+	}
+	opts := &bind.TransactOpts{
+		From:    s.deployer,
+		Nonce:   new(big.Int).SetUint64(nonce),
+		Context: ctx,
+		NoSend:  true,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != s.deployer {
+				return nil, bind.ErrNotAuthorized
+			}
+			return s.deployerSigner.SignTx(ctx, tx, big.NewInt(network.ChainID))
+		},
+	}
+	tx, err := build(opts)
+	if err != nil {
+		return err
+	}
+	if err := s.record(collection, record, tx, 0); err != nil {
+		return err
+	}
+	s.nonces[network.ChainID] = nonce + 1
+	if err := network.Client.SendTransaction(ctx, tx); err != nil {
+		log.Printf("failed to broadcast transaction %s of collection %d, it is sent again later: %v", tx.Hash().Hex(), collection.ID, err)
+	}
+	return nil
+}
+
+// replace sends tx again under its nonce with fees raised by a quarter, above
+// the tenth nodes require to accept a replacement
+func (s *DeploymentService) replace(ctx context.Context, network *chainInterfaces.Network, collection *models.Collection, txHash *string, tx *types.Transaction) error {
+	bump := func(fee *big.Int) *big.Int {
+		return new(big.Int).Div(new(big.Int).Mul(fee, big.NewInt(5)), big.NewInt(4))
+	}
+	var inner types.TxData
+	switch tx.Type() {
+	case types.DynamicFeeTxType:
+		inner = &types.DynamicFeeTx{
+			ChainID:   tx.ChainId(),
+			Nonce:     tx.Nonce(),
+			GasTipCap: bump(tx.GasTipCap()),
+			GasFeeCap: bump(tx.GasFeeCap()),
+			Gas:       tx.Gas(),
+			To:        tx.To(),
+			Value:     tx.Value(),
+			Data:      tx.Data(),
+		}
+	default:
+		inner = &types.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: bump(tx.GasPrice()),
+			Gas:      tx.Gas(),
+			To:       tx.To(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		}
+	}
+
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	replacement, err := s.deployerSigner.SignTx(ctx, types.NewTx(inner), big.NewInt(network.ChainID))
+	if err != nil {
+		return err
+	}
+	if err := s.record(collection, func(hash string) { *txHash = hash }, replacement, collection.PendingTxReplacements+1); err != nil {
+		return err
+	}
+	if err := network.Client.SendTransaction(ctx, replacement); err != nil {
+		return fmt.Errorf("failed to send replacement %s: %w", replacement.Hash().Hex(), err)
+	}
+	return nil
+}
+
+// record stores tx on collection as the deployer transaction in flight
+func (s *DeploymentService) record(collection *models.Collection, record func(hash string), tx *types.Transaction, replacements int) error {
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	record(tx.Hash().Hex())
+	collection.PendingTx = hexutil.Encode(raw)
+	collection.PendingTxSentAt = &now
+	collection.PendingTxReplacements = replacements
+	if err := s.collectionRepo.Update(collection); err != nil {
+		return fmt.Errorf("failed to store transaction: %w", err)
+	}
+	return nil
+}
+
+// resetNonce has the next deployer transaction on network take its nonce
+// from the node again, after a transaction holding one was given up
+func (s *DeploymentService) resetNonce(network *chainInterfaces.Network) {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	delete(s.nonces, network.ChainID)
+}
+
+// txNotFound reports whether a node does not know a transaction. Nodes still
+// indexing their history answer lookups of unknown transactions with an error.
+func txNotFound(err error) bool {
+	return errors.Is(err, ethereum.NotFound) || strings.Contains(err.Error(), "transaction indexing is in progress")
+}
+
+func decodeTx(raw string) (*types.Transaction, error) {
+	encoded, err := hexutil.Decode(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stored transaction: %w", err)
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(encoded); err != nil {
+		return nil, fmt.Errorf("invalid stored transaction: %w", err)
+	}
+	return tx, nil
+}
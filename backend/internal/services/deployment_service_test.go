@@ -0,0 +1,419 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	chainAdapters "github.com/igwedaniel/artizan/internal/adapters/chain"
+	signerAdapters "github.com/igwedaniel/artizan/internal/adapters/signer"
+	chainInterfaces "github.com/igwedaniel/artizan/internal/interfaces/chain"
+	busInterfaces "github.com/igwedaniel/artizan/internal/interfaces/eventbus"
+	signerInterfaces "github.com/igwedaniel/artizan/internal/interfaces/signer"
+	"github.com/igwedaniel/artizan/internal/models"
+	"github.com/igwedaniel/artizan/pkg/contracts"
+)
+
+func (r *memoryCollectionRepo) Update(c *models.Collection) error {
+	if r.updateErr != nil {
+		return r.updateErr
+	}
+	stored := *c
+	r.collections[c.ID] = &stored
+	return nil
+}
+
+func (r *memoryCollectionRepo) ClaimDeployment(id uint, withZone bool) (bool, error) {
+	c := r.collections[id]
+	if c == nil || c.ContractAddress != nil || (c.DeploymentStatus != models.DeploymentStatusNone && c.DeploymentStatus != models.DeploymentStatusFailed) {
+		return false, nil
+	}
+	c.DeploymentStatus, c.DeploymentError, c.WithZone = models.DeploymentStatusDeploying, "", withZone
+	return true, nil
+}
+
+func (r *memoryCollectionRepo) ListByDeploymentStatus(statuses ...string) ([]*models.Collection, error) {
+	var collections []*models.Collection
+	for id := uint(1); id <= uint(len(r.collections)); id++ {
+		if c := r.collections[id]; slices.Contains(statuses, c.DeploymentStatus) {
+			copied := *c
+			collections = append(collections, &copied)
+		}
+	}
+	return collections, nil
+}
+
+// ListSignerOutdated mirrors the query of the gorm repository
+func (r *memoryCollectionRepo) ListSignerOutdated(signerAddress string, now time.Time) ([]*models.Collection, error) {
+	var collections []*models.Collection
+	for id := uint(1); id <= uint(len(r.collections)); id++ {
+		c := r.collections[id]
+		if c.DeploymentStatus != models.DeploymentStatusDeployed || c.ContractAddress == nil {
+			continue
+		}
+		if c.SignerAddress != nil && *c.SignerAddress == signerAddress && c.SignerTxHash == "" {
+			continue
+		}
+		due := c.SignerFailedAt == nil && (c.SignerRetryAt == nil || !c.SignerRetryAt.After(now))
+		if c.SignerTxHash == "" && c.PendingSignerAddress != nil && *c.PendingSignerAddress == signerAddress && !due {
+			continue
+		}
+		copied := *c
+		collections = append(collections, &copied)
+	}
+	return collections, nil
+}
+
+type recordingBus struct {
+	events []string
+	// handlerErr is what the handlers of events published and waited for
+	// fail with
+	handlerErr error
+}
+
+func (b *recordingBus) Subscribe(string, busInterfaces.HandlerFunc) {}
+
+func (b *recordingBus) Publish(event string, _ interface{}) {
+	b.events = append(b.events, event)
+}
+
+func (b *recordingBus) PublishAndWait(event string, data interface{}) error {
+	b.Publish(event, data)
+	return b.handlerErr
+}
+
+// droppingClient loses the next drops transactions it is asked to send, like
+// a node that accepts a transaction and then evicts it
+type droppingClient struct {
+	chainInterfaces.Client
+	drops int
+}
+
+func (c *droppingClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if c.drops > 0 {
+		c.drops--
+		return nil
+	}
+	return c.Client.SendTransaction(ctx, tx)
+}
+
+type deploymentTest struct {
+	backend  *simulated.Backend
+	client   *droppingClient
+	network  *chainInterfaces.Network
+	deployer signerInterfaces.Signer
+	repo     *memoryCollectionRepo
+	bus      *recordingBus
+	creator  *models.User
+}
+
+func newDeploymentTest(t *testing.T, funded ...common.Address) *deploymentTest {
+	t.Helper()
+	deployer, err := signerAdapters.GenerateMemorySigner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	balance := new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18))
+	alloc := types.GenesisAlloc{deployer.Address(): {Balance: balance}}
+	for _, address := range funded {
+		alloc[address] = types.Account{Balance: balance}
+	}
+	backend := simulated.NewBackend(alloc)
+	t.Cleanup(func() { backend.Close() })
+	client := &droppingClient{Client: backend.Client()}
+	return &deploymentTest{
+		backend:  backend,
+		client:   client,
+		network:  &chainInterfaces.Network{ChainID: 1337, Name: "simulated", Client: client, Confirmations: 1},
+		deployer: deployer,
+		repo:     &memoryCollectionRepo{collections: map[uint]*models.Collection{}},
+		bus:      &recordingBus{},
+		creator:  testUser(1, models.RoleCreator),
+	}
+}
+
+func (d *deploymentTest) service(t *testing.T, voucherSigner common.Address) *DeploymentService {
+	t.Helper()
+	chains, err := chainAdapters.NewRegistry(d.network)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewDeploymentService(d.repo, chains, d.bus, d.deployer, voucherSigner)
+}
+
+func (d *deploymentTest) collection(t *testing.T) *models.Collection {
+	t.Helper()
+	c := &models.Collection{CreatorID: d.creator.ID, Name: "Drops", ChainID: d.network.ChainID}
+	if err := d.repo.Create(c); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func (d *deploymentTest) stored(id uint) *models.Collection {
+	return d.repo.collections[id]
+}
+
+func (d *deploymentTest) track(t *testing.T, s *DeploymentService) {
+	t.Helper()
+	if err := s.TrackPending(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// age makes the transaction in flight of collection id look stuck
+func (d *deploymentTest) age(id uint) {
+	sentAt := time.Now().Add(-stuckTxTimeout - time.Minute)
+	d.stored(id).PendingTxSentAt = &sentAt
+}
+
+func (d *deploymentTest) signerOf(t *testing.T, contract string) common.Address {
+	t.Helper()
+	caller, err := contracts.NewLazyMint1155Caller(common.HexToAddress(contract), d.backend.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := caller.Signer(&bind.CallOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+func TestPublishDeploysCollectionAndZone(t *testing.T) {
+	d := newDeploymentTest(t)
+	voucherSigner := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	s := d.service(t, voucherSigner)
+	c := d.collection(t)
+
+	published, err := s.Publish(context.Background(), d.creator, c.ID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if published.DeploymentStatus != models.DeploymentStatusDeploying || d.stored(c.ID).DeployTxHash == "" {
+		t.Fatalf("publish did not record the deployment: %+v", d.stored(c.ID))
+	}
+
+	d.backend.Commit()
+	d.track(t, s)
+	if got := d.stored(c.ID); got.DeploymentStatus != models.DeploymentStatusDeployingZone || got.ZoneTxHash == "" {
+		t.Fatalf("zone deployment was not sent: %+v", got)
+	}
+	d.backend.Commit()
+	d.track(t, s)
+
+	got := d.stored(c.ID)
+	if got.DeploymentStatus != models.DeploymentStatusDeployed || got.ContractAddress == nil || got.ZoneAddress == nil {
+		t.Fatalf("collection was not deployed: %+v", got)
+	}
+	if got.PendingTx != "" {
+		t.Fatal("deployed collection still has a transaction in flight")
+	}
+	if signer := d.signerOf(t, *got.ContractAddress); signer != voucherSigner {
+		t.Fatalf("contract signer = %s, want %s", signer.Hex(), voucherSigner.Hex())
+	}
+	if !slices.Contains(d.bus.events, busInterfaces.EventCollectionDeployed) {
+		t.Fatalf("events = %v, want %s", d.bus.events, busInterfaces.EventCollectionDeployed)
+	}
+}
+
+func TestDeploymentIsStoredBeforeBroadcast(t *testing.T) {
+	d := newDeploymentTest(t)
+	s := d.service(t, common.HexToAddress("0x00000000000000000000000000000000000000a1"))
+	c := d.collection(t)
+
+	d.repo.updateErr = errors.New("database is down")
+	if _, err := s.Publish(context.Background(), d.creator, c.ID, false); err == nil {
+		t.Fatal("publish succeeded without storing its transaction")
+	}
+	nonce, err := d.backend.Client().PendingNonceAt(context.Background(), d.deployer.Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nonce != 0 {
+		t.Fatalf("a transaction was broadcast although it could not be stored (pending nonce %d)", nonce)
+	}
+}
+
+func TestDroppedDeploymentIsBroadcastAgain(t *testing.T) {
+	d := newDeploymentTest(t)
+	s := d.service(t, common.HexToAddress("0x00000000000000000000000000000000000000a1"))
+	c := d.collection(t)
+
+	d.client.drops = 1
+	if _, err := s.Publish(context.Background(), d.creator, c.ID, false); err != nil {
+		t.Fatal(err)
+	}
+	d.backend.Commit()
+	d.track(t, s) // the node never saw it, so it is sent again
+	d.backend.Commit()
+	d.track(t, s)
+
+	if got := d.stored(c.ID); got.DeploymentStatus != models.DeploymentStatusDeployed {
+		t.Fatalf("status = %q, want %q", got.DeploymentStatus, models.DeploymentStatusDeployed)
+	}
+}
+
+func TestStuckDeploymentIsReplacedThenFailed(t *testing.T) {
+	d := newDeploymentTest(t)
+	s := d.service(t, common.HexToAddress("0x00000000000000000000000000000000000000a1"))
+	c := d.collection(t)
+
+	d.client.drops = maxTxReplacements + 1
+	if _, err := s.Publish(context.Background(), d.creator, c.ID, false); err != nil {
+		t.Fatal(err)
+	}
+	hashes := []string{d.stored(c.ID).DeployTxHash}
+	for i := 1; i <= maxTxReplacements; i++ {
+		d.age(c.ID)
+		d.track(t, s)
+		got := d.stored(c.ID)
+		if got.PendingTxReplacements != i || slices.Contains(hashes, got.DeployTxHash) {
+			t.Fatalf("replacement %d was not recorded: %+v", i, got)
+		}
+		hashes = append(hashes, got.DeployTxHash)
+	}
+
+	d.age(c.ID)
+	d.track(t, s)
+	got := d.stored(c.ID)
+	if got.DeploymentStatus != models.DeploymentStatusFailed || !strings.Contains(got.DeploymentError, "not mined") {
+		t.Fatalf("stuck deployment was not failed: %+v", got)
+	}
+
+	// the nonce of the abandoned transaction is used again
+	if _, err := s.Publish(context.Background(), d.creator, c.ID, false); err != nil {
+		t.Fatal(err)
+	}
+	d.backend.Commit()
+	d.track(t, s)
+	if got := d.stored(c.ID); got.DeploymentStatus != models.DeploymentStatusDeployed {
+		t.Fatalf("republished collection status = %q, want %q", got.DeploymentStatus, models.DeploymentStatusDeployed)
+	}
+}
+
+func TestReplacedDeploymentMinedUnderEarlierHash(t *testing.T) {
+	d := newDeploymentTest(t)
+	s := d.service(t, common.HexToAddress("0x00000000000000000000000000000000000000a1"))
+	c := d.collection(t)
+
+	if _, err := s.Publish(context.Background(), d.creator, c.ID, false); err != nil {
+		t.Fatal(err)
+	}
+	original := d.stored(c.ID).DeployTxHash
+	d.age(c.ID)
+	d.client.drops = 1 // the replacement never reaches the node
+	d.track(t, s)
+	if d.stored(c.ID).DeployTxHash == original {
+		t.Fatal("stuck deployment was not replaced")
+	}
+
+	d.backend.Commit() // mines the original
+	d.track(t, s)
+	got := d.stored(c.ID)
+	want := crypto.CreateAddress(d.deployer.Address(), 0).Hex()
+	if got.DeploymentStatus != models.DeploymentStatusDeployed || got.ContractAddress == nil || *got.ContractAddress != want {
+		t.Fatalf("collection = %+v, want deployed at %s", got, want)
+	}
+}
+
+func TestSignerRotationUpdatesDeployedContract(t *testing.T) {
+	d := newDeploymentTest(t)
+	oldSigner := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	newSigner := common.HexToAddress("0x00000000000000000000000000000000000000b2")
+	c := d.collection(t)
+
+	s := d.service(t, oldSigner)
+	if _, err := s.Publish(context.Background(), d.creator, c.ID, false); err != nil {
+		t.Fatal(err)
+	}
+	d.backend.Commit()
+	d.track(t, s)
+
+	rotated := d.service(t, newSigner)
+	d.track(t, rotated)
+	if d.stored(c.ID).SignerTxHash == "" {
+		t.Fatal("setSigner was not sent")
+	}
+	d.backend.Commit()
+	d.track(t, rotated)
+
+	got := d.stored(c.ID)
+	if got.SignerAddress == nil || *got.SignerAddress != newSigner.Hex() || got.PendingSignerAddress != nil {
+		t.Fatalf("signer was not moved: %+v", got)
+	}
+	if signer := d.signerOf(t, *got.ContractAddress); signer != newSigner {
+		t.Fatalf("contract signer = %s, want %s", signer.Hex(), newSigner.Hex())
+	}
+	if !slices.Contains(d.bus.events, busInterfaces.EventCollectionSignerUpdated) {
+		t.Fatalf("events = %v, want %s", d.bus.events, busInterfaces.EventCollectionSignerUpdated)
+	}
+}
+
+func TestFailingSetSignerBacksOffThenGivesUp(t *testing.T) {
+	other, err := signerAdapters.GenerateMemorySigner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := newDeploymentTest(t, other.Address())
+	oldSigner := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+
+	// a contract the deployer does not own rejects its setSigner
+	opts := &bind.TransactOpts{
+		From: other.Address(),
+		Signer: func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return other.SignTx(context.Background(), tx, big.NewInt(d.network.ChainID))
+		},
+	}
+	address, _, _, err := contracts.DeployLazyMint1155(opts, d.backend.Client(), oldSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.backend.Commit()
+	c := d.collection(t)
+	contract, signer := address.Hex(), oldSigner.Hex()
+	stored := d.stored(c.ID)
+	stored.DeploymentStatus = models.DeploymentStatusDeployed
+	stored.ContractAddress = &contract
+	stored.SignerAddress = &signer
+
+	s := d.service(t, common.HexToAddress("0x00000000000000000000000000000000000000b2"))
+	d.track(t, s)
+	got := d.stored(c.ID)
+	if got.SignerAttempts != 1 || got.SignerRetryAt == nil || time.Until(*got.SignerRetryAt) < signerRetryBackoff/2 {
+		t.Fatalf("failed setSigner did not back off: %+v", got)
+	}
+	d.track(t, s) // still backing off
+	if got := d.stored(c.ID); got.SignerAttempts != 1 {
+		t.Fatalf("setSigner retried before its backoff: %d attempts", got.SignerAttempts)
+	}
+
+	for i := 2; i <= maxSignerAttempts; i++ {
+		past := time.Now().Add(-time.Second)
+		d.stored(c.ID).SignerRetryAt = &past
+		d.track(t, s)
+	}
+	got = d.stored(c.ID)
+	if got.SignerAttempts != maxSignerAttempts || got.SignerFailedAt == nil || got.SignerError == "" {
+		t.Fatalf("setSigner was not given up: %+v", got)
+	}
+	d.track(t, s)
+	if got := d.stored(c.ID); got.SignerAttempts != maxSignerAttempts {
+		t.Fatalf("setSigner retried after it was given up: %d attempts", got.SignerAttempts)
+	}
+
+	// the next rotation tries again
+	d.track(t, d.service(t, common.HexToAddress("0x00000000000000000000000000000000000000c3")))
+	if got := d.stored(c.ID); got.SignerAttempts != 1 || got.SignerFailedAt != nil {
+		t.Fatalf("a new rotation did not start over: %+v", got)
+	}
+}
@@ -0,0 +1,459 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	busInterfaces "github.com/igwedaniel/artizan/internal/interfaces/eventbus"
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+const (
+	dropSchedulerInterval = 10 * time.Second
+	dropEventLock         = time.Minute // how long a deliverer holds a claimed event
+)
+
+// dropTransitions lists the statuses each status may move to
+var dropTransitions = map[string][]string{
+	models.DropStatusDraft:     {models.DropStatusScheduled, models.DropStatusArchived},
+	models.DropStatusScheduled: {models.DropStatusLive},
+	models.DropStatusLive:      {models.DropStatusSoldOut, models.DropStatusEnded},
+	models.DropStatusSoldOut:   {models.DropStatusArchived},
+	models.DropStatusEnded:     {models.DropStatusArchived},
+}
+
+// dropStageEvents names the event announcing each stage that has one
+var dropStageEvents = map[string]string{
+	models.DropStatusScheduled: models.DropEventScheduled,
+	models.DropStatusLive:      models.DropEventStarted,
+	models.DropStatusSoldOut:   models.DropEventEnded,
+	models.DropStatusEnded:     models.DropEventEnded,
+}
+
+// public drop statuses, in lifecycle order
+var listedDropStatuses = []string{
+	models.DropStatusScheduled, models.DropStatusLive, models.DropStatusSoldOut, models.DropStatusEnded,
+}
+
+// DropService manages the drops of a collection. Creators edit drafts and
+// schedule them; Run starts scheduled drops at their start time, ends them
+// at their end time or once sold out, and publishes drop.scheduled,
+// drop.started and drop.ended. Each event goes into an outbox in the same
+// transaction as the stage it announces and leaves it only once its handlers
+// have run, so an event is never lost to a restart; one whose delivery was
+// cut short is delivered again.
+type DropService struct {
+	dropRepo       repoInterfaces.DropRepository
+	collectionRepo repoInterfaces.CollectionRepository
+	eventBus       busInterfaces.EventBus
+}
+
+// NewDropService creates a new DropService instance
+func NewDropService(
+	dropRepo repoInterfaces.DropRepository,
+	collectionRepo repoInterfaces.CollectionRepository,
+	eventBus busInterfaces.EventBus,
+) *DropService {
+	return &DropService{
+		dropRepo:       dropRepo,
+		collectionRepo: collectionRepo,
+		eventBus:       eventBus,
+	}
+}
+
+// Create adds a draft drop to a collection owned by actor
+func (s *DropService) Create(actor *models.User, collectionID uint, input CreateDropInput) (*models.Drop, error) {
+	collection, err := s.ownedCollection(actor, collectionID)
+	if err != nil {
+		return nil, err
+	}
+	drop := &models.Drop{
+		CollectionID: collection.ID,
+		DropType:     strings.ToLower(strings.TrimSpace(input.DropType)),
+		StartTime:    input.StartTime,
+		EndTime:      input.EndTime,
+		Price:        input.Price,
+		Supply:       input.Supply,
+		MaxPerWallet: input.MaxPerWallet,
+		Status:       models.DropStatusDraft,
+	}
+	if drop.DropType == "" {
+		drop.DropType = models.DropTypePublic
+	}
+	if err := validateDrop(drop); err != nil {
+		return nil, err
+	}
+	if err := s.dropRepo.Create(drop); err != nil {
+		return nil, fmt.Errorf("failed to create drop: %w", err)
+	}
+	drop.Collection = collection
+	return drop, nil
+}
+
+// Get returns a drop of a collection; drafts are only visible to their creator
+func (s *DropService) Get(collectionID, dropID uint) (*models.Drop, error) {
+	drop, err := s.get(collectionID, dropID)
+	if err != nil {
+		return nil, err
+	}
+	if drop.Status == models.DropStatusDraft {
+		return nil, ErrDropNotFound
+	}
+	return drop, nil
+}
+
+// List returns the scheduled, live and finished drops of a collection
+func (s *DropService) List(collectionID uint) ([]*models.Drop, error) {
+	if _, err := s.collection(collectionID); err != nil {
+		return nil, err
+	}
+	return s.dropRepo.ListByCollection(collectionID, listedDropStatuses...)
+}
+
+// ListDrafts returns the draft drops of a collection owned by actor
+func (s *DropService) ListDrafts(actor *models.User, collectionID uint) ([]*models.Drop, error) {
+	if _, err := s.ownedCollection(actor, collectionID); err != nil {
+		return nil, err
+	}
+	return s.dropRepo.ListByCollection(collectionID, models.DropStatusDraft)
+}
+
+// Update changes the given fields of a draft drop owned by actor
+func (s *DropService) Update(actor *models.User, collectionID, dropID uint, input UpdateDropInput) (*models.Drop, error) {
+	drop, err := s.getOwned(actor, collectionID, dropID)
+	if err != nil {
+		return nil, err
+	}
+	if drop.Status != models.DropStatusDraft {
+		return nil, ErrDropNotEditable
+	}
+	if input.DropType != nil {
+		drop.DropType = strings.ToLower(strings.TrimSpace(*input.DropType))
+	}
+	if input.StartTime != nil {
+		drop.StartTime = *input.StartTime
+	}
+	if input.EndTime != nil {
+		drop.EndTime = input.EndTime
+		if *input.EndTime == 0 {
+			drop.EndTime = nil
+		}
+	}
+	if input.Price != nil {
+		drop.Price = *input.Price
+	}
+	if input.Supply != nil {
+		drop.Supply = *input.Supply
+	}
+	if input.MaxPerWallet != nil {
+		drop.MaxPerWallet = *input.MaxPerWallet
+	}
+	if err := validateDrop(drop); err != nil {
+		return nil, err
+	}
+	if err := s.dropRepo.Update(drop); err != nil {
+		return nil, fmt.Errorf("failed to update drop: %w", err)
+	}
+	return drop, nil
+}
+
+// Schedule locks in a draft drop owned by actor; it goes live at its start time
+func (s *DropService) Schedule(actor *models.User, collectionID, dropID uint) (*models.Drop, error) {
+	drop, err := s.getOwned(actor, collectionID, dropID)
+	if err != nil {
+		return nil, err
+	}
+	if drop.Collection.ArchivedAt != nil {
+		return nil, ErrCollectionArchived
+	}
+	if err := validateDrop(drop); err != nil {
+		return nil, err
+	}
+	if drop.StartTime <= time.Now().Unix() {
+		return nil, ErrDropStartPassed
+	}
+	return s.userTransition(drop, models.DropStatusScheduled)
+}
+
+// End stops a live drop owned by actor before its end time
+func (s *DropService) End(actor *models.User, collectionID, dropID uint) (*models.Drop, error) {
+	drop, err := s.getOwned(actor, collectionID, dropID)
+	if err != nil {
+		return nil, err
+	}
+	return s.userTransition(drop, models.DropStatusEnded)
+}
+
+// Archive retires a draft or finished drop owned by actor
+func (s *DropService) Archive(actor *models.User, collectionID, dropID uint) (*models.Drop, error) {
+	drop, err := s.getOwned(actor, collectionID, dropID)
+	if err != nil {
+		return nil, err
+	}
+	if drop.Status == models.DropStatusArchived {
+		return drop, nil
+	}
+	return s.userTransition(drop, models.DropStatusArchived)
+}
+
+// Subscribe asks for an email reminder when a scheduled drop starts
+func (s *DropService) Subscribe(actor *models.User, collectionID, dropID uint) error {
+	drop, err := s.Get(collectionID, dropID)
+	if err != nil {
+		return err
+	}
+	if drop.Status != models.DropStatusScheduled {
+		return ErrDropNotUpcoming
+	}
+	return s.dropRepo.Subscribe(drop.ID, actor.ID)
+}
+
+// Unsubscribe cancels the reminder of a drop
+func (s *DropService) Unsubscribe(actor *models.User, collectionID, dropID uint) error {
+	drop, err := s.Get(collectionID, dropID)
+	if err != nil {
+		return err
+	}
+	return s.dropRepo.Unsubscribe(drop.ID, actor.ID)
+}
+
+// Run advances drops and publishes their events until ctx is cancelled
+func (s *DropService) Run(ctx context.Context) {
+	ticker := time.NewTicker(dropSchedulerInterval)
+	defer ticker.Stop()
+	for {
+		if err := s.Advance(time.Now()); err != nil {
+			log.Printf("failed to advance drops: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Advance starts the scheduled drops due at now, ends the live ones past
+// their end time or sold out, then delivers every event in the outbox,
+// including those a crashed run left behind
+func (s *DropService) Advance(now time.Time) error {
+	due, err := s.dropRepo.ListDue(now)
+	if err != nil {
+		return err
+	}
+	for _, drop := range due {
+		if drop.Status == models.DropStatusScheduled {
+			if err := s.transition(drop, models.DropStatusLive, now); err != nil {
+				log.Printf("failed to start drop %d: %v", drop.ID, err)
+				continue
+			}
+		}
+		// a drop whose whole window passed while the scheduler was down
+		// starts and ends in the same run
+		if drop.EndTime != nil && *drop.EndTime <= now.Unix() {
+			if err := s.transition(drop, models.DropStatusEnded, now); err != nil {
+				log.Printf("failed to end drop %d: %v", drop.ID, err)
+			}
+		}
+	}
+
+	soldOut, err := s.dropRepo.ListSoldOut()
+	if err != nil {
+		return err
+	}
+	for _, drop := range soldOut {
+		if err := s.transition(drop, models.DropStatusSoldOut, now); err != nil {
+			log.Printf("failed to mark drop %d sold out: %v", drop.ID, err)
+		}
+	}
+
+	return s.deliverEvents(now)
+}
+
+// userTransition moves a drop on behalf of its creator and delivers the
+// event of the new stage straight away rather than on the next run
+func (s *DropService) userTransition(drop *models.Drop, to string) (*models.Drop, error) {
+	now := time.Now()
+	if err := s.transition(drop, to, now); err != nil {
+		return nil, err
+	}
+	if err := s.deliverEvents(now); err != nil {
+		log.Printf("failed to deliver drop events: %v", err)
+	}
+	return drop, nil
+}
+
+// transition moves drop to status to if the lifecycle allows it and nobody
+// moved it first, stamping the time the new stage began and queueing its
+// event
+func (s *DropService) transition(drop *models.Drop, to string, now time.Time) error {
+	from := drop.Status
+	if !slices.Contains(dropTransitions[from], to) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidDropTransition, from, to)
+	}
+	drop.Status = to
+	switch to {
+	case models.DropStatusScheduled:
+		drop.ScheduledAt = &now
+	case models.DropStatusLive:
+		drop.StartedAt = &now
+	case models.DropStatusSoldOut, models.DropStatusEnded:
+		drop.EndedAt = &now
+	case models.DropStatusArchived:
+		drop.ArchivedAt = &now
+	}
+	var event *models.DropEvent
+	if name, ok := dropStageEvents[to]; ok {
+		event = &models.DropEvent{Event: name, Status: to}
+	}
+	moved, err := s.dropRepo.Transition(drop, from, event)
+	if err != nil {
+		return fmt.Errorf("failed to move drop to %s: %w", to, err)
+	}
+	if !moved {
+		return fmt.Errorf("%w: %s to %s, the drop changed meanwhile", ErrInvalidDropTransition, from, to)
+	}
+	return nil
+}
+
+// deliverEvents hands the events in the outbox to their handlers, oldest
+// first, and marks each delivered once every handler succeeded. An event
+// that failed, or whose deliverer stopped before marking it, is claimed
+// again when its lock runs out, so handlers may see an event twice but
+// never miss one; they key their side effects on the event ID.
+func (s *DropService) deliverEvents(now time.Time) error {
+	for {
+		event, err := s.dropRepo.ClaimNextEvent(now, now.Add(dropEventLock))
+		if err != nil || event == nil {
+			return err
+		}
+		if err := s.deliver(event); err != nil {
+			// stays locked, so it is retried once the lock runs out
+			log.Printf("failed to deliver the %s event of drop %d: %v", event.Event, event.DropID, err)
+			continue
+		}
+		if err := s.dropRepo.MarkEventDelivered(event.ID, now); err != nil {
+			return err
+		}
+	}
+}
+
+// deliver publishes event to its handlers and returns their failures
+func (s *DropService) deliver(event *models.DropEvent) error {
+	drop, err := s.dropRepo.GetByID(event.DropID)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil // deleted since; nobody is left to tell
+		}
+		return err
+	}
+	creatorID := drop.Collection.CreatorID
+
+	switch event.Event {
+	case models.DropEventScheduled:
+		return s.eventBus.PublishAndWait(busInterfaces.EventDropScheduled, busInterfaces.DropScheduledEvent{
+			EventID:      event.ID,
+			DropID:       drop.ID,
+			CollectionID: drop.CollectionID,
+			CreatorID:    creatorID,
+			StartTime:    drop.StartTime,
+			EndTime:      drop.EndTime,
+		})
+	case models.DropEventStarted:
+		return s.eventBus.PublishAndWait(busInterfaces.EventDropStarted, busInterfaces.DropStartedEvent{
+			EventID:      event.ID,
+			DropID:       drop.ID,
+			CollectionID: drop.CollectionID,
+			CreatorID:    creatorID,
+		})
+	case models.DropEventEnded:
+		return s.eventBus.PublishAndWait(busInterfaces.EventDropEnded, busInterfaces.DropEndedEvent{
+			EventID:      event.ID,
+			DropID:       drop.ID,
+			CollectionID: drop.CollectionID,
+			CreatorID:    creatorID,
+			Status:       event.Status,
+		})
+	}
+	return fmt.Errorf("unknown drop event %q", event.Event)
+}
+
+func (s *DropService) get(collectionID, dropID uint) (*models.Drop, error) {
+	drop, err := s.dropRepo.GetByID(dropID)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrDropNotFound
+		}
+		return nil, err
+	}
+	if drop.CollectionID != collectionID {
+		return nil, ErrDropNotFound
+	}
+	return drop, nil
+}
+
+func (s *DropService) getOwned(actor *models.User, collectionID, dropID uint) (*models.Drop, error) {
+	drop, err := s.get(collectionID, dropID)
+	if err != nil {
+		return nil, err
+	}
+	if drop.Collection == nil || drop.Collection.CreatorID != actor.ID {
+		return nil, ErrNotCollectionOwner
+	}
+	return drop, nil
+}
+
+func (s *DropService) collection(id uint) (*models.Collection, error) {
+	collection, err := s.collectionRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrCollectionNotFound
+		}
+		return nil, err
+	}
+	return collection, nil
+}
+
+func (s *DropService) ownedCollection(actor *models.User, id uint) (*models.Collection, error) {
+	collection, err := s.collection(id)
+	if err != nil {
+		return nil, err
+	}
+	if collection.CreatorID != actor.ID {
+		return nil, ErrNotCollectionOwner
+	}
+	if collection.ArchivedAt != nil {
+		return nil, ErrCollectionArchived
+	}
+	return collection, nil
+}
+
+func validateDrop(drop *models.Drop) error {
+	if drop.DropType != models.DropTypePublic && drop.DropType != models.DropTypePrivate {
+		return ErrInvalidDropType
+	}
+	if drop.Price < 0 {
+		return ErrInvalidDropPrice
+	}
+	// priced copies are sold through Seaport listings, which the allowlist
+	// cannot gate
+	if drop.DropType == models.DropTypePrivate && drop.Price > 0 {
+		return ErrPrivateDropPriced
+	}
+	if drop.Supply <= 0 {
+		return ErrInvalidDropSupply
+	}
+	if drop.MaxPerWallet < 0 {
+		return ErrInvalidDropWalletCap
+	}
+	if drop.StartTime < 0 || (drop.EndTime != nil && *drop.EndTime <= drop.StartTime) {
+		return ErrInvalidDropTimes
+	}
+	return nil
+}
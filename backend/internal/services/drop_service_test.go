@@ -0,0 +1,221 @@
+package services
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+// memoryDropRepo keeps the outbox next to the drops like the Postgres
+// repository, writing an event only when its transition succeeds
+type memoryDropRepo struct {
+	repoInterfaces.DropRepository
+	drops   map[uint]*models.Drop
+	events  []*models.DropEvent
+	markErr error
+}
+
+func (r *memoryDropRepo) Create(drop *models.Drop) error {
+	drop.ID = uint(len(r.drops) + 1)
+	copied := *drop
+	r.drops[drop.ID] = &copied
+	return nil
+}
+
+func (r *memoryDropRepo) Update(drop *models.Drop) error {
+	copied := *drop
+	r.drops[drop.ID] = &copied
+	return nil
+}
+
+func (r *memoryDropRepo) GetByID(id uint) (*models.Drop, error) {
+	drop, ok := r.drops[id]
+	if !ok {
+		return nil, repoInterfaces.ErrRecordNotFound
+	}
+	copied := *drop
+	return &copied, nil
+}
+
+func (r *memoryDropRepo) Transition(drop *models.Drop, from string, event *models.DropEvent) (bool, error) {
+	if r.drops[drop.ID].Status != from {
+		return false, nil
+	}
+	copied := *drop
+	r.drops[drop.ID] = &copied
+	if event != nil {
+		event.DropID = drop.ID
+		event.ID = uint(len(r.events) + 1)
+		r.events = append(r.events, event)
+	}
+	return true, nil
+}
+
+func (r *memoryDropRepo) ListDue(now time.Time) ([]*models.Drop, error) {
+	var due []*models.Drop
+	for id, drop := range r.drops {
+		if (drop.Status == models.DropStatusScheduled && drop.StartTime <= now.Unix()) ||
+			(drop.Status == models.DropStatusLive && drop.EndTime != nil && *drop.EndTime <= now.Unix()) {
+			copied, _ := r.GetByID(id)
+			due = append(due, copied)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryDropRepo) ListSoldOut() ([]*models.Drop, error) { return nil, nil }
+
+func (r *memoryDropRepo) ClaimNextEvent(now, lockUntil time.Time) (*models.DropEvent, error) {
+	for _, event := range r.events {
+		if event.DeliveredAt == nil && (event.LockedUntil == nil || !event.LockedUntil.After(now)) {
+			event.Attempts++
+			event.LockedUntil = &lockUntil
+			return event, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryDropRepo) MarkEventDelivered(id uint, at time.Time) error {
+	if r.markErr != nil {
+		return r.markErr
+	}
+	r.events[id-1].DeliveredAt = &at
+	return nil
+}
+
+func newDropTest(status string, startTime int64) (*DropService, *memoryDropRepo, *recordingBus) {
+	collection := &models.Collection{CreatorID: 1}
+	collection.ID = 1
+	drop := &models.Drop{CollectionID: 1, Collection: collection, DropType: models.DropTypePublic, StartTime: startTime, Supply: 10, Status: status}
+	drop.ID = 1
+	repo := &memoryDropRepo{drops: map[uint]*models.Drop{1: drop}}
+	bus := &recordingBus{}
+	return NewDropService(repo, nil, bus), repo, bus
+}
+
+func TestDropEventIsRedeliveredWhenDeliveryIsCutShort(t *testing.T) {
+	now := time.Now()
+	s, repo, bus := newDropTest(models.DropStatusScheduled, now.Unix()-1)
+
+	// the handlers ran but the process lost the database before recording it
+	repo.markErr = errors.New("connection lost")
+	if err := s.Advance(now); err == nil {
+		t.Fatal("Advance hid the failure to mark the event delivered")
+	}
+	if !slices.Equal(bus.events, []string{"drop.started"}) {
+		t.Fatalf("events = %v, want one drop.started", bus.events)
+	}
+
+	// nobody else picks the event up while its deliverer holds it
+	repo.markErr = nil
+	if err := s.Advance(now.Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if len(bus.events) != 1 {
+		t.Fatalf("events = %v, want the claimed event left alone", bus.events)
+	}
+
+	later := now.Add(dropEventLock)
+	if err := s.Advance(later); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(bus.events, []string{"drop.started", "drop.started"}) {
+		t.Fatalf("events = %v, want drop.started delivered again", bus.events)
+	}
+	if event := repo.events[0]; event.DeliveredAt == nil || event.Attempts != 2 {
+		t.Fatalf("event delivered at %v after %d attempts, want delivered after 2", event.DeliveredAt, event.Attempts)
+	}
+	if err := s.Advance(later.Add(dropEventLock)); err != nil {
+		t.Fatal(err)
+	}
+	if len(bus.events) != 2 {
+		t.Fatalf("events = %v, a delivered event was published again", bus.events)
+	}
+}
+
+func TestDropEventIsOnlyQueuedWithItsStage(t *testing.T) {
+	now := time.Now()
+	s, repo, bus := newDropTest(models.DropStatusLive, now.Unix()-1)
+
+	// a stale copy loses the race, so the stage and its event are not stored
+	stale, _ := repo.GetByID(1)
+	stale.Status = models.DropStatusScheduled
+	if err := s.transition(stale, models.DropStatusLive, now); !errors.Is(err, ErrInvalidDropTransition) {
+		t.Fatalf("err = %v, want %v", err, ErrInvalidDropTransition)
+	}
+	if len(repo.events) != 0 {
+		t.Fatalf("%d events queued for a transition that did not happen", len(repo.events))
+	}
+
+	creator := testUser(1, models.RoleCreator)
+	if _, err := s.End(creator, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(bus.events, []string{"drop.ended"}) || repo.events[0].DeliveredAt == nil {
+		t.Fatalf("events = %v, want drop.ended delivered straight away", bus.events)
+	}
+	if repo.events[0].Status != models.DropStatusEnded {
+		t.Fatalf("event status = %s, want %s", repo.events[0].Status, models.DropStatusEnded)
+	}
+}
+
+func TestDropEventIsKeptUntilEveryHandlerSucceeds(t *testing.T) {
+	now := time.Now()
+	s, repo, bus := newDropTest(models.DropStatusScheduled, now.Unix()-1)
+
+	bus.handlerErr = errors.New("mail queue is down")
+	if err := s.Advance(now); err != nil {
+		t.Fatal(err)
+	}
+	if event := repo.events[0]; event.DeliveredAt != nil {
+		t.Fatal("an event whose handler failed was marked delivered")
+	}
+
+	bus.handlerErr = nil
+	if err := s.Advance(now.Add(dropEventLock)); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(bus.events, []string{"drop.started", "drop.started"}) {
+		t.Fatalf("events = %v, want drop.started delivered again", bus.events)
+	}
+	if event := repo.events[0]; event.DeliveredAt == nil || event.Attempts != 2 {
+		t.Fatalf("event delivered at %v after %d attempts, want delivered after 2", event.DeliveredAt, event.Attempts)
+	}
+}
+
+// Priced copies are bought through Seaport listings that any wallet can
+// fill, so a private drop's allowlist could not hold them back.
+func TestPrivateDropsMustBeFree(t *testing.T) {
+	creator := testUser(1, models.RoleCreator)
+	collection := &models.Collection{CreatorID: creator.ID}
+	collection.ID = 1
+	drops := &memoryDropRepo{drops: map[uint]*models.Drop{}}
+	s := NewDropService(drops, &memoryCollectionRepo{collections: map[uint]*models.Collection{1: collection}}, &recordingBus{})
+	start := time.Now().Add(time.Hour).Unix()
+
+	if _, err := s.Create(creator, 1, CreateDropInput{DropType: models.DropTypePrivate, StartTime: start, Price: 100, Supply: 10}); !errors.Is(err, ErrPrivateDropPriced) {
+		t.Fatalf("err = %v, want %v", err, ErrPrivateDropPriced)
+	}
+	if _, err := s.Create(creator, 1, CreateDropInput{DropType: models.DropTypePublic, StartTime: start, Price: 100, Supply: 10}); err != nil {
+		t.Fatalf("public priced drop: %v", err)
+	}
+	private, err := s.Create(creator, 1, CreateDropInput{DropType: models.DropTypePrivate, StartTime: start, Supply: 10})
+	if err != nil {
+		t.Fatalf("private free drop: %v", err)
+	}
+	drops.drops[private.ID].Collection = collection // loaded with the drop, as Postgres does
+
+	price := int64(100)
+	if _, err := s.Update(creator, 1, private.ID, UpdateDropInput{Price: &price}); !errors.Is(err, ErrPrivateDropPriced) {
+		t.Fatalf("pricing a private drop: err = %v, want %v", err, ErrPrivateDropPriced)
+	}
+	public := models.DropTypePublic
+	if _, err := s.Update(creator, 1, private.ID, UpdateDropInput{DropType: &public, Price: &price}); err != nil {
+		t.Fatalf("pricing a drop made public: %v", err)
+	}
+}
@@ -0,0 +1,23 @@
+package services
+
+type CreateDropInput struct {
+	DropType  string `json:"drop_type"`
+	StartTime int64  `json:"start_time"` // unix seconds
+	EndTime   *int64 `json:"end_time"`   // unix seconds; nil runs until sold out
+	Price     int64  `json:"price"`
+	Supply    int64  `json:"supply"`
+	// copies one wallet may claim; 0 is unlimited
+	MaxPerWallet int64 `json:"max_per_wallet"`
+}
+
+// UpdateDropInput holds the fields to change; nil fields are left as is and
+// an end time of 0 removes it
+type UpdateDropInput struct {
+	DropType  *string `json:"drop_type"`
+	StartTime *int64  `json:"start_time"`
+	EndTime   *int64  `json:"end_time"`
+	Price     *int64  `json:"price"`
+	Supply    *int64  `json:"supply"`
+	// MaxPerWallet of 0 removes the limit
+	MaxPerWallet *int64 `json:"max_per_wallet"`
+}
@@ -0,0 +1,385 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	mailerInterfaces "github.com/igwedaniel/artizan/internal/interfaces/mailer"
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"github.com/igwedaniel/artizan/pkg/utils"
+)
+
+// constant prefixes for email token keys => prefix(constant) + secret(dynamic)
+
+const (
+	emailVerificationPrefix   = "email_verification_"
+	emailVerificationDuration = 24 * time.Hour
+	emailUnsubscribePrefix    = "email_unsubscribe_"
+	emailUnsubscribeDuration  = 5 * 365 * 24 * time.Hour
+)
+
+const (
+	maxEmailAttempts    = 6
+	emailRetryBaseDelay = 30 * time.Second
+	emailRetryMaxDelay  = time.Hour
+	emailPollInterval   = 10 * time.Second
+	emailBatchSize      = 50
+)
+
+// SaleReceipt holds the fields shown in a sale receipt email
+type SaleReceipt struct {
+	SaleID   uint
+	NFTID    uint
+	Price    string
+	Currency string
+}
+
+type EmailService struct {
+	emailRepo  repoInterfaces.EmailRepository
+	userRepo   repoInterfaces.UserRepository
+	followRepo repoInterfaces.FollowRepository
+	dropRepo   repoInterfaces.DropRepository
+	mailer     mailerInterfaces.Mailer
+	templates  map[string]*emailTemplate
+	secret     string
+	baseURL    string
+}
+
+// NewEmailService creates a new EmailService instance
+func NewEmailService(
+	secret, baseURL string,
+	emailRepo repoInterfaces.EmailRepository,
+	userRepo repoInterfaces.UserRepository,
+	followRepo repoInterfaces.FollowRepository,
+	dropRepo repoInterfaces.DropRepository,
+	mailer mailerInterfaces.Mailer,
+) (*EmailService, error) {
+	templates, err := loadEmailTemplates()
+	if err != nil {
+		return nil, err
+	}
+	return &EmailService{
+		emailRepo:  emailRepo,
+		userRepo:   userRepo,
+		followRepo: followRepo,
+		dropRepo:   dropRepo,
+		mailer:     mailer,
+		templates:  templates,
+		secret:     secret,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+	}, nil
+}
+
+// RequestVerification sends a verification link to email. The address is only
+// stored on the user once the link is opened.
+func (s *EmailService) RequestVerification(user *models.User, email string) error {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return err
+	}
+	if user.Email != nil && strings.EqualFold(*user.Email, email) {
+		return ErrEmailAlreadyVerified
+	}
+	if err := s.ensureEmailAvailable(user.ID, email); err != nil {
+		return err
+	}
+
+	token, err := utils.IssueJWT(fmt.Sprintf("%d|%s", user.ID, email), emailVerificationDuration, []byte(s.secret+emailVerificationPrefix))
+	if err != nil {
+		return fmt.Errorf("failed to issue verification token: %w", err)
+	}
+	data := map[string]interface{}{
+		"Email":     email,
+		"VerifyURL": s.baseURL + "/email/verify?token=" + url.QueryEscape(token),
+		"ValidFor":  "24 hours",
+	}
+	return s.enqueue(&user.ID, email, models.EmailCategoryAccount, emailTemplateVerify, data, nil)
+}
+
+// VerifyEmail stores the address carried by a verification token on its user
+func (s *EmailService) VerifyEmail(token string) (*models.User, error) {
+	claims, err := utils.ParseJWT(token, []byte(s.secret+emailVerificationPrefix))
+	if err != nil || claims == nil {
+		return nil, ErrInvalidEmailToken
+	}
+	userID, email, ok := strings.Cut(claims.ID, "|")
+	if !ok {
+		return nil, ErrInvalidEmailToken
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrInvalidEmailToken
+		}
+		return nil, err
+	}
+	if user.Email != nil && strings.EqualFold(*user.Email, email) {
+		return user, nil
+	}
+	if err := s.ensureEmailAvailable(user.ID, email); err != nil {
+		return nil, err
+	}
+
+	previous := user.Email
+	now := time.Now()
+	if err := s.userRepo.UpdateUserByID(userID, &models.User{Email: &email, EmailVerifiedAt: &now}); err != nil {
+		return nil, fmt.Errorf("failed to update user email: %w", err)
+	}
+	user.Email = &email
+	user.EmailVerifiedAt = &now
+
+	if previous != nil {
+		detail := fmt.Sprintf("The email address on your Artizan account was changed to %s.", email)
+		if err := s.sendSecurityAlertTo(user.ID, *previous, "Your email address was changed", detail); err != nil {
+			log.Printf("failed to queue email change alert for user %d: %v", user.ID, err)
+		}
+	}
+	return user, nil
+}
+
+// Unsubscribe opts the user in an unsubscribe token out of its category
+func (s *EmailService) Unsubscribe(token string) (string, error) {
+	claims, err := utils.ParseJWT(token, []byte(s.secret+emailUnsubscribePrefix))
+	if err != nil || claims == nil {
+		return "", ErrInvalidEmailToken
+	}
+	rawID, category, ok := strings.Cut(claims.ID, "|")
+	if !ok {
+		return "", ErrInvalidEmailToken
+	}
+	userID, err := strconv.ParseUint(rawID, 10, 64)
+	if err != nil {
+		return "", ErrInvalidEmailToken
+	}
+	if err := s.emailRepo.Unsubscribe(uint(userID), category); err != nil {
+		return "", err
+	}
+	return category, nil
+}
+
+func (s *EmailService) SendSaleReceipt(userID uint, receipt SaleReceipt) error {
+	data := map[string]interface{}{
+		"SaleID":   receipt.SaleID,
+		"NFTID":    receipt.NFTID,
+		"Price":    receipt.Price,
+		"Currency": receipt.Currency,
+	}
+	return s.sendToUser(userID, models.EmailCategorySaleReceipts, emailTemplateSaleReceipt, data, nil)
+}
+
+// SendDropReminders reminds the users subscribed to a drop and the
+// followers of its creator that it started. The reminders are queued once
+// per dedupeKey, so a retry only queues those that failed.
+func (s *EmailService) SendDropReminders(creatorID, dropID uint, dedupeKey string) error {
+	subscribers, err := s.dropRepo.ListSubscriberIDs(dropID)
+	if err != nil {
+		return fmt.Errorf("failed to load drop subscribers: %w", err)
+	}
+	followers, err := s.followRepo.ListFollowerIDs(creatorID)
+	if err != nil {
+		return fmt.Errorf("failed to load followers: %w", err)
+	}
+	seen := map[uint]bool{creatorID: true}
+	var errs []error
+	for _, userID := range append(subscribers, followers...) {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		key := fmt.Sprintf("%s:%s:%d", dedupeKey, models.EmailCategoryDropReminders, userID)
+		if err := s.sendDropReminder(userID, dropID, &key); err != nil {
+			errs = append(errs, fmt.Errorf("user %d: %w", userID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *EmailService) SendDropReminder(userID, dropID uint) error {
+	return s.sendDropReminder(userID, dropID, nil)
+}
+
+func (s *EmailService) sendDropReminder(userID, dropID uint, dedupeKey *string) error {
+	data := map[string]interface{}{
+		"DropID":  dropID,
+		"DropURL": fmt.Sprintf("%s/drops/%d", s.baseURL, dropID),
+	}
+	return s.sendToUser(userID, models.EmailCategoryDropReminders, emailTemplateDropReminder, data, dedupeKey)
+}
+
+func (s *EmailService) SendSecurityAlert(userID uint, summary, detail string) error {
+	data := map[string]interface{}{
+		"Summary": summary,
+		"Detail":  detail,
+	}
+	return s.sendToUser(userID, models.EmailCategorySecurity, emailTemplateSecurityAlert, data, nil)
+}
+
+// Run delivers queued emails until ctx is cancelled, retrying failures with
+// exponential backoff.
+func (s *EmailService) Run(ctx context.Context) {
+	ticker := time.NewTicker(emailPollInterval)
+	defer ticker.Stop()
+	for {
+		s.deliverDue(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *EmailService) deliverDue(ctx context.Context) {
+	messages, err := s.emailRepo.ListDueMessages(time.Now(), emailBatchSize)
+	if err != nil {
+		log.Printf("failed to load queued emails: %v", err)
+		return
+	}
+	for _, m := range messages {
+		if ctx.Err() != nil {
+			return
+		}
+		s.deliver(ctx, m)
+	}
+}
+
+func (s *EmailService) deliver(ctx context.Context, m *models.EmailMessage) {
+	msg := &mailerInterfaces.Message{
+		To:      m.To,
+		Subject: m.Subject,
+		Text:    m.TextBody,
+		HTML:    m.HTMLBody,
+	}
+	if m.Unsubscribe != "" {
+		msg.Headers = map[string]string{
+			"List-Unsubscribe":      "<" + m.Unsubscribe + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		}
+	}
+
+	m.Attempts++
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		m.LastError = err.Error()
+		if m.Attempts >= maxEmailAttempts {
+			m.Status = models.EmailStatusFailed
+		} else {
+			m.NextAttemptAt = time.Now().Add(emailRetryDelay(m.Attempts))
+		}
+	} else {
+		now := time.Now()
+		m.Status = models.EmailStatusSent
+		m.SentAt = &now
+		m.LastError = ""
+	}
+	if err := s.emailRepo.UpdateMessage(m); err != nil {
+		log.Printf("failed to update email %d: %v", m.ID, err)
+	}
+}
+
+// emailRetryDelay doubles the delay after every failed attempt
+func emailRetryDelay(attempts int) time.Duration {
+	delay := emailRetryBaseDelay << (attempts - 1)
+	if delay <= 0 || delay > emailRetryMaxDelay {
+		return emailRetryMaxDelay
+	}
+	return delay
+}
+
+// sendToUser queues an email to the user's verified address, skipping users
+// without one
+func (s *EmailService) sendToUser(userID uint, category, templateName string, data map[string]interface{}, dedupeKey *string) error {
+	if userID == 0 {
+		return nil
+	}
+	user, err := s.userRepo.GetByID(strconv.FormatUint(uint64(userID), 10))
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	if user.Email == nil || user.EmailVerifiedAt == nil {
+		return nil
+	}
+	return s.enqueue(&user.ID, *user.Email, category, templateName, data, dedupeKey)
+}
+
+func (s *EmailService) sendSecurityAlertTo(userID uint, to, summary, detail string) error {
+	data := map[string]interface{}{
+		"Summary": summary,
+		"Detail":  detail,
+	}
+	return s.enqueue(&userID, to, models.EmailCategorySecurity, emailTemplateSecurityAlert, data, nil)
+}
+
+// enqueue renders an email into the outbox; a message whose dedupeKey was
+// queued before is skipped
+func (s *EmailService) enqueue(userID *uint, to, category, templateName string, data map[string]interface{}, dedupeKey *string) error {
+	if userID != nil && category != models.EmailCategoryAccount {
+		unsubscribed, err := s.emailRepo.IsUnsubscribed(*userID, category)
+		if err != nil {
+			return err
+		}
+		if unsubscribed {
+			return nil
+		}
+		token, err := utils.IssueJWT(fmt.Sprintf("%d|%s", *userID, category), emailUnsubscribeDuration, []byte(s.secret+emailUnsubscribePrefix))
+		if err != nil {
+			return fmt.Errorf("failed to issue unsubscribe token: %w", err)
+		}
+		data["UnsubscribeURL"] = s.baseURL + "/email/unsubscribe?token=" + url.QueryEscape(token)
+	}
+
+	rendered, err := s.templates[templateName].render(data)
+	if err != nil {
+		return fmt.Errorf("failed to render %s email: %w", templateName, err)
+	}
+	unsubscribeURL, _ := data["UnsubscribeURL"].(string)
+	err = s.emailRepo.CreateMessage(&models.EmailMessage{
+		UserID:        userID,
+		To:            to,
+		Category:      category,
+		Subject:       rendered.Subject,
+		TextBody:      rendered.Text,
+		HTMLBody:      rendered.HTML,
+		Unsubscribe:   unsubscribeURL,
+		Status:        models.EmailStatusPending,
+		NextAttemptAt: time.Now(),
+		DedupeKey:     dedupeKey,
+	})
+	if errors.Is(err, repoInterfaces.ErrDuplicateRecord) {
+		return nil
+	}
+	return err
+}
+
+func (s *EmailService) ensureEmailAvailable(userID uint, email string) error {
+	owner, err := s.userRepo.GetUserByEmail(email)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if owner.ID != userID {
+		return ErrEmailTaken
+	}
+	return nil
+}
+
+func normalizeEmail(email string) (string, error) {
+	addr, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil || addr.Name != "" {
+		return "", ErrInvalidEmail
+	}
+	return strings.ToLower(addr.Address), nil
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/igwedaniel/artizan/internal/adapters/mailer"
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+type memoryEmailRepo struct {
+	messages     []*models.EmailMessage
+	unsubscribed map[uint]string
+}
+
+// CreateMessage enforces the unique dedupe key like Postgres does
+func (r *memoryEmailRepo) CreateMessage(m *models.EmailMessage) error {
+	for _, queued := range r.messages {
+		if m.DedupeKey != nil && queued.DedupeKey != nil && *m.DedupeKey == *queued.DedupeKey {
+			return repoInterfaces.ErrDuplicateRecord
+		}
+	}
+	m.ID = uint(len(r.messages) + 1)
+	r.messages = append(r.messages, m)
+	return nil
+}
+
+func (r *memoryEmailRepo) UpdateMessage(*models.EmailMessage) error { return nil }
+
+func (r *memoryEmailRepo) ListDueMessages(now time.Time, limit int) ([]*models.EmailMessage, error) {
+	var due []*models.EmailMessage
+	for _, m := range r.messages {
+		if m.Status == models.EmailStatusPending && !m.NextAttemptAt.After(now) {
+			due = append(due, m)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryEmailRepo) IsUnsubscribed(userID uint, category string) (bool, error) {
+	return r.unsubscribed[userID] == category, nil
+}
+
+func (r *memoryEmailRepo) Unsubscribe(userID uint, category string) error {
+	r.unsubscribed[userID] = category
+	return nil
+}
+
+type memoryUserRepo struct {
+	repoInterfaces.UserRepository
+	users map[uint]*models.User
+}
+
+func (r *memoryUserRepo) GetByID(id string) (*models.User, error) {
+	n, _ := strconv.ParseUint(id, 10, 64)
+	if user, ok := r.users[uint(n)]; ok {
+		return user, nil
+	}
+	return nil, repoInterfaces.ErrRecordNotFound
+}
+
+type memoryFollowRepo struct {
+	repoInterfaces.FollowRepository
+	followers map[uint][]uint
+}
+
+func (r *memoryFollowRepo) ListFollowerIDs(followeeID uint) ([]uint, error) {
+	return r.followers[followeeID], nil
+}
+
+type subscribedDropRepo struct {
+	repoInterfaces.DropRepository
+	subscribers map[uint][]uint
+}
+
+func (r *subscribedDropRepo) ListSubscriberIDs(dropID uint) ([]uint, error) {
+	return r.subscribers[dropID], nil
+}
+
+func verifiedUser(id uint, email string) *models.User {
+	user := &models.User{Email: &email}
+	user.ID = id
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+	return user
+}
+
+// The drop reminder goes to the drop's subscribers and the creator's
+// followers, once each, skipping the creator, users without a verified
+// address and users who unsubscribed from drop reminders.
+func TestSendDropRemindersDeliversToSubscribersAndFollowers(t *testing.T) {
+	server, err := mailer.NewLocalSMTPServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	smtpMailer, err := mailer.NewSMTPMailer(server.Host(), server.Port(), "", "", "noreply@artizan.test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const creatorID, dropID = 1, 9
+	unverified := &models.User{}
+	unverified.ID = 5
+	users := &memoryUserRepo{users: map[uint]*models.User{
+		1: verifiedUser(1, "creator@example.com"),
+		2: verifiedUser(2, "follower@example.com"),
+		3: verifiedUser(3, "subscriber@example.com"),
+		4: verifiedUser(4, "both@example.com"),
+		5: unverified,
+		6: verifiedUser(6, "optout@example.com"),
+	}}
+	emails := &memoryEmailRepo{unsubscribed: map[uint]string{6: models.EmailCategoryDropReminders}}
+	follows := &memoryFollowRepo{followers: map[uint][]uint{creatorID: {2, 4, 5, 6}}}
+	drops := &subscribedDropRepo{subscribers: map[uint][]uint{dropID: {3, 4, creatorID}}}
+
+	s, err := NewEmailService("secret", "https://artizan.test", emails, users, follows, drops, smtpMailer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a drop event delivered twice queues each reminder once
+	for range 2 {
+		if err := s.SendDropReminders(creatorID, dropID, "drop_event:1"); err != nil {
+			t.Fatalf("SendDropReminders: %v", err)
+		}
+	}
+	s.deliverDue(context.Background())
+
+	var recipients []string
+	for _, m := range server.Messages() {
+		recipients = append(recipients, m.To...)
+	}
+	slices.Sort(recipients)
+	want := []string{"both@example.com", "follower@example.com", "subscriber@example.com"}
+	if !slices.Equal(recipients, want) {
+		t.Fatalf("reminded %v, want %v", recipients, want)
+	}
+	for _, m := range emails.messages {
+		if m.Status != models.EmailStatusSent {
+			t.Errorf("email to %s is %s: %s", m.To, m.Status, m.LastError)
+		}
+		if m.Unsubscribe == "" {
+			t.Errorf("email to %s has no unsubscribe link", m.To)
+		}
+	}
+}
+
+// A relay that is down leaves the email queued for a later attempt.
+func TestDeliverRetriesWhenRelayIsDown(t *testing.T) {
+	server, err := mailer.NewLocalSMTPServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	host, port := server.Host(), server.Port()
+	server.Close()
+	smtpMailer, err := mailer.NewSMTPMailer(host, port, "", "", "noreply@artizan.test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	users := &memoryUserRepo{users: map[uint]*models.User{2: verifiedUser(2, "follower@example.com")}}
+	emails := &memoryEmailRepo{unsubscribed: map[uint]string{}}
+	s, err := NewEmailService("secret", "https://artizan.test", emails, users, &memoryFollowRepo{}, &subscribedDropRepo{}, smtpMailer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SendDropReminder(2, 9); err != nil {
+		t.Fatal(err)
+	}
+	s.deliverDue(context.Background())
+
+	m := emails.messages[0]
+	if m.Status != models.EmailStatusPending || m.Attempts != 1 || m.LastError == "" {
+		t.Fatalf("after a failed send the email is %s with %d attempts and error %q", m.Status, m.Attempts, m.LastError)
+	}
+	if !m.NextAttemptAt.After(time.Now()) {
+		t.Fatal("failed email was not scheduled for a later attempt")
+	}
+}
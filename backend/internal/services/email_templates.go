@@ -0,0 +1,67 @@
+package services
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmlTemplate "html/template"
+	"strings"
+	textTemplate "text/template"
+)
+
+//go:embed templates/email/*.tmpl
+var emailTemplateFS embed.FS
+
+const (
+	emailTemplateVerify        = "verify_email"
+	emailTemplateSaleReceipt   = "sale_receipt"
+	emailTemplateDropReminder  = "drop_reminder"
+	emailTemplateSecurityAlert = "security_alert"
+)
+
+type emailTemplate struct {
+	html *htmlTemplate.Template
+	text *textTemplate.Template
+}
+
+type renderedEmail struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// loadEmailTemplates parses the HTML and text variant of every email template
+func loadEmailTemplates() (map[string]*emailTemplate, error) {
+	names := []string{emailTemplateVerify, emailTemplateSaleReceipt, emailTemplateDropReminder, emailTemplateSecurityAlert}
+	templates := make(map[string]*emailTemplate, len(names))
+	for _, name := range names {
+		html, err := htmlTemplate.ParseFS(emailTemplateFS, "templates/email/layout.html.tmpl", "templates/email/"+name+".html.tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s html template: %w", name, err)
+		}
+		text, err := textTemplate.ParseFS(emailTemplateFS, "templates/email/layout.txt.tmpl", "templates/email/"+name+".txt.tmpl")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s text template: %w", name, err)
+		}
+		templates[name] = &emailTemplate{html: html, text: text}
+	}
+	return templates, nil
+}
+
+func (t *emailTemplate) render(data map[string]interface{}) (*renderedEmail, error) {
+	var subject, text, html bytes.Buffer
+	if err := t.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, err
+	}
+	if err := t.text.ExecuteTemplate(&text, "layout", data); err != nil {
+		return nil, err
+	}
+	if err := t.html.ExecuteTemplate(&html, "layout", data); err != nil {
+		return nil, err
+	}
+	return &renderedEmail{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(text.String()),
+		HTML:    html.String(),
+	}, nil
+}
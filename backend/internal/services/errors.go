@@ -3,5 +3,160 @@ package services
 import "errors"
 
 var (
-	ErrUserNotFound = errors.New("user not found")
+	ErrUserNotFound            = errors.New("user not found")
+	ErrUnknownNotificationType = errors.New("unknown notification type")
+	ErrInvalidEmail            = errors.New("invalid email address")
+	ErrEmailTaken              = errors.New("email address is already in use")
+	ErrEmailAlreadyVerified    = errors.New("email address is already verified")
+	ErrInvalidEmailToken       = errors.New("invalid or expired email token")
+	ErrCannotFollowSelf        = errors.New("you cannot follow yourself")
+	ErrUserIsAdmin             = errors.New("admins cannot be made creators")
+)
+
+var (
+	ErrCollectionNotFound       = errors.New("collection not found")
+	ErrNotCollectionOwner       = errors.New("only the collection creator can do this")
+	ErrCollectionNameTaken      = errors.New("you already have a collection with this name")
+	ErrInvalidCollectionName    = errors.New("collection name must be between 1 and 100 characters")
+	ErrNotCreator               = errors.New("only approved creators can create collections")
+	ErrCollectionArchived       = errors.New("collection is archived")
+	ErrUnsupportedChain         = errors.New("chain is not supported")
+	ErrCollectionChainImmutable = errors.New("chain cannot be changed once the collection has a contract")
+)
+
+var (
+	ErrCollectionAlreadyDeployed = errors.New("collection already has a contract or a deployment in progress")
+)
+
+var (
+	ErrNFTNotFound           = errors.New("nft not found")
+	ErrNFTAlreadyMinted      = errors.New("nft is already minted")
+	ErrCollectionNotDeployed = errors.New("collection contract is not deployed yet")
+	ErrInvalidVoucherAmount  = errors.New("voucher amount must be a positive integer")
+)
+
+var (
+	ErrVoucherNotFound       = errors.New("voucher not found")
+	ErrVoucherNotOutstanding = errors.New("voucher is not outstanding")
+	ErrNFTVoucherOutstanding = errors.New("nft already has an outstanding voucher for another owner")
+	ErrNFTVoucherRevoked     = errors.New("nft voucher was revoked; rotate the signer before issuing a new one")
+	ErrVoucherExceedsEdition = errors.New("voucher amount exceeds the nft edition size")
+	ErrDropNotLive           = errors.New("vouchers are only issued while the drop is live")
+	ErrDropPriced            = errors.New("copies of a priced drop are sold through listings")
+)
+
+var (
+	ErrInvalidSignerAddress     = errors.New("invalid signer address")
+	ErrCollectionSignerRotating = errors.New("collection signer is being rotated, try again shortly")
+)
+
+var (
+	ErrDropNotFound         = errors.New("drop not found")
+	ErrInvalidMetadataURI   = errors.New("metadata links must be absolute uris")
+	ErrInvalidNFTName       = errors.New("nft name is required and must be at most 200 characters")
+	ErrInvalidNFTAttributes = errors.New("every nft attribute needs a value")
+	ErrInvalidEditionSize   = errors.New("edition size must be at least 1")
+	ErrEditionSizeLocked    = errors.New("edition size cannot change once a voucher was issued or a copy minted")
+)
+
+var (
+	ErrAssetNotFound = errors.New("asset not found")
+	ErrEmptyAsset    = errors.New("asset is empty")
+	ErrAssetTooLarge = errors.New("asset is too large")
+)
+
+var (
+	ErrImportNotFound        = errors.New("import not found")
+	ErrImportTooLarge        = errors.New("import archive is too large")
+	ErrInvalidImportArchive  = errors.New("import archive is not a valid zip file")
+	ErrInvalidImportManifest = errors.New("import manifest must be a csv or json file")
+	ErrImportManifestMissing = errors.New("import needs a manifest.csv or manifest.json")
+	ErrImportTooManyRows     = errors.New("import manifest has too many rows")
+)
+
+var (
+	ErrInvalidHandle     = errors.New("handle must be a username or wallet address")
+	ErrIndexerNotStarted = errors.New("the indexer has not recorded a checkpoint yet")
+)
+
+var (
+	ErrCollectionHasNoZone    = errors.New("collection has no lazy mint zone to list through")
+	ErrInvalidListingPrice    = errors.New("listing price must be a positive amount in the currency's smallest unit")
+	ErrInvalidListingCurrency = errors.New("listing currency must be empty for the native coin or an erc20 address")
+	ErrInvalidListingQuantity = errors.New("listing quantity must be between 1 and the edition size")
+	ErrInvalidListingFees     = errors.New("listing fees need valid recipients and must leave the seller a share of every copy")
+	ErrInvalidListingExpiry   = errors.New("listing must expire in the future")
+	ErrListingNotApproved     = errors.New("seller has not approved seaport to transfer the collection's tokens")
+)
+
+var (
+	ErrOrderNotFound         = errors.New("order not found")
+	ErrInvalidOrder          = errors.New("order is malformed")
+	ErrUnsupportedOrder      = errors.New("order is not a supported listing")
+	ErrOrderTokenUnknown     = errors.New("order offers a token this marketplace did not create")
+	ErrOrderZoneMismatch     = errors.New("order zone is not the collection's lazy mint zone")
+	ErrOrderHashMismatch     = errors.New("order hash does not match the order components")
+	ErrInvalidOrderSignature = errors.New("order signature was not made by the offerer")
+	ErrOrderCounterMismatch  = errors.New("order counter is not the offerer's current seaport counter")
+	ErrOrderExpired          = errors.New("order has expired")
+	ErrOrderVoucherMismatch  = errors.New("order extra data is not a valid voucher for the offered token")
+	ErrOrderAlreadySubmitted = errors.New("order was already submitted")
+	ErrInvalidOrderMaker     = errors.New("maker must be a wallet address")
+	ErrInvalidOrderStatus    = errors.New("order status must be active, filled, cancelled or expired")
+)
+
+var (
+	ErrOfferNotFound              = errors.New("offer not found")
+	ErrInvalidOfferTarget         = errors.New("offer must name either an nft or a collection")
+	ErrInvalidOfferPrice          = errors.New("offer price must be a positive amount in the currency's smallest unit")
+	ErrInvalidOfferCurrency       = errors.New("offer currency must be an erc20 address")
+	ErrInvalidOfferQuantity       = errors.New("offer quantity must be between 1 and the edition size")
+	ErrInvalidOfferExpiry         = errors.New("offer must expire in the future")
+	ErrUnsupportedOffer           = errors.New("order is not a supported offer")
+	ErrOfferTokenNotMinted        = errors.New("offers can only be made on minted tokens")
+	ErrOfferNotBidder             = errors.New("offer was not made by your wallet")
+	ErrOfferInsufficientBalance   = errors.New("bidder's balance does not cover the offer")
+	ErrOfferInsufficientAllowance = errors.New("bidder has not approved enough of the currency for seaport")
+	ErrOfferNotActive             = errors.New("offer is no longer active")
+	ErrOfferTokenMismatch         = errors.New("offer is for another token")
+	ErrInsufficientTokens         = errors.New("you do not hold enough copies of the token")
+)
+
+var (
+	ErrRelayTransactionNotFound    = errors.New("transaction not found")
+	ErrNoSponsorableVoucher        = errors.New("you hold no unexpired voucher for this nft; request one before asking for a sponsored mint")
+	ErrMintAlreadyRelaying         = errors.New("a sponsored mint of this nft is already in flight")
+	ErrRelayWouldRevert            = errors.New("mint transaction would revert")
+	ErrRelayFeesTooHigh            = errors.New("network fees are above what the relayer pays, try again later")
+	ErrCreatorSponsorshipExhausted = errors.New("the creator's sponsored mint budget is used up")
+	ErrUserSponsorshipExhausted    = errors.New("your sponsored mint budget is used up")
+)
+
+var (
+	ErrInvalidDropType       = errors.New("drop type must be public or private")
+	ErrInvalidDropPrice      = errors.New("drop price cannot be negative")
+	ErrPrivateDropPriced     = errors.New("private drops must be free, as their listings can be bought by any wallet")
+	ErrInvalidDropSupply     = errors.New("drop supply must be positive")
+	ErrInvalidDropWalletCap  = errors.New("drop per-wallet limit cannot be negative")
+	ErrInvalidDropTimes      = errors.New("drop must end after it starts")
+	ErrDropStartPassed       = errors.New("drop start time must be in the future")
+	ErrDropNotEditable       = errors.New("only draft drops can be edited")
+	ErrDropNotUpcoming       = errors.New("only scheduled drops can be subscribed to")
+	ErrInvalidDropTransition = errors.New("drop cannot make this status change")
+)
+
+var (
+	ErrDropNotPrivate         = errors.New("only private drops have an allowlist")
+	ErrDropClosed             = errors.New("drop has ended")
+	ErrInvalidAllowlist       = errors.New("allowlist must list each wallet address once with a positive quota")
+	ErrAllowlistTooLarge      = errors.New("allowlist has too many addresses")
+	ErrAllowlistNotFound      = errors.New("drop has no allowlist")
+	ErrNotAllowlisted         = errors.New("wallet is not on the drop's allowlist")
+	ErrInvalidAllowlistWallet = errors.New("wallet must be an address")
+	ErrAllowlistQuotaExceeded = errors.New("voucher would exceed the wallet's allowlist quota")
+)
+
+var (
+	ErrDropSoldOut            = errors.New("every copy of the drop is minted or reserved")
+	ErrDropWalletLimitReached = errors.New("voucher would exceed the copies one wallet may claim from the drop")
 )
@@ -0,0 +1,366 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+const maxImportKeyLength = 200
+
+// manifest files looked up at the root of an archive uploaded without one
+var importManifestNames = []string{"manifest.csv", "manifest.json"}
+
+// importRow is one validated manifest entry
+type importRow struct {
+	Row           int
+	Key           string
+	Name          string
+	Description   string
+	File          string
+	AnimationFile string
+	Attributes    models.NFTAttributes
+	EditionSize   uint64
+}
+
+// importManifestEntry is a manifest entry as written by the creator. CSV
+// columns use the same names; attributes are a JSON cell or attr:<trait>
+// columns.
+type importManifestEntry struct {
+	Key           string          `json:"key"`
+	Name          string          `json:"name"`
+	Description   string          `json:"description"`
+	File          string          `json:"file"`
+	AnimationFile string          `json:"animation_file"`
+	Attributes    json.RawMessage `json:"attributes"`
+	EditionSize   json.RawMessage `json:"edition_size"`
+
+	traits models.NFTAttributes // from attr:<trait> columns
+}
+
+// importArchive indexes the files of a ZIP by their cleaned path
+type importArchive struct {
+	files map[string]*zip.File
+}
+
+func openImportArchive(data []byte) (*importArchive, error) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, ErrInvalidImportArchive
+	}
+	archive := &importArchive{files: make(map[string]*zip.File, len(reader.File))}
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() || strings.HasPrefix(file.Name, "__MACOSX/") {
+			continue
+		}
+		name, ok := cleanArchivePath(file.Name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is outside the archive root", ErrInvalidImportArchive, file.Name)
+		}
+		archive.files[name] = file
+	}
+	return archive, nil
+}
+
+func (a *importArchive) lookup(name string) (*zip.File, bool) {
+	name, ok := cleanArchivePath(name)
+	if !ok {
+		return nil, false
+	}
+	file, ok := a.files[name]
+	return file, ok
+}
+
+// read decompresses a file, refusing to inflate more than maxSize bytes
+// whatever its header claims
+func (a *importArchive) read(name string, maxSize int64) ([]byte, error) {
+	file, ok := a.lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("%s is not in the archive", name)
+	}
+	r, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	data, err := io.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, ErrAssetTooLarge
+	}
+	return data, nil
+}
+
+// manifest finds the manifest at the root of the archive
+func (a *importArchive) manifest() (string, []byte, error) {
+	var found string
+	for _, name := range importManifestNames {
+		if _, ok := a.files[name]; ok {
+			if found != "" {
+				return "", nil, fmt.Errorf("%w: archive has both %s and %s", ErrInvalidImportManifest, found, name)
+			}
+			found = name
+		}
+	}
+	if found == "" {
+		return "", nil, ErrImportManifestMissing
+	}
+	data, err := a.read(found, maxImportManifestSize)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrInvalidImportManifest, err)
+	}
+	return found, data, nil
+}
+
+// cleanArchivePath turns a ZIP entry or manifest reference into a relative
+// slash separated path, rejecting anything that escapes the archive root
+func cleanArchivePath(name string) (string, bool) {
+	name = strings.ReplaceAll(strings.TrimSpace(name), "\\", "/")
+	if name == "" || strings.HasPrefix(name, "/") {
+		return "", false
+	}
+	name = path.Clean(name)
+	if name == "." || name == ".." || strings.HasPrefix(name, "../") {
+		return "", false
+	}
+	return name, true
+}
+
+// contentTypeOf guesses the media type of an archived file
+func contentTypeOf(name string, data []byte) string {
+	if contentType := mime.TypeByExtension(path.Ext(name)); contentType != "" {
+		return contentType
+	}
+	return http.DetectContentType(data)
+}
+
+// parseImportManifest decodes a CSV or JSON manifest, chosen by file extension
+func parseImportManifest(name string, data []byte) ([]importManifestEntry, error) {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".json":
+		var entries []importManifestEntry
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImportManifest, err)
+		}
+		return entries, nil
+	case ".csv":
+		return parseCSVManifest(data)
+	}
+	return nil, ErrInvalidImportManifest
+}
+
+func parseCSVManifest(data []byte) ([]importManifestEntry, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportManifest, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: missing header row", ErrInvalidImportManifest)
+	}
+
+	header := records[0]
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+	entries := make([]importManifestEntry, 0, len(records)-1)
+	for _, record := range records[1:] {
+		var entry importManifestEntry
+		empty := true
+		for i, value := range record {
+			if i >= len(header) {
+				break
+			}
+			value = strings.TrimSpace(value)
+			if value != "" {
+				empty = false
+			}
+			column := header[i]
+			if trait, ok := strings.CutPrefix(column, "attr:"); ok {
+				if value != "" {
+					entry.traits = append(entry.traits, models.NFTAttribute{TraitType: trait, Value: csvAttributeValue(value)})
+				}
+				continue
+			}
+			switch strings.ToLower(column) {
+			case "key":
+				entry.Key = value
+			case "name":
+				entry.Name = value
+			case "description":
+				entry.Description = value
+			case "file":
+				entry.File = value
+			case "animation_file":
+				entry.AnimationFile = value
+			case "attributes":
+				if value != "" {
+					entry.Attributes = json.RawMessage(value)
+				}
+			case "edition_size":
+				if value != "" {
+					entry.EditionSize = json.RawMessage(strconv.Quote(value))
+				}
+			}
+		}
+		// spreadsheets often export trailing blank lines
+		if empty {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// csvAttributeValue keeps numeric traits numeric so marketplaces can range them
+func csvAttributeValue(value string) interface{} {
+	if n, err := strconv.ParseFloat(value, 64); err == nil {
+		return n
+	}
+	return value
+}
+
+// validateImportManifest checks every entry against the archive and returns
+// the rows to import, or every problem found
+func validateImportManifest(entries []importManifestEntry, archive *importArchive, maxFileSize int64) ([]importRow, []models.ImportRowError) {
+	var rows []importRow
+	var problems []models.ImportRowError
+	seen := make(map[string]int, len(entries))
+
+	for i, entry := range entries {
+		row := importRow{
+			Row:           i + 1,
+			Key:           strings.TrimSpace(entry.Key),
+			Name:          strings.TrimSpace(entry.Name),
+			Description:   strings.TrimSpace(entry.Description),
+			File:          strings.TrimSpace(entry.File),
+			AnimationFile: strings.TrimSpace(entry.AnimationFile),
+			EditionSize:   1,
+		}
+		if row.Key == "" {
+			row.Key, _ = cleanArchivePath(row.File)
+		}
+		fail := func(field, message string) {
+			problems = append(problems, models.ImportRowError{Row: row.Row, Key: row.Key, Field: field, Message: message})
+		}
+		before := len(problems)
+
+		switch {
+		case row.Key == "":
+			fail("key", "key or file is required")
+		case len(row.Key) > maxImportKeyLength:
+			fail("key", fmt.Sprintf("key must be at most %d characters", maxImportKeyLength))
+		default:
+			if first, ok := seen[row.Key]; ok {
+				fail("key", fmt.Sprintf("key is already used by row %d", first))
+			} else {
+				seen[row.Key] = row.Row
+			}
+		}
+		if row.Name == "" || len(row.Name) > maxNFTNameLength {
+			fail("name", ErrInvalidNFTName.Error())
+		}
+		if row.File == "" {
+			fail("file", "file is required")
+		} else if message := checkArchivedFile(archive, row.File, maxFileSize); message != "" {
+			fail("file", message)
+		}
+		if row.AnimationFile != "" {
+			if message := checkArchivedFile(archive, row.AnimationFile, maxFileSize); message != "" {
+				fail("animation_file", message)
+			}
+		}
+
+		attributes, err := decodeImportAttributes(entry.Attributes)
+		if err != nil {
+			fail("attributes", err.Error())
+		}
+		row.Attributes = append(attributes, entry.traits...)
+		for _, attribute := range row.Attributes {
+			if attribute.Value == nil {
+				fail("attributes", ErrInvalidNFTAttributes.Error())
+				break
+			}
+		}
+
+		if len(entry.EditionSize) > 0 && string(entry.EditionSize) != "null" {
+			size, err := decodeEditionSize(entry.EditionSize)
+			if err != nil {
+				fail("edition_size", ErrInvalidEditionSize.Error())
+			}
+			row.EditionSize = size
+		}
+
+		if len(problems) == before {
+			rows = append(rows, row)
+		}
+	}
+	return rows, problems
+}
+
+func checkArchivedFile(archive *importArchive, name string, maxFileSize int64) string {
+	file, ok := archive.lookup(name)
+	if !ok {
+		return fmt.Sprintf("%s is not in the archive", name)
+	}
+	if file.UncompressedSize64 == 0 {
+		return fmt.Sprintf("%s is empty", name)
+	}
+	if file.UncompressedSize64 > uint64(maxFileSize) {
+		return fmt.Sprintf("%s is larger than %d bytes", name, maxFileSize)
+	}
+	return ""
+}
+
+// decodeImportAttributes accepts the OpenSea attributes array or a
+// {"trait": value} object, whose traits are sorted by name
+func decodeImportAttributes(raw json.RawMessage) (models.NFTAttributes, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	var list models.NFTAttributes
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return list, nil
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return nil, fmt.Errorf("attributes must be a JSON array or object")
+	}
+	traits := make([]string, 0, len(object))
+	for trait := range object {
+		traits = append(traits, trait)
+	}
+	sort.Strings(traits)
+	for _, trait := range traits {
+		list = append(list, models.NFTAttribute{TraitType: trait, Value: object[trait]})
+	}
+	return list, nil
+}
+
+// decodeEditionSize accepts a JSON number or a string holding one
+func decodeEditionSize(raw json.RawMessage) (uint64, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		s = string(raw)
+	}
+	size, err := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+	if err != nil || size == 0 {
+		return 0, ErrInvalidEditionSize
+	}
+	return size, nil
+}
@@ -0,0 +1,405 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"time"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+const (
+	importPollInterval    = 5 * time.Second
+	maxImportManifestSize = 16 << 20
+	// progress is saved every importProgressEvery rows; a processing job that
+	// has not saved for importStaleAfter is assumed abandoned and resumed
+	importProgressEvery = 25
+	importStaleAfter    = 10 * time.Minute
+)
+
+// ImportService creates the NFTs of a drop in bulk from a ZIP of media and a
+// CSV or JSON manifest. Uploads are validated in full before a job is
+// queued; Run then pins the media and metadata, allocates token IDs and
+// creates the NFTs. Every row has a key, the media path unless the manifest
+// sets one, and a row whose key already exists in the drop is skipped, so
+// re-uploading an archive, or an extended version of it, only adds what is
+// missing.
+type ImportService struct {
+	importRepo repoInterfaces.ImportJobRepository
+	nftRepo    repoInterfaces.NFTRepository
+	dropRepo   repoInterfaces.DropRepository
+	allocator  *TokenIDAllocator
+	assets     *AssetService
+	maxSize    int64
+}
+
+// NewImportService creates a new ImportService instance
+func NewImportService(
+	importRepo repoInterfaces.ImportJobRepository,
+	nftRepo repoInterfaces.NFTRepository,
+	dropRepo repoInterfaces.DropRepository,
+	allocator *TokenIDAllocator,
+	assets *AssetService,
+	maxSize int64,
+) *ImportService {
+	return &ImportService{
+		importRepo: importRepo,
+		nftRepo:    nftRepo,
+		dropRepo:   dropRepo,
+		allocator:  allocator,
+		assets:     assets,
+		maxSize:    maxSize,
+	}
+}
+
+// Create validates an import into a drop owned by actor and queues it. The
+// manifest may be uploaded next to the archive or stored in it as
+// manifest.csv or manifest.json. Uploading the same files again returns the
+// existing job and created is false.
+func (s *ImportService) Create(actor *models.User, dropID uint, archive io.Reader, manifestName string, manifest io.Reader) (job *models.ImportJob, created bool, err error) {
+	drop, err := ownedDrop(s.dropRepo, actor, dropID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	archiveData, err := io.ReadAll(io.LimitReader(archive, s.maxSize+1))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read archive: %w", err)
+	}
+	if int64(len(archiveData)) > s.maxSize {
+		return nil, false, ErrImportTooLarge
+	}
+	var manifestData []byte
+	if manifest != nil {
+		manifestData, err = io.ReadAll(io.LimitReader(manifest, maxImportManifestSize+1))
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read manifest: %w", err)
+		}
+		if len(manifestData) > maxImportManifestSize {
+			return nil, false, ErrImportTooLarge
+		}
+		manifestName = path.Base(manifestName)
+	}
+
+	hash := importHash(archiveData, manifestName, manifestData)
+	// a failed job is queued again; anything else is reported as is
+	existing, err := s.importRepo.GetByDropAndHash(drop.ID, hash)
+	if err == nil && existing.Status != models.ImportStatusFailed {
+		return existing, false, nil
+	}
+	if err != nil && !errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+		return nil, false, err
+	}
+
+	opened, err := openImportArchive(archiveData)
+	if err != nil {
+		return nil, false, err
+	}
+	if manifest == nil {
+		manifestName, manifestData, err = opened.manifest()
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	rows, err := s.validate(opened, manifestName, manifestData)
+	if err != nil {
+		return nil, false, err
+	}
+
+	job = &models.ImportJob{
+		DropID:      drop.ID,
+		ArchiveHash: hash,
+	}
+	if existing != nil {
+		job = existing
+	}
+	job.CreatorID = actor.ID
+	job.ManifestName = manifestName
+	job.Archive = archiveData
+	job.Manifest = nil
+	if manifest != nil {
+		job.Manifest = manifestData
+	}
+	job.Status = models.ImportStatusQueued
+	job.TotalRows = len(rows)
+	job.ProcessedRows, job.SkippedRows, job.FailedRows = 0, 0, 0
+	job.Errors, job.Results = nil, nil
+	job.Error = ""
+	job.StartedAt, job.CompletedAt = nil, nil
+
+	if existing != nil {
+		err = s.importRepo.Update(job)
+	} else {
+		err = s.importRepo.Create(job)
+	}
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrDuplicateRecord) {
+			existing, err := s.importRepo.GetByDropAndHash(drop.ID, hash)
+			return existing, false, err
+		}
+		return nil, false, fmt.Errorf("failed to queue import: %w", err)
+	}
+	job.Archive = nil
+	job.Manifest = nil
+	return job, true, nil
+}
+
+// GetByID returns an import started by actor
+func (s *ImportService) GetByID(actor *models.User, id uint) (*models.ImportJob, error) {
+	job, err := s.importRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrImportNotFound
+		}
+		return nil, err
+	}
+	if job.CreatorID != actor.ID {
+		return nil, ErrImportNotFound
+	}
+	return job, nil
+}
+
+// Run processes queued imports until ctx is cancelled
+func (s *ImportService) Run(ctx context.Context) {
+	ticker := time.NewTicker(importPollInterval)
+	defer ticker.Stop()
+	for {
+		s.processQueued(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ImportService) processQueued(ctx context.Context) {
+	for ctx.Err() == nil {
+		job, err := s.importRepo.ClaimNext(time.Now().Add(-importStaleAfter))
+		if err != nil {
+			log.Printf("failed to claim import: %v", err)
+			return
+		}
+		if job == nil {
+			return
+		}
+		s.process(ctx, job)
+	}
+}
+
+// process imports every row of a claimed job. A job interrupted by shutdown
+// stays processing and is resumed once it goes stale.
+func (s *ImportService) process(ctx context.Context, job *models.ImportJob) {
+	rows, archive, err := s.load(job)
+	if err != nil {
+		s.finish(job, err)
+		return
+	}
+
+	job.ProcessedRows, job.SkippedRows, job.FailedRows = 0, 0, 0
+	job.Errors, job.Results = nil, models.ImportResults{}
+
+	keys := make([]string, len(rows))
+	for i, row := range rows {
+		keys[i] = row.Key
+	}
+	imported, err := s.nftRepo.ListByImportKeys(job.DropID, keys)
+	if err != nil {
+		s.finish(job, fmt.Errorf("failed to load imported nfts: %w", err))
+		return
+	}
+	existing := make(map[string]*models.NFT, len(imported))
+	for _, nft := range imported {
+		existing[*nft.ImportKey] = nft
+	}
+
+	var pending []importRow
+	for _, row := range rows {
+		if nft, ok := existing[row.Key]; ok {
+			s.recordResult(ctx, job, row, nft)
+			job.SkippedRows++
+			job.ProcessedRows++
+			continue
+		}
+		pending = append(pending, row)
+	}
+	if err := s.importRepo.UpdateProgress(job); err != nil {
+		log.Printf("failed to save progress of import %d: %v", job.ID, err)
+	}
+	if len(pending) > 0 {
+		tokenIDs, err := s.allocator.AllocateN(job.DropID, len(pending))
+		if err != nil {
+			s.finish(job, err)
+			return
+		}
+		for i, row := range pending {
+			if ctx.Err() != nil {
+				return
+			}
+			if err := s.importRow(ctx, job, archive, row, tokenIDs[i]); err != nil {
+				job.FailedRows++
+				job.Errors = append(job.Errors, models.ImportRowError{Row: row.Row, Key: row.Key, Message: err.Error()})
+			}
+			job.ProcessedRows++
+			if job.ProcessedRows%importProgressEvery == 0 {
+				if err := s.importRepo.UpdateProgress(job); err != nil {
+					log.Printf("failed to save progress of import %d: %v", job.ID, err)
+				}
+			}
+		}
+	}
+	s.finish(job, nil)
+}
+
+// load parses the stored archive and manifest of a job again
+func (s *ImportService) load(job *models.ImportJob) ([]importRow, *importArchive, error) {
+	archive, err := openImportArchive(job.Archive)
+	if err != nil {
+		return nil, nil, err
+	}
+	manifestName, manifestData := job.ManifestName, job.Manifest
+	if manifestData == nil {
+		if manifestName, manifestData, err = archive.manifest(); err != nil {
+			return nil, nil, err
+		}
+	}
+	rows, err := s.validate(archive, manifestName, manifestData)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rows, archive, nil
+}
+
+func (s *ImportService) validate(archive *importArchive, manifestName string, manifestData []byte) ([]importRow, error) {
+	entries, err := parseImportManifest(manifestName, manifestData)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, &ImportValidationError{Rows: []models.ImportRowError{{Message: "manifest has no rows"}}}
+	}
+	if len(entries) > maxTokenBatch {
+		return nil, fmt.Errorf("%w: at most %d per import", ErrImportTooManyRows, maxTokenBatch)
+	}
+	rows, problems := validateImportManifest(entries, archive, s.assets.MaxSize())
+	if len(problems) > 0 {
+		return nil, &ImportValidationError{Rows: problems}
+	}
+	return rows, nil
+}
+
+// importRow pins the media and metadata of a row and creates its NFT. The
+// metadata pinned here is what vouchers sign as long as the NFT is not
+// edited.
+func (s *ImportService) importRow(ctx context.Context, job *models.ImportJob, archive *importArchive, row importRow, tokenID models.Uint256) error {
+	image, err := s.storeFile(ctx, job.CreatorID, archive, row.File)
+	if err != nil {
+		return err
+	}
+	var animationURL string
+	if row.AnimationFile != "" {
+		if animationURL, err = s.storeFile(ctx, job.CreatorID, archive, row.AnimationFile); err != nil {
+			return err
+		}
+	}
+
+	key := row.Key
+	nft := &models.NFT{
+		DropID:       job.DropID,
+		TokenID:      tokenID,
+		Name:         row.Name,
+		Description:  row.Description,
+		Image:        image,
+		AnimationURL: animationURL,
+		Attributes:   row.Attributes,
+		EditionSize:  row.EditionSize,
+		ImportKey:    &key,
+	}
+	metadata, err := s.assets.StoreMetadata(ctx, renderTokenMetadata(nft))
+	if err != nil {
+		return fmt.Errorf("failed to store metadata: %w", err)
+	}
+	if err := s.nftRepo.Create(nft); err != nil {
+		// another run of the same rows got there first
+		if errors.Is(err, repoInterfaces.ErrDuplicateRecord) {
+			job.SkippedRows++
+			return nil
+		}
+		return fmt.Errorf("failed to create nft: %w", err)
+	}
+	job.Results = append(job.Results, models.ImportResult{
+		Row:         row.Row,
+		Key:         row.Key,
+		NFTID:       nft.ID,
+		TokenID:     nft.TokenID,
+		Image:       nft.Image,
+		MetadataURI: "ipfs://" + metadata.CID,
+	})
+	return nil
+}
+
+func (s *ImportService) storeFile(ctx context.Context, ownerID uint, archive *importArchive, name string) (string, error) {
+	data, err := archive.read(name, s.assets.MaxSize())
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	asset, err := s.assets.StoreFile(ctx, ownerID, path.Base(name), contentTypeOf(name, data), data)
+	if err != nil {
+		return "", fmt.Errorf("failed to store %s: %w", name, err)
+	}
+	return "ipfs://" + asset.CID, nil
+}
+
+// recordResult reports an NFT created by an earlier upload. Its metadata URI
+// is only known when the metadata is pinned, which is a no-op if it already
+// was.
+func (s *ImportService) recordResult(ctx context.Context, job *models.ImportJob, row importRow, nft *models.NFT) {
+	result := models.ImportResult{Row: row.Row, Key: row.Key, NFTID: nft.ID, TokenID: nft.TokenID, Image: nft.Image}
+	if nft.MetadataURI != "" {
+		result.MetadataURI = nft.MetadataURI
+	} else if metadata, err := s.assets.StoreMetadata(ctx, renderTokenMetadata(nft)); err == nil {
+		result.MetadataURI = "ipfs://" + metadata.CID
+	}
+	job.Results = append(job.Results, result)
+}
+
+// finish marks a job completed, or failed when cause is set, and drops the
+// uploaded files
+func (s *ImportService) finish(job *models.ImportJob, cause error) {
+	now := time.Now()
+	job.Status = models.ImportStatusCompleted
+	if cause != nil {
+		job.Status = models.ImportStatusFailed
+		job.Error = cause.Error()
+		var validation *ImportValidationError
+		if errors.As(cause, &validation) {
+			job.Errors = validation.Rows
+		}
+	}
+	job.CompletedAt = &now
+	job.Archive = nil
+	job.Manifest = nil
+	if err := s.importRepo.Update(job); err != nil {
+		log.Printf("failed to finish import %d: %v", job.ID, err)
+	}
+}
+
+// importHash identifies an upload by its archive and manifest
+func importHash(archive []byte, manifestName string, manifest []byte) string {
+	h := sha256.New()
+	h.Write(archive)
+	if manifest != nil {
+		h.Write([]byte{0})
+		h.Write([]byte(manifestName))
+		h.Write([]byte{0})
+		h.Write(manifest)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,17 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+// ImportValidationError lists every manifest row that cannot be imported.
+// Nothing is imported while any row is invalid.
+type ImportValidationError struct {
+	Rows []models.ImportRowError
+}
+
+func (e *ImportValidationError) Error() string {
+	return fmt.Sprintf("import manifest has %d invalid rows", len(e.Rows))
+}
@@ -0,0 +1,518 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	chainInterfaces "github.com/igwedaniel/artizan/internal/interfaces/chain"
+	busInterfaces "github.com/igwedaniel/artizan/internal/interfaces/eventbus"
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"github.com/igwedaniel/artizan/pkg/contracts"
+)
+
+const (
+	indexerPollInterval      = 3 * time.Second
+	defaultIndexerBlockRange = 2000
+	// block hashes are kept this far behind the checkpoint to find the fork
+	// point of a reorg
+	indexerReorgWindow = 4096
+)
+
+var (
+	// errReorg reports that blocks the indexer already processed are no
+	// longer canonical
+	errReorg = errors.New("chain reorganised")
+	// errChainMoved reports logs and headers read from different forks
+	errChainMoved = errors.New("chain changed while indexing")
+)
+
+// RollbackHook removes the rows a service derived from the blocks of a chain
+// from fromBlock up. Hooks run after a reorg, before the new blocks are
+// indexed, and must be safe to run more than once.
+type RollbackHook func(ctx context.Context, chainID int64, fromBlock uint64) error
+
+// LogWatcher indexes logs of contracts other than the collections alongside
+// their transfers. FilterQueries is called once per range of blocks, before
+// the logs it matched are handed to HandleLog in chain order; the indexer
+// fills in the block range. Handling a log twice must be harmless.
+type LogWatcher interface {
+	FilterQueries() ([]ethereum.FilterQuery, error)
+	HandleLog(ctx context.Context, entry types.Log, blockTime time.Time) error
+}
+
+// IndexerService follows the LazyMint1155 contracts of every collection and
+// records their TransferSingle, TransferBatch and URI logs. Mints mark the
+// NFT minted, redeem the voucher used and publish EventNFTMinted.
+//
+// Only blocks with enough confirmations are read, and progress is kept in a
+// per-chain checkpoint. Each batch of blocks must descend from the
+// checkpoint; when it does not, the indexer finds the newest block it
+// indexed that is still canonical, runs the rollback hooks for everything
+// above it and indexes the new blocks from there.
+type IndexerService struct {
+	client         chainInterfaces.Client
+	collectionRepo repoInterfaces.CollectionRepository
+	nftRepo        repoInterfaces.NFTRepository
+	voucherRepo    repoInterfaces.VoucherRepository
+	transferRepo   repoInterfaces.TokenTransferRepository
+	checkpointRepo repoInterfaces.ChainCheckpointRepository
+	eventBus       busInterfaces.EventBus
+	filterer       *contracts.LazyMint1155Filterer
+	topics         []common.Hash
+	chainID        int64
+	startBlock     uint64
+	confirmations  uint64
+	blockRange     uint64
+	rollbackHooks  []RollbackHook
+	logWatchers    []LogWatcher
+}
+
+// NewIndexerService creates a new IndexerService instance. Without a
+// checkpoint, indexing starts at startBlock, or at the confirmed head when
+// startBlock is zero.
+func NewIndexerService(
+	client chainInterfaces.Client,
+	collectionRepo repoInterfaces.CollectionRepository,
+	nftRepo repoInterfaces.NFTRepository,
+	voucherRepo repoInterfaces.VoucherRepository,
+	transferRepo repoInterfaces.TokenTransferRepository,
+	checkpointRepo repoInterfaces.ChainCheckpointRepository,
+	eventBus busInterfaces.EventBus,
+	chainID int64,
+	startBlock uint64,
+	confirmations uint64,
+	blockRange uint64,
+) (*IndexerService, error) {
+	parsed, err := contracts.LazyMint1155MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	filterer, err := contracts.NewLazyMint1155Filterer(common.Address{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if confirmations == 0 {
+		confirmations = 1
+	}
+	if blockRange == 0 {
+		blockRange = defaultIndexerBlockRange
+	}
+	s := &IndexerService{
+		client:         client,
+		collectionRepo: collectionRepo,
+		nftRepo:        nftRepo,
+		voucherRepo:    voucherRepo,
+		transferRepo:   transferRepo,
+		checkpointRepo: checkpointRepo,
+		eventBus:       eventBus,
+		filterer:       filterer,
+		topics: []common.Hash{
+			parsed.Events["TransferSingle"].ID,
+			parsed.Events["TransferBatch"].ID,
+			parsed.Events["URI"].ID,
+		},
+		chainID:       chainID,
+		startBlock:    startBlock,
+		confirmations: confirmations,
+		blockRange:    blockRange,
+	}
+	s.AddRollbackHook(func(_ context.Context, chainID int64, fromBlock uint64) error {
+		return transferRepo.DeleteFromBlock(chainID, fromBlock)
+	})
+	s.AddRollbackHook(func(_ context.Context, chainID int64, fromBlock uint64) error {
+		return nftRepo.RevertMintsFromBlock(chainID, fromBlock)
+	})
+	return s, nil
+}
+
+// AddRollbackHook registers a hook to run when indexed blocks are orphaned
+func (s *IndexerService) AddRollbackHook(hook RollbackHook) {
+	s.rollbackHooks = append(s.rollbackHooks, hook)
+}
+
+// AddLogWatcher registers a watcher for the logs of other contracts
+func (s *IndexerService) AddLogWatcher(watcher LogWatcher) {
+	s.logWatchers = append(s.logWatchers, watcher)
+}
+
+// Run indexes new blocks until ctx is cancelled
+func (s *IndexerService) Run(ctx context.Context) {
+	ticker := time.NewTicker(indexerPollInterval)
+	defer ticker.Stop()
+	for {
+		if err := s.Sync(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("failed to index chain %d: %v", s.chainID, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync indexes every confirmed block after the checkpoint, rolling back
+// first if the checkpoint was orphaned
+func (s *IndexerService) Sync(ctx context.Context) error {
+	head, err := s.client.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	if head+1 < s.confirmations {
+		return nil
+	}
+	safe := head + 1 - s.confirmations
+
+	checkpoint, err := s.checkpointRepo.GetCheckpoint(s.chainID)
+	if err != nil && !errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+		return err
+	}
+	next := s.startBlock
+	if checkpoint != nil {
+		next = checkpoint.BlockNumber + 1
+	} else if next == 0 {
+		// nothing to backfill; start following from the confirmed head
+		header, err := s.client.HeaderByNumber(ctx, new(big.Int).SetUint64(safe))
+		if err != nil {
+			return err
+		}
+		return s.checkpointRepo.Advance(&models.ChainCheckpoint{ChainID: s.chainID, BlockNumber: safe, BlockHash: header.Hash().Hex()},
+			[]*models.IndexedBlock{indexedBlock(s.chainID, header)})
+	}
+
+	for next <= safe {
+		to := min(next+s.blockRange-1, safe)
+		checkpoint, err = s.indexRange(ctx, checkpoint, next, to)
+		if errors.Is(err, errReorg) {
+			if checkpoint, err = s.rollback(ctx); err != nil {
+				return err
+			}
+			next = checkpoint.BlockNumber + 1
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		next = to + 1
+	}
+	return nil
+}
+
+// indexRange records the logs of the blocks from to to, inclusive, and
+// moves the checkpoint to to. Logs already recorded are skipped, so a range
+// can be indexed again safely.
+func (s *IndexerService) indexRange(ctx context.Context, checkpoint *models.ChainCheckpoint, from, to uint64) (*models.ChainCheckpoint, error) {
+	headers := make(map[uint64]*types.Header)
+	first, err := s.header(ctx, headers, from)
+	if err != nil {
+		return nil, err
+	}
+	if checkpoint != nil && first.ParentHash.Hex() != checkpoint.BlockHash {
+		return nil, errReorg
+	}
+	last, err := s.header(ctx, headers, to)
+	if err != nil {
+		return nil, err
+	}
+	// an earlier attempt at this range may have stopped halfway, possibly on
+	// blocks that have since been orphaned
+	if err := s.runRollbackHooks(ctx, from); err != nil {
+		return nil, err
+	}
+
+	collections, err := s.collectionRepo.ListWithContract(s.chainID)
+	if err != nil {
+		return nil, err
+	}
+	byContract := make(map[common.Address]*models.Collection, len(collections))
+	addresses := make([]common.Address, 0, len(collections))
+	for _, collection := range collections {
+		address := common.HexToAddress(*collection.ContractAddress)
+		byContract[address] = collection
+		addresses = append(addresses, address)
+	}
+
+	var logs []types.Log
+	if len(addresses) > 0 {
+		logs, err = s.client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: addresses,
+			Topics:    [][]common.Hash{s.topics},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to filter logs of blocks %d-%d: %w", from, to, err)
+		}
+	}
+
+	// LazyMint1155 emits URI right after the mint's TransferSingle
+	uris := make(map[mintKey]string)
+	for _, entry := range logs {
+		if uri, err := s.filterer.ParseURI(entry); err == nil {
+			uris[mintKey{entry.TxHash, entry.Address, uri.Id.String()}] = uri.Value
+		}
+	}
+
+	for _, entry := range logs {
+		collection := byContract[entry.Address]
+		if entry.Removed || collection == nil {
+			continue
+		}
+		header, err := s.header(ctx, headers, entry.BlockNumber)
+		if err != nil {
+			return nil, err
+		}
+		if header.Hash() != entry.BlockHash {
+			return nil, errChainMoved
+		}
+		batch, err := s.decodeTransfers(entry)
+		if err != nil {
+			log.Printf("failed to decode log %d of %s: %v", entry.Index, entry.TxHash.Hex(), err)
+			continue
+		}
+		for i, transfer := range batch {
+			transfer.BatchIndex = uint(i)
+			transfer.Timestamp = time.Unix(int64(header.Time), 0).UTC()
+			uri := uris[mintKey{entry.TxHash, entry.Address, transfer.TokenID.String()}]
+			if err := s.record(collection, transfer, uri); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, watcher := range s.logWatchers {
+		if err := s.watch(ctx, watcher, headers, from, to); err != nil {
+			return nil, err
+		}
+	}
+
+	blocks := make([]*models.IndexedBlock, 0, len(headers))
+	for _, header := range headers {
+		blocks = append(blocks, indexedBlock(s.chainID, header))
+	}
+	next := &models.ChainCheckpoint{ChainID: s.chainID, BlockNumber: to, BlockHash: last.Hash().Hex()}
+	if err := s.checkpointRepo.Advance(next, blocks); err != nil {
+		return nil, fmt.Errorf("failed to store checkpoint: %w", err)
+	}
+	if to > indexerReorgWindow {
+		if err := s.checkpointRepo.PruneBlocks(s.chainID, to-indexerReorgWindow); err != nil {
+			log.Printf("failed to prune indexed blocks of chain %d: %v", s.chainID, err)
+		}
+	}
+	return next, nil
+}
+
+// watch hands a watcher the logs its queries match in the blocks from to to
+func (s *IndexerService) watch(ctx context.Context, watcher LogWatcher, headers map[uint64]*types.Header, from, to uint64) error {
+	queries, err := watcher.FilterQueries()
+	if err != nil {
+		return err
+	}
+	var logs []types.Log
+	for _, query := range queries {
+		query.FromBlock = new(big.Int).SetUint64(from)
+		query.ToBlock = new(big.Int).SetUint64(to)
+		matched, err := s.client.FilterLogs(ctx, query)
+		if err != nil {
+			return fmt.Errorf("failed to filter logs of blocks %d-%d: %w", from, to, err)
+		}
+		logs = append(logs, matched...)
+	}
+	sort.Slice(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+		return logs[i].Index < logs[j].Index
+	})
+
+	for _, entry := range logs {
+		if entry.Removed {
+			continue
+		}
+		header, err := s.header(ctx, headers, entry.BlockNumber)
+		if err != nil {
+			return err
+		}
+		if header.Hash() != entry.BlockHash {
+			return errChainMoved
+		}
+		if err := watcher.HandleLog(ctx, entry, time.Unix(int64(header.Time), 0).UTC()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rollback finds the newest indexed block that is still canonical, rolls
+// back every row derived from the blocks above it and makes it the
+// checkpoint
+func (s *IndexerService) rollback(ctx context.Context) (*models.ChainCheckpoint, error) {
+	checkpoint, err := s.checkpointRepo.GetCheckpoint(s.chainID)
+	if err != nil {
+		return nil, err
+	}
+	from := uint64(0)
+	if checkpoint.BlockNumber > indexerReorgWindow {
+		from = checkpoint.BlockNumber - indexerReorgWindow
+	}
+	blocks, err := s.checkpointRepo.ListBlocks(s.chainID, from)
+	if err != nil {
+		return nil, err
+	}
+	var ancestor *models.IndexedBlock
+	for _, block := range blocks {
+		header, err := s.client.HeaderByNumber(ctx, new(big.Int).SetUint64(block.Number))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read block %d: %w", block.Number, err)
+		}
+		if header.Hash().Hex() == block.Hash {
+			ancestor = block
+			break
+		}
+	}
+	if ancestor == nil {
+		return nil, fmt.Errorf("reorg below block %d is deeper than the indexed block window", from)
+	}
+
+	if err := s.runRollbackHooks(ctx, ancestor.Number+1); err != nil {
+		return nil, err
+	}
+	if err := s.checkpointRepo.Rewind(s.chainID, ancestor); err != nil {
+		return nil, fmt.Errorf("failed to rewind checkpoint: %w", err)
+	}
+	log.Printf("chain %d reorganised: rolled back from block %d to %d", s.chainID, checkpoint.BlockNumber, ancestor.Number)
+	return &models.ChainCheckpoint{ChainID: s.chainID, BlockNumber: ancestor.Number, BlockHash: ancestor.Hash}, nil
+}
+
+func (s *IndexerService) runRollbackHooks(ctx context.Context, fromBlock uint64) error {
+	for _, hook := range s.rollbackHooks {
+		if err := hook(ctx, s.chainID, fromBlock); err != nil {
+			return fmt.Errorf("failed to roll back chain %d from block %d: %w", s.chainID, fromBlock, err)
+		}
+	}
+	return nil
+}
+
+type mintKey struct {
+	txHash   common.Hash
+	contract common.Address
+	tokenID  string
+}
+
+// decodeTransfers turns a TransferSingle or TransferBatch log into transfers;
+// other logs yield none
+func (s *IndexerService) decodeTransfers(entry types.Log) ([]*models.TokenTransfer, error) {
+	if len(entry.Topics) == 0 {
+		return nil, nil
+	}
+	newTransfer := func(operator, from, to common.Address, id, value *big.Int) *models.TokenTransfer {
+		return &models.TokenTransfer{
+			ChainID:         s.chainID,
+			ContractAddress: entry.Address.Hex(),
+			TokenID:         models.NewUint256(id),
+			Operator:        operator.Hex(),
+			FromAddress:     from.Hex(),
+			ToAddress:       to.Hex(),
+			Amount:          models.NewUint256(value),
+			BlockNumber:     entry.BlockNumber,
+			BlockHash:       entry.BlockHash.Hex(),
+			TxHash:          entry.TxHash.Hex(),
+			LogIndex:        entry.Index,
+		}
+	}
+
+	switch entry.Topics[0] {
+	case s.topics[0]:
+		event, err := s.filterer.ParseTransferSingle(entry)
+		if err != nil {
+			return nil, err
+		}
+		return []*models.TokenTransfer{newTransfer(event.Operator, event.From, event.To, event.Id, event.Value)}, nil
+	case s.topics[1]:
+		event, err := s.filterer.ParseTransferBatch(entry)
+		if err != nil {
+			return nil, err
+		}
+		if len(event.Ids) != len(event.Values) {
+			return nil, errors.New("ids and values differ in length")
+		}
+		transfers := make([]*models.TokenTransfer, len(event.Ids))
+		for i := range event.Ids {
+			transfers[i] = newTransfer(event.Operator, event.From, event.To, event.Ids[i], event.Values[i])
+		}
+		return transfers, nil
+	}
+	return nil, nil
+}
+
+// record stores a transfer and, for the first mint of one of our NFTs,
+// marks it minted
+func (s *IndexerService) record(collection *models.Collection, transfer *models.TokenTransfer, uri string) error {
+	nft, err := s.nftRepo.GetByContractAndTokenID(s.chainID, transfer.ContractAddress, transfer.TokenID)
+	if err != nil && !errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+		return err
+	}
+	if nft != nil {
+		transfer.NFTID = &nft.ID
+	}
+	if _, err := s.transferRepo.Create(transfer); err != nil {
+		return fmt.Errorf("failed to store transfer %s#%d: %w", transfer.TxHash, transfer.LogIndex, err)
+	}
+	if nft == nil || transfer.FromAddress != (common.Address{}).Hex() {
+		return nil
+	}
+
+	minted, err := s.nftRepo.MarkMinted(nft.ID, transfer.BlockNumber, transfer.TxHash, uri, transfer.Timestamp)
+	if err != nil || !minted {
+		return err
+	}
+	event := busInterfaces.NFTMintedEvent{
+		NFTID:           nft.ID,
+		CollectionID:    collection.ID,
+		CreatorID:       collection.CreatorID,
+		ContractAddress: transfer.ContractAddress,
+		TokenID:         transfer.TokenID.String(),
+		OwnerAddress:    transfer.ToAddress,
+		Amount:          transfer.Amount.String(),
+		TxHash:          transfer.TxHash,
+	}
+	voucher, err := s.voucherRepo.MarkRedeemed(nft.ID, transfer.ToAddress, transfer.Amount.String(), transfer.TxHash, transfer.Timestamp)
+	switch {
+	case err == nil:
+		event.VoucherID = voucher.ID
+	case !errors.Is(err, repoInterfaces.ErrRecordNotFound):
+		log.Printf("failed to mark the voucher of nft %d redeemed: %v", nft.ID, err)
+	}
+	s.eventBus.Publish(busInterfaces.EventNFTMinted, event)
+	return nil
+}
+
+// header reads a block header once per range
+func (s *IndexerService) header(ctx context.Context, cache map[uint64]*types.Header, number uint64) (*types.Header, error) {
+	if header, ok := cache[number]; ok {
+		return header, nil
+	}
+	header, err := s.client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read block %d: %w", number, err)
+	}
+	cache[number] = header
+	return header, nil
+}
+
+func indexedBlock(chainID int64, header *types.Header) *models.IndexedBlock {
+	return &models.IndexedBlock{
+		ChainID:    chainID,
+		Number:     header.Number.Uint64(),
+		Hash:       header.Hash().Hex(),
+		ParentHash: header.ParentHash.Hex(),
+	}
+}
@@ -0,0 +1,290 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	signerAdapters "github.com/igwedaniel/artizan/internal/adapters/signer"
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"github.com/igwedaniel/artizan/pkg/contracts"
+)
+
+// reorgLog records the rewinds and rollbacks of the indexer in order
+type reorgLog []string
+
+type memoryCheckpointRepo struct {
+	checkpoint *models.ChainCheckpoint
+	blocks     map[uint64]*models.IndexedBlock
+	log        *reorgLog
+}
+
+func (r *memoryCheckpointRepo) GetCheckpoint(int64) (*models.ChainCheckpoint, error) {
+	if r.checkpoint == nil {
+		return nil, repoInterfaces.ErrRecordNotFound
+	}
+	copied := *r.checkpoint
+	return &copied, nil
+}
+
+func (r *memoryCheckpointRepo) Advance(checkpoint *models.ChainCheckpoint, blocks []*models.IndexedBlock) error {
+	for _, block := range blocks {
+		r.blocks[block.Number] = block
+	}
+	copied := *checkpoint
+	r.checkpoint = &copied
+	return nil
+}
+
+func (r *memoryCheckpointRepo) ListBlocks(_ int64, fromBlock uint64) ([]*models.IndexedBlock, error) {
+	var blocks []*models.IndexedBlock
+	for number, block := range r.blocks {
+		if number >= fromBlock {
+			blocks = append(blocks, block)
+		}
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].Number > blocks[j].Number })
+	return blocks, nil
+}
+
+func (r *memoryCheckpointRepo) Rewind(chainID int64, ancestor *models.IndexedBlock) error {
+	for number := range r.blocks {
+		if number > ancestor.Number {
+			delete(r.blocks, number)
+		}
+	}
+	r.checkpoint = &models.ChainCheckpoint{ChainID: chainID, BlockNumber: ancestor.Number, BlockHash: ancestor.Hash}
+	*r.log = append(*r.log, fmt.Sprintf("rewind to %d", ancestor.Number))
+	return nil
+}
+
+func (r *memoryCheckpointRepo) PruneBlocks(int64, uint64) error { return nil }
+
+type memoryTransferRepo struct {
+	transfers []*models.TokenTransfer
+}
+
+func (r *memoryTransferRepo) Create(transfer *models.TokenTransfer) (bool, error) {
+	for _, t := range r.transfers {
+		if t.TxHash == transfer.TxHash && t.LogIndex == transfer.LogIndex && t.BatchIndex == transfer.BatchIndex {
+			return false, nil
+		}
+	}
+	r.transfers = append(r.transfers, transfer)
+	return true, nil
+}
+
+func (r *memoryTransferRepo) DeleteFromBlock(_ int64, fromBlock uint64) error {
+	kept := r.transfers[:0]
+	for _, t := range r.transfers {
+		if t.BlockNumber < fromBlock {
+			kept = append(kept, t)
+		}
+	}
+	r.transfers = kept
+	return nil
+}
+
+func (r *memoryNFTRepo) GetByContractAndTokenID(chainID int64, contractAddress string, tokenID models.Uint256) (*models.NFT, error) {
+	for _, nft := range r.nfts {
+		collection := nft.Drop.Collection
+		if nft.TokenID.Cmp(tokenID) == 0 && collection.ChainID == chainID && collection.ContractAddress != nil && *collection.ContractAddress == contractAddress {
+			return nft, nil
+		}
+	}
+	return nil, repoInterfaces.ErrRecordNotFound
+}
+
+func (r *memoryNFTRepo) MarkMinted(id uint, block uint64, txHash, uri string, mintedAt time.Time) (bool, error) {
+	nft := r.nfts[id]
+	if nft.IsMinted {
+		return false, nil
+	}
+	nft.IsMinted, nft.MintBlock, nft.MintTxHash, nft.MintedURI, nft.MintedAt = true, &block, &txHash, uri, &mintedAt
+	return true, nil
+}
+
+func (r *memoryNFTRepo) RevertMintsFromBlock(chainID int64, fromBlock uint64) error {
+	for _, nft := range r.nfts {
+		if nft.IsMinted && nft.Drop.Collection.ChainID == chainID && *nft.MintBlock >= fromBlock {
+			nft.IsMinted, nft.MintBlock, nft.MintTxHash, nft.MintedURI, nft.MintedAt = false, nil, nil, "", nil
+		}
+	}
+	return nil
+}
+
+func (r *memoryVoucherRepo) MarkRedeemed(uint, string, string, string, time.Time) (*models.Voucher, error) {
+	return nil, repoInterfaces.ErrRecordNotFound
+}
+
+func (r *memoryCollectionRepo) ListWithContract(chainID int64) ([]*models.Collection, error) {
+	var collections []*models.Collection
+	for _, c := range r.collections {
+		if c.ChainID == chainID && c.ContractAddress != nil {
+			collections = append(collections, c)
+		}
+	}
+	return collections, nil
+}
+
+func TestIndexerRollsBackOrphanedBlocks(t *testing.T) {
+	ctx := context.Background()
+	voucherSigner, err := signerAdapters.GenerateMemorySigner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	minter, err := signerAdapters.GenerateMemorySigner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		minter.Address(): {Balance: new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18))},
+	})
+	t.Cleanup(func() { backend.Close() })
+	client := backend.Client()
+
+	contract, _, nftContract, err := contracts.DeployLazyMint1155(transactOpts(minter), client, voucherSigner.Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	forkPoint := backend.Commit() // block 1
+
+	address, signer := contract.Hex(), voucherSigner.Address().Hex()
+	collection := &models.Collection{CreatorID: 1, ChainID: 1337, ContractAddress: &address, SignerAddress: &signer}
+	collection.ID = 1
+	drop := &models.Drop{CollectionID: 1, Collection: collection, Status: models.DropStatusLive}
+	nft := &models.NFT{DropID: 1, Drop: drop, TokenID: models.NewUint256(big.NewInt(7)), MetadataURI: "ipfs://metadata", EditionSize: 1}
+	nft.ID = 1
+	nfts := &memoryNFTRepo{nfts: map[uint]*models.NFT{1: nft}}
+
+	vouchers := &memoryVoucherRepo{}
+	voucherService := NewVoucherService(nfts, vouchers, nil, voucherSigner, 0, "http://localhost", nil,
+		NewAllowlistService(nil, nil), NewSupplyService(&memoryReservationRepo{held: map[uint]*models.DropReservation{}}, 0))
+	owner := walletUser(1, minter.Address().Hex())
+	signed, err := voucherService.IssueVoucher(ctx, owner, nft.ID, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mintTx, err := nftContract.MintIfNotExists(transactOpts(minter), signed.Voucher, signed.Voucher.Owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend.Commit() // block 2 mints
+	backend.Commit() // block 3
+
+	var events reorgLog
+	checkpoints := &memoryCheckpointRepo{blocks: map[uint64]*models.IndexedBlock{}, log: &events}
+	transfers := &memoryTransferRepo{}
+	collections := &memoryCollectionRepo{collections: map[uint]*models.Collection{1: collection}}
+	indexer, err := NewIndexerService(client, collections, nfts, vouchers, transfers, checkpoints, &recordingBus{}, 1337, 1, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexer.AddRollbackHook(func(_ context.Context, _ int64, fromBlock uint64) error {
+		events = append(events, fmt.Sprintf("rollback from %d", fromBlock))
+		return nil
+	})
+
+	if err := indexer.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !nft.IsMinted || len(transfers.transfers) != 1 || checkpoints.checkpoint.BlockNumber != 3 {
+		t.Fatalf("mint was not indexed: minted %v, %d transfers, checkpoint %d", nft.IsMinted, len(transfers.transfers), checkpoints.checkpoint.BlockNumber)
+	}
+	orphaned := transfers.transfers[0].BlockHash
+
+	// replace blocks 2 and 3 with a longer fork; the orphaned mint goes back
+	// to the pool and may land in a different block
+	if err := backend.Fork(forkPoint); err != nil {
+		t.Fatal(err)
+	}
+	for range 3 {
+		backend.Commit()
+	}
+
+	events = nil
+	if err := indexer.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	rolledBack := false
+	for i := 0; i+1 < len(events); i++ {
+		rolledBack = rolledBack || events[i] == "rollback from 2" && events[i+1] == "rewind to 1"
+	}
+	if !rolledBack {
+		t.Fatalf("indexer did not roll back to the fork point: %v", events)
+	}
+
+	head, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkpoints.checkpoint.BlockNumber != head.Number.Uint64() || checkpoints.checkpoint.BlockHash != head.Hash().Hex() {
+		t.Fatalf("checkpoint = %d %s, want the new head %d %s", checkpoints.checkpoint.BlockNumber, checkpoints.checkpoint.BlockHash, head.Number, head.Hash().Hex())
+	}
+	for _, transfer := range transfers.transfers {
+		if transfer.BlockHash == orphaned {
+			t.Fatal("a transfer from an orphaned block survived the reorg")
+		}
+	}
+	receipt, err := client.TransactionReceipt(ctx, mintTx.Hash())
+	remined := err == nil
+	if remined != nft.IsMinted || remined != (len(transfers.transfers) == 1) {
+		t.Fatalf("mint mined again: %v, but nft minted %v with %d transfers", remined, nft.IsMinted, len(transfers.transfers))
+	}
+	if remined && *nft.MintBlock != receipt.BlockNumber.Uint64() {
+		t.Fatalf("nft mint block = %d, want %d", *nft.MintBlock, receipt.BlockNumber)
+	}
+}
+
+// Every range starts with the rollback hooks, so indexing one chain must
+// not touch the mints of another
+func TestIndexerRollbackLeavesOtherChainsAlone(t *testing.T) {
+	ctx := context.Background()
+	backend := simulated.NewBackend(types.GenesisAlloc{})
+	t.Cleanup(func() { backend.Close() })
+	for range 3 {
+		backend.Commit()
+	}
+
+	address := "0x00000000000000000000000000000000000000b1"
+	other := &models.Collection{CreatorID: 1, ChainID: 97, ContractAddress: &address}
+	other.ID = 2
+	block, txHash := uint64(1), "0x01"
+	nft := &models.NFT{DropID: 2, Drop: &models.Drop{CollectionID: 2, Collection: other}, TokenID: models.NewUint256(big.NewInt(7)),
+		IsMinted: true, MintBlock: &block, MintTxHash: &txHash}
+	nft.ID = 1
+	nfts := &memoryNFTRepo{nfts: map[uint]*models.NFT{1: nft}}
+
+	checkpoints := &memoryCheckpointRepo{blocks: map[uint64]*models.IndexedBlock{}, log: &reorgLog{}}
+	collections := &memoryCollectionRepo{collections: map[uint]*models.Collection{2: other}}
+	indexer, err := NewIndexerService(backend.Client(), collections, nfts, &memoryVoucherRepo{}, &memoryTransferRepo{}, checkpoints, &recordingBus{}, 1337, 1, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rolledBack []int64
+	indexer.AddRollbackHook(func(_ context.Context, chainID int64, _ uint64) error {
+		rolledBack = append(rolledBack, chainID)
+		return nil
+	})
+
+	if err := indexer.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(rolledBack) == 0 {
+		t.Fatal("no range was rolled back before indexing")
+	}
+	for _, chainID := range rolledBack {
+		if chainID != 1337 {
+			t.Fatalf("indexer of chain 1337 rolled back chain %d", chainID)
+		}
+	}
+	if !nft.IsMinted || *nft.MintBlock != 1 {
+		t.Fatal("indexing chain 1337 reverted a mint on chain 97")
+	}
+}
@@ -0,0 +1,296 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	chainInterfaces "github.com/igwedaniel/artizan/internal/interfaces/chain"
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"github.com/igwedaniel/artizan/pkg/contracts"
+	"github.com/igwedaniel/artizan/pkg/seaport"
+)
+
+const (
+	maxBasisPoints     = 10000
+	defaultListingTTL  = 30 * 24 * time.Hour
+	listingSaltEntropy = 32
+)
+
+// ListingService builds Seaport orders for creators listing NFTs that are not
+// minted yet. The order is restricted to the collection's LazyMintZone,
+// whose authorizeOrder mints the token to the creator from the voucher in
+// the order's extraData just before Seaport transfers it to the buyer.
+// Orders are built for the Seaport of the collection's chain.
+type ListingService struct {
+	nftRepo     repoInterfaces.NFTRepository
+	vouchers    *VoucherService
+	chains      chainInterfaces.Registry
+	platformFee *ListingFee
+	ttl         time.Duration
+}
+
+// NewListingService creates a new ListingService instance. A platform fee
+// recipient adds feeBasisPoints of every sale to each order.
+func NewListingService(
+	nftRepo repoInterfaces.NFTRepository,
+	vouchers *VoucherService,
+	chains chainInterfaces.Registry,
+	feeRecipient string,
+	feeBasisPoints uint,
+	ttl time.Duration,
+) *ListingService {
+	if ttl <= 0 {
+		ttl = defaultListingTTL
+	}
+	s := &ListingService{
+		nftRepo:  nftRepo,
+		vouchers: vouchers,
+		chains:   chains,
+		ttl:      ttl,
+	}
+	if feeRecipient != "" && feeBasisPoints > 0 {
+		s.platformFee = &ListingFee{Recipient: feeRecipient, BasisPoints: feeBasisPoints}
+	}
+	return s
+}
+
+// BuildListing returns the order actor signs to sell an NFT of one of their
+// collections
+func (s *ListingService) BuildListing(ctx context.Context, actor *models.User, nftID uint, input BuildListingInput) (*ListingOrder, error) {
+	price, err := models.ParseUint256(input.Price)
+	if err != nil || price.Big().Sign() == 0 {
+		return nil, ErrInvalidListingPrice
+	}
+	currency, currencyType, err := listingCurrency(input.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if !common.IsHexAddress(actor.WalletAddress) {
+		return nil, fmt.Errorf("user %d has no valid wallet address", actor.ID)
+	}
+
+	nft, err := s.nftRepo.GetByID(nftID)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrNFTNotFound
+		}
+		return nil, err
+	}
+	if nft.Drop == nil || nft.Drop.Collection == nil {
+		return nil, ErrCollectionNotFound
+	}
+	collection := nft.Drop.Collection
+	if collection.CreatorID != actor.ID {
+		return nil, ErrNotCollectionOwner
+	}
+	if nft.IsMinted {
+		return nil, ErrNFTAlreadyMinted
+	}
+	if collection.ContractAddress == nil {
+		return nil, ErrCollectionNotDeployed
+	}
+	if collection.ZoneAddress == nil {
+		return nil, ErrCollectionHasNoZone
+	}
+	network, err := s.chains.Network(collection.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	chainID := big.NewInt(network.ChainID)
+
+	quantity := input.Quantity
+	if quantity == 0 {
+		quantity = nft.EditionSize
+	}
+	if quantity > nft.EditionSize {
+		return nil, ErrInvalidListingQuantity
+	}
+	fees, err := s.listingFees(input.Fees)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	expiresAt := now.Add(s.ttl)
+	if input.ExpiresAt != nil {
+		expiresAt = *input.ExpiresAt
+	}
+	if !expiresAt.After(now) {
+		return nil, ErrInvalidListingExpiry
+	}
+
+	offerer := common.HexToAddress(actor.WalletAddress)
+	if err := listingApproved(ctx, network, common.HexToAddress(*collection.ContractAddress), offerer); err != nil {
+		return nil, err
+	}
+
+	// the voucher mints the whole edition to the creator on the first sale,
+	// so later listings of the remaining copies need no voucher
+	voucher, err := s.vouchers.IssueListingVoucher(ctx, actor, nft.ID, new(big.Int).SetUint64(nft.EditionSize), expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	extraData, err := hexutil.Decode(voucher.Encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode voucher: %w", err)
+	}
+
+	counter, err := seaport.GetCounter(ctx, network.Client, network.Seaport, offerer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seaport counter: %w", err)
+	}
+	salt, err := listingSalt()
+	if err != nil {
+		return nil, err
+	}
+	consideration, err := listingConsideration(offerer, currency, currencyType, price.Big(), quantity, fees)
+	if err != nil {
+		return nil, err
+	}
+	orderType := seaport.OrderTypeFullRestricted
+	if quantity > 1 {
+		orderType = seaport.OrderTypePartialRestricted
+	}
+	order := seaport.OrderComponents{
+		Offerer: offerer,
+		Zone:    common.HexToAddress(*collection.ZoneAddress),
+		Offer: []seaport.OfferItem{{
+			ItemType:             seaport.ItemTypeERC1155,
+			Token:                common.HexToAddress(*collection.ContractAddress),
+			IdentifierOrCriteria: nft.TokenID.Big(),
+			StartAmount:          new(big.Int).SetUint64(quantity),
+			EndAmount:            new(big.Int).SetUint64(quantity),
+		}},
+		Consideration: consideration,
+		OrderType:     orderType,
+		StartTime:     big.NewInt(now.Unix()),
+		EndTime:       big.NewInt(expiresAt.Unix()),
+		// the zone ignores zoneHash; committing to the voucher lets anyone
+		// holding the order check the extraData they were given
+		ZoneHash:   crypto.Keccak256Hash(extraData),
+		Salt:       salt,
+		ConduitKey: network.ConduitKey,
+		Counter:    counter,
+	}
+
+	orderHash := seaport.OrderHash(order)
+	return &ListingOrder{
+		NFTID:     nft.ID,
+		ChainID:   network.ChainID,
+		Seaport:   network.Seaport.Hex(),
+		OrderHash: orderHash.Hex(),
+		Digest:    seaport.Digest(chainID, network.Seaport, orderHash).Hex(),
+		Order:     order,
+		ExtraData: voucher.Encoded,
+		TypedData: seaport.TypedData(chainID, network.Seaport, order),
+		Voucher:   voucher,
+		Fees:      fees,
+	}, nil
+}
+
+// listingApproved checks seller let the account the order's conduit key
+// resolves to, Seaport itself or its conduit, move their copies of contract.
+// The copies only exist once the zone mints them to seller, so without the
+// approval every fill reverts.
+func listingApproved(ctx context.Context, network *chainInterfaces.Network, contract, seller common.Address) error {
+	operator, err := offerConduit(ctx, network)
+	if err != nil {
+		return err
+	}
+	token, err := contracts.NewLazyMint1155Caller(contract, network.Client)
+	if err != nil {
+		return err
+	}
+	approved, err := token.IsApprovedForAll(&bind.CallOpts{Context: ctx}, seller, operator)
+	if err != nil {
+		return fmt.Errorf("failed to read the seaport approval: %w", err)
+	}
+	if !approved {
+		return fmt.Errorf("%w: call setApprovalForAll(%s, true) on %s", ErrListingNotApproved, operator.Hex(), contract.Hex())
+	}
+	return nil
+}
+
+// listingFees puts the platform fee first and checks the total leaves the
+// seller something
+func (s *ListingService) listingFees(requested []ListingFee) ([]ListingFee, error) {
+	fees := make([]ListingFee, 0, len(requested)+1)
+	if s.platformFee != nil {
+		fees = append(fees, *s.platformFee)
+	}
+	fees = append(fees, requested...)
+	var total uint
+	for i, fee := range fees {
+		if !common.IsHexAddress(fee.Recipient) || fee.BasisPoints == 0 {
+			return nil, ErrInvalidListingFees
+		}
+		fees[i].Recipient = common.HexToAddress(fee.Recipient).Hex()
+		total += fee.BasisPoints
+	}
+	if total >= maxBasisPoints {
+		return nil, ErrInvalidListingFees
+	}
+	return fees, nil
+}
+
+// listingConsideration pays the seller first, then each fee. Amounts are
+// worked out per copy and multiplied by quantity so partial fills divide
+// every item exactly.
+func listingConsideration(seller, currency common.Address, itemType seaport.ItemType, unitPrice *big.Int, quantity uint64, fees []ListingFee) ([]seaport.ConsiderationItem, error) {
+	copies := new(big.Int).SetUint64(quantity)
+	item := func(unitAmount *big.Int, recipient common.Address) seaport.ConsiderationItem {
+		amount := new(big.Int).Mul(unitAmount, copies)
+		return seaport.ConsiderationItem{
+			ItemType:             itemType,
+			Token:                currency,
+			IdentifierOrCriteria: new(big.Int),
+			StartAmount:          amount,
+			EndAmount:            new(big.Int).Set(amount),
+			Recipient:            recipient,
+		}
+	}
+
+	sellerShare := new(big.Int).Set(unitPrice)
+	feeItems := make([]seaport.ConsiderationItem, 0, len(fees))
+	for _, fee := range fees {
+		share := new(big.Int).Mul(unitPrice, big.NewInt(int64(fee.BasisPoints)))
+		share.Div(share, big.NewInt(maxBasisPoints))
+		if share.Sign() == 0 {
+			continue
+		}
+		sellerShare.Sub(sellerShare, share)
+		feeItems = append(feeItems, item(share, common.HexToAddress(fee.Recipient)))
+	}
+	if sellerShare.Sign() <= 0 {
+		return nil, ErrInvalidListingFees
+	}
+	return append([]seaport.ConsiderationItem{item(sellerShare, seller)}, feeItems...), nil
+}
+
+func listingCurrency(currency string) (common.Address, seaport.ItemType, error) {
+	currency = strings.TrimSpace(currency)
+	if currency == "" {
+		return common.Address{}, seaport.ItemTypeNative, nil
+	}
+	if !common.IsHexAddress(currency) || common.HexToAddress(currency) == (common.Address{}) {
+		return common.Address{}, 0, ErrInvalidListingCurrency
+	}
+	return common.HexToAddress(currency), seaport.ItemTypeERC20, nil
+}
+
+func listingSalt() (*big.Int, error) {
+	b := make([]byte, listingSaltEntropy)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	signerAdapters "github.com/igwedaniel/artizan/internal/adapters/signer"
+	chainInterfaces "github.com/igwedaniel/artizan/internal/interfaces/chain"
+	"github.com/igwedaniel/artizan/pkg/contracts"
+)
+
+func TestListingRequiresSeaportApproval(t *testing.T) {
+	owner, err := signerAdapters.GenerateMemorySigner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	seller, err := signerAdapters.GenerateMemorySigner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	funds := new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18))
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		owner.Address():  {Balance: funds},
+		seller.Address(): {Balance: funds},
+	})
+	t.Cleanup(func() { backend.Close() })
+	address, _, token, err := contracts.DeployLazyMint1155(transactOpts(owner), backend.Client(), owner.Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend.Commit()
+
+	// a zero conduit key has Seaport itself move the tokens
+	network := &chainInterfaces.Network{ChainID: 1337, Client: backend.Client(), Seaport: common.HexToAddress("0x00000000000000ADc04C56Bf30aC9d3c0aAF14dC")}
+	ctx := context.Background()
+	if err := listingApproved(ctx, network, address, seller.Address()); !errors.Is(err, ErrListingNotApproved) {
+		t.Fatalf("err = %v, want %v", err, ErrListingNotApproved)
+	}
+
+	if _, err := token.SetApprovalForAll(transactOpts(seller), network.Seaport, true); err != nil {
+		t.Fatal(err)
+	}
+	backend.Commit()
+	if err := listingApproved(ctx, network, address, seller.Address()); err != nil {
+		t.Fatalf("approved seller was refused: %v", err)
+	}
+
+	// a global approval by the contract owner covers sellers who never opted out
+	other := common.HexToAddress("0x00000000000000000000000000000000000000e5")
+	if _, err := token.SetGlobalApproval(transactOpts(owner), network.Seaport, true); err != nil {
+		t.Fatal(err)
+	}
+	backend.Commit()
+	if err := listingApproved(ctx, network, address, other); err != nil {
+		t.Fatalf("globally approved seller was refused: %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/igwedaniel/artizan/pkg/seaport"
+)
+
+// ListingFee sends a share of the price to recipient
+type ListingFee struct {
+	Recipient   string `json:"recipient"`
+	BasisPoints uint   `json:"basis_points"`
+}
+
+// BuildListingInput describes a creator's fixed price listing. Price is per
+// copy in the currency's smallest unit; an empty currency is the native coin.
+type BuildListingInput struct {
+	Price     string       `json:"price"`
+	Currency  string       `json:"currency"`
+	Quantity  uint64       `json:"quantity"` // defaults to the edition size
+	Fees      []ListingFee `json:"fees"`
+	ExpiresAt *time.Time   `json:"expires_at"`
+}
+
+// ListingOrder is an unsigned Seaport order selling an unminted NFT through
+// its collection's LazyMintZone. The creator signs Digest, or TypedData with
+// eth_signTypedData_v4; fulfillers pass ExtraData with the signed order so the
+// zone can mint the token before Seaport transfers it.
+type ListingOrder struct {
+	NFTID     uint                    `json:"nft_id"`
+	ChainID   int64                   `json:"chain_id"`
+	Seaport   string                  `json:"seaport"`
+	OrderHash string                  `json:"order_hash"`
+	Digest    string                  `json:"digest"`
+	Order     seaport.OrderComponents `json:"order"`
+	ExtraData string                  `json:"extra_data"` // abi.encode(voucher)
+	TypedData apitypes.TypedData      `json:"typed_data"`
+	Voucher   *SignedVoucher          `json:"voucher"`
+	Fees      []ListingFee            `json:"fees"`
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+const maxNFTNameLength = 200
+
+// ERC-1155 clients replace {id} with the token ID as 64 lowercase hex characters
+var tokenIDHexPattern = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+
+type NFTService struct {
+	nftRepo     repoInterfaces.NFTRepository
+	dropRepo    repoInterfaces.DropRepository
+	voucherRepo repoInterfaces.VoucherRepository
+	allocator   *TokenIDAllocator
+}
+
+// NewNFTService creates a new NFTService instance
+func NewNFTService(nftRepo repoInterfaces.NFTRepository, dropRepo repoInterfaces.DropRepository, voucherRepo repoInterfaces.VoucherRepository, allocator *TokenIDAllocator) *NFTService {
+	return &NFTService{
+		nftRepo:     nftRepo,
+		dropRepo:    dropRepo,
+		voucherRepo: voucherRepo,
+		allocator:   allocator,
+	}
+}
+
+// Create adds an NFT to a drop owned by actor and assigns it a token ID
+func (s *NFTService) Create(actor *models.User, dropID uint, input CreateNFTInput) (*models.NFT, error) {
+	nft := &models.NFT{
+		Name:         strings.TrimSpace(input.Name),
+		Description:  strings.TrimSpace(input.Description),
+		Image:        strings.TrimSpace(input.Image),
+		AnimationURL: strings.TrimSpace(input.AnimationURL),
+		Attributes:   input.Attributes,
+		MetadataURI:  strings.TrimSpace(input.MetadataURI),
+		EditionSize:  input.EditionSize,
+	}
+	if nft.EditionSize == 0 {
+		nft.EditionSize = 1
+	}
+	if err := validateNFTMetadata(nft); err != nil {
+		return nil, err
+	}
+	drop, err := ownedDrop(s.dropRepo, actor, dropID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenID, err := s.allocator.Allocate(drop.ID)
+	if err != nil {
+		return nil, err
+	}
+	nft.DropID = drop.ID
+	nft.TokenID = tokenID
+	if err := s.nftRepo.Create(nft); err != nil {
+		return nil, fmt.Errorf("failed to create nft: %w", err)
+	}
+	return nft, nil
+}
+
+func (s *NFTService) GetByID(id uint) (*models.NFT, error) {
+	nft, err := s.nftRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrNFTNotFound
+		}
+		return nil, err
+	}
+	return nft, nil
+}
+
+// Update changes the metadata fields of an NFT whose collection belongs to
+// actor. Hosted metadata changes take effect immediately, minted or not. The
+// edition size is fixed once a voucher was signed or a copy minted, since
+// signed vouchers and supply accounting carry it.
+func (s *NFTService) Update(actor *models.User, id uint, input UpdateNFTInput) (*models.NFT, error) {
+	nft, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if nft.Drop == nil || nft.Drop.Collection == nil || nft.Drop.Collection.CreatorID != actor.ID {
+		return nil, ErrNotCollectionOwner
+	}
+	if nft.Drop.Collection.ArchivedAt != nil {
+		return nil, ErrCollectionArchived
+	}
+
+	if input.Name != nil {
+		nft.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.Description != nil {
+		nft.Description = strings.TrimSpace(*input.Description)
+	}
+	if input.Image != nil {
+		nft.Image = strings.TrimSpace(*input.Image)
+	}
+	if input.AnimationURL != nil {
+		nft.AnimationURL = strings.TrimSpace(*input.AnimationURL)
+	}
+	if input.Attributes != nil {
+		nft.Attributes = *input.Attributes
+	}
+	if input.EditionSize != nil && *input.EditionSize != nft.EditionSize {
+		if err := s.checkEditionSizeChangeable(nft); err != nil {
+			return nil, err
+		}
+		nft.EditionSize = *input.EditionSize
+	}
+	if err := validateNFTMetadata(nft); err != nil {
+		return nil, err
+	}
+	if err := s.nftRepo.Update(nft); err != nil {
+		return nil, fmt.Errorf("failed to update nft: %w", err)
+	}
+	return nft, nil
+}
+
+// checkEditionSizeChangeable fails once any voucher, whatever its status,
+// was issued for nft or a copy of it minted
+func (s *NFTService) checkEditionSizeChangeable(nft *models.NFT) error {
+	if nft.IsMinted {
+		return ErrEditionSizeLocked
+	}
+	vouchers, err := s.voucherRepo.ListByNFT(nft.ID)
+	if err != nil {
+		return err
+	}
+	if len(vouchers) > 0 {
+		return ErrEditionSizeLocked
+	}
+	return nil
+}
+
+// Metadata renders the ERC-1155 metadata of the token tokenIDHex of a
+// collection contract on chainID
+func (s *NFTService) Metadata(chainID int64, contractAddress, tokenIDHex string) (*TokenMetadata, error) {
+	if !common.IsHexAddress(contractAddress) || !tokenIDHexPattern.MatchString(tokenIDHex) {
+		return nil, ErrNFTNotFound
+	}
+	tokenID, err := models.ParseUint256("0x" + tokenIDHex)
+	if err != nil {
+		return nil, ErrNFTNotFound
+	}
+	nft, err := s.nftRepo.GetByContractAndTokenID(chainID, common.HexToAddress(contractAddress).Hex(), tokenID)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrNFTNotFound
+		}
+		return nil, err
+	}
+
+	return renderTokenMetadata(nft), nil
+}
+
+// renderTokenMetadata builds the metadata JSON of an NFT. The output is
+// deterministic, so the same fields always pin to the same CID.
+func renderTokenMetadata(nft *models.NFT) *TokenMetadata {
+	attributes := nft.Attributes
+	if attributes == nil {
+		attributes = models.NFTAttributes{}
+	}
+	return &TokenMetadata{
+		Name:         nft.Name,
+		Description:  nft.Description,
+		Image:        nft.Image,
+		AnimationURL: nft.AnimationURL,
+		Attributes:   attributes,
+	}
+}
+
+// TokenMetadataURI is the ERC-1155 URI template under which the backend
+// serves the metadata of every token of a contract on chainID
+func TokenMetadataURI(baseURL string, chainID int64, contractAddress string) string {
+	return strings.TrimRight(baseURL, "/") + "/metadata/" + strconv.FormatInt(chainID, 10) + "/" + strings.ToLower(contractAddress) + "/{id}.json"
+}
+
+// ownedDrop loads a drop whose collection belongs to actor and is not archived
+func ownedDrop(dropRepo repoInterfaces.DropRepository, actor *models.User, dropID uint) (*models.Drop, error) {
+	drop, err := dropRepo.GetByID(dropID)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrDropNotFound
+		}
+		return nil, err
+	}
+	if drop.Collection == nil || drop.Collection.CreatorID != actor.ID {
+		return nil, ErrNotCollectionOwner
+	}
+	if drop.Collection.ArchivedAt != nil {
+		return nil, ErrCollectionArchived
+	}
+	return drop, nil
+}
+
+// validateNFTMetadata requires a name unless the metadata is hosted elsewhere,
+// and absolute URIs for every link
+func validateNFTMetadata(nft *models.NFT) error {
+	if nft.MetadataURI == "" && nft.Name == "" {
+		return ErrInvalidNFTName
+	}
+	if len(nft.Name) > maxNFTNameLength {
+		return ErrInvalidNFTName
+	}
+	for _, link := range []string{nft.MetadataURI, nft.Image, nft.AnimationURL} {
+		if link == "" {
+			continue
+		}
+		if u, err := url.Parse(link); err != nil || u.Scheme == "" {
+			return ErrInvalidMetadataURI
+		}
+	}
+	for _, attribute := range nft.Attributes {
+		if attribute.Value == nil {
+			return ErrInvalidNFTAttributes
+		}
+	}
+	if nft.EditionSize == 0 {
+		return ErrInvalidEditionSize
+	}
+	return nil
+}
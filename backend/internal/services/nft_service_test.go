@@ -0,0 +1,113 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+func (r *memoryNFTRepo) Update(*models.NFT) error { return nil }
+
+func (r *memoryVoucherRepo) ListByNFT(nftID uint) ([]*models.Voucher, error) {
+	var vouchers []*models.Voucher
+	for _, v := range r.vouchers {
+		if v.NFTID == nftID {
+			vouchers = append(vouchers, v)
+		}
+	}
+	return vouchers, nil
+}
+
+func newNFTTest() (*NFTService, *models.NFT, *memoryVoucherRepo, *models.User) {
+	creator := &models.User{}
+	creator.ID = 7
+	collection := &models.Collection{CreatorID: creator.ID}
+	nft := &models.NFT{Name: "Sunrise", EditionSize: 10, Drop: &models.Drop{Collection: collection}}
+	nft.ID = 1
+	vouchers := &memoryVoucherRepo{}
+	s := NewNFTService(&memoryNFTRepo{nfts: map[uint]*models.NFT{1: nft}}, nil, vouchers, nil)
+	return s, nft, vouchers, creator
+}
+
+func TestEditionSizeChangesBeforeAnyVoucher(t *testing.T) {
+	s, _, _, creator := newNFTTest()
+	size := uint64(25)
+
+	nft, err := s.Update(creator, 1, UpdateNFTInput{EditionSize: &size})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nft.EditionSize != 25 {
+		t.Fatalf("edition size = %d, want 25", nft.EditionSize)
+	}
+}
+
+func TestEditionSizeLockedOnceVouchedOrMinted(t *testing.T) {
+	for name, setup := range map[string]func(*models.NFT, *memoryVoucherRepo){
+		"issued voucher": func(nft *models.NFT, vouchers *memoryVoucherRepo) {
+			vouchers.vouchers = append(vouchers.vouchers, &models.Voucher{NFTID: nft.ID, Status: models.VoucherStatusIssued})
+		},
+		"revoked voucher": func(nft *models.NFT, vouchers *memoryVoucherRepo) {
+			vouchers.vouchers = append(vouchers.vouchers, &models.Voucher{NFTID: nft.ID, Status: models.VoucherStatusRevoked})
+		},
+		"creator voucher": func(nft *models.NFT, vouchers *memoryVoucherRepo) {
+			vouchers.vouchers = append(vouchers.vouchers, &models.Voucher{NFTID: nft.ID, Status: models.VoucherStatusIssued, ForCreator: true})
+		},
+		"minted copy": func(nft *models.NFT, _ *memoryVoucherRepo) {
+			nft.IsMinted = true
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s, nft, vouchers, creator := newNFTTest()
+			setup(nft, vouchers)
+			size := uint64(25)
+
+			if _, err := s.Update(creator, 1, UpdateNFTInput{EditionSize: &size}); !errors.Is(err, ErrEditionSizeLocked) {
+				t.Fatalf("err = %v, want %v", err, ErrEditionSizeLocked)
+			}
+			if nft.EditionSize != 10 {
+				t.Fatalf("edition size = %d, want it kept at 10", nft.EditionSize)
+			}
+
+			// other fields and an unchanged size still go through
+			same, name := uint64(10), "Sunset"
+			updated, err := s.Update(creator, 1, UpdateNFTInput{Name: &name, EditionSize: &same})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if updated.Name != "Sunset" {
+				t.Fatalf("name = %q, want Sunset", updated.Name)
+			}
+		})
+	}
+}
+
+func TestMetadataIsScopedToItsChain(t *testing.T) {
+	contract := "0x00000000000000000000000000000000000000B1"
+	nft := &models.NFT{Name: "Sunrise", TokenID: models.NewUint256(big.NewInt(7)),
+		Drop: &models.Drop{Collection: &models.Collection{ChainID: 1337, ContractAddress: &contract}}}
+	nft.ID = 1
+	s := NewNFTService(&memoryNFTRepo{nfts: map[uint]*models.NFT{1: nft}}, nil, nil, nil)
+	tokenIDHex := fmt.Sprintf("%064x", 7)
+
+	metadata, err := s.Metadata(1337, strings.ToLower(contract), tokenIDHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metadata.Name != "Sunrise" {
+		t.Fatalf("name = %q, want Sunrise", metadata.Name)
+	}
+	// the same contract address deployed on another chain
+	if _, err := s.Metadata(97, contract, tokenIDHex); !errors.Is(err, ErrNFTNotFound) {
+		t.Fatalf("err = %v, want %v", err, ErrNFTNotFound)
+	}
+
+	want := "https://api.example.com/metadata/1337/" + strings.ToLower(contract) + "/{id}.json"
+	if uri := TokenMetadataURI("https://api.example.com/", 1337, contract); uri != want {
+		t.Fatalf("uri = %s, want %s", uri, want)
+	}
+}
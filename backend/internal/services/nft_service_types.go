@@ -0,0 +1,34 @@
+package services
+
+import "github.com/igwedaniel/artizan/internal/models"
+
+type CreateNFTInput struct {
+	Name         string               `json:"name"`
+	Description  string               `json:"description"`
+	Image        string               `json:"image"`
+	AnimationURL string               `json:"animation_url"`
+	Attributes   models.NFTAttributes `json:"attributes"`
+	EditionSize  uint64               `json:"edition_size"` // defaults to 1
+	// MetadataURI points the token at externally hosted metadata instead of /metadata
+	MetadataURI string `json:"metadata_uri"`
+}
+
+// UpdateNFTInput holds the fields to change; nil fields are left as is
+type UpdateNFTInput struct {
+	Name         *string               `json:"name"`
+	Description  *string               `json:"description"`
+	Image        *string               `json:"image"`
+	AnimationURL *string               `json:"animation_url"`
+	Attributes   *models.NFTAttributes `json:"attributes"`
+	EditionSize  *uint64               `json:"edition_size"`
+}
+
+// TokenMetadata is the ERC-1155 metadata JSON of a token, with the OpenSea
+// animation_url and attributes extensions
+type TokenMetadata struct {
+	Name         string               `json:"name"`
+	Description  string               `json:"description"`
+	Image        string               `json:"image"`
+	AnimationURL string               `json:"animation_url,omitempty"`
+	Attributes   models.NFTAttributes `json:"attributes"`
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+const (
+	defaultNotificationPageSize = 20
+	maxNotificationPageSize     = 100
+)
+
+type NotificationService struct {
+	notificationRepo repoInterfaces.NotificationRepository
+}
+
+// NewNotificationService creates a new NotificationService instance
+func NewNotificationService(notificationRepo repoInterfaces.NotificationRepository) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+	}
+}
+
+// Notify stores a notification for a user unless they disabled its type.
+// It returns nil without storing anything when the type is disabled.
+func (s *NotificationService) Notify(userID uint, notificationType, title, body string, data models.NotificationData) error {
+	return s.notify(nil, userID, notificationType, title, body, data)
+}
+
+// NotifyOnce is Notify for an event that may be handled more than once:
+// the notification is stored once per dedupeKey, user and type.
+func (s *NotificationService) NotifyOnce(dedupeKey string, userID uint, notificationType, title, body string, data models.NotificationData) error {
+	key := fmt.Sprintf("%s:%s:%d", dedupeKey, notificationType, userID)
+	return s.notify(&key, userID, notificationType, title, body, data)
+}
+
+func (s *NotificationService) notify(dedupeKey *string, userID uint, notificationType, title, body string, data models.NotificationData) error {
+	if userID == 0 {
+		return nil
+	}
+	enabled, err := s.isEnabled(userID, notificationType)
+	if err != nil {
+		return fmt.Errorf("failed to load notification preferences: %w", err)
+	}
+	if !enabled {
+		return nil
+	}
+
+	notification := &models.Notification{
+		UserID:    userID,
+		Type:      notificationType,
+		Title:     title,
+		Body:      body,
+		Data:      data,
+		DedupeKey: dedupeKey,
+	}
+	if err := s.notificationRepo.Create(notification); err != nil {
+		if errors.Is(err, repoInterfaces.ErrDuplicateRecord) {
+			return nil // stored by an earlier delivery
+		}
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+	return nil
+}
+
+// list notifications of a user, newest first
+func (s *NotificationService) List(userID uint, unreadOnly bool, limit, offset int) ([]*models.Notification, error) {
+	if limit <= 0 {
+		limit = defaultNotificationPageSize
+	}
+	if limit > maxNotificationPageSize {
+		limit = maxNotificationPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.notificationRepo.ListByUser(userID, unreadOnly, limit, offset)
+}
+
+func (s *NotificationService) UnreadCount(userID uint) (int64, error) {
+	return s.notificationRepo.CountUnread(userID)
+}
+
+func (s *NotificationService) MarkRead(userID uint, ids []uint) error {
+	return s.notificationRepo.MarkRead(userID, ids)
+}
+
+func (s *NotificationService) MarkAllRead(userID uint) error {
+	return s.notificationRepo.MarkAllRead(userID)
+}
+
+// GetPreferences returns the enabled state of every notification type
+func (s *NotificationService) GetPreferences(userID uint) (map[string]bool, error) {
+	stored, err := s.notificationRepo.GetPreferences(userID)
+	if err != nil {
+		return nil, err
+	}
+	preferences := make(map[string]bool, len(models.NotificationTypes))
+	for _, t := range models.NotificationTypes {
+		preferences[t] = true
+	}
+	for _, p := range stored {
+		preferences[p.Type] = p.Enabled
+	}
+	return preferences, nil
+}
+
+// UpdatePreferences stores the given per-type toggles and returns the full set
+func (s *NotificationService) UpdatePreferences(userID uint, updates map[string]bool) (map[string]bool, error) {
+	for t := range updates {
+		if !slices.Contains(models.NotificationTypes, t) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownNotificationType, t)
+		}
+	}
+	for t, enabled := range updates {
+		preference := &models.NotificationPreference{
+			UserID:  userID,
+			Type:    t,
+			Enabled: enabled,
+		}
+		if err := s.notificationRepo.UpsertPreference(preference); err != nil {
+			return nil, err
+		}
+	}
+	return s.GetPreferences(userID)
+}
+
+func (s *NotificationService) isEnabled(userID uint, notificationType string) (bool, error) {
+	preferences, err := s.notificationRepo.GetPreferences(userID)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range preferences {
+		if p.Type == notificationType {
+			return p.Enabled, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"testing"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+// memoryNotificationRepo enforces the unique dedupe key like Postgres does
+type memoryNotificationRepo struct {
+	repoInterfaces.NotificationRepository
+	notifications []*models.Notification
+}
+
+func (r *memoryNotificationRepo) Create(n *models.Notification) error {
+	for _, stored := range r.notifications {
+		if n.DedupeKey != nil && stored.DedupeKey != nil && *n.DedupeKey == *stored.DedupeKey {
+			return repoInterfaces.ErrDuplicateRecord
+		}
+	}
+	n.ID = uint(len(r.notifications) + 1)
+	r.notifications = append(r.notifications, n)
+	return nil
+}
+
+func (r *memoryNotificationRepo) GetPreferences(uint) ([]*models.NotificationPreference, error) {
+	return nil, nil
+}
+
+func TestNotifyOnceStoresOneNotificationPerKey(t *testing.T) {
+	repo := &memoryNotificationRepo{}
+	s := NewNotificationService(repo)
+
+	for range 2 {
+		for _, userID := range []uint{1, 2} {
+			if err := s.NotifyOnce("drop_event:1", userID, models.NotificationTypeDropStarted, "Your drop is live", "", nil); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := s.NotifyOnce("drop_event:2", 1, models.NotificationTypeDropStarted, "Your drop is live", "", nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.notifications) != 3 {
+		t.Fatalf("%d notifications stored, want one per user and event", len(repo.notifications))
+	}
+
+	// plain notifications are never deduplicated
+	for range 2 {
+		if err := s.Notify(1, models.NotificationTypeNewFollower, "New follower", "", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(repo.notifications) != 5 {
+		t.Fatalf("%d notifications stored, want both new follower notifications", len(repo.notifications))
+	}
+}
@@ -0,0 +1,691 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	chainInterfaces "github.com/igwedaniel/artizan/internal/interfaces/chain"
+	busInterfaces "github.com/igwedaniel/artizan/internal/interfaces/eventbus"
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"github.com/igwedaniel/artizan/pkg/contracts"
+	"github.com/igwedaniel/artizan/pkg/seaport"
+)
+
+const (
+	defaultOfferTTL = 7 * 24 * time.Hour
+	// holders told about a new offer on their token
+	maxOfferRecipients = 100
+)
+
+// OfferService takes bids from collectors: Seaport orders offering an
+// ERC-20 for copies of one token, or of any token of a collection through a
+// criteria item. Offers are open orders; sellers accept them by fulfilling
+// the order with the token, paying the marketplace fee out of the price.
+type OfferService struct {
+	offerRepo      repoInterfaces.OfferRepository
+	nftRepo        repoInterfaces.NFTRepository
+	collectionRepo repoInterfaces.CollectionRepository
+	balanceRepo    repoInterfaces.TokenBalanceRepository
+	userRepo       repoInterfaces.UserRepository
+	eventBus       busInterfaces.EventBus
+	chains         chainInterfaces.Registry
+	platformFee    *ListingFee
+	ttl            time.Duration
+}
+
+// NewOfferService creates a new OfferService instance. Offers without a
+// currency bid the wrapped native coin of their chain; chains without one
+// require the currency.
+func NewOfferService(
+	offerRepo repoInterfaces.OfferRepository,
+	nftRepo repoInterfaces.NFTRepository,
+	collectionRepo repoInterfaces.CollectionRepository,
+	balanceRepo repoInterfaces.TokenBalanceRepository,
+	userRepo repoInterfaces.UserRepository,
+	eventBus busInterfaces.EventBus,
+	chains chainInterfaces.Registry,
+	feeRecipient string,
+	feeBasisPoints uint,
+	ttl time.Duration,
+) *OfferService {
+	if ttl <= 0 {
+		ttl = defaultOfferTTL
+	}
+	s := &OfferService{
+		offerRepo:      offerRepo,
+		nftRepo:        nftRepo,
+		collectionRepo: collectionRepo,
+		balanceRepo:    balanceRepo,
+		userRepo:       userRepo,
+		eventBus:       eventBus,
+		chains:         chains,
+		ttl:            ttl,
+	}
+	if common.IsHexAddress(feeRecipient) && feeBasisPoints > 0 && feeBasisPoints < maxBasisPoints {
+		s.platformFee = &ListingFee{Recipient: common.HexToAddress(feeRecipient).Hex(), BasisPoints: feeBasisPoints}
+	}
+	return s
+}
+
+// BuildOffer returns the order actor signs to bid on an NFT or a collection
+func (s *OfferService) BuildOffer(ctx context.Context, actor *models.User, input BuildOfferInput) (*OfferOrder, error) {
+	price, err := models.ParseUint256(input.Price)
+	if err != nil || price.Big().Sign() == 0 {
+		return nil, ErrInvalidOfferPrice
+	}
+	if !common.IsHexAddress(actor.WalletAddress) {
+		return nil, fmt.Errorf("user %d has no valid wallet address", actor.ID)
+	}
+	if (input.NFTID == 0) == (input.CollectionID == 0) {
+		return nil, ErrInvalidOfferTarget
+	}
+
+	quantity := input.Quantity
+	if quantity == 0 {
+		quantity = 1
+	}
+	var nft *models.NFT
+	var collection *models.Collection
+	if input.NFTID != 0 {
+		if nft, err = s.biddableNFT(input.NFTID); err != nil {
+			return nil, err
+		}
+		collection = nft.Drop.Collection
+		if quantity > nft.EditionSize {
+			return nil, ErrInvalidOfferQuantity
+		}
+	} else {
+		if collection, err = s.collectionRepo.GetByID(input.CollectionID); err != nil {
+			if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+				return nil, ErrCollectionNotFound
+			}
+			return nil, err
+		}
+		if collection.ContractAddress == nil {
+			return nil, ErrCollectionNotDeployed
+		}
+	}
+	network, err := s.chains.Network(collection.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	currency, err := offerCurrency(network, input.Currency)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	expiresAt := now.Add(s.ttl)
+	if input.ExpiresAt != nil {
+		expiresAt = *input.ExpiresAt
+	}
+	if !expiresAt.After(now) {
+		return nil, ErrInvalidOfferExpiry
+	}
+
+	bidder := common.HexToAddress(actor.WalletAddress)
+	counter, err := seaport.GetCounter(ctx, network.Client, network.Seaport, bidder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seaport counter: %w", err)
+	}
+	conduit, err := offerConduit(ctx, network)
+	if err != nil {
+		return nil, err
+	}
+	salt, err := listingSalt()
+	if err != nil {
+		return nil, err
+	}
+
+	copies := new(big.Int).SetUint64(quantity)
+	wanted := seaport.ConsiderationItem{
+		ItemType:             seaport.ItemTypeERC1155WithCriteria,
+		Token:                common.HexToAddress(*collection.ContractAddress),
+		IdentifierOrCriteria: new(big.Int),
+		StartAmount:          copies,
+		EndAmount:            new(big.Int).Set(copies),
+		Recipient:            bidder,
+	}
+	var nftID *uint
+	if nft != nil {
+		wanted.ItemType = seaport.ItemTypeERC1155
+		wanted.IdentifierOrCriteria = nft.TokenID.Big()
+		nftID = &nft.ID
+	}
+	total := new(big.Int).Mul(price.Big(), copies)
+	consideration := []seaport.ConsiderationItem{wanted}
+	var fees []ListingFee
+	if s.platformFee != nil {
+		share := new(big.Int).Mul(price.Big(), big.NewInt(int64(s.platformFee.BasisPoints)))
+		share.Div(share, big.NewInt(maxBasisPoints))
+		if share.Sign() > 0 {
+			fees = append(fees, *s.platformFee)
+			share.Mul(share, copies)
+			consideration = append(consideration, seaport.ConsiderationItem{
+				ItemType:             seaport.ItemTypeERC20,
+				Token:                currency,
+				IdentifierOrCriteria: new(big.Int),
+				StartAmount:          share,
+				EndAmount:            new(big.Int).Set(share),
+				Recipient:            common.HexToAddress(s.platformFee.Recipient),
+			})
+		}
+	}
+	orderType := seaport.OrderTypeFullOpen
+	if quantity > 1 {
+		orderType = seaport.OrderTypePartialOpen
+	}
+	order := seaport.OrderComponents{
+		Offerer: bidder,
+		Offer: []seaport.OfferItem{{
+			ItemType:             seaport.ItemTypeERC20,
+			Token:                currency,
+			IdentifierOrCriteria: new(big.Int),
+			StartAmount:          total,
+			EndAmount:            new(big.Int).Set(total),
+		}},
+		Consideration: consideration,
+		OrderType:     orderType,
+		StartTime:     big.NewInt(now.Unix()),
+		EndTime:       big.NewInt(expiresAt.Unix()),
+		Salt:          salt,
+		ConduitKey:    network.ConduitKey,
+		Counter:       counter,
+	}
+
+	orderHash := seaport.OrderHash(order)
+	chainID := big.NewInt(network.ChainID)
+	return &OfferOrder{
+		NFTID:        nftID,
+		CollectionID: collection.ID,
+		ChainID:      network.ChainID,
+		Seaport:      network.Seaport.Hex(),
+		Conduit:      conduit.Hex(),
+		OrderHash:    orderHash.Hex(),
+		Digest:       seaport.Digest(chainID, network.Seaport, orderHash).Hex(),
+		Order:        order,
+		TypedData:    seaport.TypedData(chainID, network.Seaport, order),
+		Fees:         fees,
+	}, nil
+}
+
+// Submit verifies a signed offer of actor's, checks the bidder can pay it
+// and tells the holders of the token, or the creator for collection-wide
+// offers
+func (s *OfferService) Submit(ctx context.Context, actor *models.User, input SubmitOrderInput) (*models.Offer, error) {
+	var components seaport.OrderComponents
+	if len(input.Order) == 0 {
+		return nil, fmt.Errorf("%w: order is required", ErrInvalidOrder)
+	}
+	if err := json.Unmarshal(input.Order, &components); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOrder, err)
+	}
+	signature, err := hexutil.Decode(strings.TrimSpace(input.Signature))
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature is not hex", ErrInvalidOrderSignature)
+	}
+	terms, err := offerTerms(components)
+	if err != nil {
+		return nil, err
+	}
+	if components.EndTime.Cmp(components.StartTime) <= 0 || !components.EndTime.IsInt64() {
+		return nil, fmt.Errorf("%w: end time must be after start time", ErrInvalidOrder)
+	}
+	if components.EndTime.Int64() <= time.Now().Unix() {
+		return nil, ErrOrderExpired
+	}
+	if !common.IsHexAddress(actor.WalletAddress) || common.HexToAddress(actor.WalletAddress) != components.Offerer {
+		return nil, ErrOfferNotBidder
+	}
+
+	network, err := resolveNetwork(s.chains, input.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	var nft *models.NFT
+	var collection *models.Collection
+	if terms.wanted.ItemType == seaport.ItemTypeERC1155 {
+		found, err := s.nftRepo.GetByContractAndTokenID(network.ChainID, terms.wanted.Token.Hex(), models.NewUint256(terms.wanted.IdentifierOrCriteria))
+		if err != nil {
+			if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+				return nil, ErrOrderTokenUnknown
+			}
+			return nil, err
+		}
+		if nft, err = s.biddableNFT(found.ID); err != nil {
+			return nil, err
+		}
+		collection = nft.Drop.Collection
+	} else {
+		collection, err = s.collectionRepo.GetByContractAddress(network.ChainID, terms.wanted.Token.Hex())
+		if err != nil {
+			if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+				return nil, ErrOrderTokenUnknown
+			}
+			return nil, err
+		}
+	}
+
+	orderHash := seaport.OrderHash(components)
+	if input.OrderHash != "" && !strings.EqualFold(strings.TrimSpace(input.OrderHash), orderHash.Hex()) {
+		return nil, fmt.Errorf("%w: expected %s", ErrOrderHashMismatch, orderHash.Hex())
+	}
+	signer, err := seaport.RecoverSigner(seaport.Digest(big.NewInt(network.ChainID), network.Seaport, orderHash), signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOrderSignature, err)
+	}
+	if signer != components.Offerer {
+		return nil, ErrInvalidOrderSignature
+	}
+	counter, err := seaport.GetCounter(ctx, network.Client, network.Seaport, components.Offerer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seaport counter: %w", err)
+	}
+	if counter.Cmp(components.Counter) != 0 {
+		return nil, fmt.Errorf("%w: current counter is %s", ErrOrderCounterMismatch, counter)
+	}
+	if err := checkOfferFunding(ctx, network, components.Offerer, terms.currency, terms.price, components.ConduitKey); err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(components)
+	if err != nil {
+		return nil, err
+	}
+	quantity := terms.wanted.StartAmount.Uint64()
+	offer := &models.Offer{
+		ChainID:         network.ChainID,
+		OrderHash:       orderHash.Hex(),
+		Seaport:         network.Seaport.Hex(),
+		BidderID:        actor.ID,
+		Maker:           components.Offerer.Hex(),
+		CollectionID:    collection.ID,
+		ContractAddress: terms.wanted.Token.Hex(),
+		Quantity:        quantity,
+		Currency:        terms.currency.Hex(),
+		Price:           models.NewUint256(terms.price),
+		UnitPrice:       models.NewUint256(new(big.Int).Div(terms.price, new(big.Int).SetUint64(quantity))),
+		Fees:            models.NewUint256(terms.fees),
+		Counter:         models.NewUint256(components.Counter),
+		StartTime:       time.Unix(components.StartTime.Int64(), 0),
+		EndTime:         time.Unix(components.EndTime.Int64(), 0),
+		Components:      models.OrderComponents(encoded),
+		Signature:       hexutil.Encode(signature),
+		Status:          models.OrderStatusActive,
+	}
+	if nft != nil {
+		offer.NFTID = &nft.ID
+		offer.TokenID = &nft.TokenID
+	}
+	if err := s.offerRepo.Create(offer); err != nil {
+		if errors.Is(err, repoInterfaces.ErrDuplicateRecord) {
+			return nil, ErrOrderAlreadySubmitted
+		}
+		return nil, fmt.Errorf("failed to store offer: %w", err)
+	}
+
+	event := busInterfaces.OfferReceivedEvent{
+		OfferID:      offer.ID,
+		BidderID:     actor.ID,
+		RecipientIDs: s.offerRecipients(collection, offer),
+		CollectionID: collection.ID,
+		Price:        offer.Price.String(),
+		Currency:     offer.Currency,
+	}
+	if offer.NFTID != nil {
+		event.NFTID = *offer.NFTID
+	}
+	s.eventBus.Publish(busInterfaces.EventOfferReceived, event)
+	return offer, nil
+}
+
+// GetByHash returns an offer in any status
+func (s *OfferService) GetByHash(orderHash string) (*models.Offer, error) {
+	offer, err := s.offerRepo.GetByHash(common.HexToHash(orderHash).Hex())
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrOfferNotFound
+		}
+		return nil, err
+	}
+	return offer, nil
+}
+
+// List returns the offers matching input, highest price per copy first
+func (s *OfferService) List(input ListOffersInput) ([]*models.Offer, error) {
+	filter := repoInterfaces.OfferFilter{
+		NFTID:        input.NFTID,
+		CollectionID: input.CollectionID,
+		Limit:        input.Limit,
+		Offset:       input.Offset,
+	}
+	if input.NFTID != 0 {
+		nft, err := s.nftRepo.GetByID(input.NFTID)
+		if err != nil {
+			if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+				return nil, ErrNFTNotFound
+			}
+			return nil, err
+		}
+		if nft.Drop != nil {
+			filter.CollectionID = nft.Drop.CollectionID
+			filter.IncludeCollection = true
+		}
+	}
+	if input.Maker != "" {
+		if !common.IsHexAddress(input.Maker) {
+			return nil, ErrInvalidOrderMaker
+		}
+		filter.Maker = common.HexToAddress(input.Maker).Hex()
+	}
+	switch input.Status {
+	case "", models.OrderStatusActive:
+		now := time.Now()
+		filter.LiveAt = &now
+	case models.OrderStatusFilled, models.OrderStatusCancelled, models.OrderStatusExpired:
+		filter.Status = input.Status
+	default:
+		return nil, ErrInvalidOrderStatus
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultOrderPageSize
+	}
+	if filter.Limit > maxOrderPageSize {
+		filter.Limit = maxOrderPageSize
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	return s.offerRepo.List(filter)
+}
+
+// Fulfillment returns what actor needs to accept an offer with copies of
+// one of their tokens. It checks on chain that actor holds the copies and
+// that the bidder can still pay.
+func (s *OfferService) Fulfillment(ctx context.Context, actor *models.User, orderHash string, input AcceptOfferInput) (*OfferFulfillment, error) {
+	offer, err := s.GetByHash(orderHash)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if offer.Status != models.OrderStatusActive || !offer.EndTime.After(now) || offer.StartTime.After(now) {
+		return nil, ErrOfferNotActive
+	}
+	if !common.IsHexAddress(actor.WalletAddress) {
+		return nil, fmt.Errorf("user %d has no valid wallet address", actor.ID)
+	}
+	var components seaport.OrderComponents
+	if err := json.Unmarshal(offer.Components, &components); err != nil {
+		return nil, fmt.Errorf("failed to decode offer %s: %w", offer.OrderHash, err)
+	}
+
+	nftID := input.NFTID
+	if offer.NFTID != nil {
+		if nftID != 0 && nftID != *offer.NFTID {
+			return nil, ErrOfferTokenMismatch
+		}
+		nftID = *offer.NFTID
+	}
+	if nftID == 0 {
+		return nil, ErrOfferTokenMismatch
+	}
+	nft, err := s.biddableNFT(nftID)
+	if err != nil {
+		return nil, err
+	}
+	if nft.Drop.CollectionID != offer.CollectionID {
+		return nil, ErrOfferTokenMismatch
+	}
+	network, err := s.chains.Network(offer.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := offer.Quantity - min(offer.FilledQuantity, offer.Quantity)
+	quantity := input.Quantity
+	if quantity == 0 {
+		quantity = remaining
+	}
+	if quantity == 0 || quantity > remaining {
+		return nil, ErrInvalidOfferQuantity
+	}
+	if quantity != offer.Quantity && components.OrderType != seaport.OrderTypePartialOpen {
+		return nil, ErrInvalidOfferQuantity
+	}
+
+	seller := common.HexToAddress(actor.WalletAddress)
+	contract := common.HexToAddress(offer.ContractAddress)
+	token, err := contracts.NewLazyMint1155Caller(contract, network.Client)
+	if err != nil {
+		return nil, err
+	}
+	held, err := token.BalanceOf(&bind.CallOpts{Context: ctx}, seller, nft.TokenID.Big())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token balance: %w", err)
+	}
+	if held.Cmp(new(big.Int).SetUint64(quantity)) < 0 {
+		return nil, ErrInsufficientTokens
+	}
+	if err := checkOfferFunding(ctx, network, components.Offerer, common.HexToAddress(offer.Currency), offer.Price.Big(), components.ConduitKey); err != nil {
+		return nil, err
+	}
+	operator, err := offerConduit(ctx, network)
+	if err != nil {
+		return nil, err
+	}
+
+	numerator, denominator := seaport.Fraction(quantity, offer.Quantity)
+	fees := new(big.Int).Mul(offer.Fees.Big(), numerator)
+	fees.Div(fees, denominator)
+	proceeds := new(big.Int).Mul(offer.Price.Big(), numerator)
+	proceeds.Div(proceeds, denominator).Sub(proceeds, fees)
+	fulfillment := &OfferFulfillment{
+		Offer:                           offer,
+		Seaport:                         offer.Seaport,
+		Order:                           json.RawMessage(offer.Components),
+		TotalOriginalConsiderationItems: len(components.Consideration),
+		Signature:                       offer.Signature,
+		Numerator:                       numerator.String(),
+		Denominator:                     denominator.String(),
+		ExtraData:                       "0x",
+		CriteriaResolvers:               []seaport.CriteriaResolver{},
+		FulfillerConduitKey:             network.ConduitKey.Hex(),
+		Recipient:                       seller.Hex(),
+		Contract:                        contract.Hex(),
+		Operator:                        operator.Hex(),
+		Currency:                        offer.Currency,
+		FeeAllowance:                    fees.String(),
+		Quantity:                        quantity,
+		Proceeds:                        proceeds.String(),
+	}
+	if offer.NFTID == nil {
+		fulfillment.CriteriaResolvers = append(fulfillment.CriteriaResolvers, seaport.CriteriaResolver{
+			Side:          seaport.SideConsideration,
+			Identifier:    nft.TokenID.String(),
+			CriteriaProof: []common.Hash{},
+		})
+	}
+	return fulfillment, nil
+}
+
+// Run marks ended offers expired until ctx is cancelled
+func (s *OfferService) Run(ctx context.Context) {
+	ticker := time.NewTicker(orderExpiryInterval)
+	defer ticker.Stop()
+	for {
+		if n, err := s.offerRepo.ExpireEnded(time.Now()); err != nil {
+			log.Printf("failed to expire offers: %v", err)
+		} else if n > 0 {
+			log.Printf("expired %d offers", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// biddableNFT loads an NFT with its collection and checks it is minted, as
+// only holders can accept offers
+func (s *OfferService) biddableNFT(nftID uint) (*models.NFT, error) {
+	nft, err := s.nftRepo.GetByID(nftID)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrNFTNotFound
+		}
+		return nil, err
+	}
+	if nft.Drop == nil || nft.Drop.Collection == nil {
+		return nil, ErrCollectionNotFound
+	}
+	if nft.Drop.Collection.ContractAddress == nil {
+		return nil, ErrCollectionNotDeployed
+	}
+	if !nft.IsMinted {
+		return nil, ErrOfferTokenNotMinted
+	}
+	return nft, nil
+}
+
+// checkOfferFunding makes sure the bidder holds price of currency and has
+// approved the conduit of the offer's conduit key to spend it
+func checkOfferFunding(ctx context.Context, network *chainInterfaces.Network, bidder, currency common.Address, price *big.Int, conduitKey common.Hash) error {
+	spender, err := seaport.GetConduit(ctx, network.Client, network.Seaport, conduitKey)
+	if err != nil {
+		return fmt.Errorf("failed to find the offer's conduit: %w", err)
+	}
+	if spender == (common.Address{}) {
+		return fmt.Errorf("%w: conduit key %s has no conduit", ErrUnsupportedOffer, conduitKey.Hex())
+	}
+	token := contracts.NewERC20Caller(currency, network.Client)
+	opts := &bind.CallOpts{Context: ctx}
+	balance, err := token.BalanceOf(opts, bidder)
+	if err != nil {
+		return fmt.Errorf("failed to read bidder balance: %w", err)
+	}
+	if balance.Cmp(price) < 0 {
+		return ErrOfferInsufficientBalance
+	}
+	allowance, err := token.Allowance(opts, bidder, spender)
+	if err != nil {
+		return fmt.Errorf("failed to read bidder allowance: %w", err)
+	}
+	if allowance.Cmp(price) < 0 {
+		return ErrOfferInsufficientAllowance
+	}
+	return nil
+}
+
+// offerConduit is the account sellers and bidders approve on network for
+// orders built here
+func offerConduit(ctx context.Context, network *chainInterfaces.Network) (common.Address, error) {
+	conduit, err := seaport.GetConduit(ctx, network.Client, network.Seaport, network.ConduitKey)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to find the conduit: %w", err)
+	}
+	if conduit == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("conduit key %s has no conduit", network.ConduitKey.Hex())
+	}
+	return conduit, nil
+}
+
+// offerRecipients are the accounts holding the token an offer wants, or the
+// collection's creator for collection-wide offers
+func (s *OfferService) offerRecipients(collection *models.Collection, offer *models.Offer) []uint {
+	if offer.TokenID == nil {
+		return []uint{collection.CreatorID}
+	}
+	holders, err := s.balanceRepo.ListHolders(offer.ChainID, offer.ContractAddress, *offer.TokenID, maxOfferRecipients, 0)
+	if err != nil {
+		log.Printf("failed to list holders for offer %d: %v", offer.ID, err)
+		return nil
+	}
+	ids := make([]uint, 0, len(holders))
+	for _, holder := range holders {
+		user, err := s.userRepo.GetUserByWalletAddress(strings.ToLower(holder.OwnerAddress))
+		if err == nil {
+			ids = append(ids, user.ID)
+		}
+	}
+	return ids
+}
+
+func offerCurrency(network *chainInterfaces.Network, currency string) (common.Address, error) {
+	currency = strings.TrimSpace(currency)
+	if currency == "" {
+		if network.WrappedNative == (common.Address{}) {
+			return common.Address{}, ErrInvalidOfferCurrency
+		}
+		return network.WrappedNative, nil
+	}
+	if !common.IsHexAddress(currency) || common.HexToAddress(currency) == (common.Address{}) {
+		return common.Address{}, ErrInvalidOfferCurrency
+	}
+	return common.HexToAddress(currency), nil
+}
+
+type offerTermsResult struct {
+	wanted   seaport.ConsiderationItem
+	currency common.Address
+	price    *big.Int
+	fees     *big.Int
+}
+
+// offerTerms checks an order is an open bid of a single ERC-20 amount for
+// copies of one ERC-1155 token, or of any token of a contract, whose other
+// consideration items pay fees in the same currency
+func offerTerms(o seaport.OrderComponents) (*offerTermsResult, error) {
+	unsupported := func(reason string) (*offerTermsResult, error) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedOffer, reason)
+	}
+	if o.OrderType != seaport.OrderTypeFullOpen && o.OrderType != seaport.OrderTypePartialOpen {
+		return unsupported("order must be open")
+	}
+	if len(o.Offer) != 1 || o.Offer[0].ItemType != seaport.ItemTypeERC20 || o.Offer[0].IdentifierOrCriteria.Sign() != 0 {
+		return unsupported("offer must be a single erc20 item")
+	}
+	offered := o.Offer[0]
+	if offered.StartAmount.Cmp(offered.EndAmount) != 0 || offered.StartAmount.Sign() == 0 {
+		return unsupported("offer amount must be fixed and positive")
+	}
+	if len(o.Consideration) == 0 {
+		return unsupported("consideration is empty")
+	}
+	wanted := o.Consideration[0]
+	switch {
+	case wanted.ItemType == seaport.ItemTypeERC1155:
+	case wanted.ItemType == seaport.ItemTypeERC1155WithCriteria && wanted.IdentifierOrCriteria.Sign() == 0:
+	default:
+		return unsupported("first consideration item must be an erc1155 token or any token of a contract")
+	}
+	if wanted.StartAmount.Cmp(wanted.EndAmount) != 0 || wanted.StartAmount.Sign() == 0 || !wanted.StartAmount.IsUint64() {
+		return unsupported("token amount must be fixed and positive")
+	}
+	if wanted.Recipient != o.Offerer {
+		return unsupported("tokens must go to the offerer")
+	}
+	fees := new(big.Int)
+	for _, item := range o.Consideration[1:] {
+		if item.ItemType != seaport.ItemTypeERC20 || item.Token != offered.Token || item.IdentifierOrCriteria.Sign() != 0 {
+			return unsupported("fees must be paid in the offer currency")
+		}
+		if item.StartAmount.Cmp(item.EndAmount) != 0 {
+			return unsupported("fee amounts must be fixed")
+		}
+		fees.Add(fees, item.StartAmount)
+	}
+	if fees.Cmp(offered.StartAmount) >= 0 {
+		return unsupported("fees must leave the seller a share")
+	}
+	return &offerTermsResult{wanted: wanted, currency: offered.Token, price: new(big.Int).Set(offered.StartAmount), fees: fees}, nil
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/igwedaniel/artizan/internal/models"
+	"github.com/igwedaniel/artizan/pkg/seaport"
+)
+
+// BuildOfferInput describes a bid on one NFT, or on any token of a
+// collection when only CollectionID is set. Price is per copy in the
+// currency's smallest unit; an empty currency is the default offer currency.
+type BuildOfferInput struct {
+	NFTID        uint       `json:"nft_id"`
+	CollectionID uint       `json:"collection_id"`
+	Price        string     `json:"price"`
+	Currency     string     `json:"currency"`
+	Quantity     uint64     `json:"quantity"` // defaults to 1
+	ExpiresAt    *time.Time `json:"expires_at"`
+}
+
+// OfferOrder is an unsigned Seaport order offering an ERC-20 for our
+// tokens. Before submitting it, the bidder signs Digest, or TypedData with
+// eth_signTypedData_v4, and approves Conduit to spend the total price.
+type OfferOrder struct {
+	NFTID        *uint                   `json:"nft_id"`
+	CollectionID uint                    `json:"collection_id"`
+	ChainID      int64                   `json:"chain_id"`
+	Seaport      string                  `json:"seaport"`
+	Conduit      string                  `json:"conduit"`
+	OrderHash    string                  `json:"order_hash"`
+	Digest       string                  `json:"digest"`
+	Order        seaport.OrderComponents `json:"order"`
+	TypedData    apitypes.TypedData      `json:"typed_data"`
+	Fees         []ListingFee            `json:"fees"`
+}
+
+// ListOffersInput filters offers. With NFTID set, the collection-wide offers
+// of its collection are included. Status defaults to active, which also
+// leaves out offers past their end time that were not swept yet.
+type ListOffersInput struct {
+	NFTID        uint
+	CollectionID uint
+	Maker        string
+	Status       string
+	Limit        int
+	Offset       int
+}
+
+// AcceptOfferInput is what a seller picks when accepting an offer: the NFT
+// to sell, required for collection-wide offers, and how many copies, which
+// defaults to all the offer still wants.
+type AcceptOfferInput struct {
+	NFTID    uint
+	Quantity uint64
+}
+
+// OfferFulfillment holds the arguments of the fulfillAdvancedOrder call
+// that accepts an offer. Order and Signature with Numerator/Denominator
+// make the AdvancedOrder. Seaport pays the seller the offer before taking
+// the fees from them, so the seller must first approve Operator for
+// Contract with setApprovalForAll and for FeeAllowance of Currency.
+type OfferFulfillment struct {
+	Offer                           *models.Offer              `json:"offer"`
+	Seaport                         string                     `json:"seaport"`
+	Order                           json.RawMessage            `json:"order"`
+	TotalOriginalConsiderationItems int                        `json:"total_original_consideration_items"`
+	Signature                       string                     `json:"signature"`
+	Numerator                       string                     `json:"numerator"`
+	Denominator                     string                     `json:"denominator"`
+	ExtraData                       string                     `json:"extra_data"`
+	CriteriaResolvers               []seaport.CriteriaResolver `json:"criteria_resolvers"`
+	FulfillerConduitKey             string                     `json:"fulfiller_conduit_key"`
+	Recipient                       string                     `json:"recipient"`
+	Contract                        string                     `json:"contract"`
+	Operator                        string                     `json:"operator"`
+	Currency                        string                     `json:"currency"`
+	FeeAllowance                    string                     `json:"fee_allowance"`
+	Quantity                        uint64                     `json:"quantity"`
+	Proceeds                        string                     `json:"proceeds"` // what the seller receives after fees
+}
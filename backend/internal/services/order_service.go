@@ -0,0 +1,336 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	chainInterfaces "github.com/igwedaniel/artizan/internal/interfaces/chain"
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"github.com/igwedaniel/artizan/pkg/contracts"
+	"github.com/igwedaniel/artizan/pkg/seaport"
+)
+
+const (
+	orderExpiryInterval  = time.Minute
+	defaultOrderPageSize = 20
+	maxOrderPageSize     = 100
+)
+
+// OrderService is the off-chain order book. It accepts signed Seaport
+// listings for tokens of our collections once they would pass Seaport and
+// LazyMintZone, and serves them to buyers.
+type OrderService struct {
+	orderRepo repoInterfaces.OrderRepository
+	nftRepo   repoInterfaces.NFTRepository
+	chains    chainInterfaces.Registry
+}
+
+// NewOrderService creates a new OrderService instance
+func NewOrderService(
+	orderRepo repoInterfaces.OrderRepository,
+	nftRepo repoInterfaces.NFTRepository,
+	chains chainInterfaces.Registry,
+) *OrderService {
+	return &OrderService{
+		orderRepo: orderRepo,
+		nftRepo:   nftRepo,
+		chains:    chains,
+	}
+}
+
+// Submit verifies a signed listing and adds it to the order book
+func (s *OrderService) Submit(ctx context.Context, input SubmitOrderInput) (*models.Order, error) {
+	var components seaport.OrderComponents
+	if len(input.Order) == 0 {
+		return nil, fmt.Errorf("%w: order is required", ErrInvalidOrder)
+	}
+	if err := json.Unmarshal(input.Order, &components); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOrder, err)
+	}
+	signature, err := hexutil.Decode(strings.TrimSpace(input.Signature))
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature is not hex", ErrInvalidOrderSignature)
+	}
+	var extraData []byte
+	if input.ExtraData = strings.TrimSpace(input.ExtraData); input.ExtraData != "" {
+		if extraData, err = hexutil.Decode(input.ExtraData); err != nil {
+			return nil, fmt.Errorf("%w: extra data is not hex", ErrOrderVoucherMismatch)
+		}
+	}
+
+	offer, currency, price, err := listingTerms(components)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if components.EndTime.Cmp(components.StartTime) <= 0 || !components.EndTime.IsInt64() {
+		return nil, fmt.Errorf("%w: end time must be after start time", ErrInvalidOrder)
+	}
+	if components.EndTime.Int64() <= now.Unix() {
+		return nil, ErrOrderExpired
+	}
+
+	network, err := resolveNetwork(s.chains, input.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	nft, err := s.offeredNFT(network.ChainID, offer)
+	if err != nil {
+		return nil, err
+	}
+	collection := nft.Drop.Collection
+	if collection.ZoneAddress == nil || common.HexToAddress(*collection.ZoneAddress) != components.Zone {
+		return nil, ErrOrderZoneMismatch
+	}
+	chainID := big.NewInt(network.ChainID)
+
+	orderHash := seaport.OrderHash(components)
+	if input.OrderHash != "" && !strings.EqualFold(strings.TrimSpace(input.OrderHash), orderHash.Hex()) {
+		return nil, fmt.Errorf("%w: expected %s", ErrOrderHashMismatch, orderHash.Hex())
+	}
+	signer, err := seaport.RecoverSigner(seaport.Digest(chainID, network.Seaport, orderHash), signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOrderSignature, err)
+	}
+	if signer != components.Offerer {
+		return nil, ErrInvalidOrderSignature
+	}
+	if err := s.checkVoucher(nft, components, offer, extraData); err != nil {
+		return nil, err
+	}
+	counter, err := seaport.GetCounter(ctx, network.Client, network.Seaport, components.Offerer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seaport counter: %w", err)
+	}
+	if counter.Cmp(components.Counter) != 0 {
+		return nil, fmt.Errorf("%w: current counter is %s", ErrOrderCounterMismatch, counter)
+	}
+
+	encoded, err := json.Marshal(components)
+	if err != nil {
+		return nil, err
+	}
+	quantity := offer.StartAmount.Uint64()
+	order := &models.Order{
+		ChainID:         network.ChainID,
+		OrderHash:       orderHash.Hex(),
+		Seaport:         network.Seaport.Hex(),
+		Maker:           components.Offerer.Hex(),
+		CollectionID:    collection.ID,
+		NFTID:           nft.ID,
+		ContractAddress: offer.Token.Hex(),
+		TokenID:         nft.TokenID,
+		Quantity:        quantity,
+		Currency:        currency.Hex(),
+		Price:           models.NewUint256(price),
+		UnitPrice:       models.NewUint256(new(big.Int).Div(price, new(big.Int).SetUint64(quantity))),
+		Counter:         models.NewUint256(components.Counter),
+		StartTime:       time.Unix(components.StartTime.Int64(), 0),
+		EndTime:         time.Unix(components.EndTime.Int64(), 0),
+		Components:      models.OrderComponents(encoded),
+		Signature:       hexutil.Encode(signature),
+		ExtraData:       hexutil.Encode(extraData),
+		Status:          models.OrderStatusActive,
+	}
+	if err := s.orderRepo.Create(order); err != nil {
+		if errors.Is(err, repoInterfaces.ErrDuplicateRecord) {
+			return nil, ErrOrderAlreadySubmitted
+		}
+		return nil, fmt.Errorf("failed to store order: %w", err)
+	}
+	return order, nil
+}
+
+// GetByHash returns an order in any status
+func (s *OrderService) GetByHash(orderHash string) (*models.Order, error) {
+	order, err := s.orderRepo.GetByHash(common.HexToHash(orderHash).Hex())
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return order, nil
+}
+
+// List returns the orders matching input
+func (s *OrderService) List(input ListOrdersInput) ([]*models.Order, error) {
+	filter := repoInterfaces.OrderFilter{
+		NFTID:        input.NFTID,
+		CollectionID: input.CollectionID,
+		SortBy:       input.Sort,
+		Limit:        input.Limit,
+		Offset:       input.Offset,
+	}
+	if input.Maker != "" {
+		if !common.IsHexAddress(input.Maker) {
+			return nil, ErrInvalidOrderMaker
+		}
+		filter.Maker = common.HexToAddress(input.Maker).Hex()
+	}
+	if input.Currency != "" {
+		currency, _, err := listingCurrency(input.Currency)
+		if err != nil {
+			return nil, err
+		}
+		filter.Currency = currency.Hex()
+	}
+	switch input.Status {
+	case "", models.OrderStatusActive:
+		now := time.Now()
+		filter.LiveAt = &now
+	case models.OrderStatusFilled, models.OrderStatusCancelled, models.OrderStatusExpired:
+		filter.Status = input.Status
+	default:
+		return nil, ErrInvalidOrderStatus
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultOrderPageSize
+	}
+	if filter.Limit > maxOrderPageSize {
+		filter.Limit = maxOrderPageSize
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+	return s.orderRepo.List(filter)
+}
+
+// GetBest returns the cheapest live listing of an NFT per copy, in the
+// native coin when currency is empty
+func (s *OrderService) GetBest(nftID uint, currency string) (*models.Order, error) {
+	address, _, err := listingCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+	order, err := s.orderRepo.GetBest(nftID, address.Hex(), time.Now())
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return order, nil
+}
+
+// Run marks ended orders expired until ctx is cancelled
+func (s *OrderService) Run(ctx context.Context) {
+	ticker := time.NewTicker(orderExpiryInterval)
+	defer ticker.Stop()
+	for {
+		if n, err := s.orderRepo.ExpireEnded(time.Now()); err != nil {
+			log.Printf("failed to expire orders: %v", err)
+		} else if n > 0 {
+			log.Printf("expired %d orders", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// offeredNFT finds the NFT an order sells on chainID, with its collection
+// loaded
+func (s *OrderService) offeredNFT(chainID int64, offer seaport.OfferItem) (*models.NFT, error) {
+	tokenID := models.NewUint256(offer.IdentifierOrCriteria)
+	found, err := s.nftRepo.GetByContractAndTokenID(chainID, offer.Token.Hex(), tokenID)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrOrderTokenUnknown
+		}
+		return nil, err
+	}
+	nft, err := s.nftRepo.GetByID(found.ID)
+	if err != nil {
+		return nil, err
+	}
+	if nft.Drop == nil || nft.Drop.Collection == nil {
+		return nil, ErrOrderTokenUnknown
+	}
+	return nft, nil
+}
+
+// checkVoucher makes sure LazyMintZone can decode extraData and, for a token
+// that is not minted yet, that it mints enough copies to the offerer
+func (s *OrderService) checkVoucher(nft *models.NFT, components seaport.OrderComponents, offer seaport.OfferItem, extraData []byte) error {
+	if len(extraData) == 0 {
+		return fmt.Errorf("%w: extra data is required", ErrOrderVoucherMismatch)
+	}
+	if components.ZoneHash != (common.Hash{}) && components.ZoneHash != crypto.Keccak256Hash(extraData) {
+		return fmt.Errorf("%w: zone hash does not commit to the extra data", ErrOrderVoucherMismatch)
+	}
+	voucher, err := contracts.DecodeVoucher(extraData)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrOrderVoucherMismatch, err)
+	}
+	if voucher.Owner != components.Offerer || voucher.TokenId.Cmp(offer.IdentifierOrCriteria) != 0 {
+		return fmt.Errorf("%w: voucher is for another owner or token", ErrOrderVoucherMismatch)
+	}
+	// a minted token skips the voucher, so only its shape matters
+	if nft.IsMinted {
+		return nil
+	}
+	if voucher.Amount.Cmp(offer.StartAmount) < 0 {
+		return fmt.Errorf("%w: voucher mints fewer copies than the order sells", ErrOrderVoucherMismatch)
+	}
+	collection := nft.Drop.Collection
+	digest := contracts.VoucherDigest(big.NewInt(collection.ChainID), common.HexToAddress(*collection.ContractAddress), voucher)
+	signer, err := seaport.RecoverSigner(digest, voucher.Signature)
+	if err != nil || collection.SignerAddress == nil || signer != common.HexToAddress(*collection.SignerAddress) {
+		return fmt.Errorf("%w: voucher is not signed by the collection signer", ErrOrderVoucherMismatch)
+	}
+	return nil
+}
+
+// listingTerms checks an order is a fixed price listing of one ERC-1155
+// token through a zone, paid in a single currency, and returns what it sells
+// and for how much
+func listingTerms(o seaport.OrderComponents) (seaport.OfferItem, common.Address, *big.Int, error) {
+	unsupported := func(reason string) (seaport.OfferItem, common.Address, *big.Int, error) {
+		return seaport.OfferItem{}, common.Address{}, nil, fmt.Errorf("%w: %s", ErrUnsupportedOrder, reason)
+	}
+	if o.OrderType != seaport.OrderTypeFullRestricted && o.OrderType != seaport.OrderTypePartialRestricted {
+		return unsupported("order must be restricted to the zone")
+	}
+	if len(o.Offer) != 1 || o.Offer[0].ItemType != seaport.ItemTypeERC1155 {
+		return unsupported("offer must be a single erc1155 item")
+	}
+	offer := o.Offer[0]
+	if offer.StartAmount.Cmp(offer.EndAmount) != 0 || offer.StartAmount.Sign() == 0 || !offer.StartAmount.IsUint64() {
+		return unsupported("offer amount must be fixed and positive")
+	}
+	if len(o.Consideration) == 0 {
+		return unsupported("consideration is empty")
+	}
+	currency := o.Consideration[0].Token
+	itemType := o.Consideration[0].ItemType
+	if itemType != seaport.ItemTypeNative && itemType != seaport.ItemTypeERC20 {
+		return unsupported("consideration must be paid in the native coin or an erc20")
+	}
+	price := new(big.Int)
+	for _, item := range o.Consideration {
+		if item.ItemType != itemType || item.Token != currency || item.IdentifierOrCriteria.Sign() != 0 {
+			return unsupported("consideration must be paid in one currency")
+		}
+		if item.StartAmount.Cmp(item.EndAmount) != 0 {
+			return unsupported("consideration amounts must be fixed")
+		}
+		price.Add(price, item.StartAmount)
+	}
+	if price.Sign() == 0 {
+		return unsupported("price must be positive")
+	}
+	return offer, currency, price, nil
+}
@@ -0,0 +1,28 @@
+package services
+
+import "encoding/json"
+
+// SubmitOrderInput is a signed Seaport order. Order holds the components in
+// the seaport-js format; OrderHash is optional and rejected when it differs
+// from the hash of the components. ChainID is the chain the order was
+// signed for, the default chain when zero.
+type SubmitOrderInput struct {
+	ChainID   int64           `json:"chain_id"`
+	Order     json.RawMessage `json:"order"`
+	Signature string          `json:"signature"`
+	OrderHash string          `json:"order_hash"`
+	ExtraData string          `json:"extra_data"`
+}
+
+// ListOrdersInput filters order listings. Status defaults to active, which
+// also leaves out orders past their end time that were not swept yet.
+type ListOrdersInput struct {
+	NFTID        uint
+	CollectionID uint
+	Maker        string
+	Currency     string
+	Status       string
+	Sort         string // "price" for cheapest first, otherwise newest first
+	Limit        int
+	Offset       int
+}
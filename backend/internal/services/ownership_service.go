@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	chainInterfaces "github.com/igwedaniel/artizan/internal/interfaces/chain"
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"github.com/igwedaniel/artizan/pkg/contracts"
+)
+
+const (
+	defaultOwnershipPageSize = 20
+	maxOwnershipPageSize     = 100
+	// balances compared per balanceOfBatch call
+	reconcileBatchSize = 100
+)
+
+// OwnershipService answers who holds which token from the balances the
+// indexer keeps, without calling the chain
+type OwnershipService struct {
+	balanceRepo    repoInterfaces.TokenBalanceRepository
+	userRepo       repoInterfaces.UserRepository
+	nftRepo        repoInterfaces.NFTRepository
+	collectionRepo repoInterfaces.CollectionRepository
+	checkpointRepo repoInterfaces.ChainCheckpointRepository
+	chains         chainInterfaces.Registry
+}
+
+// NewOwnershipService creates a new OwnershipService instance
+func NewOwnershipService(
+	balanceRepo repoInterfaces.TokenBalanceRepository,
+	userRepo repoInterfaces.UserRepository,
+	nftRepo repoInterfaces.NFTRepository,
+	collectionRepo repoInterfaces.CollectionRepository,
+	checkpointRepo repoInterfaces.ChainCheckpointRepository,
+	chains chainInterfaces.Registry,
+) *OwnershipService {
+	return &OwnershipService{
+		balanceRepo:    balanceRepo,
+		userRepo:       userRepo,
+		nftRepo:        nftRepo,
+		collectionRepo: collectionRepo,
+		checkpointRepo: checkpointRepo,
+		chains:         chains,
+	}
+}
+
+// ListPortfolio returns the tokens held on a chain by the wallet behind
+// handle, which is a username or a wallet address. Chain 0 is the default
+// chain.
+func (s *OwnershipService) ListPortfolio(handle string, chainID int64, limit, offset int) ([]*models.TokenBalance, error) {
+	network, err := resolveNetwork(s.chains, chainID)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := s.resolveHandle(handle)
+	if err != nil {
+		return nil, err
+	}
+	limit, offset = ownershipPage(limit, offset)
+	return s.balanceRepo.ListByOwner(network.ChainID, owner, limit, offset)
+}
+
+// ListHolders returns the wallets holding copies of an NFT
+func (s *OwnershipService) ListHolders(nftID uint, limit, offset int) ([]*models.TokenBalance, error) {
+	nft, err := s.nftRepo.GetByID(nftID)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrNFTNotFound
+		}
+		return nil, err
+	}
+	if nft.Drop == nil || nft.Drop.Collection == nil || nft.Drop.Collection.ContractAddress == nil {
+		return []*models.TokenBalance{}, nil
+	}
+	limit, offset = ownershipPage(limit, offset)
+	return s.balanceRepo.ListHolders(nft.Drop.Collection.ChainID, *nft.Drop.Collection.ContractAddress, nft.TokenID, limit, offset)
+}
+
+// CountCollectionHolders counts the wallets holding any token of a collection
+func (s *OwnershipService) CountCollectionHolders(collectionID uint) (*CollectionHolders, error) {
+	collection, err := s.collectionRepo.GetByID(collectionID)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrCollectionNotFound
+		}
+		return nil, err
+	}
+	holders := &CollectionHolders{CollectionID: collection.ID, ContractAddress: collection.ContractAddress}
+	if collection.ContractAddress == nil {
+		return holders, nil
+	}
+	holders.Holders, err = s.balanceRepo.CountHolders(collection.ChainID, *collection.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+	return holders, nil
+}
+
+// Reconcile compares every stored balance on a chain with balanceOf at the
+// indexer checkpoint, so both sides describe the same block. With fix set,
+// stored balances are overwritten with the on-chain value. Holders the
+// indexer never saw have no row and are not found this way.
+func (s *OwnershipService) Reconcile(ctx context.Context, chainID int64, fix bool) (*ReconcileReport, error) {
+	network, err := resolveNetwork(s.chains, chainID)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint, err := s.checkpointRepo.GetCheckpoint(network.ChainID)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrIndexerNotStarted
+		}
+		return nil, err
+	}
+	report := &ReconcileReport{ChainID: network.ChainID, Block: checkpoint.BlockNumber, Mismatches: []*BalanceMismatch{}}
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(checkpoint.BlockNumber)}
+
+	var afterID uint
+	for {
+		balances, err := s.balanceRepo.ListAfter(network.ChainID, afterID, reconcileBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(balances) == 0 {
+			return report, nil
+		}
+		afterID = balances[len(balances)-1].ID
+
+		for contract, group := range groupByContract(balances) {
+			onChain, err := balancesOf(opts, network.Client, contract, group)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read balances of %s: %w", contract, err)
+			}
+			for i, balance := range group {
+				report.Checked++
+				actual := models.NewUint256(onChain[i])
+				if actual.Cmp(balance.Balance) == 0 {
+					continue
+				}
+				report.Mismatches = append(report.Mismatches, &BalanceMismatch{
+					ContractAddress: balance.ContractAddress,
+					TokenID:         balance.TokenID,
+					OwnerAddress:    balance.OwnerAddress,
+					Stored:          balance.Balance,
+					OnChain:         actual,
+				})
+				if !fix {
+					continue
+				}
+				balance.Balance = actual
+				if err := s.balanceRepo.Set(balance); err != nil {
+					return nil, fmt.Errorf("failed to fix balance %d: %w", balance.ID, err)
+				}
+				report.Fixed++
+			}
+		}
+	}
+}
+
+func balancesOf(opts *bind.CallOpts, client chainInterfaces.Client, contract string, balances []*models.TokenBalance) ([]*big.Int, error) {
+	caller, err := contracts.NewLazyMint1155Caller(common.HexToAddress(contract), client)
+	if err != nil {
+		return nil, err
+	}
+	accounts := make([]common.Address, len(balances))
+	ids := make([]*big.Int, len(balances))
+	for i, balance := range balances {
+		accounts[i] = common.HexToAddress(balance.OwnerAddress)
+		ids[i] = balance.TokenID.Big()
+	}
+	onChain, err := caller.BalanceOfBatch(opts, accounts, ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(onChain) != len(balances) {
+		return nil, fmt.Errorf("balanceOfBatch returned %d values for %d accounts", len(onChain), len(balances))
+	}
+	return onChain, nil
+}
+
+// resolveHandle turns a username or wallet address into the checksummed
+// address balances are stored under
+func (s *OwnershipService) resolveHandle(handle string) (string, error) {
+	handle = strings.TrimPrefix(strings.TrimSpace(handle), "@")
+	if common.IsHexAddress(handle) {
+		return common.HexToAddress(handle).Hex(), nil
+	}
+	if handle == "" {
+		return "", ErrInvalidHandle
+	}
+	user, err := s.userRepo.GetUserByUsername(handle)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return "", ErrUserNotFound
+		}
+		return "", err
+	}
+	return common.HexToAddress(user.WalletAddress).Hex(), nil
+}
+
+func groupByContract(balances []*models.TokenBalance) map[string][]*models.TokenBalance {
+	groups := make(map[string][]*models.TokenBalance)
+	for _, balance := range balances {
+		groups[balance.ContractAddress] = append(groups[balance.ContractAddress], balance)
+	}
+	return groups
+}
+
+func ownershipPage(limit, offset int) (int, int) {
+	if limit <= 0 {
+		limit = defaultOwnershipPageSize
+	}
+	if limit > maxOwnershipPageSize {
+		limit = maxOwnershipPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
@@ -0,0 +1,28 @@
+package services
+
+import "github.com/igwedaniel/artizan/internal/models"
+
+// CollectionHolders is the number of wallets holding a collection's tokens
+type CollectionHolders struct {
+	CollectionID    uint    `json:"collection_id"`
+	ContractAddress *string `json:"contract_address"`
+	Holders         int64   `json:"holders"`
+}
+
+// BalanceMismatch is a stored balance that disagrees with balanceOf
+type BalanceMismatch struct {
+	ContractAddress string         `json:"contract_address"`
+	TokenID         models.Uint256 `json:"token_id"`
+	OwnerAddress    string         `json:"owner_address"`
+	Stored          models.Uint256 `json:"stored"`
+	OnChain         models.Uint256 `json:"on_chain"`
+}
+
+// ReconcileReport sums up a comparison of stored balances with the chain
+type ReconcileReport struct {
+	ChainID    int64              `json:"chain_id"`
+	Block      uint64             `json:"block"`
+	Checked    int                `json:"checked"`
+	Fixed      int                `json:"fixed"`
+	Mismatches []*BalanceMismatch `json:"mismatches"`
+}
@@ -0,0 +1,432 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	chainInterfaces "github.com/igwedaniel/artizan/internal/interfaces/chain"
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	signerInterfaces "github.com/igwedaniel/artizan/internal/interfaces/signer"
+	"github.com/igwedaniel/artizan/internal/models"
+	"github.com/igwedaniel/artizan/pkg/contracts"
+)
+
+const (
+	relayerPollInterval      = 5 * time.Second
+	defaultRelayStuckAfter   = 3 * time.Minute
+	defaultRelayBudgetWindow = 24 * time.Hour
+	minRelayFeeBumpPercent   = 10 // the smallest bump geth accepts for a replacement
+	relayGasMarginPercent    = 20
+)
+
+// RelayerService pays for mintIfNotExists on behalf of users from a hot
+// wallet, on whichever chain the voucher was signed for. Nonces are reserved in the database, so concurrent requests, even
+// from several API processes, never share one. Run broadcasts what is
+// queued, replaces transactions that stay unmined with higher fees and
+// records their receipts.
+type RelayerService struct {
+	relayRepo   repoInterfaces.RelayTransactionRepository
+	nftRepo     repoInterfaces.NFTRepository
+	voucherRepo repoInterfaces.VoucherRepository
+	chains      chainInterfaces.Registry
+	signer      signerInterfaces.Signer
+	relayer     common.Address
+	policy      RelayPolicy
+	sendMu      sync.Mutex // requests and the tracker never broadcast at once
+}
+
+// NewRelayerService creates a new RelayerService instance
+func NewRelayerService(
+	relayRepo repoInterfaces.RelayTransactionRepository,
+	nftRepo repoInterfaces.NFTRepository,
+	voucherRepo repoInterfaces.VoucherRepository,
+	chains chainInterfaces.Registry,
+	relayerSigner signerInterfaces.Signer,
+	policy RelayPolicy,
+) *RelayerService {
+	if policy.BudgetWindow <= 0 {
+		policy.BudgetWindow = defaultRelayBudgetWindow
+	}
+	if policy.StuckAfter <= 0 {
+		policy.StuckAfter = defaultRelayStuckAfter
+	}
+	policy.FeeBumpPercent = max(policy.FeeBumpPercent, minRelayFeeBumpPercent)
+	return &RelayerService{
+		relayRepo:   relayRepo,
+		nftRepo:     nftRepo,
+		voucherRepo: voucherRepo,
+		chains:      chains,
+		signer:      relayerSigner,
+		relayer:     relayerSigner.Address(),
+		policy:      policy,
+	}
+}
+
+// Sponsor queues a mintIfNotExists paid by the relayer that redeems the
+// unexpired voucher actor holds for an NFT. The transaction is broadcast
+// straight away when the node accepts it; otherwise Run retries it.
+func (s *RelayerService) Sponsor(ctx context.Context, actor *models.User, nftID uint) (*models.RelayTransaction, error) {
+	nft, err := s.nftRepo.GetByID(nftID)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrNFTNotFound
+		}
+		return nil, err
+	}
+	if nft.IsMinted {
+		return nil, ErrNFTAlreadyMinted
+	}
+	if nft.Drop == nil || nft.Drop.Collection == nil || nft.Drop.Collection.ContractAddress == nil {
+		return nil, ErrCollectionNotDeployed
+	}
+	record, err := s.voucherRepo.GetIssuedForOwner(nft.ID, actor.WalletAddress)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrNoSponsorableVoucher
+		}
+		return nil, err
+	}
+	if !time.Now().Before(record.ExpiresAt) {
+		return nil, ErrNoSponsorableVoucher
+	}
+	network, err := s.chains.Network(record.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	voucher, err := signedVoucherFromRecord(record)
+	if err != nil {
+		return nil, err
+	}
+	data, err := mintIfNotExistsData(voucher.Voucher)
+	if err != nil {
+		return nil, err
+	}
+
+	contract := common.HexToAddress(record.ContractAddress)
+	gas, err := network.Client.EstimateGas(ctx, ethereum.CallMsg{From: s.relayer, To: &contract, Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRelayWouldRevert, err)
+	}
+	gasLimit := gas * (100 + relayGasMarginPercent) / 100
+	tipCap, feeCap, err := networkFees(ctx, network)
+	if err != nil {
+		return nil, err
+	}
+	if s.policy.MaxFeeCap != nil && feeCap.Cmp(s.policy.MaxFeeCap) > 0 {
+		return nil, ErrRelayFeesTooHigh
+	}
+	chainNonce, err := network.Client.PendingNonceAt(ctx, s.relayer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read relayer nonce: %w", err)
+	}
+
+	relayTx := &models.RelayTransaction{
+		ChainID:      network.ChainID,
+		RequesterID:  actor.ID,
+		CreatorID:    nft.Drop.Collection.CreatorID,
+		CollectionID: nft.Drop.Collection.ID,
+		NFTID:        nft.ID,
+		VoucherID:    record.ID,
+		From:         s.relayer.Hex(),
+		To:           contract.Hex(),
+		Data:         hexutil.Encode(data),
+		GasLimit:     gasLimit,
+		GasTipCap:    models.NewUint256(tipCap),
+		GasFeeCap:    models.NewUint256(feeCap),
+		Cost:         models.NewUint256(maxGasCost(gasLimit, feeCap)),
+	}
+	now := time.Now()
+	budget := repoInterfaces.SponsorshipBudget{
+		Since:   now.Add(-s.policy.BudgetWindow),
+		Creator: s.policy.CreatorBudget,
+		User:    s.policy.UserBudget,
+	}
+	if err := s.relayRepo.Reserve(relayTx, chainNonce, budget); err != nil {
+		switch {
+		case errors.Is(err, repoInterfaces.ErrDuplicateRecord):
+			return nil, ErrMintAlreadyRelaying
+		case errors.Is(err, repoInterfaces.ErrCreatorBudgetExceeded):
+			return nil, ErrCreatorSponsorshipExhausted
+		case errors.Is(err, repoInterfaces.ErrUserBudgetExceeded):
+			return nil, ErrUserSponsorshipExhausted
+		}
+		return nil, fmt.Errorf("failed to reserve relayer nonce: %w", err)
+	}
+
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	if err := s.broadcast(ctx, network, relayTx, tipCap, feeCap); err != nil {
+		log.Printf("failed to broadcast relay transaction %d, retrying later: %v", relayTx.ID, err)
+	}
+	return relayTx, nil
+}
+
+// Get returns a relay transaction to its requester, the creator of the
+// collection or an admin
+func (s *RelayerService) Get(actor *models.User, id uint) (*models.RelayTransaction, error) {
+	relayTx, err := s.relayRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrRelayTransactionNotFound
+		}
+		return nil, err
+	}
+	if relayTx.RequesterID != actor.ID && relayTx.CreatorID != actor.ID && actor.Role != models.RoleAdmin {
+		return nil, ErrRelayTransactionNotFound
+	}
+	return relayTx, nil
+}
+
+// Run tracks open relay transactions until ctx is cancelled
+func (s *RelayerService) Run(ctx context.Context) {
+	ticker := time.NewTicker(relayerPollInterval)
+	defer ticker.Stop()
+	for {
+		if err := s.Track(ctx); err != nil {
+			log.Printf("failed to track relay transactions: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Track settles mined relay transactions, broadcasts queued ones and bumps
+// the fees of those stuck in the mempool, on every chain
+func (s *RelayerService) Track(ctx context.Context) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	var errs []error
+	for _, network := range s.chains.Networks() {
+		if err := s.trackChain(ctx, network); err != nil {
+			errs = append(errs, fmt.Errorf("chain %d: %w", network.ChainID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *RelayerService) trackChain(ctx context.Context, network *chainInterfaces.Network) error {
+	open, err := s.relayRepo.ListOpen(network.ChainID, s.relayer.Hex())
+	if err != nil || len(open) == 0 {
+		return err
+	}
+	// every nonce below this one is spent for good
+	mined, err := network.Client.NonceAt(ctx, s.relayer, nil)
+	if err != nil {
+		return fmt.Errorf("failed to read relayer nonce: %w", err)
+	}
+	for _, relayTx := range open {
+		if err := s.track(ctx, network, relayTx, mined); err != nil {
+			log.Printf("failed to track relay transaction %d: %v", relayTx.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *RelayerService) track(ctx context.Context, network *chainInterfaces.Network, relayTx *models.RelayTransaction, mined uint64) error {
+	receipt, err := minedReceipt(ctx, network, relayTx)
+	if err != nil {
+		return err
+	}
+	if receipt != nil {
+		head, err := network.Client.BlockNumber(ctx)
+		if err != nil {
+			return err
+		}
+		if head+1 < receipt.BlockNumber.Uint64()+max(network.Confirmations, 1) {
+			return nil
+		}
+		return s.settle(relayTx, receipt)
+	}
+
+	if relayTx.Nonce < mined {
+		// none of our broadcasts was mined at this nonce; something else
+		// the hot wallet signed was
+		relayTx.Status = models.RelayStatusFailed
+		relayTx.Error = "nonce was used by another transaction"
+		relayTx.Cost = models.NewUint256(new(big.Int))
+		return s.relayRepo.Update(relayTx)
+	}
+	if relayTx.Status == models.RelayStatusQueued {
+		return s.broadcast(ctx, network, relayTx, relayTx.GasTipCap.Big(), relayTx.GasFeeCap.Big())
+	}
+	if relayTx.BroadcastAt != nil && time.Since(*relayTx.BroadcastAt) < s.policy.StuckAfter {
+		return nil
+	}
+	return s.bump(ctx, network, relayTx)
+}
+
+// bump replaces a stuck transaction with one at the same nonce that pays
+// at least FeeBumpPercent more per gas, or the current network fees when
+// they rose further
+func (s *RelayerService) bump(ctx context.Context, network *chainInterfaces.Network, relayTx *models.RelayTransaction) error {
+	tipCap, feeCap, err := networkFees(ctx, network)
+	if err != nil {
+		return err
+	}
+	tipCap = bigMax(tipCap, s.bumped(relayTx.GasTipCap.Big()))
+	feeCap = bigMax(feeCap, s.bumped(relayTx.GasFeeCap.Big()), tipCap)
+	if limit := s.policy.MaxFeeCap; limit != nil && feeCap.Cmp(limit) > 0 {
+		if relayTx.GasFeeCap.Big().Cmp(limit) >= 0 {
+			return nil // already paying the most we will; keep waiting
+		}
+		feeCap = new(big.Int).Set(limit)
+		if tipCap.Cmp(feeCap) > 0 {
+			tipCap = new(big.Int).Set(feeCap)
+		}
+	}
+	return s.broadcast(ctx, network, relayTx, tipCap, feeCap)
+}
+
+func (s *RelayerService) bumped(v *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(v, big.NewInt(int64(100+s.policy.FeeBumpPercent)))
+	bumped.Div(bumped, big.NewInt(100))
+	// tiny values would not move at all
+	if bumped.Cmp(v) <= 0 {
+		bumped.Add(v, big.NewInt(1))
+	}
+	return bumped
+}
+
+// broadcast signs relayTx with the given fees and sends it. The hash is
+// stored before sending, so a broadcast whose response was lost is still
+// found by its receipt. Callers hold sendMu.
+func (s *RelayerService) broadcast(ctx context.Context, network *chainInterfaces.Network, relayTx *models.RelayTransaction, tipCap, feeCap *big.Int) error {
+	data, err := hexutil.Decode(relayTx.Data)
+	if err != nil {
+		return fmt.Errorf("invalid calldata: %w", err)
+	}
+	chainID := big.NewInt(network.ChainID)
+	to := common.HexToAddress(relayTx.To)
+	signed, err := s.signer.SignTx(ctx, types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     relayTx.Nonce,
+		GasTipCap: tipCap,
+		GasFeeCap: feeCap,
+		Gas:       relayTx.GasLimit,
+		To:        &to,
+		Data:      data,
+	}), chainID)
+	if err != nil {
+		return fmt.Errorf("failed to sign relay transaction: %w", err)
+	}
+	hash := signed.Hash().Hex()
+	if !slices.Contains(relayTx.TxHashes, hash) {
+		relayTx.TxHashes = append(relayTx.TxHashes, hash)
+		if err := s.relayRepo.Update(relayTx); err != nil {
+			return err
+		}
+	}
+
+	if err := network.Client.SendTransaction(ctx, signed); err != nil && !strings.Contains(err.Error(), "already known") {
+		relayTx.Error = err.Error()
+		if updateErr := s.relayRepo.Update(relayTx); updateErr != nil {
+			return updateErr
+		}
+		return err
+	}
+	now := time.Now()
+	relayTx.Status = models.RelayStatusPending
+	relayTx.Error = ""
+	relayTx.TxHash = hash
+	relayTx.GasTipCap = models.NewUint256(tipCap)
+	relayTx.GasFeeCap = models.NewUint256(feeCap)
+	relayTx.Cost = models.NewUint256(maxGasCost(relayTx.GasLimit, feeCap))
+	relayTx.Attempts++
+	relayTx.BroadcastAt = &now
+	return s.relayRepo.Update(relayTx)
+}
+
+// minedReceipt returns the receipt of whichever broadcast of relayTx was
+// mined, or nil
+func minedReceipt(ctx context.Context, network *chainInterfaces.Network, relayTx *models.RelayTransaction) (*types.Receipt, error) {
+	for _, hash := range relayTx.TxHashes {
+		receipt, err := network.Client.TransactionReceipt(ctx, common.HexToHash(hash))
+		if err != nil {
+			if txNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if receipt.BlockNumber != nil {
+			return receipt, nil
+		}
+	}
+	return nil, nil
+}
+
+// settle records the outcome and the actual cost of a confirmed transaction
+func (s *RelayerService) settle(relayTx *models.RelayTransaction, receipt *types.Receipt) error {
+	price := receipt.EffectiveGasPrice
+	if price == nil {
+		price = relayTx.GasFeeCap.Big()
+	}
+	block := receipt.BlockNumber.Uint64()
+	now := time.Now()
+	relayTx.TxHash = receipt.TxHash.Hex()
+	relayTx.GasUsed = receipt.GasUsed
+	relayTx.BlockNumber = &block
+	relayTx.Cost = models.NewUint256(maxGasCost(receipt.GasUsed, price))
+	relayTx.ConfirmedAt = &now
+	relayTx.Error = ""
+	relayTx.Status = models.RelayStatusConfirmed
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		relayTx.Status = models.RelayStatusFailed
+		relayTx.Error = "mintIfNotExists reverted"
+	}
+	return s.relayRepo.Update(relayTx)
+}
+
+// networkFees prices a transaction the way bind does: the suggested tip on
+// top of twice the current base fee
+func networkFees(ctx context.Context, network *chainInterfaces.Network) (*big.Int, *big.Int, error) {
+	tipCap, err := network.Client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to suggest gas tip: %w", err)
+	}
+	head, err := network.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if head.BaseFee == nil {
+		return nil, nil, errors.New("chain does not support dynamic fee transactions")
+	}
+	feeCap := new(big.Int).Mul(head.BaseFee, big.NewInt(2))
+	return tipCap, feeCap.Add(feeCap, tipCap), nil
+}
+
+func mintIfNotExistsData(voucher contracts.LazyMint1155Voucher) ([]byte, error) {
+	parsed, err := contracts.LazyMint1155MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Pack("mintIfNotExists", voucher, voucher.Owner)
+}
+
+func maxGasCost(gas uint64, price *big.Int) *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(gas), price)
+}
+
+func bigMax(first *big.Int, rest ...*big.Int) *big.Int {
+	m := first
+	for _, v := range rest {
+		if v.Cmp(m) > 0 {
+			m = v
+		}
+	}
+	return new(big.Int).Set(m)
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	chainAdapters "github.com/igwedaniel/artizan/internal/adapters/chain"
+	chainInterfaces "github.com/igwedaniel/artizan/internal/interfaces/chain"
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"github.com/igwedaniel/artizan/pkg/contracts"
+)
+
+// memoryRelayRepo hands out nonces the way the Postgres repository does
+type memoryRelayRepo struct {
+	txs  []*models.RelayTransaction
+	next map[string]uint64
+}
+
+func (r *memoryRelayRepo) Reserve(relayTx *models.RelayTransaction, chainNonce uint64, _ repoInterfaces.SponsorshipBudget) error {
+	for _, open := range r.txs {
+		if open.NFTID == relayTx.NFTID && (open.Status == models.RelayStatusQueued || open.Status == models.RelayStatusPending) {
+			return repoInterfaces.ErrDuplicateRecord
+		}
+	}
+	relayTx.Nonce = max(r.next[relayTx.From], chainNonce)
+	relayTx.Status = models.RelayStatusQueued
+	relayTx.ID = uint(len(r.txs) + 1)
+	r.txs = append(r.txs, relayTx)
+	r.next[relayTx.From] = relayTx.Nonce + 1
+	return nil
+}
+
+func (r *memoryRelayRepo) GetByID(id uint) (*models.RelayTransaction, error) {
+	if id == 0 || int(id) > len(r.txs) {
+		return nil, repoInterfaces.ErrRecordNotFound
+	}
+	return r.txs[id-1], nil
+}
+
+func (r *memoryRelayRepo) Update(*models.RelayTransaction) error { return nil }
+
+func (r *memoryRelayRepo) ListOpen(chainID int64, from string) ([]*models.RelayTransaction, error) {
+	var open []*models.RelayTransaction
+	for _, relayTx := range r.txs {
+		if relayTx.ChainID == chainID && relayTx.From == from &&
+			(relayTx.Status == models.RelayStatusQueued || relayTx.Status == models.RelayStatusPending) {
+			open = append(open, relayTx)
+		}
+	}
+	return open, nil
+}
+
+// newRelayerTest issues a collector a voucher on a simulated chain and
+// returns a relayer paying from the funded minter wallet
+func newRelayerTest(t *testing.T) (*voucherTest, *RelayerService, *models.User) {
+	t.Helper()
+	v := newVoucherTest(t, 0)
+	collector := walletUser(2, "0x00000000000000000000000000000000000000d4")
+	if _, err := v.service.IssueVoucher(context.Background(), collector, v.nft.ID, big.NewInt(3)); err != nil {
+		t.Fatal(err)
+	}
+	chains, err := chainAdapters.NewRegistry(&chainInterfaces.Network{ChainID: 1337, Name: "simulated", Client: v.backend.Client(), Confirmations: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	relayer := NewRelayerService(&memoryRelayRepo{next: map[string]uint64{}}, &memoryNFTRepo{nfts: map[uint]*models.NFT{v.nft.ID: v.nft}},
+		v.vouchers, chains, v.minter, RelayPolicy{StuckAfter: time.Hour})
+	return v, relayer, collector
+}
+
+func TestStuckRelayIsReplacedUntilMined(t *testing.T) {
+	v, relayer, collector := newRelayerTest(t)
+	ctx := context.Background()
+	relayTx, err := relayer.Sponsor(ctx, collector, v.nft.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if relayTx.Status != models.RelayStatusPending || len(relayTx.TxHashes) != 1 {
+		t.Fatalf("status %s with %d broadcasts, want one pending", relayTx.Status, len(relayTx.TxHashes))
+	}
+	if _, err := relayer.Sponsor(ctx, collector, v.nft.ID); err != ErrMintAlreadyRelaying {
+		t.Fatalf("err = %v, want %v", err, ErrMintAlreadyRelaying)
+	}
+
+	// still within StuckAfter, so nothing is replaced
+	if err := relayer.Track(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(relayTx.TxHashes) != 1 {
+		t.Fatalf("%d broadcasts before the transaction was stuck, want 1", len(relayTx.TxHashes))
+	}
+	firstFeeCap := relayTx.GasFeeCap.Big()
+	stuckSince := time.Now().Add(-2 * time.Hour)
+	relayTx.BroadcastAt = &stuckSince
+	if err := relayer.Track(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(relayTx.TxHashes) != 2 || relayTx.GasFeeCap.Big().Cmp(firstFeeCap) <= 0 {
+		t.Fatalf("stuck transaction was not replaced with higher fees: %d broadcasts, fee cap %s", len(relayTx.TxHashes), relayTx.GasFeeCap.Big())
+	}
+
+	v.backend.Commit()
+	if err := relayer.Track(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if relayTx.Status != models.RelayStatusConfirmed || relayTx.TxHash != relayTx.TxHashes[1] {
+		t.Fatalf("status %s under %s, want confirmed under the replacement %s (error %q)", relayTx.Status, relayTx.TxHash, relayTx.TxHashes[1], relayTx.Error)
+	}
+	if relayTx.GasUsed == 0 || relayTx.Cost.Big().Sign() == 0 {
+		t.Fatal("the actual cost was not recorded")
+	}
+	nft, err := contracts.NewLazyMint1155(v.contract, v.backend.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	balance, err := nft.BalanceOf(&bind.CallOpts{}, common.HexToAddress(collector.WalletAddress), v.nft.TokenID.Big())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Cmp(big.NewInt(3)) != 0 {
+		t.Fatalf("balance = %s, want 3", balance)
+	}
+}
+
+func TestRelayFailsWhenItsNonceIsSpentElsewhere(t *testing.T) {
+	v, relayer, collector := newRelayerTest(t)
+	ctx := context.Background()
+	relayTx, err := relayer.Sponsor(ctx, collector, v.nft.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the hot wallet replaces the relay with a plain transfer
+	client := v.backend.Client()
+	to := v.minter.Address()
+	feeCap := new(big.Int).Mul(relayTx.GasFeeCap.Big(), big.NewInt(2))
+	other, err := v.minter.SignTx(ctx, types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(1337),
+		Nonce:     relayTx.Nonce,
+		GasTipCap: feeCap,
+		GasFeeCap: feeCap,
+		Gas:       21000,
+		To:        &to,
+	}), big.NewInt(1337))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.SendTransaction(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+	v.backend.Commit()
+
+	if err := relayer.Track(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if relayTx.Status != models.RelayStatusFailed || relayTx.Cost.Big().Sign() != 0 {
+		t.Fatalf("status %s costing %s, want failed at no cost", relayTx.Status, relayTx.Cost.Big())
+	}
+}
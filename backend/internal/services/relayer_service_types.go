@@ -0,0 +1,19 @@
+package services
+
+import (
+	"math/big"
+	"time"
+)
+
+// RelayPolicy is what the relayer sponsors and how it prices transactions
+type RelayPolicy struct {
+	// budgets for the transactions sent within BudgetWindow; nil is unlimited
+	CreatorBudget *big.Int
+	UserBudget    *big.Int
+	BudgetWindow  time.Duration
+	// how long a broadcast may stay unmined before it is replaced
+	StuckAfter     time.Duration
+	FeeBumpPercent uint
+	// highest fee cap per gas the relayer pays; nil is unlimited
+	MaxFeeCap *big.Int
+}
@@ -0,0 +1,406 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	chainInterfaces "github.com/igwedaniel/artizan/internal/interfaces/chain"
+	busInterfaces "github.com/igwedaniel/artizan/internal/interfaces/eventbus"
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+	"github.com/igwedaniel/artizan/pkg/seaport"
+)
+
+const (
+	defaultSalePageSize = 20
+	maxSalePageSize     = 100
+)
+
+// SaleService follows Seaport for the indexers. OrderFulfilled logs that
+// trade one of our tokens become sales and fill their listing or offer;
+// OrderCancelled and CounterIncremented logs cancel orders in the book.
+type SaleService struct {
+	saleRepo       repoInterfaces.SaleRepository
+	orderRepo      repoInterfaces.OrderRepository
+	offerRepo      repoInterfaces.OfferRepository
+	nftRepo        repoInterfaces.NFTRepository
+	collectionRepo repoInterfaces.CollectionRepository
+	userRepo       repoInterfaces.UserRepository
+	eventBus       busInterfaces.EventBus
+	feeRecipient   common.Address
+}
+
+// saleWatcher follows the Seaport of one chain
+type saleWatcher struct {
+	*SaleService
+	chainID int64
+	seaport common.Address
+	// collections by contract, refreshed for every range of blocks
+	collections map[common.Address]*models.Collection
+}
+
+// NewSaleService creates a new SaleService instance. Payments to
+// feeRecipient count as the marketplace fee of a sale.
+func NewSaleService(
+	saleRepo repoInterfaces.SaleRepository,
+	orderRepo repoInterfaces.OrderRepository,
+	offerRepo repoInterfaces.OfferRepository,
+	nftRepo repoInterfaces.NFTRepository,
+	collectionRepo repoInterfaces.CollectionRepository,
+	userRepo repoInterfaces.UserRepository,
+	eventBus busInterfaces.EventBus,
+	feeRecipient string,
+) *SaleService {
+	s := &SaleService{
+		saleRepo:       saleRepo,
+		orderRepo:      orderRepo,
+		offerRepo:      offerRepo,
+		nftRepo:        nftRepo,
+		collectionRepo: collectionRepo,
+		userRepo:       userRepo,
+		eventBus:       eventBus,
+	}
+	if common.IsHexAddress(feeRecipient) {
+		s.feeRecipient = common.HexToAddress(feeRecipient)
+	}
+	return s
+}
+
+// ListByNFT returns the sales of an NFT, newest first
+func (s *SaleService) ListByNFT(nftID uint, limit, offset int) ([]*models.Sale, error) {
+	if _, err := s.nftRepo.GetByID(nftID); err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrNFTNotFound
+		}
+		return nil, err
+	}
+	limit, offset = salePage(limit, offset)
+	return s.saleRepo.ListByNFT(nftID, limit, offset)
+}
+
+// ListByCollection returns the sales of a collection's NFTs, newest first
+func (s *SaleService) ListByCollection(collectionID uint, limit, offset int) ([]*models.Sale, error) {
+	if _, err := s.collectionRepo.GetByID(collectionID); err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrCollectionNotFound
+		}
+		return nil, err
+	}
+	limit, offset = salePage(limit, offset)
+	return s.saleRepo.ListByCollection(collectionID, limit, offset)
+}
+
+// Watcher returns the LogWatcher for the indexer of network
+func (s *SaleService) Watcher(network *chainInterfaces.Network) LogWatcher {
+	return &saleWatcher{SaleService: s, chainID: network.ChainID, seaport: network.Seaport}
+}
+
+// FilterQueries matches every fill on Seaport, since the tokens traded are
+// only in the log data, and the cancellations of makers in the order book
+func (s *saleWatcher) FilterQueries() ([]ethereum.FilterQuery, error) {
+	collections, err := s.collectionRepo.ListWithContract(s.chainID)
+	if err != nil {
+		return nil, err
+	}
+	s.collections = make(map[common.Address]*models.Collection, len(collections))
+	for _, collection := range collections {
+		s.collections[common.HexToAddress(*collection.ContractAddress)] = collection
+	}
+
+	queries := []ethereum.FilterQuery{{
+		Addresses: []common.Address{s.seaport},
+		Topics:    [][]common.Hash{{seaport.OrderFulfilledTopic}},
+	}}
+	makers, err := s.orderRepo.ListActiveMakers(s.chainID)
+	if err != nil {
+		return nil, err
+	}
+	bidders, err := s.offerRepo.ListActiveMakers(s.chainID)
+	if err != nil {
+		return nil, err
+	}
+	makers = append(makers, bidders...)
+	if len(makers) > 0 {
+		offerers := make([]common.Hash, len(makers))
+		for i, maker := range makers {
+			offerers[i] = common.BytesToHash(common.HexToAddress(maker).Bytes())
+		}
+		queries = append(queries, ethereum.FilterQuery{
+			Addresses: []common.Address{s.seaport},
+			Topics:    [][]common.Hash{{seaport.OrderCancelledTopic, seaport.CounterIncrementedTopic}, offerers},
+		})
+	}
+	return queries, nil
+}
+
+// HandleLog records one Seaport log
+func (s *saleWatcher) HandleLog(ctx context.Context, entry types.Log, blockTime time.Time) error {
+	if len(entry.Topics) == 0 || entry.Address != s.seaport {
+		return nil
+	}
+	var err error
+	switch entry.Topics[0] {
+	case seaport.OrderFulfilledTopic:
+		err = s.recordFill(entry, blockTime)
+	case seaport.OrderCancelledTopic:
+		err = s.recordCancel(entry, blockTime)
+	case seaport.CounterIncrementedTopic:
+		err = s.recordCounter(entry, blockTime)
+	}
+	return err
+}
+
+// Rollback removes the sales and cancellations of orphaned blocks
+func (s *SaleService) Rollback(_ context.Context, chainID int64, fromBlock uint64) error {
+	if err := s.saleRepo.DeleteFromBlock(chainID, fromBlock); err != nil {
+		return err
+	}
+	if err := s.orderRepo.RevertCancellationsFromBlock(chainID, fromBlock); err != nil {
+		return err
+	}
+	return s.offerRepo.RevertCancellationsFromBlock(chainID, fromBlock)
+}
+
+// recordFill stores a fill that traded one of our tokens. A listing offers
+// the token, so the offerer sells to the recipient; a bid asks for it in
+// the consideration, so the offerer buys from the recipient. Fills of
+// bundles record the first of our tokens they hold.
+func (s *saleWatcher) recordFill(entry types.Log, blockTime time.Time) error {
+	event, err := seaport.ParseOrderFulfilled(entry)
+	if err != nil {
+		log.Printf("failed to decode log %d of %s: %v", entry.Index, entry.TxHash.Hex(), err)
+		return nil
+	}
+
+	var token *seaport.SpentItem
+	var paid []seaport.SpentItem
+	seller, buyer := event.Offerer, event.Recipient
+	for i, item := range event.Offer {
+		if token == nil && s.isOurToken(item.ItemType, item.Token) {
+			token = &event.Offer[i]
+		} else if isCurrencyItem(item.ItemType) {
+			paid = append(paid, item)
+		}
+	}
+	var received []seaport.ReceivedItem
+	for _, item := range event.Consideration {
+		if token == nil && s.isOurToken(item.ItemType, item.Token) {
+			token = &seaport.SpentItem{ItemType: item.ItemType, Token: item.Token, Identifier: item.Identifier, Amount: item.Amount}
+			seller, buyer = event.Recipient, event.Offerer
+		} else if isCurrencyItem(item.ItemType) {
+			received = append(received, item)
+		}
+	}
+	if token == nil || token.Amount.Sign() == 0 || !token.Amount.IsUint64() {
+		return nil
+	}
+
+	collection := s.collections[token.Token]
+	tokenID := models.NewUint256(token.Identifier)
+	nft, err := s.nftRepo.GetByContractAndTokenID(s.chainID, token.Token.Hex(), tokenID)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	sale := &models.Sale{
+		ChainID:         s.chainID,
+		OrderHash:       common.Hash(event.OrderHash).Hex(),
+		NFTID:           nft.ID,
+		CollectionID:    collection.ID,
+		ContractAddress: token.Token.Hex(),
+		TokenID:         tokenID,
+		Quantity:        token.Amount.Uint64(),
+		Seller:          seller.Hex(),
+		Buyer:           buyer.Hex(),
+		TxHash:          entry.TxHash.Hex(),
+		LogIndex:        entry.Index,
+		BlockNumber:     entry.BlockNumber,
+		BlockHash:       entry.BlockHash.Hex(),
+		BlockTime:       blockTime,
+	}
+	s.settle(sale, paid, received)
+
+	if err := s.linkOrder(sale); err != nil {
+		return err
+	}
+	created, err := s.saleRepo.Create(sale)
+	if err != nil {
+		return fmt.Errorf("failed to store sale %s#%d: %w", sale.TxHash, sale.LogIndex, err)
+	}
+	if !created {
+		return nil
+	}
+	s.eventBus.Publish(busInterfaces.EventSaleCompleted, busInterfaces.SaleCompletedEvent{
+		SaleID:   sale.ID,
+		NFTID:    sale.NFTID,
+		SellerID: s.userID(seller),
+		BuyerID:  s.userID(buyer),
+		Price:    sale.Price.String(),
+		Currency: sale.Currency,
+	})
+	return nil
+}
+
+// settle works out the price and its split. The currency is the first one
+// paid. For a listing the buyer pays every consideration item; for a bid
+// the offer is the price and consideration items besides the token are
+// deducted from what the seller receives. Payments to the seller are
+// proceeds, to the fee recipient the fee, and to anyone else royalties.
+func (s *SaleService) settle(sale *models.Sale, paid []seaport.SpentItem, received []seaport.ReceivedItem) {
+	var currency *common.Address
+	switch {
+	case len(paid) > 0:
+		currency = &paid[0].Token
+	case len(received) > 0:
+		currency = &received[0].Token
+	}
+	if currency == nil {
+		currency = &common.Address{}
+	}
+	sale.Currency = currency.Hex()
+
+	price := new(big.Int)
+	for _, item := range paid {
+		if item.Token == *currency {
+			price.Add(price, item.Amount)
+		}
+	}
+	fee, royalties, proceeds := new(big.Int), new(big.Int), new(big.Int)
+	sellerAddress := common.HexToAddress(sale.Seller)
+	for _, item := range received {
+		if item.Token != *currency {
+			continue
+		}
+		if len(paid) == 0 {
+			price.Add(price, item.Amount)
+		}
+		payment := models.SalePayment{Recipient: item.Recipient.Hex(), Amount: item.Amount.String()}
+		switch {
+		case item.Recipient == sellerAddress:
+			payment.Kind = models.SalePaymentSeller
+			proceeds.Add(proceeds, item.Amount)
+		case s.feeRecipient != (common.Address{}) && item.Recipient == s.feeRecipient:
+			payment.Kind = models.SalePaymentFee
+			fee.Add(fee, item.Amount)
+		default:
+			payment.Kind = models.SalePaymentRoyalty
+			royalties.Add(royalties, item.Amount)
+		}
+		sale.Payments = append(sale.Payments, payment)
+	}
+	if len(paid) > 0 {
+		// the seller of a bid keeps what the consideration does not take
+		proceeds.Sub(price, fee)
+		proceeds.Sub(proceeds, royalties)
+		if proceeds.Sign() < 0 {
+			proceeds.SetInt64(0)
+		}
+		sale.Payments = append(sale.Payments, models.SalePayment{Recipient: sale.Seller, Amount: proceeds.String(), Kind: models.SalePaymentSeller})
+	}
+	sale.Price = models.NewUint256(price)
+	sale.SellerProceeds = models.NewUint256(proceeds)
+	sale.Fee = models.NewUint256(fee)
+	sale.Royalties = models.NewUint256(royalties)
+}
+
+func (s *saleWatcher) recordCancel(entry types.Log, blockTime time.Time) error {
+	event, err := seaport.ParseOrderCancelled(entry)
+	if err != nil {
+		log.Printf("failed to decode log %d of %s: %v", entry.Index, entry.TxHash.Hex(), err)
+		return nil
+	}
+	orderHash := common.Hash(event.OrderHash).Hex()
+	if _, err := s.orderRepo.MarkCancelled(s.chainID, orderHash, blockTime, entry.BlockNumber); err != nil {
+		return fmt.Errorf("failed to cancel order %s: %w", orderHash, err)
+	}
+	if _, err := s.offerRepo.MarkCancelled(s.chainID, orderHash, blockTime, entry.BlockNumber); err != nil {
+		return fmt.Errorf("failed to cancel offer %s: %w", orderHash, err)
+	}
+	return nil
+}
+
+func (s *saleWatcher) recordCounter(entry types.Log, blockTime time.Time) error {
+	event, err := seaport.ParseCounterIncremented(entry)
+	if err != nil {
+		log.Printf("failed to decode log %d of %s: %v", entry.Index, entry.TxHash.Hex(), err)
+		return nil
+	}
+	counter := models.NewUint256(event.NewCounter)
+	if _, err := s.orderRepo.CancelBelowCounter(s.chainID, event.Offerer.Hex(), counter, blockTime, entry.BlockNumber); err != nil {
+		return fmt.Errorf("failed to cancel orders of %s: %w", event.Offerer.Hex(), err)
+	}
+	if _, err := s.offerRepo.CancelBelowCounter(s.chainID, event.Offerer.Hex(), counter, blockTime, entry.BlockNumber); err != nil {
+		return fmt.Errorf("failed to cancel offers of %s: %w", event.Offerer.Hex(), err)
+	}
+	return nil
+}
+
+// linkOrder points a sale at the listing or offer it filled, if either is
+// in our order book
+func (s *SaleService) linkOrder(sale *models.Sale) error {
+	order, err := s.orderRepo.GetByHash(sale.OrderHash)
+	if err == nil {
+		if order.ChainID == sale.ChainID {
+			sale.OrderID = &order.ID
+		}
+		return nil
+	}
+	if !errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+		return err
+	}
+	offer, err := s.offerRepo.GetByHash(sale.OrderHash)
+	if err == nil {
+		if offer.ChainID == sale.ChainID {
+			sale.OfferID = &offer.ID
+		}
+		return nil
+	}
+	if !errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (s *saleWatcher) isOurToken(itemType seaport.ItemType, token common.Address) bool {
+	return itemType == seaport.ItemTypeERC1155 && s.collections[token] != nil
+}
+
+// userID finds the account behind a wallet, zero when there is none
+func (s *SaleService) userID(address common.Address) uint {
+	user, err := s.userRepo.GetUserByWalletAddress(strings.ToLower(address.Hex()))
+	if err != nil {
+		if !errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			log.Printf("failed to look up user of %s: %v", address.Hex(), err)
+		}
+		return 0
+	}
+	return user.ID
+}
+
+func isCurrencyItem(itemType seaport.ItemType) bool {
+	return itemType == seaport.ItemTypeNative || itemType == seaport.ItemTypeERC20
+}
+
+func salePage(limit, offset int) (int, int) {
+	if limit <= 0 {
+		limit = defaultSalePageSize
+	}
+	if limit > maxSalePageSize {
+		limit = maxSalePageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
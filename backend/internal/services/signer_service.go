@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	signerInterfaces "github.com/igwedaniel/artizan/internal/interfaces/signer"
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+// SignerService keeps track of the keys the backend signs with and of every
+// signature they produce.
+type SignerService struct {
+	signerRepo repoInterfaces.SignerRepository
+}
+
+// NewSignerService creates a new SignerService instance
+func NewSignerService(signerRepo repoInterfaces.SignerRepository) *SignerService {
+	return &SignerService{
+		signerRepo: signerRepo,
+	}
+}
+
+// Activate records signer as the active key for purpose, retiring the key it
+// replaces, and returns a Signer that audits every signature it produces.
+func (s *SignerService) Activate(purpose, backend string, signer signerInterfaces.Signer) (signerInterfaces.Signer, error) {
+	address := signer.Address().Hex()
+	active, err := s.signerRepo.GetActiveKey(purpose)
+	if err != nil && !errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+		return nil, err
+	}
+	if active == nil || active.Address != address || active.Backend != backend {
+		key := &models.SignerKey{Purpose: purpose, Address: address, Backend: backend}
+		if err := s.signerRepo.ActivateKey(key); err != nil {
+			return nil, fmt.Errorf("failed to activate %s signer: %w", purpose, err)
+		}
+	}
+	return &auditedSigner{Signer: signer, purpose: purpose, signerRepo: s.signerRepo}, nil
+}
+
+// ListKeys returns the keys used for purpose, or for every purpose when it is
+// empty
+func (s *SignerService) ListKeys(purpose string) ([]*models.SignerKey, error) {
+	return s.signerRepo.ListKeys(purpose)
+}
+
+// ListSignatures returns the audit trail of a key, newest first
+func (s *SignerService) ListSignatures(address string, limit, offset int) ([]*models.SignatureAudit, error) {
+	if !common.IsHexAddress(address) {
+		return nil, ErrInvalidSignerAddress
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.signerRepo.ListAudits(common.HexToAddress(address).Hex(), limit, offset)
+}
+
+// auditedSigner stores a SignatureAudit for every signature before handing it
+// out; a signature that cannot be audited is not released
+type auditedSigner struct {
+	signerInterfaces.Signer
+	purpose    string
+	signerRepo repoInterfaces.SignerRepository
+}
+
+func (s *auditedSigner) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	signature, err := s.Signer.SignHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	audit := &models.SignatureAudit{
+		SignerAddress: s.Address().Hex(),
+		Purpose:       s.purpose,
+		Kind:          models.SignatureKindHash,
+		Digest:        hash.Hex(),
+		Signature:     hexutil.Encode(signature),
+	}
+	if err := s.signerRepo.CreateAudit(audit); err != nil {
+		return nil, fmt.Errorf("failed to audit signature: %w", err)
+	}
+	return signature, nil
+}
+
+func (s *auditedSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	signed, err := s.Signer.SignTx(ctx, tx, chainID)
+	if err != nil {
+		return nil, err
+	}
+	v, r, sig := signed.RawSignatureValues()
+	chain := chainID.Int64()
+	audit := &models.SignatureAudit{
+		SignerAddress: s.Address().Hex(),
+		Purpose:       s.purpose,
+		Kind:          models.SignatureKindTransaction,
+		Digest:        types.LatestSignerForChainID(chainID).Hash(signed).Hex(),
+		Signature:     hexutil.Encode(txSignatureBytes(v, r, sig)),
+		ChainID:       &chain,
+		TxHash:        signed.Hash().Hex(),
+	}
+	if err := s.signerRepo.CreateAudit(audit); err != nil {
+		return nil, fmt.Errorf("failed to audit transaction signature: %w", err)
+	}
+	return signed, nil
+}
+
+// txSignatureBytes lays out a transaction signature as R || S || V, where V
+// keeps its EIP-155 value for legacy transactions
+func txSignatureBytes(v, r, s *big.Int) []byte {
+	out := append(common.LeftPadBytes(r.Bytes(), 32), common.LeftPadBytes(s.Bytes(), 32)...)
+	if v.Sign() == 0 {
+		return append(out, 0)
+	}
+	return append(out, v.Bytes()...)
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/igwedaniel/artizan/internal/adapters/signer"
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+type memorySignerRepo struct {
+	keys     []*models.SignerKey
+	audits   []*models.SignatureAudit
+	auditErr error
+}
+
+func (r *memorySignerRepo) GetActiveKey(purpose string) (*models.SignerKey, error) {
+	for _, k := range r.keys {
+		if k.Purpose == purpose && k.Status == models.SignerKeyStatusActive {
+			return k, nil
+		}
+	}
+	return nil, repoInterfaces.ErrRecordNotFound
+}
+
+func (r *memorySignerRepo) ActivateKey(key *models.SignerKey) error {
+	now := time.Now()
+	for _, k := range r.keys {
+		if k.Purpose == key.Purpose && k.Status == models.SignerKeyStatusActive {
+			k.Status = models.SignerKeyStatusRetired
+			k.RetiredAt = &now
+		}
+	}
+	key.ID = uint(len(r.keys) + 1)
+	key.Status = models.SignerKeyStatusActive
+	key.ActivatedAt = now
+	r.keys = append(r.keys, key)
+	return nil
+}
+
+func (r *memorySignerRepo) ListKeys(purpose string) ([]*models.SignerKey, error) {
+	var keys []*models.SignerKey
+	for _, k := range r.keys {
+		if purpose == "" || k.Purpose == purpose {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+func (r *memorySignerRepo) CreateAudit(audit *models.SignatureAudit) error {
+	if r.auditErr != nil {
+		return r.auditErr
+	}
+	audit.ID = uint(len(r.audits) + 1)
+	r.audits = append(r.audits, audit)
+	return nil
+}
+
+func (r *memorySignerRepo) ListAudits(signerAddress string, limit, offset int) ([]*models.SignatureAudit, error) {
+	var audits []*models.SignatureAudit
+	for i := len(r.audits) - 1; i >= 0; i-- {
+		if r.audits[i].SignerAddress == signerAddress {
+			audits = append(audits, r.audits[i])
+		}
+	}
+	if offset >= len(audits) {
+		return nil, nil
+	}
+	return audits[offset:min(offset+limit, len(audits))], nil
+}
+
+func TestSignerActivationRetiresThePreviousKey(t *testing.T) {
+	repo := &memorySignerRepo{}
+	s := NewSignerService(repo)
+	first, _ := signer.GenerateMemorySigner()
+	second, _ := signer.GenerateMemorySigner()
+
+	if _, err := s.Activate(models.SignerPurposeVoucher, signer.BackendMemory, first); err != nil {
+		t.Fatal(err)
+	}
+	// restarting with the same key keeps its record
+	if _, err := s.Activate(models.SignerPurposeVoucher, signer.BackendMemory, first); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.keys) != 1 {
+		t.Fatalf("%d keys recorded after activating the same key twice", len(repo.keys))
+	}
+	if _, err := s.Activate(models.SignerPurposeVoucher, signer.BackendRemote, second); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, _ := s.ListKeys(models.SignerPurposeVoucher)
+	if len(keys) != 2 {
+		t.Fatalf("%d keys, want 2", len(keys))
+	}
+	if keys[0].Address != first.Address().Hex() || keys[0].Status != models.SignerKeyStatusRetired || keys[0].RetiredAt == nil {
+		t.Fatalf("first key = %+v, want it retired", keys[0])
+	}
+	if keys[1].Address != second.Address().Hex() || keys[1].Status != models.SignerKeyStatusActive || keys[1].Backend != signer.BackendRemote {
+		t.Fatalf("second key = %+v, want it active on the remote backend", keys[1])
+	}
+}
+
+func TestAuditedSignerRecordsEverySignature(t *testing.T) {
+	repo := &memorySignerRepo{}
+	s := NewSignerService(repo)
+	backing, _ := signer.GenerateMemorySigner()
+	audited, err := s.Activate(models.SignerPurposeDeployer, signer.BackendMemory, backing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	hash := crypto.Keccak256Hash([]byte("voucher"))
+	signature, err := audited.SignHash(ctx, hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chainID := big.NewInt(1337)
+	to := common.HexToAddress("0x00000000000000000000000000000000000000e1")
+	signed, err := audited.SignTx(ctx, types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1), Gas: 21000, To: &to}), chainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	audits, err := s.ListSignatures(backing.Address().Hex(), 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(audits) != 2 {
+		t.Fatalf("%d audits, want 2", len(audits))
+	}
+	txAudit, hashAudit := audits[0], audits[1]
+	if hashAudit.Kind != models.SignatureKindHash || hashAudit.Purpose != models.SignerPurposeDeployer ||
+		hashAudit.Digest != hash.Hex() || hashAudit.Signature != hexutil.Encode(signature) {
+		t.Fatalf("hash audit = %+v", hashAudit)
+	}
+	if txAudit.Kind != models.SignatureKindTransaction || txAudit.TxHash != signed.Hash().Hex() ||
+		txAudit.ChainID == nil || *txAudit.ChainID != 1337 ||
+		txAudit.Digest != types.LatestSignerForChainID(chainID).Hash(signed).Hex() {
+		t.Fatalf("transaction audit = %+v", txAudit)
+	}
+	// legacy transactions keep their EIP-155 v
+	v, _, _ := signed.RawSignatureValues()
+	if sig := hexutil.MustDecode(txAudit.Signature); len(sig) <= 64 || new(big.Int).SetBytes(sig[64:]).Cmp(v) != 0 {
+		t.Fatalf("transaction audit signature %s does not end in v %s", txAudit.Signature, v)
+	}
+
+	if _, err := s.ListSignatures("not an address", 10, 0); !errors.Is(err, ErrInvalidSignerAddress) {
+		t.Fatalf("err = %v, want %v", err, ErrInvalidSignerAddress)
+	}
+}
+
+func TestAuditedSignerWithholdsUnauditedSignatures(t *testing.T) {
+	repo := &memorySignerRepo{}
+	s := NewSignerService(repo)
+	backing, _ := signer.GenerateMemorySigner()
+	audited, err := s.Activate(models.SignerPurposeVoucher, signer.BackendMemory, backing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo.auditErr = errors.New("database is down")
+
+	if signature, err := audited.SignHash(context.Background(), crypto.Keccak256Hash([]byte("voucher"))); err == nil || signature != nil {
+		t.Fatalf("SignHash = %x, %v; want no signature without an audit", signature, err)
+	}
+	to := common.HexToAddress("0x00000000000000000000000000000000000000e1")
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1), Gas: 21000, To: &to})
+	if signed, err := audited.SignTx(context.Background(), tx, big.NewInt(1337)); err == nil || signed != nil {
+		t.Fatalf("SignTx = %v, %v; want no transaction without an audit", signed, err)
+	}
+}
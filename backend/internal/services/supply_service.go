@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+const (
+	defaultReservationTTL    = 15 * time.Minute
+	reservationSweepInterval = time.Minute
+)
+
+// SupplyService keeps drops from issuing more vouchers than their Supply.
+// Every collector voucher, and every listing voucher of a priced drop, holds
+// a reservation of one slot, taken under a lock on the drop, so however many
+// wallets ask at once, minted NFTs and live holds never pass the supply. The contract honours a voucher until
+// the collection's signer is rotated, whatever its expiry, so a slot is only
+// released once no voucher for it can mint; Run releases those, and a slot
+// past its TTL can be taken over by a new voucher for the same NFT.
+type SupplyService struct {
+	reservationRepo repoInterfaces.DropReservationRepository
+	ttl             time.Duration // longest a slot is held for a voucher
+}
+
+// NewSupplyService creates a new SupplyService instance
+func NewSupplyService(reservationRepo repoInterfaces.DropReservationRepository, ttl time.Duration) *SupplyService {
+	if ttl <= 0 {
+		ttl = defaultReservationTTL
+	}
+	return &SupplyService{
+		reservationRepo: reservationRepo,
+		ttl:             ttl,
+	}
+}
+
+// Run releases expired reservations until ctx is cancelled
+func (s *SupplyService) Run(ctx context.Context) {
+	ticker := time.NewTicker(reservationSweepInterval)
+	defer ticker.Stop()
+	for {
+		if released, err := s.reservationRepo.ReleaseExpired(time.Now()); err != nil {
+			log.Printf("failed to release expired drop reservations: %v", err)
+		} else if released > 0 {
+			log.Printf("released %d expired drop reservations", released)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reserve holds a slot of nft's drop for owner to mint amount copies until
+// until or the reservation TTL, whichever comes first. A non-nil quota caps
+// the copies owner may hold or have bought in the drop; passing it fails
+// with quotaErr.
+func (s *SupplyService) reserve(nft *models.NFT, owner string, amount uint64, quota *uint64, quotaErr error, now, until time.Time) (*models.DropReservation, error) {
+	expiresAt := now.Add(s.ttl)
+	if until.Before(expiresAt) {
+		expiresAt = until
+	}
+	reservation := &models.DropReservation{
+		DropID:       nft.DropID,
+		NFTID:        nft.ID,
+		OwnerAddress: owner,
+		Amount:       amount,
+		ExpiresAt:    expiresAt,
+	}
+	reserved, err := s.reservationRepo.Reserve(reservation, quota, now)
+	if err != nil {
+		switch {
+		case errors.Is(err, repoInterfaces.ErrRecordNotFound):
+			return nil, ErrDropNotFound
+		case errors.Is(err, repoInterfaces.ErrDuplicateRecord):
+			return nil, ErrNFTVoucherOutstanding
+		case errors.Is(err, repoInterfaces.ErrQuotaExceeded):
+			return nil, quotaErr
+		}
+		return nil, err
+	}
+	if !reserved {
+		return nil, ErrDropSoldOut
+	}
+	return reservation, nil
+}
+
+// release frees the slot an NFT holds, if no voucher for it can mint
+func (s *SupplyService) release(nftID uint) error {
+	_, err := s.reservationRepo.Release(nftID, time.Now())
+	return err
+}
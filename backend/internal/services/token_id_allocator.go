@@ -0,0 +1,79 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	"github.com/igwedaniel/artizan/internal/models"
+)
+
+// Token IDs put the drop ID in the high 128 bits and a per-drop sequence,
+// starting at 1, in the low 128 bits. Drop IDs are unique, so two drops can
+// never produce the same ID, whichever collection contract they mint on.
+const tokenSequenceBits = 128
+
+var tokenSequenceMask = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), tokenSequenceBits), big.NewInt(1))
+
+// maxTokenBatch bounds a single AllocateN call
+const maxTokenBatch = 10000
+
+// ComposeTokenID builds the token ID of the sequence-th NFT of a drop
+func ComposeTokenID(dropID uint, sequence uint64) models.Uint256 {
+	id := new(big.Int).Lsh(new(big.Int).SetUint64(uint64(dropID)), tokenSequenceBits)
+	return models.NewUint256(id.Or(id, new(big.Int).SetUint64(sequence)))
+}
+
+// SplitTokenID is the inverse of ComposeTokenID
+func SplitTokenID(tokenID models.Uint256) (dropID uint, sequence uint64, err error) {
+	id := tokenID.Big()
+	high := new(big.Int).Rsh(id, tokenSequenceBits)
+	low := new(big.Int).And(id, tokenSequenceMask)
+	if !high.IsUint64() || !low.IsUint64() {
+		return 0, 0, fmt.Errorf("token id %s was not allocated by this backend", id)
+	}
+	return uint(high.Uint64()), low.Uint64(), nil
+}
+
+// TokenIDAllocator hands out token IDs. IDs are never reused; an ID whose NFT
+// failed to insert leaves a gap in the drop's sequence.
+type TokenIDAllocator struct {
+	dropRepo repoInterfaces.DropRepository
+}
+
+// NewTokenIDAllocator creates a new TokenIDAllocator instance
+func NewTokenIDAllocator(dropRepo repoInterfaces.DropRepository) *TokenIDAllocator {
+	return &TokenIDAllocator{
+		dropRepo: dropRepo,
+	}
+}
+
+// Allocate reserves the next token ID of a drop
+func (a *TokenIDAllocator) Allocate(dropID uint) (models.Uint256, error) {
+	ids, err := a.AllocateN(dropID, 1)
+	if err != nil {
+		return models.Uint256{}, err
+	}
+	return ids[0], nil
+}
+
+// AllocateN reserves n consecutive token IDs of a drop in one round trip
+func (a *TokenIDAllocator) AllocateN(dropID uint, n int) ([]models.Uint256, error) {
+	if n <= 0 || n > maxTokenBatch {
+		return nil, fmt.Errorf("cannot allocate %d token ids at once", n)
+	}
+	last, err := a.dropRepo.ReserveTokenSequences(dropID, uint64(n))
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrDropNotFound
+		}
+		return nil, fmt.Errorf("failed to reserve token ids: %w", err)
+	}
+	ids := make([]models.Uint256, n)
+	first := last - uint64(n) + 1
+	for i := range ids {
+		ids[i] = ComposeTokenID(dropID, first+uint64(i))
+	}
+	return ids, nil
+}
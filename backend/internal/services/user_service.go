@@ -2,22 +2,115 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
+	busInterfaces "github.com/igwedaniel/artizan/internal/interfaces/eventbus"
 	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
 	"github.com/igwedaniel/artizan/internal/models"
 )
 
 type UserService struct {
-	userRepo repoInterfaces.UserRepository
+	userRepo   repoInterfaces.UserRepository
+	followRepo repoInterfaces.FollowRepository
+	eventBus   busInterfaces.EventBus
 }
 
 // NewUserService creates a new UserService instance
-func NewUserService(userRepo repoInterfaces.UserRepository) *UserService {
+func NewUserService(
+	userRepo repoInterfaces.UserRepository,
+	followRepo repoInterfaces.FollowRepository,
+	eventBus busInterfaces.EventBus,
+) *UserService {
 	return &UserService{
-		userRepo: userRepo,
+		userRepo:   userRepo,
+		followRepo: followRepo,
+		eventBus:   eventBus,
 	}
 }
 
+// Follow makes actor follow the user behind handle and publishes
+// user.followed. Following someone already followed does nothing.
+func (s *UserService) Follow(actor *models.User, handle string) (*models.User, error) {
+	followee, err := s.resolveHandle(handle)
+	if err != nil {
+		return nil, err
+	}
+	if followee.ID == actor.ID {
+		return nil, ErrCannotFollowSelf
+	}
+	err = s.followRepo.Create(&models.Follow{FollowerID: actor.ID, FolloweeID: followee.ID})
+	switch {
+	case errors.Is(err, repoInterfaces.ErrDuplicateRecord):
+		return followee, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to follow user: %w", err)
+	}
+	s.eventBus.Publish(busInterfaces.EventUserFollowed, busInterfaces.UserFollowedEvent{
+		FollowerID: actor.ID,
+		FolloweeID: followee.ID,
+	})
+	return followee, nil
+}
+
+// Unfollow stops actor following the user behind handle
+func (s *UserService) Unfollow(actor *models.User, handle string) error {
+	followee, err := s.resolveHandle(handle)
+	if err != nil {
+		return err
+	}
+	if _, err := s.followRepo.Delete(actor.ID, followee.ID); err != nil {
+		return fmt.Errorf("failed to unfollow user: %w", err)
+	}
+	return nil
+}
+
+// ApproveCreator gives a user the creator role and publishes
+// creator.approved. Approving a creator again does nothing.
+func (s *UserService) ApproveCreator(userID uint) (*models.User, error) {
+	id := strconv.FormatUint(uint64(userID), 10)
+	user, err := s.GetUserByID(id)
+	if err != nil {
+		return nil, err
+	}
+	switch user.Role {
+	case models.RoleCreator:
+		return user, nil
+	case models.RoleAdmin:
+		return nil, ErrUserIsAdmin
+	}
+	if err := s.userRepo.UpdateUserByID(id, &models.User{Role: models.RoleCreator}); err != nil {
+		return nil, fmt.Errorf("failed to approve creator: %w", err)
+	}
+	user.Role = models.RoleCreator
+	s.eventBus.Publish(busInterfaces.EventCreatorApproved, busInterfaces.CreatorApprovedEvent{UserID: user.ID})
+	return user, nil
+}
+
+// resolveHandle finds the user behind a username or wallet address
+func (s *UserService) resolveHandle(handle string) (*models.User, error) {
+	handle = strings.TrimPrefix(strings.TrimSpace(handle), "@")
+	if handle == "" {
+		return nil, ErrInvalidHandle
+	}
+	var user *models.User
+	var err error
+	if common.IsHexAddress(handle) {
+		user, err = s.userRepo.GetUserByWalletAddress(strings.ToLower(handle))
+	} else {
+		user, err = s.userRepo.GetUserByUsername(handle)
+	}
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
 // get User by ID
 func (s *UserService) GetUserByID(id string) (*models.User, error) {
 	user, err := s.userRepo.GetByID(id)
@@ -0,0 +1,456 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	signerInterfaces "github.com/igwedaniel/artizan/internal/interfaces/signer"
+	"github.com/igwedaniel/artizan/internal/models"
+	"github.com/igwedaniel/artizan/pkg/contracts"
+)
+
+const defaultVoucherTTL = 24 * time.Hour
+
+// VoucherService signs LazyMint1155 mint vouchers with the platform signer
+// and keeps a ledger of every voucher it hands out.
+type VoucherService struct {
+	nftRepo        repoInterfaces.NFTRepository
+	voucherRepo    repoInterfaces.VoucherRepository
+	collectionRepo repoInterfaces.CollectionRepository
+	signer         signerInterfaces.Signer
+	ttl            time.Duration
+	baseURL        string // where tokens without external metadata resolve theirs
+	assets         *AssetService
+	allowlists     *AllowlistService
+	supply         *SupplyService
+}
+
+// NewVoucherService creates a new VoucherService instance. With an
+// AssetService, metadata is pinned to IPFS and vouchers carry its ipfs://
+// URI; without one they point at the metadata served under baseURL.
+// Vouchers of private drops only go to wallets on the drop's allowlist, for
+// at most their quota of copies, and each voucher holds a slot of its drop's
+// supply until it expires.
+func NewVoucherService(
+	nftRepo repoInterfaces.NFTRepository,
+	voucherRepo repoInterfaces.VoucherRepository,
+	collectionRepo repoInterfaces.CollectionRepository,
+	signer signerInterfaces.Signer,
+	ttl time.Duration,
+	baseURL string,
+	assets *AssetService,
+	allowlists *AllowlistService,
+	supply *SupplyService,
+) *VoucherService {
+	if ttl <= 0 {
+		ttl = defaultVoucherTTL
+	}
+	return &VoucherService{
+		nftRepo:        nftRepo,
+		voucherRepo:    voucherRepo,
+		collectionRepo: collectionRepo,
+		signer:         signer,
+		ttl:            ttl,
+		baseURL:        baseURL,
+		assets:         assets,
+		allowlists:     allowlists,
+		supply:         supply,
+	}
+}
+
+// SignerAddress is the address the collection contracts must have as signer
+func (s *VoucherService) SignerAddress() common.Address {
+	return s.signer.Address()
+}
+
+// IssueVoucher signs a voucher that lets owner mint amount copies of an NFT
+// of a live drop. Asking again for the same voucher returns the one already
+// issued.
+func (s *VoucherService) IssueVoucher(ctx context.Context, owner *models.User, nftID uint, amount *big.Int) (*SignedVoucher, error) {
+	return s.issueVoucher(ctx, owner, nftID, amount, time.Time{})
+}
+
+// IssueListingVoucher signs the voucher a listing of an NFT by the
+// collection's creator carries, honoured until the listing ends at endTime
+func (s *VoucherService) IssueListingVoucher(ctx context.Context, owner *models.User, nftID uint, amount *big.Int, endTime time.Time) (*SignedVoucher, error) {
+	return s.issueVoucher(ctx, owner, nftID, amount, endTime)
+}
+
+// issueVoucher issues a voucher honoured until listingEnd for a listing, or
+// for the voucher TTL capped by the drop reservation when listingEnd is zero
+func (s *VoucherService) issueVoucher(ctx context.Context, owner *models.User, nftID uint, amount *big.Int, listingEnd time.Time) (*SignedVoucher, error) {
+	if amount == nil || amount.Sign() <= 0 {
+		return nil, ErrInvalidVoucherAmount
+	}
+	if !common.IsHexAddress(owner.WalletAddress) {
+		return nil, fmt.Errorf("user %d has no valid wallet address", owner.ID)
+	}
+
+	nft, err := s.nftRepo.GetByID(nftID)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrNFTNotFound
+		}
+		return nil, err
+	}
+	if nft.IsMinted {
+		return nil, ErrNFTAlreadyMinted
+	}
+	if amount.Cmp(new(big.Int).SetUint64(nft.EditionSize)) > 0 {
+		return nil, ErrVoucherExceedsEdition
+	}
+	if nft.Drop == nil || nft.Drop.Collection == nil || nft.Drop.Collection.ContractAddress == nil {
+		return nil, ErrCollectionNotDeployed
+	}
+	if nft.Drop.Status != models.DropStatusLive {
+		return nil, ErrDropNotLive
+	}
+	// mintIfNotExists takes no payment, so only the creator gets vouchers for
+	// a priced drop, to sell the copies through listings; anyone may claim a
+	// free drop
+	forCreator := owner.ID == nft.Drop.Collection.CreatorID
+	if nft.Drop.Price > 0 && !forCreator {
+		return nil, ErrDropPriced
+	}
+	// until setSigner is confirmed the contract rejects the current signer
+	signer := s.SignerAddress().Hex()
+	if collection := nft.Drop.Collection; collection.SignerAddress == nil || *collection.SignerAddress != signer {
+		return nil, ErrCollectionSignerRotating
+	}
+
+	// a revoked signature stays valid on-chain until the signer is rotated
+	revoked, err := s.voucherRepo.HasRevokedBySigner(nft.ID, signer)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, ErrNFTVoucherRevoked
+	}
+	if forCreator {
+		return s.issueForCreator(ctx, nft, owner, amount, signer, listingEnd)
+	}
+	if !listingEnd.IsZero() {
+		return nil, ErrNotCollectionOwner
+	}
+	allowed, err := s.allowlists.entryFor(nft.Drop, owner.WalletAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.voucherRepo.GetIssuedByNFT(nft.ID)
+	if err != nil && !errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+		return nil, err
+	}
+	if existing != nil {
+		if time.Now().Before(existing.ExpiresAt) {
+			if strings.EqualFold(existing.OwnerAddress, owner.WalletAddress) && existing.Amount == amount.String() && existing.SignerAddress == signer {
+				return signedVoucherFromRecord(existing)
+			}
+			return nil, ErrNFTVoucherOutstanding
+		}
+		existing.Status = models.VoucherStatusExpired
+		if err := s.voucherRepo.Update(existing); err != nil {
+			return nil, err
+		}
+	}
+	// the quota is checked under the same lock that takes the slot, so
+	// concurrent requests of one wallet cannot pass it together
+	quota, quotaErr := walletQuota(nft.Drop, allowed)
+	now := time.Now()
+	reservation, err := s.supply.reserve(nft, owner.WalletAddress, amount.Uint64(), quota, quotaErr, now, now.Add(s.ttl))
+	if err != nil {
+		return nil, err
+	}
+	signed, err := s.issue(ctx, nft, owner, amount, false, now, reservation.ExpiresAt)
+	if err != nil {
+		// give the slot back rather than hold it until it expires; nobody
+		// got a signature for it
+		if releaseErr := s.supply.release(nft.ID); releaseErr != nil {
+			log.Printf("failed to release the drop reservation of nft %d: %v", nft.ID, releaseErr)
+		}
+		return nil, err
+	}
+	return signed, nil
+}
+
+// issueForCreator signs a voucher for the collection's creator, who lists
+// the NFT rather than claiming a copy of its drop. The drop's allowlist does
+// not apply to them. In a free drop their vouchers take no slot and leave the
+// NFT free for a collector's; a priced drop sells only through listings, so
+// each listed NFT holds a slot of its supply. A listing's voucher is
+// honoured until the listing ends.
+func (s *VoucherService) issueForCreator(ctx context.Context, nft *models.NFT, owner *models.User, amount *big.Int, signer string, listingEnd time.Time) (*SignedVoucher, error) {
+	existing, err := s.voucherRepo.GetIssuedForOwner(nft.ID, owner.WalletAddress)
+	if err != nil && !errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+		return nil, err
+	}
+	now := time.Now()
+	expiresAt := listingEnd
+	if expiresAt.IsZero() {
+		expiresAt = now.Add(s.ttl)
+	}
+	if existing != nil && existing.ForCreator {
+		covers := now.Before(existing.ExpiresAt)
+		if !listingEnd.IsZero() {
+			covers = !existing.ExpiresAt.Before(listingEnd)
+		}
+		if covers && existing.Amount == amount.String() && existing.SignerAddress == signer {
+			return signedVoucherFromRecord(existing)
+		}
+		if !now.Before(existing.ExpiresAt) {
+			existing.Status = models.VoucherStatusExpired
+			if err := s.voucherRepo.Update(existing); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if nft.Drop.Price == 0 {
+		return s.issue(ctx, nft, owner, amount, true, now, expiresAt)
+	}
+
+	if _, err := s.supply.reserve(nft, owner.WalletAddress, amount.Uint64(), nil, nil, now, expiresAt); err != nil {
+		return nil, err
+	}
+	signed, err := s.issue(ctx, nft, owner, amount, true, now, expiresAt)
+	if err != nil {
+		if releaseErr := s.supply.release(nft.ID); releaseErr != nil {
+			log.Printf("failed to release the drop reservation of nft %d: %v", nft.ID, releaseErr)
+		}
+		return nil, err
+	}
+	return signed, nil
+}
+
+// walletQuota is the most copies of drop one wallet may hold or have
+// bought, nil when unlimited: its allowlist quota, capped by the drop's
+// MaxPerWallet. quotaErr is the error a voucher passing it is refused with.
+func walletQuota(drop *models.Drop, entry *models.AllowlistEntry) (quota *uint64, quotaErr error) {
+	quotaErr = ErrAllowlistQuotaExceeded
+	if entry != nil {
+		quota = &entry.Quota
+	}
+	if drop.MaxPerWallet > 0 && (quota == nil || uint64(drop.MaxPerWallet) < *quota) {
+		limit := uint64(drop.MaxPerWallet)
+		quota, quotaErr = &limit, ErrDropWalletLimitReached
+	}
+	return quota, quotaErr
+}
+
+// issue signs a voucher for nft and records it in the ledger
+func (s *VoucherService) issue(ctx context.Context, nft *models.NFT, owner *models.User, amount *big.Int, forCreator bool, issuedAt, expiresAt time.Time) (*SignedVoucher, error) {
+	contract := common.HexToAddress(*nft.Drop.Collection.ContractAddress)
+	uri, err := s.metadataURI(ctx, nft, nft.Drop.Collection.ChainID, contract)
+	if err != nil {
+		return nil, err
+	}
+	signed, err := s.sign(ctx, big.NewInt(nft.Drop.Collection.ChainID), contract, contracts.LazyMint1155Voucher{
+		Owner:   common.HexToAddress(owner.WalletAddress),
+		TokenId: nft.TokenID.Big(),
+		Amount:  amount,
+		Uri:     uri,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	record := s.newRecord(nft.ID, signed, issuedAt, expiresAt)
+	record.ForCreator = forCreator
+	if err := s.voucherRepo.Create(record); err != nil {
+		if errors.Is(err, repoInterfaces.ErrDuplicateRecord) {
+			return nil, ErrNFTVoucherOutstanding
+		}
+		return nil, fmt.Errorf("failed to record voucher: %w", err)
+	}
+	signed.ID = record.ID
+	signed.ExpiresAt = record.ExpiresAt
+	return signed, nil
+}
+
+// metadataURI is the URI a voucher for nft commits to on-chain
+func (s *VoucherService) metadataURI(ctx context.Context, nft *models.NFT, chainID int64, contract common.Address) (string, error) {
+	if nft.MetadataURI != "" {
+		return nft.MetadataURI, nil
+	}
+	if s.assets == nil {
+		return TokenMetadataURI(s.baseURL, chainID, contract.Hex()), nil
+	}
+	asset, err := s.assets.StoreMetadata(ctx, renderTokenMetadata(nft))
+	if err != nil {
+		return "", fmt.Errorf("failed to store nft metadata: %w", err)
+	}
+	return "ipfs://" + asset.CID, nil
+}
+
+// ListForNFT returns every voucher issued for an NFT, newest first
+func (s *VoucherService) ListForNFT(nftID uint) ([]*models.Voucher, error) {
+	return s.voucherRepo.ListByNFT(nftID)
+}
+
+// Revoke stops the backend from honouring an issued voucher and blocks new
+// vouchers for its NFT until the signer is rotated. The voucher keeps its
+// slot of the drop until then, since the contract still accepts it.
+func (s *VoucherService) Revoke(voucherID uint, reason string) (*models.Voucher, error) {
+	voucher, err := s.voucherRepo.GetByID(voucherID)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrVoucherNotFound
+		}
+		return nil, err
+	}
+	if voucher.Status != models.VoucherStatusIssued {
+		return nil, ErrVoucherNotOutstanding
+	}
+	now := time.Now()
+	voucher.Status = models.VoucherStatusRevoked
+	voucher.RevokedAt = &now
+	voucher.RevokedReason = reason
+	if err := s.voucherRepo.Update(voucher); err != nil {
+		return nil, err
+	}
+	return voucher, nil
+}
+
+// RevokeForNFT revokes the outstanding collector voucher of an NFT, if any
+func (s *VoucherService) RevokeForNFT(nftID uint, reason string) (*models.Voucher, error) {
+	voucher, err := s.voucherRepo.GetIssuedByNFT(nftID)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrVoucherNotOutstanding
+		}
+		return nil, err
+	}
+	return s.Revoke(voucher.ID, reason)
+}
+
+// ReissueOutstanding re-signs every unexpired, unrevoked voucher of a
+// collection that was signed by a previous signer. Run it after the
+// contract's signer was changed with setSigner.
+func (s *VoucherService) ReissueOutstanding(ctx context.Context, collectionID uint) ([]*models.Voucher, error) {
+	collection, err := s.collectionRepo.GetByID(collectionID)
+	if err != nil {
+		if errors.Is(err, repoInterfaces.ErrRecordNotFound) {
+			return nil, ErrCollectionNotFound
+		}
+		return nil, err
+	}
+	if collection.ContractAddress == nil {
+		return nil, ErrCollectionNotDeployed
+	}
+	signer := s.SignerAddress().Hex()
+	if collection.SignerAddress == nil || *collection.SignerAddress != signer {
+		return nil, ErrCollectionSignerRotating
+	}
+
+	outstanding, err := s.voucherRepo.ListIssuedByContract(collection.ChainID, *collection.ContractAddress, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	var reissued []*models.Voucher
+	for _, old := range outstanding {
+		if old.SignerAddress == signer {
+			continue
+		}
+		previous, err := signedVoucherFromRecord(old)
+		if err != nil {
+			return reissued, err
+		}
+		signed, err := s.sign(ctx, big.NewInt(old.ChainID), common.HexToAddress(old.ContractAddress), previous.Voucher)
+		if err != nil {
+			return reissued, err
+		}
+		replacement := s.newRecord(old.NFTID, signed, time.Now(), old.ExpiresAt)
+		replacement.ForCreator = old.ForCreator
+		if err := s.voucherRepo.Supersede(old, replacement); err != nil {
+			return reissued, fmt.Errorf("failed to reissue voucher %d: %w", old.ID, err)
+		}
+		reissued = append(reissued, replacement)
+	}
+	return reissued, nil
+}
+
+func (s *VoucherService) newRecord(nftID uint, signed *SignedVoucher, issuedAt, expiresAt time.Time) *models.Voucher {
+	return &models.Voucher{
+		NFTID:           nftID,
+		ChainID:         signed.ChainID,
+		ContractAddress: signed.Contract,
+		OwnerAddress:    signed.Owner,
+		TokenID:         models.NewUint256(signed.Voucher.TokenId),
+		Amount:          signed.Amount,
+		URI:             signed.URI,
+		Signature:       signed.Signature,
+		SignerAddress:   s.SignerAddress().Hex(),
+		Digest:          signed.Digest,
+		IssuedAt:        issuedAt,
+		ExpiresAt:       expiresAt,
+		Status:          models.VoucherStatusIssued,
+	}
+}
+
+// sign produces the EIP-712 signature LazyMint1155.mintIfNotExists checks
+// on chainID
+func (s *VoucherService) sign(ctx context.Context, chainID *big.Int, contract common.Address, voucher contracts.LazyMint1155Voucher) (*SignedVoucher, error) {
+	digest := contracts.VoucherDigest(chainID, contract, voucher)
+	signature, err := s.signer.SignHash(ctx, digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign voucher: %w", err)
+	}
+	signature[crypto.RecoveryIDOffset] += 27 // ECDSA.recover expects v in {27, 28}
+	voucher.Signature = signature
+	return newSignedVoucher(chainID, contract, voucher, digest)
+}
+
+func newSignedVoucher(chainID *big.Int, contract common.Address, voucher contracts.LazyMint1155Voucher, digest common.Hash) (*SignedVoucher, error) {
+	encoded, err := contracts.EncodeVoucher(voucher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode voucher: %w", err)
+	}
+	return &SignedVoucher{
+		ChainID:   chainID.Int64(),
+		Contract:  contract.Hex(),
+		Owner:     voucher.Owner.Hex(),
+		TokenID:   voucher.TokenId.String(),
+		Amount:    voucher.Amount.String(),
+		URI:       voucher.Uri,
+		Signature: hexutil.Encode(voucher.Signature),
+		Digest:    digest.Hex(),
+		Encoded:   hexutil.Encode(encoded),
+		TypedData: contracts.VoucherTypedData(chainID, contract, voucher),
+		Voucher:   voucher,
+	}, nil
+}
+
+// signedVoucherFromRecord rebuilds the signed voucher stored in the ledger
+func signedVoucherFromRecord(record *models.Voucher) (*SignedVoucher, error) {
+	tokenID := record.TokenID.Big()
+	amount, ok := new(big.Int).SetString(record.Amount, 10)
+	if !ok {
+		return nil, fmt.Errorf("voucher %d has an invalid amount", record.ID)
+	}
+	signature, err := hexutil.Decode(record.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("voucher %d has an invalid signature: %w", record.ID, err)
+	}
+	voucher := contracts.LazyMint1155Voucher{
+		Owner:     common.HexToAddress(record.OwnerAddress),
+		TokenId:   tokenID,
+		Amount:    amount,
+		Uri:       record.URI,
+		Signature: signature,
+	}
+	signed, err := newSignedVoucher(big.NewInt(record.ChainID), common.HexToAddress(record.ContractAddress), voucher, common.HexToHash(record.Digest))
+	if err != nil {
+		return nil, err
+	}
+	signed.ID = record.ID
+	signed.ExpiresAt = record.ExpiresAt
+	return signed, nil
+}
@@ -0,0 +1,428 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	signerAdapters "github.com/igwedaniel/artizan/internal/adapters/signer"
+	repoInterfaces "github.com/igwedaniel/artizan/internal/interfaces/repositories"
+	signerInterfaces "github.com/igwedaniel/artizan/internal/interfaces/signer"
+	"github.com/igwedaniel/artizan/internal/models"
+	"github.com/igwedaniel/artizan/pkg/contracts"
+)
+
+type memoryNFTRepo struct {
+	repoInterfaces.NFTRepository
+	nfts map[uint]*models.NFT
+}
+
+func (r *memoryNFTRepo) GetByID(id uint) (*models.NFT, error) {
+	nft, ok := r.nfts[id]
+	if !ok {
+		return nil, repoInterfaces.ErrRecordNotFound
+	}
+	return nft, nil
+}
+
+// memoryVoucherRepo enforces idx_voucher_collector_outstanding like
+// Postgres does
+type memoryVoucherRepo struct {
+	repoInterfaces.VoucherRepository
+	vouchers []*models.Voucher
+}
+
+func (r *memoryVoucherRepo) Create(v *models.Voucher) error {
+	if _, err := r.GetIssuedByNFT(v.NFTID); err == nil && !v.ForCreator {
+		return repoInterfaces.ErrDuplicateRecord
+	}
+	v.ID = uint(len(r.vouchers) + 1)
+	r.vouchers = append(r.vouchers, v)
+	return nil
+}
+
+func (r *memoryVoucherRepo) Update(*models.Voucher) error { return nil }
+
+func (r *memoryVoucherRepo) GetIssuedByNFT(nftID uint) (*models.Voucher, error) {
+	for _, v := range r.vouchers {
+		if v.NFTID == nftID && v.Status == models.VoucherStatusIssued && !v.ForCreator {
+			return v, nil
+		}
+	}
+	return nil, repoInterfaces.ErrRecordNotFound
+}
+
+func (r *memoryVoucherRepo) GetIssuedForOwner(nftID uint, ownerAddress string) (*models.Voucher, error) {
+	for i := len(r.vouchers) - 1; i >= 0; i-- {
+		v := r.vouchers[i]
+		if v.NFTID == nftID && v.Status == models.VoucherStatusIssued && strings.EqualFold(v.OwnerAddress, ownerAddress) {
+			return v, nil
+		}
+	}
+	return nil, repoInterfaces.ErrRecordNotFound
+}
+
+func (r *memoryVoucherRepo) GetByID(id uint) (*models.Voucher, error) {
+	if id == 0 || int(id) > len(r.vouchers) {
+		return nil, repoInterfaces.ErrRecordNotFound
+	}
+	return r.vouchers[id-1], nil
+}
+
+func (r *memoryVoucherRepo) HasRevokedBySigner(nftID uint, signerAddress string) (bool, error) {
+	for _, v := range r.vouchers {
+		if v.NFTID == nftID && v.Status == models.VoucherStatusRevoked && v.SignerAddress == signerAddress {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// memoryAllowlistRepo holds one allowlist version with the given quotas by
+// checksummed address, or none when quotas is nil
+type memoryAllowlistRepo struct {
+	repoInterfaces.AllowlistRepository
+	quotas map[string]uint64
+}
+
+func (r *memoryAllowlistRepo) GetLatest(dropID uint) (*models.Allowlist, error) {
+	if r.quotas == nil {
+		return nil, repoInterfaces.ErrRecordNotFound
+	}
+	return &models.Allowlist{ID: 1, DropID: dropID, Version: 1}, nil
+}
+
+func (r *memoryAllowlistRepo) GetEntry(_ uint, address string) (*models.AllowlistEntry, error) {
+	quota, ok := r.quotas[address]
+	if !ok {
+		return nil, repoInterfaces.ErrRecordNotFound
+	}
+	return &models.AllowlistEntry{AllowlistID: 1, Address: address, Quota: quota}, nil
+}
+
+// memoryReservationRepo ignores expiry but enforces quotas and, when set,
+// the supply of holds
+type memoryReservationRepo struct {
+	repoInterfaces.DropReservationRepository
+	held   map[uint]*models.DropReservation
+	supply int
+}
+
+func (r *memoryReservationRepo) Reserve(reservation *models.DropReservation, quota *uint64, _ time.Time) (bool, error) {
+	if held := r.held[reservation.NFTID]; held != nil && !strings.EqualFold(held.OwnerAddress, reservation.OwnerAddress) {
+		return false, repoInterfaces.ErrDuplicateRecord
+	}
+	if r.supply > 0 && r.held[reservation.NFTID] == nil && len(r.held) >= r.supply {
+		return false, nil
+	}
+	if quota != nil {
+		allocated := reservation.Amount
+		for _, held := range r.held {
+			if held.DropID == reservation.DropID && strings.EqualFold(held.OwnerAddress, reservation.OwnerAddress) {
+				allocated += held.Amount
+			}
+		}
+		if allocated > *quota {
+			return false, repoInterfaces.ErrQuotaExceeded
+		}
+	}
+	r.held[reservation.NFTID] = reservation
+	return true, nil
+}
+
+func (r *memoryReservationRepo) Release(nftID uint, _ time.Time) (bool, error) {
+	held := r.held[nftID] != nil
+	delete(r.held, nftID)
+	return held, nil
+}
+
+type voucherTest struct {
+	backend      *simulated.Backend
+	minter       signerInterfaces.Signer // pays for mints
+	contract     common.Address
+	nft          *models.NFT
+	service      *VoucherService
+	nfts         *memoryNFTRepo
+	vouchers     *memoryVoucherRepo
+	reservations *memoryReservationRepo
+}
+
+// newVoucherTest deploys a LazyMint1155 that trusts the voucher signer on a
+// simulated chain and puts one unminted NFT of a live drop on it
+func newVoucherTest(t *testing.T, price int64) *voucherTest {
+	t.Helper()
+	voucherSigner, err := signerAdapters.GenerateMemorySigner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	minter, err := signerAdapters.GenerateMemorySigner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		minter.Address(): {Balance: new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18))},
+	})
+	t.Cleanup(func() { backend.Close() })
+	contract, _, _, err := contracts.DeployLazyMint1155(transactOpts(minter), backend.Client(), voucherSigner.Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend.Commit()
+
+	address, signer := contract.Hex(), voucherSigner.Address().Hex()
+	collection := &models.Collection{CreatorID: 1, ChainID: 1337, ContractAddress: &address, SignerAddress: &signer}
+	collection.ID = 1
+	drop := &models.Drop{CollectionID: 1, Collection: collection, DropType: models.DropTypePublic, Price: price, Supply: 10, Status: models.DropStatusLive}
+	drop.ID = 1
+	nft := &models.NFT{DropID: 1, Drop: drop, TokenID: models.NewUint256(big.NewInt(42)), MetadataURI: "ipfs://metadata", EditionSize: 5}
+	nft.ID = 1
+
+	vouchers := &memoryVoucherRepo{}
+	nfts := &memoryNFTRepo{nfts: map[uint]*models.NFT{1: nft}}
+	reservations := &memoryReservationRepo{held: map[uint]*models.DropReservation{}}
+	service := NewVoucherService(nfts, vouchers, nil, voucherSigner,
+		0, "http://localhost", nil, NewAllowlistService(nil, &memoryAllowlistRepo{}), NewSupplyService(reservations, 0))
+	return &voucherTest{backend: backend, minter: minter, contract: contract, nft: nft, service: service, nfts: nfts, vouchers: vouchers, reservations: reservations}
+}
+
+func transactOpts(from signerInterfaces.Signer) *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From: from.Address(),
+		Signer: func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return from.SignTx(context.Background(), tx, big.NewInt(1337))
+		},
+	}
+}
+
+func walletUser(id uint, wallet string) *models.User {
+	user := testUser(id, "")
+	user.WalletAddress = wallet
+	return user
+}
+
+func TestIssuedVoucherMintsOnChain(t *testing.T) {
+	v := newVoucherTest(t, 0)
+	owner := walletUser(2, "0x00000000000000000000000000000000000000d4")
+
+	signed, err := v.service.IssueVoucher(context.Background(), owner, v.nft.ID, big.NewInt(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	nft, err := contracts.NewLazyMint1155(v.contract, v.backend.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the contract checks every field the signature covers
+	tampered := signed.Voucher
+	tampered.Amount = big.NewInt(5)
+	if _, err := nft.MintIfNotExists(transactOpts(v.minter), tampered, tampered.Owner); err == nil || !strings.Contains(err.Error(), "Invalid signature") {
+		t.Fatalf("tampered voucher was not rejected: %v", err)
+	}
+
+	if _, err := nft.MintIfNotExists(transactOpts(v.minter), signed.Voucher, signed.Voucher.Owner); err != nil {
+		t.Fatalf("contract rejected the voucher: %v", err)
+	}
+	v.backend.Commit()
+	balance, err := nft.BalanceOf(&bind.CallOpts{}, common.HexToAddress(owner.WalletAddress), v.nft.TokenID.Big())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Cmp(big.NewInt(3)) != 0 {
+		t.Fatalf("balance = %s, want 3", balance)
+	}
+}
+
+func TestVouchersRequireALiveDrop(t *testing.T) {
+	for _, status := range []string{models.DropStatusScheduled, models.DropStatusSoldOut, models.DropStatusEnded} {
+		v := newVoucherTest(t, 0)
+		v.nft.Drop.Status = status
+		creator := walletUser(1, "0x00000000000000000000000000000000000000c1")
+		if _, err := v.service.IssueVoucher(context.Background(), creator, v.nft.ID, big.NewInt(1)); !errors.Is(err, ErrDropNotLive) {
+			t.Fatalf("%s drop: err = %v, want %v", status, err, ErrDropNotLive)
+		}
+	}
+}
+
+func TestPricedDropVouchersOnlyGoToTheCreator(t *testing.T) {
+	v := newVoucherTest(t, 1000)
+	collector := walletUser(2, "0x00000000000000000000000000000000000000d4")
+	if _, err := v.service.IssueVoucher(context.Background(), collector, v.nft.ID, big.NewInt(1)); !errors.Is(err, ErrDropPriced) {
+		t.Fatalf("err = %v, want %v", err, ErrDropPriced)
+	}
+	if len(v.vouchers.vouchers) != 0 {
+		t.Fatal("a refused voucher was recorded")
+	}
+
+	creator := walletUser(1, "0x00000000000000000000000000000000000000c1")
+	if _, err := v.service.IssueVoucher(context.Background(), creator, v.nft.ID, big.NewInt(1)); err != nil {
+		t.Fatalf("creator was refused a voucher: %v", err)
+	}
+}
+
+func TestCreatorVouchersSkipDropLimits(t *testing.T) {
+	v := newVoucherTest(t, 0)
+	collector := walletUser(2, "0x00000000000000000000000000000000000000d4")
+	claimed, err := v.service.IssueVoucher(context.Background(), collector, v.nft.ID, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a private drop without an allowlist refuses collectors but not the
+	// creator, who lists the NFT while the collector's voucher is outstanding
+	v.nft.Drop.DropType = models.DropTypePrivate
+	other := walletUser(3, "0x00000000000000000000000000000000000000d5")
+	if _, err := v.service.IssueVoucher(context.Background(), other, v.nft.ID, big.NewInt(1)); !errors.Is(err, ErrNotAllowlisted) {
+		t.Fatalf("err = %v, want %v", err, ErrNotAllowlisted)
+	}
+	creator := walletUser(1, "0x00000000000000000000000000000000000000c1")
+	listed, err := v.service.IssueVoucher(context.Background(), creator, v.nft.ID, big.NewInt(5))
+	if err != nil {
+		t.Fatalf("creator was refused a voucher: %v", err)
+	}
+	again, err := v.service.IssueVoucher(context.Background(), creator, v.nft.ID, big.NewInt(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.ID != listed.ID {
+		t.Fatalf("creator voucher was reissued as %d, want %d", again.ID, listed.ID)
+	}
+
+	// the creator holds no slot, so revoking their voucher keeps the
+	// collector's
+	if _, err := v.service.Revoke(listed.ID, "relisted"); err != nil {
+		t.Fatal(err)
+	}
+	if v.reservations.held[v.nft.ID] == nil {
+		t.Fatal("revoking the creator voucher released the collector's slot")
+	}
+	if outstanding, err := v.vouchers.GetIssuedByNFT(v.nft.ID); err != nil || outstanding.ID != claimed.ID {
+		t.Fatalf("outstanding collector voucher = %v, %v; want %d", outstanding, err, claimed.ID)
+	}
+}
+
+func TestAllowlistQuotaCountsHeldSlots(t *testing.T) {
+	v := newVoucherTest(t, 0)
+	collector := walletUser(2, "0x00000000000000000000000000000000000000d4")
+	v.nft.Drop.DropType = models.DropTypePrivate
+	v.service.allowlists = NewAllowlistService(nil, &memoryAllowlistRepo{quotas: map[string]uint64{
+		common.HexToAddress(collector.WalletAddress).Hex(): 3,
+	}})
+	second := &models.NFT{DropID: 1, Drop: v.nft.Drop, TokenID: models.NewUint256(big.NewInt(43)), MetadataURI: "ipfs://metadata", EditionSize: 5}
+	second.ID = 2
+	v.nfts.nfts[second.ID] = second
+
+	if _, err := v.service.IssueVoucher(context.Background(), collector, v.nft.ID, big.NewInt(2)); err != nil {
+		t.Fatal(err)
+	}
+	// the first voucher's slot counts even though it is not minted
+	if _, err := v.service.IssueVoucher(context.Background(), collector, second.ID, big.NewInt(2)); !errors.Is(err, ErrAllowlistQuotaExceeded) {
+		t.Fatalf("err = %v, want %v", err, ErrAllowlistQuotaExceeded)
+	}
+	if v.reservations.held[second.ID] != nil || len(v.vouchers.vouchers) != 1 {
+		t.Fatal("a voucher over the quota held a slot or was recorded")
+	}
+	if _, err := v.service.IssueVoucher(context.Background(), collector, second.ID, big.NewInt(1)); err != nil {
+		t.Fatalf("voucher within the quota was refused: %v", err)
+	}
+}
+
+func TestDropWalletLimitCapsPublicDrops(t *testing.T) {
+	v := newVoucherTest(t, 0)
+	v.nft.Drop.MaxPerWallet = 2
+	second := &models.NFT{DropID: 1, Drop: v.nft.Drop, TokenID: models.NewUint256(big.NewInt(43)), MetadataURI: "ipfs://metadata", EditionSize: 5}
+	second.ID = 2
+	v.nfts.nfts[second.ID] = second
+	collector := walletUser(2, "0x00000000000000000000000000000000000000d4")
+
+	if _, err := v.service.IssueVoucher(context.Background(), collector, v.nft.ID, big.NewInt(2)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.service.IssueVoucher(context.Background(), collector, second.ID, big.NewInt(1)); !errors.Is(err, ErrDropWalletLimitReached) {
+		t.Fatalf("err = %v, want %v", err, ErrDropWalletLimitReached)
+	}
+	other := walletUser(3, "0x00000000000000000000000000000000000000d5")
+	if _, err := v.service.IssueVoucher(context.Background(), other, second.ID, big.NewInt(1)); err != nil {
+		t.Fatalf("another wallet was refused: %v", err)
+	}
+}
+
+func TestRevokedVoucherKeepsItsSlot(t *testing.T) {
+	v := newVoucherTest(t, 0)
+	collector := walletUser(2, "0x00000000000000000000000000000000000000d4")
+	signed, err := v.service.IssueVoucher(context.Background(), collector, v.nft.ID, big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.service.Revoke(signed.ID, "fraud"); err != nil {
+		t.Fatal(err)
+	}
+	// the contract still accepts the signature, so the slot must stay taken
+	if v.reservations.held[v.nft.ID] == nil {
+		t.Fatal("revoking released the slot of a voucher the contract still accepts")
+	}
+}
+
+func TestListingVoucherLastsUntilTheListingEnds(t *testing.T) {
+	v := newVoucherTest(t, 0)
+	creator := walletUser(1, "0x00000000000000000000000000000000000000c1")
+	end := time.Now().Add(7 * 24 * time.Hour).Truncate(time.Second)
+
+	signed, err := v.service.IssueListingVoucher(context.Background(), creator, v.nft.ID, big.NewInt(5), end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !signed.ExpiresAt.Equal(end) {
+		t.Fatalf("voucher expires at %v, want the listing end %v", signed.ExpiresAt, end)
+	}
+	again, err := v.service.IssueListingVoucher(context.Background(), creator, v.nft.ID, big.NewInt(5), end.Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.ID != signed.ID {
+		t.Fatal("a voucher covering a shorter listing was not reused")
+	}
+	longer, err := v.service.IssueListingVoucher(context.Background(), creator, v.nft.ID, big.NewInt(5), end.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if longer.ID == signed.ID {
+		t.Fatal("a voucher ending before the listing was reused")
+	}
+
+	collector := walletUser(2, "0x00000000000000000000000000000000000000d4")
+	if _, err := v.service.IssueListingVoucher(context.Background(), collector, v.nft.ID, big.NewInt(1), end); !errors.Is(err, ErrNotCollectionOwner) {
+		t.Fatalf("err = %v, want %v", err, ErrNotCollectionOwner)
+	}
+}
+
+func TestPricedDropListingsHoldItsSupply(t *testing.T) {
+	v := newVoucherTest(t, 1000)
+	v.reservations.supply = 1
+	second := &models.NFT{DropID: 1, Drop: v.nft.Drop, TokenID: models.NewUint256(big.NewInt(43)), MetadataURI: "ipfs://metadata", EditionSize: 5}
+	second.ID = 2
+	v.nfts.nfts[second.ID] = second
+	creator := walletUser(1, "0x00000000000000000000000000000000000000c1")
+	end := time.Now().Add(24 * time.Hour)
+
+	if _, err := v.service.IssueListingVoucher(context.Background(), creator, v.nft.ID, big.NewInt(5), end); err != nil {
+		t.Fatal(err)
+	}
+	if held := v.reservations.held[v.nft.ID]; held == nil || held.Amount != 5 {
+		t.Fatalf("listing holds %v, want a slot of 5 copies", held)
+	}
+	if _, err := v.service.IssueListingVoucher(context.Background(), creator, second.ID, big.NewInt(1), end); !errors.Is(err, ErrDropSoldOut) {
+		t.Fatalf("err = %v, want %v", err, ErrDropSoldOut)
+	}
+	// relisting for longer keeps the slot the NFT already holds
+	if _, err := v.service.IssueListingVoucher(context.Background(), creator, v.nft.ID, big.NewInt(5), end.Add(time.Hour)); err != nil {
+		t.Fatalf("relisting was refused: %v", err)
+	}
+}
@@ -0,0 +1,27 @@
+package services
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/igwedaniel/artizan/pkg/contracts"
+)
+
+// SignedVoucher is a LazyMint1155 voucher signed by the platform signer
+type SignedVoucher struct {
+	ID        uint               `json:"id"`
+	ExpiresAt time.Time          `json:"expires_at"` // enforced by the backend only
+	ChainID   int64              `json:"chain_id"`
+	Contract  string             `json:"contract"`
+	Owner     string             `json:"owner"`
+	TokenID   string             `json:"token_id"`
+	Amount    string             `json:"amount"`
+	URI       string             `json:"uri"`
+	Signature string             `json:"signature"`
+	Digest    string             `json:"digest"`
+	Encoded   string             `json:"encoded"` // abi.encode(voucher), the LazyMintZone extraData
+	TypedData apitypes.TypedData `json:"typed_data"`
+
+	// Voucher is the value to pass to mintIfNotExists through the bindings
+	Voucher contracts.LazyMint1155Voucher `json:"-"`
+}
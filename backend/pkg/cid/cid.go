@@ -0,0 +1,71 @@
+// Package cid computes IPFS content identifiers (CIDv1) locally, without an
+// IPFS node. Files are chunked and linked the way `ipfs add --cid-version=1`
+// does by default: 256 KiB chunks stored as raw leaves under a balanced
+// UnixFS dag-pb tree with at most 174 links per node. A file that fits in a
+// single chunk is addressed by its raw leaf.
+package cid
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"strings"
+)
+
+// multicodec codes
+const (
+	CodecRaw   = 0x55
+	CodecDagPB = 0x70
+
+	multihashSHA256 = 0x12
+	cidVersion1     = 0x01
+)
+
+var base32Lower = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// CID is a binary CIDv1 with a sha2-256 multihash
+type CID []byte
+
+// newCID hashes block and wraps the digest with codec
+func newCID(codec uint64, block []byte) CID {
+	digest := sha256.Sum256(block)
+	out := binary.AppendUvarint(nil, cidVersion1)
+	out = binary.AppendUvarint(out, codec)
+	out = append(out, multihashSHA256, sha256.Size)
+	return append(out, digest[:]...)
+}
+
+// String is the multibase base32 form, e.g. bafkrei...
+func (c CID) String() string {
+	return "b" + base32Lower.EncodeToString(c)
+}
+
+// URI is the ipfs:// form of the CID
+func (c CID) URI() string {
+	return "ipfs://" + c.String()
+}
+
+// Parse decodes the base32 string form of a CIDv1
+func Parse(s string) (CID, error) {
+	if !strings.HasPrefix(s, "b") {
+		return nil, errors.New("cid: only base32 CIDv1 strings are supported")
+	}
+	raw, err := base32Lower.DecodeString(s[1:])
+	if err != nil {
+		return nil, errors.New("cid: invalid base32")
+	}
+	version, n := binary.Uvarint(raw)
+	if n <= 0 || version != cidVersion1 {
+		return nil, errors.New("cid: not a CIDv1")
+	}
+	_, m := binary.Uvarint(raw[n:])
+	if m <= 0 {
+		return nil, errors.New("cid: invalid codec")
+	}
+	mh := raw[n+m:]
+	if len(mh) != 2+sha256.Size || mh[0] != multihashSHA256 || mh[1] != sha256.Size {
+		return nil, errors.New("cid: only sha2-256 multihashes are supported")
+	}
+	return CID(raw), nil
+}
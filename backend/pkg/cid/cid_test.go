@@ -0,0 +1,79 @@
+package cid
+
+import (
+	"bytes"
+	"testing"
+)
+
+// pattern is deterministic content where byte i is (31i+7) mod 251
+func pattern(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte((i*31 + 7) % 251)
+	}
+	return b
+}
+
+// CIDs that `ipfs add --cid-version=1 --raw-leaves` assigns, produced by
+// the balanced importer of boxo that kubo runs
+var kuboVectors = []struct {
+	name string
+	data []byte
+	want string
+}{
+	{"empty", nil, "bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku"},
+	{"hello world", []byte("hello world"), "bafkreifzjut3te2nhyekklss27nh3k72ysco7y32koao5eei66wof36n5e"},
+	{"one full chunk", pattern(ChunkSize), "bafkreieiuj5mzeuqor24l4lmo2yumm6665yv4gq7ozzp2nrfmqrjyboosy"},
+	{"two chunks", pattern(ChunkSize + 1), "bafybeich6cqd6yabw3cku6edwmz4rvwe275nx2l6ootvc2bpbt5bdvarua"},
+	{"three chunks", pattern(3*ChunkSize - 100), "bafybeia2okibif2wwed2lnup2gcf5cpsoldmrgqmcqikiud2kz3axo33kq"},
+	{"a full node of leaves", pattern(MaxLinks * ChunkSize), "bafybeiafkae3md3zfgdgpk2ztpkul5dzotu4bgbnvuinadceqrqa2rz7bi"},
+	{"a second level", pattern(MaxLinks*ChunkSize + 1), "bafybeibo5yjphmmdq6aigqtgrijost25l5lmv5l37von7hrjv7sagnx4sy"},
+}
+
+func TestSumMatchesKubo(t *testing.T) {
+	for _, tt := range kuboVectors {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sum(tt.data).String(); got != tt.want {
+				t.Fatalf("cid = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// oneByteReader hands out a byte per Read, so chunks span many reads
+type oneByteReader struct{ r *bytes.Reader }
+
+func (o oneByteReader) Read(p []byte) (int, error) {
+	if len(p) > 1 {
+		p = p[:1]
+	}
+	return o.r.Read(p)
+}
+
+func TestComputeAssemblesChunksAcrossReads(t *testing.T) {
+	tt := kuboVectors[4]
+	got, err := Compute(oneByteReader{bytes.NewReader(tt.data)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != tt.want {
+		t.Fatalf("cid = %s, want %s", got, tt.want)
+	}
+}
+
+func TestParseRoundTrips(t *testing.T) {
+	for _, tt := range kuboVectors {
+		c, err := Parse(tt.want)
+		if err != nil {
+			t.Fatalf("parse %s: %v", tt.want, err)
+		}
+		if c.String() != tt.want {
+			t.Fatalf("parsed %s back as %s", tt.want, c)
+		}
+	}
+	for _, s := range []string{"", "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG", "bafy!", "bafkqaaa"} {
+		if _, err := Parse(s); err == nil {
+			t.Fatalf("parsed %q", s)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package cid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+// kubo defaults for `ipfs add --cid-version=1`
+const (
+	ChunkSize    = 256 * 1024
+	MaxLinks     = 174
+	unixfsFile   = 2
+	pbWireVarint = 0
+	pbWireBytes  = 2
+)
+
+// Sum computes the CID `ipfs add --cid-version=1` assigns to data
+func Sum(data []byte) CID {
+	c, _ := Compute(bytes.NewReader(data))
+	return c
+}
+
+// Compute reads r to the end and returns the CID of its content
+func Compute(r io.Reader) (CID, error) {
+	b := &builder{r: r, buf: make([]byte, ChunkSize)}
+	if err := b.read(); err != nil {
+		return nil, err
+	}
+	root, err := b.leaf()
+	if err != nil {
+		return nil, err
+	}
+	for depth := 1; !b.done; depth++ {
+		parent := &dagNode{}
+		parent.add(root)
+		if root, err = b.fill(parent, depth); err != nil {
+			return nil, err
+		}
+	}
+	return root.cid, nil
+}
+
+// link is a built node as seen from its parent
+type link struct {
+	cid      CID
+	tsize    uint64 // size of the node and everything below it
+	fileSize uint64 // bytes of file content below it
+}
+
+type dagNode struct {
+	links []link
+}
+
+func (n *dagNode) add(l link) {
+	n.links = append(n.links, l)
+}
+
+// commit encodes the node as a UnixFS file in a dag-pb block
+func (n *dagNode) commit() link {
+	var fileSize, tsize uint64
+	for _, l := range n.links {
+		fileSize += l.fileSize
+		tsize += l.tsize
+	}
+
+	var data []byte
+	data = appendVarintField(data, 1, unixfsFile)
+	data = appendVarintField(data, 3, fileSize)
+	for _, l := range n.links {
+		data = appendVarintField(data, 4, l.fileSize)
+	}
+
+	// dag-pb puts Links (field 2) before Data (field 1)
+	var block []byte
+	for _, l := range n.links {
+		var pbLink []byte
+		pbLink = appendBytesField(pbLink, 1, l.cid)
+		pbLink = appendBytesField(pbLink, 2, nil) // empty Name
+		pbLink = appendVarintField(pbLink, 3, l.tsize)
+		block = appendBytesField(block, 2, pbLink)
+	}
+	block = appendBytesField(block, 1, data)
+
+	return link{cid: newCID(CodecDagPB, block), tsize: uint64(len(block)) + tsize, fileSize: fileSize}
+}
+
+// builder reads chunks one ahead so it knows when the input is exhausted
+type builder struct {
+	r    io.Reader
+	buf  []byte
+	next []byte
+	done bool
+}
+
+func (b *builder) read() error {
+	n, err := io.ReadFull(b.r, b.buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return err
+	}
+	b.next = append([]byte(nil), b.buf[:n]...)
+	b.done = n == 0
+	return nil
+}
+
+// leaf consumes the next chunk as a raw leaf; empty input yields an empty leaf
+func (b *builder) leaf() (link, error) {
+	chunk := b.next
+	if err := b.read(); err != nil {
+		return link{}, err
+	}
+	size := uint64(len(chunk))
+	return link{cid: newCID(CodecRaw, chunk), tsize: size, fileSize: size}, nil
+}
+
+// fill adds children to node until it is full or the input runs out, the way
+// the balanced layout of go-unixfs does
+func (b *builder) fill(node *dagNode, depth int) (link, error) {
+	for len(node.links) < MaxLinks && !b.done {
+		var child link
+		var err error
+		if depth == 1 {
+			child, err = b.leaf()
+		} else {
+			child, err = b.fill(&dagNode{}, depth-1)
+		}
+		if err != nil {
+			return link{}, err
+		}
+		node.add(child)
+	}
+	return node.commit(), nil
+}
+
+func appendVarintField(buf []byte, field int, v uint64) []byte {
+	buf = binary.AppendUvarint(buf, uint64(field<<3|pbWireVarint))
+	return binary.AppendUvarint(buf, v)
+}
+
+func appendBytesField(buf []byte, field int, v []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(field<<3|pbWireBytes))
+	buf = binary.AppendUvarint(buf, uint64(len(v)))
+	return append(buf, v...)
+}
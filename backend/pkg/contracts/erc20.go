@@ -0,0 +1,41 @@
+package contracts
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var erc20ABI, _ = abi.JSON(strings.NewReader(`[
+	{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"account","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"allowance","stateMutability":"view","inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"}],"outputs":[{"name":"","type":"uint256"}]}
+]`))
+
+// ERC20Caller reads the balances and allowances of an ERC-20 token
+type ERC20Caller struct {
+	contract *bind.BoundContract
+}
+
+// NewERC20Caller binds the ERC-20 at address for reading
+func NewERC20Caller(address common.Address, caller bind.ContractCaller) *ERC20Caller {
+	return &ERC20Caller{contract: bind.NewBoundContract(address, erc20ABI, caller, nil, nil)}
+}
+
+func (c *ERC20Caller) BalanceOf(opts *bind.CallOpts, account common.Address) (*big.Int, error) {
+	return c.call(opts, "balanceOf", account)
+}
+
+func (c *ERC20Caller) Allowance(opts *bind.CallOpts, owner, spender common.Address) (*big.Int, error) {
+	return c.call(opts, "allowance", owner, spender)
+}
+
+func (c *ERC20Caller) call(opts *bind.CallOpts, method string, args ...interface{}) (*big.Int, error) {
+	var out []interface{}
+	if err := c.contract.Call(opts, &out, method, args...); err != nil {
+		return nil, err
+	}
+	return abi.ConvertType(out[0], new(big.Int)).(*big.Int), nil
+}
@@ -0,0 +1,17 @@
+// Package contracts holds Go bindings for the Artizan Solidity contracts.
+//
+// The bindings are generated from the Hardhat artifacts deployed through
+// Ignition. After changing the Solidity sources, compile and deploy (or point
+// the -artifact flags below at contracts/artifacts) and run
+//
+//	go generate ./pkg/contracts
+//
+// TestBindingsUpToDate replays these directives under go test and fails when
+// the committed bindings no longer match the artifacts;
+// scripts/check-contract-bindings.sh does the same with the real tools.
+package contracts
+
+//go:generate go run ./internal/extract -artifact ../../../contracts/ignition/deployments/chain-97/artifacts/LazyMint1155ModuleV1#LazyMint1155.json -out build
+//go:generate go run ./internal/extract -artifact ../../../contracts/ignition/deployments/chain-97/artifacts/lazyMintZoneV3#LazyMintZone.json -out build
+//go:generate go tool abigen --abi build/LazyMint1155.abi --bin build/LazyMint1155.bin --pkg contracts --type LazyMint1155 --out lazymint1155.go
+//go:generate go tool abigen --abi build/LazyMintZone.abi --bin build/LazyMintZone.bin --pkg contracts --type LazyMintZone --out lazymintzone.go
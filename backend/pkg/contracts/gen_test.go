@@ -0,0 +1,114 @@
+package contracts
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/abigen"
+)
+
+// TestBindingsUpToDate replays the go:generate directives of gen.go in memory
+// and fails when the committed build files or bindings differ from what the
+// Hardhat artifacts produce
+func TestBindingsUpToDate(t *testing.T) {
+	directives := generateDirectives(t)
+	if len(directives) == 0 {
+		t.Fatal("gen.go has no go:generate directives")
+	}
+	for _, args := range directives {
+		switch {
+		case len(args) > 2 && args[0] == "run" && args[1] == "./internal/extract":
+			checkExtract(t, flagValues(args[2:]))
+		case len(args) > 1 && args[0] == "tool" && args[1] == "abigen":
+			checkAbigen(t, flagValues(args[2:]))
+		default:
+			t.Fatalf("unexpected go:generate directive: go %s", strings.Join(args, " "))
+		}
+	}
+}
+
+// generateDirectives returns the arguments following "go" of every
+// go:generate directive in gen.go
+func generateDirectives(t *testing.T) [][]string {
+	t.Helper()
+	f, err := os.Open("gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var directives [][]string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, ok := strings.CutPrefix(scanner.Text(), "//go:generate go ")
+		if ok {
+			directives = append(directives, strings.Fields(line))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return directives
+}
+
+func flagValues(args []string) map[string]string {
+	values := make(map[string]string)
+	for i := 0; i+1 < len(args); i += 2 {
+		values[strings.TrimLeft(args[i], "-")] = args[i+1]
+	}
+	return values
+}
+
+// checkExtract compares the .abi and .bin files of an artifact with the
+// ones internal/extract writes
+func checkExtract(t *testing.T, flags map[string]string) {
+	t.Helper()
+	raw, err := os.ReadFile(flags["artifact"])
+	if err != nil {
+		t.Fatalf("failed to read artifact: %v", err)
+	}
+	var artifact struct {
+		ContractName string          `json:"contractName"`
+		ABI          json.RawMessage `json:"abi"`
+		Bytecode     string          `json:"bytecode"`
+	}
+	if err := json.Unmarshal(raw, &artifact); err != nil {
+		t.Fatalf("failed to decode %s: %v", flags["artifact"], err)
+	}
+	base := filepath.Join(flags["out"], artifact.ContractName)
+	expectFile(t, base+".abi", string(artifact.ABI)+"\n")
+	expectFile(t, base+".bin", strings.TrimPrefix(artifact.Bytecode, "0x")+"\n")
+}
+
+// checkAbigen regenerates a binding the way the abigen command does and
+// compares it with the committed one
+func checkAbigen(t *testing.T, flags map[string]string) {
+	t.Helper()
+	abi, err := os.ReadFile(flags["abi"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	bin, err := os.ReadFile(flags["bin"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := abigen.Bind([]string{flags["type"]}, []string{string(abi)}, []string{string(bin)}, nil, flags["pkg"], nil, map[string]string{})
+	if err != nil {
+		t.Fatalf("failed to bind %s: %v", flags["type"], err)
+	}
+	expectFile(t, flags["out"], code)
+}
+
+func expectFile(t *testing.T, path, want string) {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("%s is out of date; run 'go generate ./pkg/contracts' and commit the result", path)
+	}
+}
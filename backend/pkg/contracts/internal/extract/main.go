@@ -0,0 +1,50 @@
+// Command extract splits a Hardhat artifact into the .abi and .bin files
+// abigen reads.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type hardhatArtifact struct {
+	ContractName string          `json:"contractName"`
+	ABI          json.RawMessage `json:"abi"`
+	Bytecode     string          `json:"bytecode"`
+}
+
+func main() {
+	artifactPath := flag.String("artifact", "", "path to the Hardhat artifact JSON")
+	outDir := flag.String("out", "build", "directory to write <ContractName>.abi and .bin into")
+	flag.Parse()
+	if *artifactPath == "" {
+		log.Fatal("-artifact is required")
+	}
+
+	raw, err := os.ReadFile(*artifactPath)
+	if err != nil {
+		log.Fatalf("failed to read artifact: %v", err)
+	}
+	var artifact hardhatArtifact
+	if err := json.Unmarshal(raw, &artifact); err != nil {
+		log.Fatalf("failed to decode artifact: %v", err)
+	}
+	if artifact.ContractName == "" || len(artifact.ABI) == 0 || !strings.HasPrefix(artifact.Bytecode, "0x") {
+		log.Fatalf("%s is not a Hardhat contract artifact", *artifactPath)
+	}
+
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		log.Fatalf("failed to create %s: %v", *outDir, err)
+	}
+	base := filepath.Join(*outDir, artifact.ContractName)
+	if err := os.WriteFile(base+".abi", append(artifact.ABI, '\n'), 0o644); err != nil {
+		log.Fatalf("failed to write abi: %v", err)
+	}
+	if err := os.WriteFile(base+".bin", []byte(strings.TrimPrefix(artifact.Bytecode, "0x")+"\n"), 0o644); err != nil {
+		log.Fatalf("failed to write bytecode: %v", err)
+	}
+}
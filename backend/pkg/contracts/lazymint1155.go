@@ -0,0 +1,1984 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// LazyMint1155Voucher is an auto generated low-level Go binding around an user-defined struct.
+type LazyMint1155Voucher struct {
+	Owner     common.Address
+	TokenId   *big.Int
+	Amount    *big.Int
+	Uri       string
+	Signature []byte
+}
+
+// LazyMint1155MetaData contains all meta data concerning the LazyMint1155 contract.
+var LazyMint1155MetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_signer\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"ECDSAInvalidSignature\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"length\",\"type\":\"uint256\"}],\"name\":\"ECDSAInvalidSignatureLength\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"ECDSAInvalidSignatureS\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"balance\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"needed\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"ERC1155InsufficientBalance\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"approver\",\"type\":\"address\"}],\"name\":\"ERC1155InvalidApprover\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"idsLength\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"valuesLength\",\"type\":\"uint256\"}],\"name\":\"ERC1155InvalidArrayLength\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"}],\"name\":\"ERC1155InvalidOperator\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"ERC1155InvalidReceiver\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"}],\"name\":\"ERC1155InvalidSender\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"ERC1155MissingApprovalForAll\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidShortString\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"OwnableInvalidOwner\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"OwnableUnauthorizedAccount\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"ReentrancyGuardReentrantCall\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"str\",\"type\":\"string\"}],\"name\":\"StringTooLong\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"approved\",\"type\":\"bool\"}],\"name\":\"ApprovalForAll\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[],\"name\":\"EIP712DomainChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"holder\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"optedOut\",\"type\":\"bool\"}],\"name\":\"GlobalApprovalOptOut\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"approved\",\"type\":\"bool\"}],\"name\":\"GlobalApprovalSet\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256[]\",\"name\":\"ids\",\"type\":\"uint256[]\"},{\"indexed\":false,\"internalType\":\"uint256[]\",\"name\":\"values\",\"type\":\"uint256[]\"}],\"name\":\"TransferBatch\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"id\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"TransferSingle\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"value\",\"type\":\"string\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"id\",\"type\":\"uint256\"}],\"name\":\"URI\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"id\",\"type\":\"uint256\"}],\"name\":\"balanceOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address[]\",\"name\":\"accounts\",\"type\":\"address[]\"},{\"internalType\":\"uint256[]\",\"name\":\"ids\",\"type\":\"uint256[]\"}],\"name\":\"balanceOfBatch\",\"outputs\":[{\"internalType\":\"uint256[]\",\"name\":\"\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"eip712Domain\",\"outputs\":[{\"internalType\":\"bytes1\",\"name\":\"fields\",\"type\":\"bytes1\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"version\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"chainId\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"verifyingContract\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"salt\",\"type\":\"bytes32\"},{\"internalType\":\"uint256[]\",\"name\":\"extensions\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"}],\"name\":\"hasOptedOutOfGlobalApproval\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"}],\"name\":\"isApprovedForAll\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"}],\"name\":\"isGlobalApprover\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"isTokenMinted\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"uri\",\"type\":\"string\"},{\"internalType\":\"bytes\",\"name\":\"signature\",\"type\":\"bytes\"}],\"internalType\":\"structLazyMint1155.Voucher\",\"name\":\"v\",\"type\":\"tuple\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"}],\"name\":\"mintIfNotExists\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"renounceOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256[]\",\"name\":\"ids\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[]\",\"name\":\"values\",\"type\":\"uint256[]\"},{\"internalType\":\"bytes\",\"name\":\"data\",\"type\":\"bytes\"}],\"name\":\"safeBatchTransferFrom\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"id\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"data\",\"type\":\"bytes\"}],\"name\":\"safeTransferFrom\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"approved\",\"type\":\"bool\"}],\"name\":\"setApprovalForAll\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"approved\",\"type\":\"bool\"}],\"name\":\"setGlobalApproval\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bool\",\"name\":\"optOut\",\"type\":\"bool\"}],\"name\":\"setGlobalApprovalOptOut\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_signer\",\"type\":\"address\"}],\"name\":\"setSigner\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"signer\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes4\",\"name\":\"interfaceId\",\"type\":\"bytes4\"}],\"name\":\"supportsInterface\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"uri\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	Bin: "0x61016060405234801561001157600080fd5b5060405161410338038061410383398181016040528101906100339190610494565b6040518060400160405280600c81526020017f4c617a794d696e743131353500000000000000000000000000000000000000008152506040518060400160405280600181526020017f310000000000000000000000000000000000000000000000000000000000000081525033604051806020016040528060008152506100bf8161023b60201b60201c565b50600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff16036101325760006040517f1e4fbdf700000000000000000000000000000000000000000000000000000000815260040161012991906104d0565b60405180910390fd5b6101418161024e60201b60201c565b50600160048190555061015e60058361031460201b90919060201c565b610120818152505061017a60068261031460201b90919060201c565b6101408181525050818051906020012060e08181525050808051906020012061010081815250504660a081815250506101b761036460201b60201c565b608081815250503073ffffffffffffffffffffffffffffffffffffffff1660c08173ffffffffffffffffffffffffffffffffffffffff1681525050505080600a60006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550506109c6565b806002908161024a919061073b565b5050565b6000600360009054906101000a900473ffffffffffffffffffffffffffffffffffffffff16905081600360006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff1602179055508173ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff167f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e060405160405180910390a35050565b60006020835110156103365761032f836103bf60201b60201c565b905061035e565b826103468361042760201b60201c565b6000019081610355919061073b565b5060ff60001b90505b92915050565b60007f8b73c3c69bb8fe3d512ecc4cf759cc79239f7b179b0ffacaa9a75d522b39400f60e0516101005146306040516020016103a4959493929190610835565b60405160208183030381529060405280519060200120905090565b600080829050601f8151111561040c57826040517f305a27a9000000000000000000000000000000000000000000000000000000008152600401610403919061090d565b60405180910390fd5b8051816104189061095f565b60001c1760001b915050919050565b6000819050919050565b600080fd5b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b600061046182610436565b9050919050565b61047181610456565b811461047c57600080fd5b50565b60008151905061048e81610468565b92915050565b6000602082840312156104aa576104a9610431565b5b60006104b88482850161047f565b91505092915050565b6104ca81610456565b82525050565b60006020820190506104e560008301846104c1565b92915050565b600081519050919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052604160045260246000fd5b7f4e487b7100000000000000000000000000000000000000000000000000000000600052602260045260246000fd5b6000600282049050600182168061056c57607f821691505b60208210810361057f5761057e610525565b5b50919050565b60008190508160005260206000209050919050565b60006020601f8301049050919050565b600082821b905092915050565b6000600883026105e77fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff826105aa565b6105f186836105aa565b95508019841693508086168417925050509392505050565b6000819050919050565b6000819050919050565b600061063861063361062e84610609565b610613565b610609565b9050919050565b6000819050919050565b6106528361061d565b61066661065e8261063f565b8484546105b7565b825550505050565b600090565b61067b61066e565b610686818484610649565b505050565b5b818110156106aa5761069f600082610673565b60018101905061068c565b5050565b601f8211156106ef576106c081610585565b6106c98461059a565b810160208510156106d8578190505b6106ec6106e48561059a565b83018261068b565b50505b505050565b600082821c905092915050565b6000610712600019846008026106f4565b1980831691505092915050565b600061072b8383610701565b9150826002028217905092915050565b610744826104eb565b67ffffffffffffffff81111561075d5761075c6104f6565b5b6107678254610554565b6107728282856106ae565b600060209050601f8311600181146107a55760008415610793578287015190505b61079d858261071f565b865550610805565b601f1984166107b386610585565b60005b828110156107db578489015182556001820191506020850194506020810190506107b6565b868310156107f857848901516107f4601f891682610701565b8355505b6001600288020188555050505b505050505050565b6000819050919050565b6108208161080d565b82525050565b61082f81610609565b82525050565b600060a08201905061084a6000830188610817565b6108576020830187610817565b6108646040830186610817565b6108716060830185610826565b61087e60808301846104c1565b9695505050505050565b600082825260208201905092915050565b60005b838110156108b757808201518184015260208101905061089c565b60008484015250505050565b6000601f19601f8301169050919050565b60006108df826104eb565b6108e98185610888565b93506108f9818560208601610899565b610902816108c3565b840191505092915050565b6000602082019050818103600083015261092781846108d4565b905092915050565b600081519050919050565b6000819050602082019050919050565b6000610956825161080d565b80915050919050565b600061096a8261092f565b826109748461093a565b905061097f8161094a565b925060208210156109bf576109ba7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff836020036008026105aa565b831692505b5050919050565b60805160a05160c05160e0516101005161012051610140516136e3610a2060003960006114470152600061140c015260006124aa01526000612489015260006122080152600061225e0152600061228701526136e36000f3fe608060405234801561001057600080fd5b506004361061012b5760003560e01c80637793533b116100ad578063d94aeee311610071578063d94aeee314610346578063e985e9c514610362578063f242432a14610392578063f2fde38b146103ae578063f8527875146103ca5761012b565b80637793533b1461029c57806384b0196e146102b85780638da5cb5b146102dc5780638f96a7cb146102fa578063a22cb4651461032a5761012b565b80634e1273f4116100f45780634e1273f4146101fa578063530869911461022a5780636c19e7831461024657806370857c7b14610262578063715018a6146102925761012b565b8062fdd58e1461013057806301ffc9a7146101605780630e89341c14610190578063238ac933146101c05780632eb2c2d6146101de575b600080fd5b61014a600480360381019061014591906125f2565b6103fa565b6040516101579190612641565b60405180910390f35b61017a600480360381019061017591906126b4565b610454565b60405161018791906126fc565b60405180910390f35b6101aa60048036038101906101a59190612717565b610536565b6040516101b791906127d4565b60405180910390f35b6101c86105ca565b6040516101d59190612805565b60405180910390f35b6101f860048036038101906101f39190612a1d565b6105f0565b005b610214600480360381019061020f9190612baf565b610698565b6040516102219190612ce5565b60405180910390f35b610244600480360381019061023f9190612d2b565b6107a1565b005b610260600480360381019061025b9190612d87565b6109fd565b005b61027c60048036038101906102779190612d87565b610a49565b60405161028991906126fc565b60405180910390f35b61029a610a9f565b005b6102b660048036038101906102b19190612de0565b610ab3565b005b6102c0610b64565b6040516102d39796959493929190612e74565b60405180910390f35b6102e4610c0e565b6040516102f19190612805565b60405180910390f35b610314600480360381019061030f9190612717565b610c38565b60405161032191906126fc565b60405180910390f35b610344600480360381019061033f9190612de0565b610c62565b005b610360600480360381019061035b9190612ef8565b610c78565b005b61037c60048036038101906103779190612f25565b610d20565b60405161038991906126fc565b60405180910390f35b6103ac60048036038101906103a79190612f65565b610dec565b005b6103c860048036038101906103c39190612d87565b610e94565b005b6103e460048036038101906103df9190612d87565b610f1a565b6040516103f191906126fc565b60405180910390f35b600080600083815260200190815260200160002060008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054905092915050565b60007fd9b67a26000000000000000000000000000000000000000000000000000000007bffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916827bffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916148061051f57507f0e89341c000000000000000000000000000000000000000000000000000000007bffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916827bffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916145b8061052f575061052e82610f70565b5b9050919050565b6060600280546105459061302b565b80601f01602080910402602001604051908101604052809291908181526020018280546105719061302b565b80156105be5780601f10610593576101008083540402835291602001916105be565b820191906000526020600020905b8154815290600101906020018083116105a157829003601f168201915b50505050509050919050565b600a60009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b60006105fa610fda565b90508073ffffffffffffffffffffffffffffffffffffffff168673ffffffffffffffffffffffffffffffffffffffff161415801561063f575061063d8682610d20565b155b156106835780866040517fe237d92200000000000000000000000000000000000000000000000000000000815260040161067a92919061305c565b60405180910390fd5b6106908686868686610fe2565b505050505050565b606081518351146106e457815183516040517f5b0599910000000000000000000000000000000000000000000000000000000081526004016106db929190613085565b60405180910390fd5b6000835167ffffffffffffffff81111561070157610700612825565b5b60405190808252806020026020018201604052801561072f5781602001602082028036833780820191505090505b50905060005b84518110156107965761076c61075482876110da90919063ffffffff16565b61076783876110ee90919063ffffffff16565b6103fa565b82828151811061077f5761077e6130ae565b5b602002602001018181525050806001019050610735565b508091505092915050565b6107a9611102565b600760008360200135815260200190815260200160002060009054906101000a900460ff166109f1578160000160208101906107e59190612d87565b73ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff1614610852576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161084990613129565b60405180910390fd5b600061085d83611148565b905060006108bd828580608001906108759190613158565b8080601f016020809104026020016040519081016040528093929190818152602001838380828437600081840152601f19601f820116905080830192505050505050506111e7565b9050600a60009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff161461094f576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161094690613207565b60405180910390fd5b6001600760008660200135815260200190815260200160002060006101000a81548160ff0219169083151502179055506109a2838560200135866040013560405180602001604052806000815250611213565b83602001357f6bb7ff708619ba0610cba295a58592e0451dee2622938c8755667688daf3529b8580606001906109d89190613227565b6040516109e69291906132b7565b60405180910390a250505b6109f96112ac565b5050565b610a056112b6565b80600a60006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555050565b6000600960008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060009054906101000a900460ff169050919050565b610aa76112b6565b610ab1600061133d565b565b610abb6112b6565b80600860008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060006101000a81548160ff0219169083151502179055508173ffffffffffffffffffffffffffffffffffffffff167f55e9b849461170707f294e61f80adeeebbfe3710af8a980ec12519a85a85177782604051610b5891906126fc565b60405180910390a25050565b600060608060008060006060610b78611403565b610b8061143e565b46306000801b600067ffffffffffffffff811115610ba157610ba0612825565b5b604051908082528060200260200182016040528015610bcf5781602001602082028036833780820191505090505b507f0f00000000000000000000000000000000000000000000000000000000000000959493929190965096509650965096509650965090919293949596565b6000600360009054906101000a900473ffffffffffffffffffffffffffffffffffffffff16905090565b60006007600083815260200190815260200160002060009054906101000a900460ff169050919050565b610c74610c6d610fda565b8383611479565b5050565b80600960003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060006101000a81548160ff0219169083151502179055503373ffffffffffffffffffffffffffffffffffffffff167f270b8f88e36f200de897b13870a0199d326df0a5a0e0ac32e889112845dd735882604051610d1591906126fc565b60405180910390a250565b6000600960008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060009054906101000a900460ff1615610d8557610d7e83836115e9565b9050610de6565b610d8f83836115e9565b80610de35750600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060009054906101000a900460ff165b90505b92915050565b6000610df6610fda565b90508073ffffffffffffffffffffffffffffffffffffffff168673ffffffffffffffffffffffffffffffffffffffff1614158015610e3b5750610e398682610d20565b155b15610e7f5780866040517fe237d922000000000000000000000000000000000000000000000000000000008152600401610e7692919061305c565b60405180910390fd5b610e8c868686868661167d565b505050505050565b610e9c6112b6565b600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff1603610f0e5760006040517f1e4fbdf7000000000000000000000000000000000000000000000000000000008152600401610f059190612805565b60405180910390fd5b610f178161133d565b50565b6000600860008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060009054906101000a900460ff169050919050565b60007f01ffc9a7000000000000000000000000000000000000000000000000000000007bffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916827bffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916149050919050565b600033905090565b600073ffffffffffffffffffffffffffffffffffffffff168473ffffffffffffffffffffffffffffffffffffffff16036110545760006040517f57f447ce00000000000000000000000000000000000000000000000000000000815260040161104b9190612805565b60405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff168573ffffffffffffffffffffffffffffffffffffffff16036110c65760006040517f01a835140000000000000000000000000000000000000000000000000000000081526004016110bd9190612805565b60405180910390fd5b6110d38585858585611788565b5050505050565b600060208202602084010151905092915050565b600060208202602084010151905092915050565b60026004540361113e576040517f3ee5aeb500000000000000000000000000000000000000000000000000000000815260040160405180910390fd5b6002600481905550565b60006111e07fd4491adbd9907334cf448f7fda5efde05c935ac5bf584c74afe470663a2eabc38360000160208101906111819190612d87565b8460200135856040013586806060019061119b9190613227565b6040516111a992919061330b565b60405180910390206040516020016111c5959493929190613324565b6040516020818303038152906040528051906020012061183a565b9050919050565b6000806000806111f78686611854565b92509250925061120782826118b0565b82935050505092915050565b600073ffffffffffffffffffffffffffffffffffffffff168473ffffffffffffffffffffffffffffffffffffffff16036112855760006040517f57f447ce00000000000000000000000000000000000000000000000000000000815260040161127c9190612805565b60405180910390fd5b6000806112928585611a14565b915091506112a4600087848487611788565b505050505050565b6001600481905550565b6112be610fda565b73ffffffffffffffffffffffffffffffffffffffff166112dc610c0e565b73ffffffffffffffffffffffffffffffffffffffff161461133b576112ff610fda565b6040517f118cdaa70000000000000000000000000000000000000000000000000000000081526004016113329190612805565b60405180910390fd5b565b6000600360009054906101000a900473ffffffffffffffffffffffffffffffffffffffff16905081600360006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff1602179055508173ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff167f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e060405160405180910390a35050565b606061143960057f0000000000000000000000000000000000000000000000000000000000000000611a4490919063ffffffff16565b905090565b606061147460067f0000000000000000000000000000000000000000000000000000000000000000611a4490919063ffffffff16565b905090565b600073ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff16036114eb5760006040517fced3e1000000000000000000000000000000000000000000000000000000000081526004016114e29190612805565b60405180910390fd5b80600160008573ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060006101000a81548160ff0219169083151502179055508173ffffffffffffffffffffffffffffffffffffffff168373ffffffffffffffffffffffffffffffffffffffff167f17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31836040516115dc91906126fc565b60405180910390a3505050565b6000600160008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060009054906101000a900460ff16905092915050565b600073ffffffffffffffffffffffffffffffffffffffff168473ffffffffffffffffffffffffffffffffffffffff16036116ef5760006040517f57f447ce0000000000000000000000000000000000000000000000000000000081526004016116e69190612805565b60405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff168573ffffffffffffffffffffffffffffffffffffffff16036117615760006040517f01a835140000000000000000000000000000000000000000000000000000000081526004016117589190612805565b60405180910390fd5b60008061176e8585611a14565b9150915061177f8787848487611788565b50505050505050565b61179485858585611af4565b600073ffffffffffffffffffffffffffffffffffffffff168473ffffffffffffffffffffffffffffffffffffffff16146118335760006117d2610fda565b905060018451036118225760006117f36000866110ee90919063ffffffff16565b9050600061180b6000866110ee90919063ffffffff16565b905061181b838989858589611e9c565b5050611831565b611830818787878787612050565b5b505b5050505050565b600061184d611847612204565b836122bb565b9050919050565b600080600060418451036118995760008060006020870151925060408701519150606087015160001a905061188b888285856122fc565b9550955095505050506118a9565b60006002855160001b9250925092505b9250925092565b600060038111156118c4576118c3613377565b5b8260038111156118d7576118d6613377565b5b0315611a1057600160038111156118f1576118f0613377565b5b82600381111561190457611903613377565b5b0361193b576040517ff645eedf00000000000000000000000000000000000000000000000000000000815260040160405180910390fd5b6002600381111561194f5761194e613377565b5b82600381111561196257611961613377565b5b036119a7578060001c6040517ffce698f700000000000000000000000000000000000000000000000000000000815260040161199e9190612641565b60405180910390fd5b6003808111156119ba576119b9613377565b5b8260038111156119cd576119cc613377565b5b03611a0f57806040517fd78bce0c000000000000000000000000000000000000000000000000000000008152600401611a0691906133a6565b60405180910390fd5b5b5050565b60608060405191506001825283602083015260408201905060018152826020820152604081016040529250929050565b606060ff60001b8314611a6157611a5a836123f0565b9050611aee565b818054611a6d9061302b565b80601f0160208091040260200160405190810160405280929190818152602001828054611a999061302b565b8015611ae65780601f10611abb57610100808354040283529160200191611ae6565b820191906000526020600020905b815481529060010190602001808311611ac957829003601f168201915b505050505090505b92915050565b8051825114611b3e57815181516040517f5b059991000000000000000000000000000000000000000000000000000000008152600401611b35929190613085565b60405180910390fd5b6000611b48610fda565b905060005b8351811015611d57576000611b6b82866110ee90919063ffffffff16565b90506000611b8283866110ee90919063ffffffff16565b9050600073ffffffffffffffffffffffffffffffffffffffff168873ffffffffffffffffffffffffffffffffffffffff1614611caf57600080600084815260200190815260200160002060008a73ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054905081811015611c5757888183856040517f03dee4c5000000000000000000000000000000000000000000000000000000008152600401611c4e94939291906133c1565b60405180910390fd5b81810360008085815260200190815260200160002060008b73ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002081905550505b600073ffffffffffffffffffffffffffffffffffffffff168773ffffffffffffffffffffffffffffffffffffffff1614611d4a578060008084815260200190815260200160002060008973ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206000828254611d429190613435565b925050819055505b5050806001019050611b4d565b506001835103611e16576000611d776000856110ee90919063ffffffff16565b90506000611d8f6000856110ee90919063ffffffff16565b90508573ffffffffffffffffffffffffffffffffffffffff168773ffffffffffffffffffffffffffffffffffffffff168473ffffffffffffffffffffffffffffffffffffffff167fc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f628585604051611e07929190613085565b60405180910390a45050611e95565b8373ffffffffffffffffffffffffffffffffffffffff168573ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff167f4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb8686604051611e8c929190613469565b60405180910390a45b5050505050565b60008473ffffffffffffffffffffffffffffffffffffffff163b1115612048578373ffffffffffffffffffffffffffffffffffffffff1663f23a6e6187878686866040518663ffffffff1660e01b8152600401611efd9594939291906134f5565b6020604051808303816000875af1925050508015611f3957506040513d601f19601f82011682018060405250810190611f369190613564565b60015b611fbd573d8060008114611f69576040519150601f19603f3d011682016040523d82523d6000602084013e611f6e565b606091505b506000815103611fb557846040517f57f447ce000000000000000000000000000000000000000000000000000000008152600401611fac9190612805565b60405180910390fd5b805181602001fd5b63f23a6e6160e01b7bffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916817bffffffffffffffffffffffffffffffffffffffffffffffffffffffff19161461204657846040517f57f447ce00000000000000000000000000000000000000000000000000000000815260040161203d9190612805565b60405180910390fd5b505b505050505050565b60008473ffffffffffffffffffffffffffffffffffffffff163b11156121fc578373ffffffffffffffffffffffffffffffffffffffff1663bc197c8187878686866040518663ffffffff1660e01b81526004016120b1959493929190613591565b6020604051808303816000875af19250505080156120ed57506040513d601f19601f820116820180604052508101906120ea9190613564565b60015b612171573d806000811461211d576040519150601f19603f3d011682016040523d82523d6000602084013e612122565b606091505b50600081510361216957846040517f57f447ce0000000000000000000000000000000000000000000000000000000081526004016121609190612805565b60405180910390fd5b805181602001fd5b63bc197c8160e01b7bffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916817bffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916146121fa57846040517f57f447ce0000000000000000000000000000000000000000000000000000000081526004016121f19190612805565b60405180910390fd5b505b505050505050565b60007f000000000000000000000000000000000000000000000000000000000000000073ffffffffffffffffffffffffffffffffffffffff163073ffffffffffffffffffffffffffffffffffffffff1614801561228057507f000000000000000000000000000000000000000000000000000000000000000046145b156122ad577f000000000000000000000000000000000000000000000000000000000000000090506122b8565b6122b5612464565b90505b90565b60006040517f190100000000000000000000000000000000000000000000000000000000000081528360028201528260228201526042812091505092915050565b60008060007f7fffffffffffffffffffffffffffffff5d576e7357a4501ddfe92f46681b20a08460001c111561233c5760006003859250925092506123e6565b6000600188888888604051600081526020016040526040516123619493929190613615565b6020604051602081039080840390855afa158015612383573d6000803e3d6000fd5b505050602060405103519050600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff16036123d757600060016000801b935093509350506123e6565b8060008060001b935093509350505b9450945094915050565b606060006123fd836124fa565b90506000602067ffffffffffffffff81111561241c5761241b612825565b5b6040519080825280601f01601f19166020018201604052801561244e5781602001600182028036833780820191505090505b5090508181528360208201528092505050919050565b60007f8b73c3c69bb8fe3d512ecc4cf759cc79239f7b179b0ffacaa9a75d522b39400f7f00000000000000000000000000000000000000000000000000000000000000007f000000000000000000000000000000000000000000000000000000000000000046306040516020016124df95949392919061365a565b60405160208183030381529060405280519060200120905090565b60008060ff8360001c169050601f811115612541576040517fb3512b0c00000000000000000000000000000000000000000000000000000000815260040160405180910390fd5b80915050919050565b6000604051905090565b600080fd5b600080fd5b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b60006125898261255e565b9050919050565b6125998161257e565b81146125a457600080fd5b50565b6000813590506125b681612590565b92915050565b6000819050919050565b6125cf816125bc565b81146125da57600080fd5b50565b6000813590506125ec816125c6565b92915050565b6000806040838503121561260957612608612554565b5b6000612617858286016125a7565b9250506020612628858286016125dd565b9150509250929050565b61263b816125bc565b82525050565b60006020820190506126566000830184612632565b92915050565b60007fffffffff0000000000000000000000000000000000000000000000000000000082169050919050565b6126918161265c565b811461269c57600080fd5b50565b6000813590506126ae81612688565b92915050565b6000602082840312156126ca576126c9612554565b5b60006126d88482850161269f565b91505092915050565b60008115159050919050565b6126f6816126e1565b82525050565b600060208201905061271160008301846126ed565b92915050565b60006020828403121561272d5761272c612554565b5b600061273b848285016125dd565b91505092915050565b600081519050919050565b600082825260208201905092915050565b60005b8381101561277e578082015181840152602081019050612763565b60008484015250505050565b6000601f19601f8301169050919050565b60006127a682612744565b6127b0818561274f565b93506127c0818560208601612760565b6127c98161278a565b840191505092915050565b600060208201905081810360008301526127ee818461279b565b905092915050565b6127ff8161257e565b82525050565b600060208201905061281a60008301846127f6565b92915050565b600080fd5b7f4e487b7100000000000000000000000000000000000000000000000000000000600052604160045260246000fd5b61285d8261278a565b810181811067ffffffffffffffff8211171561287c5761287b612825565b5b80604052505050565b600061288f61254a565b905061289b8282612854565b919050565b600067ffffffffffffffff8211156128bb576128ba612825565b5b602082029050602081019050919050565b600080fd5b60006128e46128df846128a0565b612885565b90508083825260208201905060208402830185811115612907576129066128cc565b5b835b81811015612930578061291c88826125dd565b845260208401935050602081019050612909565b5050509392505050565b600082601f83011261294f5761294e612820565b5b813561295f8482602086016128d1565b91505092915050565b600080fd5b600067ffffffffffffffff82111561298857612987612825565b5b6129918261278a565b9050602081019050919050565b82818337600083830152505050565b60006129c06129bb8461296d565b612885565b9050828152602081018484840111156129dc576129db612968565b5b6129e784828561299e565b509392505050565b600082601f830112612a0457612a03612820565b5b8135612a148482602086016129ad565b91505092915050565b600080600080600060a08688031215612a3957612a38612554565b5b6000612a47888289016125a7565b9550506020612a58888289016125a7565b945050604086013567ffffffffffffffff811115612a7957612a78612559565b5b612a858882890161293a565b935050606086013567ffffffffffffffff811115612aa657612aa5612559565b5b612ab28882890161293a565b925050608086013567ffffffffffffffff811115612ad357612ad2612559565b5b612adf888289016129ef565b9150509295509295909350565b600067ffffffffffffffff821115612b0757612b06612825565b5b602082029050602081019050919050565b6000612b2b612b2684612aec565b612885565b90508083825260208201905060208402830185811115612b4e57612b4d6128cc565b5b835b81811015612b775780612b6388826125a7565b845260208401935050602081019050612b50565b5050509392505050565b600082601f830112612b9657612b95612820565b5b8135612ba6848260208601612b18565b91505092915050565b60008060408385031215612bc657612bc5612554565b5b600083013567ffffffffffffffff811115612be457612be3612559565b5b612bf085828601612b81565b925050602083013567ffffffffffffffff811115612c1157612c10612559565b5b612c1d8582860161293a565b9150509250929050565b600081519050919050565b600082825260208201905092915050565b6000819050602082019050919050565b612c5c816125bc565b82525050565b6000612c6e8383612c53565b60208301905092915050565b6000602082019050919050565b6000612c9282612c27565b612c9c8185612c32565b9350612ca783612c43565b8060005b83811015612cd8578151612cbf8882612c62565b9750612cca83612c7a565b925050600181019050612cab565b5085935050505092915050565b60006020820190508181036000830152612cff8184612c87565b905092915050565b600080fd5b600060a08284031215612d2257612d21612d07565b5b81905092915050565b60008060408385031215612d4257612d41612554565b5b600083013567ffffffffffffffff811115612d6057612d5f612559565b5b612d6c85828601612d0c565b9250506020612d7d858286016125a7565b9150509250929050565b600060208284031215612d9d57612d9c612554565b5b6000612dab848285016125a7565b91505092915050565b612dbd816126e1565b8114612dc857600080fd5b50565b600081359050612dda81612db4565b92915050565b60008060408385031215612df757612df6612554565b5b6000612e05858286016125a7565b9250506020612e1685828601612dcb565b9150509250929050565b60007fff0000000000000000000000000000000000000000000000000000000000000082169050919050565b612e5581612e20565b82525050565b6000819050919050565b612e6e81612e5b565b82525050565b600060e082019050612e89600083018a612e4c565b8181036020830152612e9b818961279b565b90508181036040830152612eaf818861279b565b9050612ebe6060830187612632565b612ecb60808301866127f6565b612ed860a0830185612e65565b81810360c0830152612eea8184612c87565b905098975050505050505050565b600060208284031215612f0e57612f0d612554565b5b6000612f1c84828501612dcb565b91505092915050565b60008060408385031215612f3c57612f3b612554565b5b6000612f4a858286016125a7565b9250506020612f5b858286016125a7565b9150509250929050565b600080600080600060a08688031215612f8157612f80612554565b5b6000612f8f888289016125a7565b9550506020612fa0888289016125a7565b9450506040612fb1888289016125dd565b9350506060612fc2888289016125dd565b925050608086013567ffffffffffffffff811115612fe357612fe2612559565b5b612fef888289016129ef565b9150509295509295909350565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052602260045260246000fd5b6000600282049050600182168061304357607f821691505b60208210810361305657613055612ffc565b5b50919050565b600060408201905061307160008301856127f6565b61307e60208301846127f6565b9392505050565b600060408201905061309a6000830185612632565b6130a76020830184612632565b9392505050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052603260045260246000fd5b7f566f7563686572206f776e6572206d69736d6174636800000000000000000000600082015250565b600061311360168361274f565b915061311e826130dd565b602082019050919050565b6000602082019050818103600083015261314281613106565b9050919050565b600080fd5b600080fd5b600080fd5b6000808335600160200384360303811261317557613174613149565b5b80840192508235915067ffffffffffffffff8211156131975761319661314e565b5b6020830192506001820236038313156131b3576131b2613153565b5b509250929050565b7f496e76616c6964207369676e6174757265000000000000000000000000000000600082015250565b60006131f160118361274f565b91506131fc826131bb565b602082019050919050565b60006020820190508181036000830152613220816131e4565b9050919050565b6000808335600160200384360303811261324457613243613149565b5b80840192508235915067ffffffffffffffff8211156132665761326561314e565b5b60208301925060018202360383131561328257613281613153565b5b509250929050565b6000613296838561274f565b93506132a383858461299e565b6132ac8361278a565b840190509392505050565b600060208201905081810360008301526132d281848661328a565b90509392505050565b600081905092915050565b60006132f283856132db565b93506132ff83858461299e565b82840190509392505050565b60006133188284866132e6565b91508190509392505050565b600060a0820190506133396000830188612e65565b61334660208301876127f6565b6133536040830186612632565b6133606060830185612632565b61336d6080830184612e65565b9695505050505050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052602160045260246000fd5b60006020820190506133bb6000830184612e65565b92915050565b60006080820190506133d660008301876127f6565b6133e36020830186612632565b6133f06040830185612632565b6133fd6060830184612632565b95945050505050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601160045260246000fd5b6000613440826125bc565b915061344b836125bc565b925082820190508082111561346357613462613406565b5b92915050565b600060408201905081810360008301526134838185612c87565b905081810360208301526134978184612c87565b90509392505050565b600081519050919050565b600082825260208201905092915050565b60006134c7826134a0565b6134d181856134ab565b93506134e1818560208601612760565b6134ea8161278a565b840191505092915050565b600060a08201905061350a60008301886127f6565b61351760208301876127f6565b6135246040830186612632565b6135316060830185612632565b818103608083015261354381846134bc565b90509695505050505050565b60008151905061355e81612688565b92915050565b60006020828403121561357a57613579612554565b5b60006135888482850161354f565b91505092915050565b600060a0820190506135a660008301886127f6565b6135b360208301876127f6565b81810360408301526135c58186612c87565b905081810360608301526135d98185612c87565b905081810360808301526135ed81846134bc565b90509695505050505050565b600060ff82169050919050565b61360f816135f9565b82525050565b600060808201905061362a6000830187612e65565b6136376020830186613606565b6136446040830185612e65565b6136516060830184612e65565b95945050505050565b600060a08201905061366f6000830188612e65565b61367c6020830187612e65565b6136896040830186612e65565b6136966060830185612632565b6136a360808301846127f6565b969550505050505056fea26469706673582212202dd4fc28ffb2633917e20af0083d81084b009fd6a3b38c1f091c3808acda861664736f6c634300081c0033",
+}
+
+// LazyMint1155ABI is the input ABI used to generate the binding from.
+// Deprecated: Use LazyMint1155MetaData.ABI instead.
+var LazyMint1155ABI = LazyMint1155MetaData.ABI
+
+// LazyMint1155Bin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use LazyMint1155MetaData.Bin instead.
+var LazyMint1155Bin = LazyMint1155MetaData.Bin
+
+// DeployLazyMint1155 deploys a new Ethereum contract, binding an instance of LazyMint1155 to it.
+func DeployLazyMint1155(auth *bind.TransactOpts, backend bind.ContractBackend, _signer common.Address) (common.Address, *types.Transaction, *LazyMint1155, error) {
+	parsed, err := LazyMint1155MetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(LazyMint1155Bin), backend, _signer)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &LazyMint1155{LazyMint1155Caller: LazyMint1155Caller{contract: contract}, LazyMint1155Transactor: LazyMint1155Transactor{contract: contract}, LazyMint1155Filterer: LazyMint1155Filterer{contract: contract}}, nil
+}
+
+// LazyMint1155 is an auto generated Go binding around an Ethereum contract.
+type LazyMint1155 struct {
+	LazyMint1155Caller     // Read-only binding to the contract
+	LazyMint1155Transactor // Write-only binding to the contract
+	LazyMint1155Filterer   // Log filterer for contract events
+}
+
+// LazyMint1155Caller is an auto generated read-only Go binding around an Ethereum contract.
+type LazyMint1155Caller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// LazyMint1155Transactor is an auto generated write-only Go binding around an Ethereum contract.
+type LazyMint1155Transactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// LazyMint1155Filterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type LazyMint1155Filterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// LazyMint1155Session is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type LazyMint1155Session struct {
+	Contract     *LazyMint1155     // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// LazyMint1155CallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type LazyMint1155CallerSession struct {
+	Contract *LazyMint1155Caller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts       // Call options to use throughout this session
+}
+
+// LazyMint1155TransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type LazyMint1155TransactorSession struct {
+	Contract     *LazyMint1155Transactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// LazyMint1155Raw is an auto generated low-level Go binding around an Ethereum contract.
+type LazyMint1155Raw struct {
+	Contract *LazyMint1155 // Generic contract binding to access the raw methods on
+}
+
+// LazyMint1155CallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type LazyMint1155CallerRaw struct {
+	Contract *LazyMint1155Caller // Generic read-only contract binding to access the raw methods on
+}
+
+// LazyMint1155TransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type LazyMint1155TransactorRaw struct {
+	Contract *LazyMint1155Transactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewLazyMint1155 creates a new instance of LazyMint1155, bound to a specific deployed contract.
+func NewLazyMint1155(address common.Address, backend bind.ContractBackend) (*LazyMint1155, error) {
+	contract, err := bindLazyMint1155(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &LazyMint1155{LazyMint1155Caller: LazyMint1155Caller{contract: contract}, LazyMint1155Transactor: LazyMint1155Transactor{contract: contract}, LazyMint1155Filterer: LazyMint1155Filterer{contract: contract}}, nil
+}
+
+// NewLazyMint1155Caller creates a new read-only instance of LazyMint1155, bound to a specific deployed contract.
+func NewLazyMint1155Caller(address common.Address, caller bind.ContractCaller) (*LazyMint1155Caller, error) {
+	contract, err := bindLazyMint1155(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &LazyMint1155Caller{contract: contract}, nil
+}
+
+// NewLazyMint1155Transactor creates a new write-only instance of LazyMint1155, bound to a specific deployed contract.
+func NewLazyMint1155Transactor(address common.Address, transactor bind.ContractTransactor) (*LazyMint1155Transactor, error) {
+	contract, err := bindLazyMint1155(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &LazyMint1155Transactor{contract: contract}, nil
+}
+
+// NewLazyMint1155Filterer creates a new log filterer instance of LazyMint1155, bound to a specific deployed contract.
+func NewLazyMint1155Filterer(address common.Address, filterer bind.ContractFilterer) (*LazyMint1155Filterer, error) {
+	contract, err := bindLazyMint1155(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &LazyMint1155Filterer{contract: contract}, nil
+}
+
+// bindLazyMint1155 binds a generic wrapper to an already deployed contract.
+func bindLazyMint1155(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := LazyMint1155MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_LazyMint1155 *LazyMint1155Raw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _LazyMint1155.Contract.LazyMint1155Caller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_LazyMint1155 *LazyMint1155Raw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _LazyMint1155.Contract.LazyMint1155Transactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_LazyMint1155 *LazyMint1155Raw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _LazyMint1155.Contract.LazyMint1155Transactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_LazyMint1155 *LazyMint1155CallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _LazyMint1155.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_LazyMint1155 *LazyMint1155TransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _LazyMint1155.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_LazyMint1155 *LazyMint1155TransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _LazyMint1155.Contract.contract.Transact(opts, method, params...)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x00fdd58e.
+//
+// Solidity: function balanceOf(address account, uint256 id) view returns(uint256)
+func (_LazyMint1155 *LazyMint1155Caller) BalanceOf(opts *bind.CallOpts, account common.Address, id *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _LazyMint1155.contract.Call(opts, &out, "balanceOf", account, id)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x00fdd58e.
+//
+// Solidity: function balanceOf(address account, uint256 id) view returns(uint256)
+func (_LazyMint1155 *LazyMint1155Session) BalanceOf(account common.Address, id *big.Int) (*big.Int, error) {
+	return _LazyMint1155.Contract.BalanceOf(&_LazyMint1155.CallOpts, account, id)
+}
+
+// BalanceOf is a free data retrieval call binding the contract method 0x00fdd58e.
+//
+// Solidity: function balanceOf(address account, uint256 id) view returns(uint256)
+func (_LazyMint1155 *LazyMint1155CallerSession) BalanceOf(account common.Address, id *big.Int) (*big.Int, error) {
+	return _LazyMint1155.Contract.BalanceOf(&_LazyMint1155.CallOpts, account, id)
+}
+
+// BalanceOfBatch is a free data retrieval call binding the contract method 0x4e1273f4.
+//
+// Solidity: function balanceOfBatch(address[] accounts, uint256[] ids) view returns(uint256[])
+func (_LazyMint1155 *LazyMint1155Caller) BalanceOfBatch(opts *bind.CallOpts, accounts []common.Address, ids []*big.Int) ([]*big.Int, error) {
+	var out []interface{}
+	err := _LazyMint1155.contract.Call(opts, &out, "balanceOfBatch", accounts, ids)
+
+	if err != nil {
+		return *new([]*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]*big.Int)).(*[]*big.Int)
+
+	return out0, err
+
+}
+
+// BalanceOfBatch is a free data retrieval call binding the contract method 0x4e1273f4.
+//
+// Solidity: function balanceOfBatch(address[] accounts, uint256[] ids) view returns(uint256[])
+func (_LazyMint1155 *LazyMint1155Session) BalanceOfBatch(accounts []common.Address, ids []*big.Int) ([]*big.Int, error) {
+	return _LazyMint1155.Contract.BalanceOfBatch(&_LazyMint1155.CallOpts, accounts, ids)
+}
+
+// BalanceOfBatch is a free data retrieval call binding the contract method 0x4e1273f4.
+//
+// Solidity: function balanceOfBatch(address[] accounts, uint256[] ids) view returns(uint256[])
+func (_LazyMint1155 *LazyMint1155CallerSession) BalanceOfBatch(accounts []common.Address, ids []*big.Int) ([]*big.Int, error) {
+	return _LazyMint1155.Contract.BalanceOfBatch(&_LazyMint1155.CallOpts, accounts, ids)
+}
+
+// Eip712Domain is a free data retrieval call binding the contract method 0x84b0196e.
+//
+// Solidity: function eip712Domain() view returns(bytes1 fields, string name, string version, uint256 chainId, address verifyingContract, bytes32 salt, uint256[] extensions)
+func (_LazyMint1155 *LazyMint1155Caller) Eip712Domain(opts *bind.CallOpts) (struct {
+	Fields            [1]byte
+	Name              string
+	Version           string
+	ChainId           *big.Int
+	VerifyingContract common.Address
+	Salt              [32]byte
+	Extensions        []*big.Int
+}, error) {
+	var out []interface{}
+	err := _LazyMint1155.contract.Call(opts, &out, "eip712Domain")
+
+	outstruct := new(struct {
+		Fields            [1]byte
+		Name              string
+		Version           string
+		ChainId           *big.Int
+		VerifyingContract common.Address
+		Salt              [32]byte
+		Extensions        []*big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Fields = *abi.ConvertType(out[0], new([1]byte)).(*[1]byte)
+	outstruct.Name = *abi.ConvertType(out[1], new(string)).(*string)
+	outstruct.Version = *abi.ConvertType(out[2], new(string)).(*string)
+	outstruct.ChainId = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.VerifyingContract = *abi.ConvertType(out[4], new(common.Address)).(*common.Address)
+	outstruct.Salt = *abi.ConvertType(out[5], new([32]byte)).(*[32]byte)
+	outstruct.Extensions = *abi.ConvertType(out[6], new([]*big.Int)).(*[]*big.Int)
+
+	return *outstruct, err
+
+}
+
+// Eip712Domain is a free data retrieval call binding the contract method 0x84b0196e.
+//
+// Solidity: function eip712Domain() view returns(bytes1 fields, string name, string version, uint256 chainId, address verifyingContract, bytes32 salt, uint256[] extensions)
+func (_LazyMint1155 *LazyMint1155Session) Eip712Domain() (struct {
+	Fields            [1]byte
+	Name              string
+	Version           string
+	ChainId           *big.Int
+	VerifyingContract common.Address
+	Salt              [32]byte
+	Extensions        []*big.Int
+}, error) {
+	return _LazyMint1155.Contract.Eip712Domain(&_LazyMint1155.CallOpts)
+}
+
+// Eip712Domain is a free data retrieval call binding the contract method 0x84b0196e.
+//
+// Solidity: function eip712Domain() view returns(bytes1 fields, string name, string version, uint256 chainId, address verifyingContract, bytes32 salt, uint256[] extensions)
+func (_LazyMint1155 *LazyMint1155CallerSession) Eip712Domain() (struct {
+	Fields            [1]byte
+	Name              string
+	Version           string
+	ChainId           *big.Int
+	VerifyingContract common.Address
+	Salt              [32]byte
+	Extensions        []*big.Int
+}, error) {
+	return _LazyMint1155.Contract.Eip712Domain(&_LazyMint1155.CallOpts)
+}
+
+// HasOptedOutOfGlobalApproval is a free data retrieval call binding the contract method 0x70857c7b.
+//
+// Solidity: function hasOptedOutOfGlobalApproval(address user) view returns(bool)
+func (_LazyMint1155 *LazyMint1155Caller) HasOptedOutOfGlobalApproval(opts *bind.CallOpts, user common.Address) (bool, error) {
+	var out []interface{}
+	err := _LazyMint1155.contract.Call(opts, &out, "hasOptedOutOfGlobalApproval", user)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// HasOptedOutOfGlobalApproval is a free data retrieval call binding the contract method 0x70857c7b.
+//
+// Solidity: function hasOptedOutOfGlobalApproval(address user) view returns(bool)
+func (_LazyMint1155 *LazyMint1155Session) HasOptedOutOfGlobalApproval(user common.Address) (bool, error) {
+	return _LazyMint1155.Contract.HasOptedOutOfGlobalApproval(&_LazyMint1155.CallOpts, user)
+}
+
+// HasOptedOutOfGlobalApproval is a free data retrieval call binding the contract method 0x70857c7b.
+//
+// Solidity: function hasOptedOutOfGlobalApproval(address user) view returns(bool)
+func (_LazyMint1155 *LazyMint1155CallerSession) HasOptedOutOfGlobalApproval(user common.Address) (bool, error) {
+	return _LazyMint1155.Contract.HasOptedOutOfGlobalApproval(&_LazyMint1155.CallOpts, user)
+}
+
+// IsApprovedForAll is a free data retrieval call binding the contract method 0xe985e9c5.
+//
+// Solidity: function isApprovedForAll(address account, address operator) view returns(bool)
+func (_LazyMint1155 *LazyMint1155Caller) IsApprovedForAll(opts *bind.CallOpts, account common.Address, operator common.Address) (bool, error) {
+	var out []interface{}
+	err := _LazyMint1155.contract.Call(opts, &out, "isApprovedForAll", account, operator)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsApprovedForAll is a free data retrieval call binding the contract method 0xe985e9c5.
+//
+// Solidity: function isApprovedForAll(address account, address operator) view returns(bool)
+func (_LazyMint1155 *LazyMint1155Session) IsApprovedForAll(account common.Address, operator common.Address) (bool, error) {
+	return _LazyMint1155.Contract.IsApprovedForAll(&_LazyMint1155.CallOpts, account, operator)
+}
+
+// IsApprovedForAll is a free data retrieval call binding the contract method 0xe985e9c5.
+//
+// Solidity: function isApprovedForAll(address account, address operator) view returns(bool)
+func (_LazyMint1155 *LazyMint1155CallerSession) IsApprovedForAll(account common.Address, operator common.Address) (bool, error) {
+	return _LazyMint1155.Contract.IsApprovedForAll(&_LazyMint1155.CallOpts, account, operator)
+}
+
+// IsGlobalApprover is a free data retrieval call binding the contract method 0xf8527875.
+//
+// Solidity: function isGlobalApprover(address operator) view returns(bool)
+func (_LazyMint1155 *LazyMint1155Caller) IsGlobalApprover(opts *bind.CallOpts, operator common.Address) (bool, error) {
+	var out []interface{}
+	err := _LazyMint1155.contract.Call(opts, &out, "isGlobalApprover", operator)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsGlobalApprover is a free data retrieval call binding the contract method 0xf8527875.
+//
+// Solidity: function isGlobalApprover(address operator) view returns(bool)
+func (_LazyMint1155 *LazyMint1155Session) IsGlobalApprover(operator common.Address) (bool, error) {
+	return _LazyMint1155.Contract.IsGlobalApprover(&_LazyMint1155.CallOpts, operator)
+}
+
+// IsGlobalApprover is a free data retrieval call binding the contract method 0xf8527875.
+//
+// Solidity: function isGlobalApprover(address operator) view returns(bool)
+func (_LazyMint1155 *LazyMint1155CallerSession) IsGlobalApprover(operator common.Address) (bool, error) {
+	return _LazyMint1155.Contract.IsGlobalApprover(&_LazyMint1155.CallOpts, operator)
+}
+
+// IsTokenMinted is a free data retrieval call binding the contract method 0x8f96a7cb.
+//
+// Solidity: function isTokenMinted(uint256 tokenId) view returns(bool)
+func (_LazyMint1155 *LazyMint1155Caller) IsTokenMinted(opts *bind.CallOpts, tokenId *big.Int) (bool, error) {
+	var out []interface{}
+	err := _LazyMint1155.contract.Call(opts, &out, "isTokenMinted", tokenId)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsTokenMinted is a free data retrieval call binding the contract method 0x8f96a7cb.
+//
+// Solidity: function isTokenMinted(uint256 tokenId) view returns(bool)
+func (_LazyMint1155 *LazyMint1155Session) IsTokenMinted(tokenId *big.Int) (bool, error) {
+	return _LazyMint1155.Contract.IsTokenMinted(&_LazyMint1155.CallOpts, tokenId)
+}
+
+// IsTokenMinted is a free data retrieval call binding the contract method 0x8f96a7cb.
+//
+// Solidity: function isTokenMinted(uint256 tokenId) view returns(bool)
+func (_LazyMint1155 *LazyMint1155CallerSession) IsTokenMinted(tokenId *big.Int) (bool, error) {
+	return _LazyMint1155.Contract.IsTokenMinted(&_LazyMint1155.CallOpts, tokenId)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_LazyMint1155 *LazyMint1155Caller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _LazyMint1155.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_LazyMint1155 *LazyMint1155Session) Owner() (common.Address, error) {
+	return _LazyMint1155.Contract.Owner(&_LazyMint1155.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_LazyMint1155 *LazyMint1155CallerSession) Owner() (common.Address, error) {
+	return _LazyMint1155.Contract.Owner(&_LazyMint1155.CallOpts)
+}
+
+// Signer is a free data retrieval call binding the contract method 0x238ac933.
+//
+// Solidity: function signer() view returns(address)
+func (_LazyMint1155 *LazyMint1155Caller) Signer(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _LazyMint1155.contract.Call(opts, &out, "signer")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Signer is a free data retrieval call binding the contract method 0x238ac933.
+//
+// Solidity: function signer() view returns(address)
+func (_LazyMint1155 *LazyMint1155Session) Signer() (common.Address, error) {
+	return _LazyMint1155.Contract.Signer(&_LazyMint1155.CallOpts)
+}
+
+// Signer is a free data retrieval call binding the contract method 0x238ac933.
+//
+// Solidity: function signer() view returns(address)
+func (_LazyMint1155 *LazyMint1155CallerSession) Signer() (common.Address, error) {
+	return _LazyMint1155.Contract.Signer(&_LazyMint1155.CallOpts)
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_LazyMint1155 *LazyMint1155Caller) SupportsInterface(opts *bind.CallOpts, interfaceId [4]byte) (bool, error) {
+	var out []interface{}
+	err := _LazyMint1155.contract.Call(opts, &out, "supportsInterface", interfaceId)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_LazyMint1155 *LazyMint1155Session) SupportsInterface(interfaceId [4]byte) (bool, error) {
+	return _LazyMint1155.Contract.SupportsInterface(&_LazyMint1155.CallOpts, interfaceId)
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_LazyMint1155 *LazyMint1155CallerSession) SupportsInterface(interfaceId [4]byte) (bool, error) {
+	return _LazyMint1155.Contract.SupportsInterface(&_LazyMint1155.CallOpts, interfaceId)
+}
+
+// Uri is a free data retrieval call binding the contract method 0x0e89341c.
+//
+// Solidity: function uri(uint256 ) view returns(string)
+func (_LazyMint1155 *LazyMint1155Caller) Uri(opts *bind.CallOpts, arg0 *big.Int) (string, error) {
+	var out []interface{}
+	err := _LazyMint1155.contract.Call(opts, &out, "uri", arg0)
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// Uri is a free data retrieval call binding the contract method 0x0e89341c.
+//
+// Solidity: function uri(uint256 ) view returns(string)
+func (_LazyMint1155 *LazyMint1155Session) Uri(arg0 *big.Int) (string, error) {
+	return _LazyMint1155.Contract.Uri(&_LazyMint1155.CallOpts, arg0)
+}
+
+// Uri is a free data retrieval call binding the contract method 0x0e89341c.
+//
+// Solidity: function uri(uint256 ) view returns(string)
+func (_LazyMint1155 *LazyMint1155CallerSession) Uri(arg0 *big.Int) (string, error) {
+	return _LazyMint1155.Contract.Uri(&_LazyMint1155.CallOpts, arg0)
+}
+
+// MintIfNotExists is a paid mutator transaction binding the contract method 0x53086991.
+//
+// Solidity: function mintIfNotExists((address,uint256,uint256,string,bytes) v, address to) returns()
+func (_LazyMint1155 *LazyMint1155Transactor) MintIfNotExists(opts *bind.TransactOpts, v LazyMint1155Voucher, to common.Address) (*types.Transaction, error) {
+	return _LazyMint1155.contract.Transact(opts, "mintIfNotExists", v, to)
+}
+
+// MintIfNotExists is a paid mutator transaction binding the contract method 0x53086991.
+//
+// Solidity: function mintIfNotExists((address,uint256,uint256,string,bytes) v, address to) returns()
+func (_LazyMint1155 *LazyMint1155Session) MintIfNotExists(v LazyMint1155Voucher, to common.Address) (*types.Transaction, error) {
+	return _LazyMint1155.Contract.MintIfNotExists(&_LazyMint1155.TransactOpts, v, to)
+}
+
+// MintIfNotExists is a paid mutator transaction binding the contract method 0x53086991.
+//
+// Solidity: function mintIfNotExists((address,uint256,uint256,string,bytes) v, address to) returns()
+func (_LazyMint1155 *LazyMint1155TransactorSession) MintIfNotExists(v LazyMint1155Voucher, to common.Address) (*types.Transaction, error) {
+	return _LazyMint1155.Contract.MintIfNotExists(&_LazyMint1155.TransactOpts, v, to)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_LazyMint1155 *LazyMint1155Transactor) RenounceOwnership(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _LazyMint1155.contract.Transact(opts, "renounceOwnership")
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_LazyMint1155 *LazyMint1155Session) RenounceOwnership() (*types.Transaction, error) {
+	return _LazyMint1155.Contract.RenounceOwnership(&_LazyMint1155.TransactOpts)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_LazyMint1155 *LazyMint1155TransactorSession) RenounceOwnership() (*types.Transaction, error) {
+	return _LazyMint1155.Contract.RenounceOwnership(&_LazyMint1155.TransactOpts)
+}
+
+// SafeBatchTransferFrom is a paid mutator transaction binding the contract method 0x2eb2c2d6.
+//
+// Solidity: function safeBatchTransferFrom(address from, address to, uint256[] ids, uint256[] values, bytes data) returns()
+func (_LazyMint1155 *LazyMint1155Transactor) SafeBatchTransferFrom(opts *bind.TransactOpts, from common.Address, to common.Address, ids []*big.Int, values []*big.Int, data []byte) (*types.Transaction, error) {
+	return _LazyMint1155.contract.Transact(opts, "safeBatchTransferFrom", from, to, ids, values, data)
+}
+
+// SafeBatchTransferFrom is a paid mutator transaction binding the contract method 0x2eb2c2d6.
+//
+// Solidity: function safeBatchTransferFrom(address from, address to, uint256[] ids, uint256[] values, bytes data) returns()
+func (_LazyMint1155 *LazyMint1155Session) SafeBatchTransferFrom(from common.Address, to common.Address, ids []*big.Int, values []*big.Int, data []byte) (*types.Transaction, error) {
+	return _LazyMint1155.Contract.SafeBatchTransferFrom(&_LazyMint1155.TransactOpts, from, to, ids, values, data)
+}
+
+// SafeBatchTransferFrom is a paid mutator transaction binding the contract method 0x2eb2c2d6.
+//
+// Solidity: function safeBatchTransferFrom(address from, address to, uint256[] ids, uint256[] values, bytes data) returns()
+func (_LazyMint1155 *LazyMint1155TransactorSession) SafeBatchTransferFrom(from common.Address, to common.Address, ids []*big.Int, values []*big.Int, data []byte) (*types.Transaction, error) {
+	return _LazyMint1155.Contract.SafeBatchTransferFrom(&_LazyMint1155.TransactOpts, from, to, ids, values, data)
+}
+
+// SafeTransferFrom is a paid mutator transaction binding the contract method 0xf242432a.
+//
+// Solidity: function safeTransferFrom(address from, address to, uint256 id, uint256 value, bytes data) returns()
+func (_LazyMint1155 *LazyMint1155Transactor) SafeTransferFrom(opts *bind.TransactOpts, from common.Address, to common.Address, id *big.Int, value *big.Int, data []byte) (*types.Transaction, error) {
+	return _LazyMint1155.contract.Transact(opts, "safeTransferFrom", from, to, id, value, data)
+}
+
+// SafeTransferFrom is a paid mutator transaction binding the contract method 0xf242432a.
+//
+// Solidity: function safeTransferFrom(address from, address to, uint256 id, uint256 value, bytes data) returns()
+func (_LazyMint1155 *LazyMint1155Session) SafeTransferFrom(from common.Address, to common.Address, id *big.Int, value *big.Int, data []byte) (*types.Transaction, error) {
+	return _LazyMint1155.Contract.SafeTransferFrom(&_LazyMint1155.TransactOpts, from, to, id, value, data)
+}
+
+// SafeTransferFrom is a paid mutator transaction binding the contract method 0xf242432a.
+//
+// Solidity: function safeTransferFrom(address from, address to, uint256 id, uint256 value, bytes data) returns()
+func (_LazyMint1155 *LazyMint1155TransactorSession) SafeTransferFrom(from common.Address, to common.Address, id *big.Int, value *big.Int, data []byte) (*types.Transaction, error) {
+	return _LazyMint1155.Contract.SafeTransferFrom(&_LazyMint1155.TransactOpts, from, to, id, value, data)
+}
+
+// SetApprovalForAll is a paid mutator transaction binding the contract method 0xa22cb465.
+//
+// Solidity: function setApprovalForAll(address operator, bool approved) returns()
+func (_LazyMint1155 *LazyMint1155Transactor) SetApprovalForAll(opts *bind.TransactOpts, operator common.Address, approved bool) (*types.Transaction, error) {
+	return _LazyMint1155.contract.Transact(opts, "setApprovalForAll", operator, approved)
+}
+
+// SetApprovalForAll is a paid mutator transaction binding the contract method 0xa22cb465.
+//
+// Solidity: function setApprovalForAll(address operator, bool approved) returns()
+func (_LazyMint1155 *LazyMint1155Session) SetApprovalForAll(operator common.Address, approved bool) (*types.Transaction, error) {
+	return _LazyMint1155.Contract.SetApprovalForAll(&_LazyMint1155.TransactOpts, operator, approved)
+}
+
+// SetApprovalForAll is a paid mutator transaction binding the contract method 0xa22cb465.
+//
+// Solidity: function setApprovalForAll(address operator, bool approved) returns()
+func (_LazyMint1155 *LazyMint1155TransactorSession) SetApprovalForAll(operator common.Address, approved bool) (*types.Transaction, error) {
+	return _LazyMint1155.Contract.SetApprovalForAll(&_LazyMint1155.TransactOpts, operator, approved)
+}
+
+// SetGlobalApproval is a paid mutator transaction binding the contract method 0x7793533b.
+//
+// Solidity: function setGlobalApproval(address operator, bool approved) returns()
+func (_LazyMint1155 *LazyMint1155Transactor) SetGlobalApproval(opts *bind.TransactOpts, operator common.Address, approved bool) (*types.Transaction, error) {
+	return _LazyMint1155.contract.Transact(opts, "setGlobalApproval", operator, approved)
+}
+
+// SetGlobalApproval is a paid mutator transaction binding the contract method 0x7793533b.
+//
+// Solidity: function setGlobalApproval(address operator, bool approved) returns()
+func (_LazyMint1155 *LazyMint1155Session) SetGlobalApproval(operator common.Address, approved bool) (*types.Transaction, error) {
+	return _LazyMint1155.Contract.SetGlobalApproval(&_LazyMint1155.TransactOpts, operator, approved)
+}
+
+// SetGlobalApproval is a paid mutator transaction binding the contract method 0x7793533b.
+//
+// Solidity: function setGlobalApproval(address operator, bool approved) returns()
+func (_LazyMint1155 *LazyMint1155TransactorSession) SetGlobalApproval(operator common.Address, approved bool) (*types.Transaction, error) {
+	return _LazyMint1155.Contract.SetGlobalApproval(&_LazyMint1155.TransactOpts, operator, approved)
+}
+
+// SetGlobalApprovalOptOut is a paid mutator transaction binding the contract method 0xd94aeee3.
+//
+// Solidity: function setGlobalApprovalOptOut(bool optOut) returns()
+func (_LazyMint1155 *LazyMint1155Transactor) SetGlobalApprovalOptOut(opts *bind.TransactOpts, optOut bool) (*types.Transaction, error) {
+	return _LazyMint1155.contract.Transact(opts, "setGlobalApprovalOptOut", optOut)
+}
+
+// SetGlobalApprovalOptOut is a paid mutator transaction binding the contract method 0xd94aeee3.
+//
+// Solidity: function setGlobalApprovalOptOut(bool optOut) returns()
+func (_LazyMint1155 *LazyMint1155Session) SetGlobalApprovalOptOut(optOut bool) (*types.Transaction, error) {
+	return _LazyMint1155.Contract.SetGlobalApprovalOptOut(&_LazyMint1155.TransactOpts, optOut)
+}
+
+// SetGlobalApprovalOptOut is a paid mutator transaction binding the contract method 0xd94aeee3.
+//
+// Solidity: function setGlobalApprovalOptOut(bool optOut) returns()
+func (_LazyMint1155 *LazyMint1155TransactorSession) SetGlobalApprovalOptOut(optOut bool) (*types.Transaction, error) {
+	return _LazyMint1155.Contract.SetGlobalApprovalOptOut(&_LazyMint1155.TransactOpts, optOut)
+}
+
+// SetSigner is a paid mutator transaction binding the contract method 0x6c19e783.
+//
+// Solidity: function setSigner(address _signer) returns()
+func (_LazyMint1155 *LazyMint1155Transactor) SetSigner(opts *bind.TransactOpts, _signer common.Address) (*types.Transaction, error) {
+	return _LazyMint1155.contract.Transact(opts, "setSigner", _signer)
+}
+
+// SetSigner is a paid mutator transaction binding the contract method 0x6c19e783.
+//
+// Solidity: function setSigner(address _signer) returns()
+func (_LazyMint1155 *LazyMint1155Session) SetSigner(_signer common.Address) (*types.Transaction, error) {
+	return _LazyMint1155.Contract.SetSigner(&_LazyMint1155.TransactOpts, _signer)
+}
+
+// SetSigner is a paid mutator transaction binding the contract method 0x6c19e783.
+//
+// Solidity: function setSigner(address _signer) returns()
+func (_LazyMint1155 *LazyMint1155TransactorSession) SetSigner(_signer common.Address) (*types.Transaction, error) {
+	return _LazyMint1155.Contract.SetSigner(&_LazyMint1155.TransactOpts, _signer)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_LazyMint1155 *LazyMint1155Transactor) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return _LazyMint1155.contract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_LazyMint1155 *LazyMint1155Session) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _LazyMint1155.Contract.TransferOwnership(&_LazyMint1155.TransactOpts, newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_LazyMint1155 *LazyMint1155TransactorSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _LazyMint1155.Contract.TransferOwnership(&_LazyMint1155.TransactOpts, newOwner)
+}
+
+// LazyMint1155ApprovalForAllIterator is returned from FilterApprovalForAll and is used to iterate over the raw logs and unpacked data for ApprovalForAll events raised by the LazyMint1155 contract.
+type LazyMint1155ApprovalForAllIterator struct {
+	Event *LazyMint1155ApprovalForAll // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LazyMint1155ApprovalForAllIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LazyMint1155ApprovalForAll)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LazyMint1155ApprovalForAll)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LazyMint1155ApprovalForAllIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LazyMint1155ApprovalForAllIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LazyMint1155ApprovalForAll represents a ApprovalForAll event raised by the LazyMint1155 contract.
+type LazyMint1155ApprovalForAll struct {
+	Account  common.Address
+	Operator common.Address
+	Approved bool
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterApprovalForAll is a free log retrieval operation binding the contract event 0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31.
+//
+// Solidity: event ApprovalForAll(address indexed account, address indexed operator, bool approved)
+func (_LazyMint1155 *LazyMint1155Filterer) FilterApprovalForAll(opts *bind.FilterOpts, account []common.Address, operator []common.Address) (*LazyMint1155ApprovalForAllIterator, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _LazyMint1155.contract.FilterLogs(opts, "ApprovalForAll", accountRule, operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LazyMint1155ApprovalForAllIterator{contract: _LazyMint1155.contract, event: "ApprovalForAll", logs: logs, sub: sub}, nil
+}
+
+// WatchApprovalForAll is a free log subscription operation binding the contract event 0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31.
+//
+// Solidity: event ApprovalForAll(address indexed account, address indexed operator, bool approved)
+func (_LazyMint1155 *LazyMint1155Filterer) WatchApprovalForAll(opts *bind.WatchOpts, sink chan<- *LazyMint1155ApprovalForAll, account []common.Address, operator []common.Address) (event.Subscription, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _LazyMint1155.contract.WatchLogs(opts, "ApprovalForAll", accountRule, operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LazyMint1155ApprovalForAll)
+				if err := _LazyMint1155.contract.UnpackLog(event, "ApprovalForAll", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseApprovalForAll is a log parse operation binding the contract event 0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31.
+//
+// Solidity: event ApprovalForAll(address indexed account, address indexed operator, bool approved)
+func (_LazyMint1155 *LazyMint1155Filterer) ParseApprovalForAll(log types.Log) (*LazyMint1155ApprovalForAll, error) {
+	event := new(LazyMint1155ApprovalForAll)
+	if err := _LazyMint1155.contract.UnpackLog(event, "ApprovalForAll", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LazyMint1155EIP712DomainChangedIterator is returned from FilterEIP712DomainChanged and is used to iterate over the raw logs and unpacked data for EIP712DomainChanged events raised by the LazyMint1155 contract.
+type LazyMint1155EIP712DomainChangedIterator struct {
+	Event *LazyMint1155EIP712DomainChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LazyMint1155EIP712DomainChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LazyMint1155EIP712DomainChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LazyMint1155EIP712DomainChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LazyMint1155EIP712DomainChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LazyMint1155EIP712DomainChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LazyMint1155EIP712DomainChanged represents a EIP712DomainChanged event raised by the LazyMint1155 contract.
+type LazyMint1155EIP712DomainChanged struct {
+	Raw types.Log // Blockchain specific contextual infos
+}
+
+// FilterEIP712DomainChanged is a free log retrieval operation binding the contract event 0x0a6387c9ea3628b88a633bb4f3b151770f70085117a15f9bf3787cda53f13d31.
+//
+// Solidity: event EIP712DomainChanged()
+func (_LazyMint1155 *LazyMint1155Filterer) FilterEIP712DomainChanged(opts *bind.FilterOpts) (*LazyMint1155EIP712DomainChangedIterator, error) {
+
+	logs, sub, err := _LazyMint1155.contract.FilterLogs(opts, "EIP712DomainChanged")
+	if err != nil {
+		return nil, err
+	}
+	return &LazyMint1155EIP712DomainChangedIterator{contract: _LazyMint1155.contract, event: "EIP712DomainChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchEIP712DomainChanged is a free log subscription operation binding the contract event 0x0a6387c9ea3628b88a633bb4f3b151770f70085117a15f9bf3787cda53f13d31.
+//
+// Solidity: event EIP712DomainChanged()
+func (_LazyMint1155 *LazyMint1155Filterer) WatchEIP712DomainChanged(opts *bind.WatchOpts, sink chan<- *LazyMint1155EIP712DomainChanged) (event.Subscription, error) {
+
+	logs, sub, err := _LazyMint1155.contract.WatchLogs(opts, "EIP712DomainChanged")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LazyMint1155EIP712DomainChanged)
+				if err := _LazyMint1155.contract.UnpackLog(event, "EIP712DomainChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseEIP712DomainChanged is a log parse operation binding the contract event 0x0a6387c9ea3628b88a633bb4f3b151770f70085117a15f9bf3787cda53f13d31.
+//
+// Solidity: event EIP712DomainChanged()
+func (_LazyMint1155 *LazyMint1155Filterer) ParseEIP712DomainChanged(log types.Log) (*LazyMint1155EIP712DomainChanged, error) {
+	event := new(LazyMint1155EIP712DomainChanged)
+	if err := _LazyMint1155.contract.UnpackLog(event, "EIP712DomainChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LazyMint1155GlobalApprovalOptOutIterator is returned from FilterGlobalApprovalOptOut and is used to iterate over the raw logs and unpacked data for GlobalApprovalOptOut events raised by the LazyMint1155 contract.
+type LazyMint1155GlobalApprovalOptOutIterator struct {
+	Event *LazyMint1155GlobalApprovalOptOut // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LazyMint1155GlobalApprovalOptOutIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LazyMint1155GlobalApprovalOptOut)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LazyMint1155GlobalApprovalOptOut)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LazyMint1155GlobalApprovalOptOutIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LazyMint1155GlobalApprovalOptOutIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LazyMint1155GlobalApprovalOptOut represents a GlobalApprovalOptOut event raised by the LazyMint1155 contract.
+type LazyMint1155GlobalApprovalOptOut struct {
+	Holder   common.Address
+	OptedOut bool
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterGlobalApprovalOptOut is a free log retrieval operation binding the contract event 0x270b8f88e36f200de897b13870a0199d326df0a5a0e0ac32e889112845dd7358.
+//
+// Solidity: event GlobalApprovalOptOut(address indexed holder, bool optedOut)
+func (_LazyMint1155 *LazyMint1155Filterer) FilterGlobalApprovalOptOut(opts *bind.FilterOpts, holder []common.Address) (*LazyMint1155GlobalApprovalOptOutIterator, error) {
+
+	var holderRule []interface{}
+	for _, holderItem := range holder {
+		holderRule = append(holderRule, holderItem)
+	}
+
+	logs, sub, err := _LazyMint1155.contract.FilterLogs(opts, "GlobalApprovalOptOut", holderRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LazyMint1155GlobalApprovalOptOutIterator{contract: _LazyMint1155.contract, event: "GlobalApprovalOptOut", logs: logs, sub: sub}, nil
+}
+
+// WatchGlobalApprovalOptOut is a free log subscription operation binding the contract event 0x270b8f88e36f200de897b13870a0199d326df0a5a0e0ac32e889112845dd7358.
+//
+// Solidity: event GlobalApprovalOptOut(address indexed holder, bool optedOut)
+func (_LazyMint1155 *LazyMint1155Filterer) WatchGlobalApprovalOptOut(opts *bind.WatchOpts, sink chan<- *LazyMint1155GlobalApprovalOptOut, holder []common.Address) (event.Subscription, error) {
+
+	var holderRule []interface{}
+	for _, holderItem := range holder {
+		holderRule = append(holderRule, holderItem)
+	}
+
+	logs, sub, err := _LazyMint1155.contract.WatchLogs(opts, "GlobalApprovalOptOut", holderRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LazyMint1155GlobalApprovalOptOut)
+				if err := _LazyMint1155.contract.UnpackLog(event, "GlobalApprovalOptOut", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseGlobalApprovalOptOut is a log parse operation binding the contract event 0x270b8f88e36f200de897b13870a0199d326df0a5a0e0ac32e889112845dd7358.
+//
+// Solidity: event GlobalApprovalOptOut(address indexed holder, bool optedOut)
+func (_LazyMint1155 *LazyMint1155Filterer) ParseGlobalApprovalOptOut(log types.Log) (*LazyMint1155GlobalApprovalOptOut, error) {
+	event := new(LazyMint1155GlobalApprovalOptOut)
+	if err := _LazyMint1155.contract.UnpackLog(event, "GlobalApprovalOptOut", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LazyMint1155GlobalApprovalSetIterator is returned from FilterGlobalApprovalSet and is used to iterate over the raw logs and unpacked data for GlobalApprovalSet events raised by the LazyMint1155 contract.
+type LazyMint1155GlobalApprovalSetIterator struct {
+	Event *LazyMint1155GlobalApprovalSet // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LazyMint1155GlobalApprovalSetIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LazyMint1155GlobalApprovalSet)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LazyMint1155GlobalApprovalSet)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LazyMint1155GlobalApprovalSetIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LazyMint1155GlobalApprovalSetIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LazyMint1155GlobalApprovalSet represents a GlobalApprovalSet event raised by the LazyMint1155 contract.
+type LazyMint1155GlobalApprovalSet struct {
+	Operator common.Address
+	Approved bool
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterGlobalApprovalSet is a free log retrieval operation binding the contract event 0x55e9b849461170707f294e61f80adeeebbfe3710af8a980ec12519a85a851777.
+//
+// Solidity: event GlobalApprovalSet(address indexed operator, bool approved)
+func (_LazyMint1155 *LazyMint1155Filterer) FilterGlobalApprovalSet(opts *bind.FilterOpts, operator []common.Address) (*LazyMint1155GlobalApprovalSetIterator, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _LazyMint1155.contract.FilterLogs(opts, "GlobalApprovalSet", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LazyMint1155GlobalApprovalSetIterator{contract: _LazyMint1155.contract, event: "GlobalApprovalSet", logs: logs, sub: sub}, nil
+}
+
+// WatchGlobalApprovalSet is a free log subscription operation binding the contract event 0x55e9b849461170707f294e61f80adeeebbfe3710af8a980ec12519a85a851777.
+//
+// Solidity: event GlobalApprovalSet(address indexed operator, bool approved)
+func (_LazyMint1155 *LazyMint1155Filterer) WatchGlobalApprovalSet(opts *bind.WatchOpts, sink chan<- *LazyMint1155GlobalApprovalSet, operator []common.Address) (event.Subscription, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+
+	logs, sub, err := _LazyMint1155.contract.WatchLogs(opts, "GlobalApprovalSet", operatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LazyMint1155GlobalApprovalSet)
+				if err := _LazyMint1155.contract.UnpackLog(event, "GlobalApprovalSet", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseGlobalApprovalSet is a log parse operation binding the contract event 0x55e9b849461170707f294e61f80adeeebbfe3710af8a980ec12519a85a851777.
+//
+// Solidity: event GlobalApprovalSet(address indexed operator, bool approved)
+func (_LazyMint1155 *LazyMint1155Filterer) ParseGlobalApprovalSet(log types.Log) (*LazyMint1155GlobalApprovalSet, error) {
+	event := new(LazyMint1155GlobalApprovalSet)
+	if err := _LazyMint1155.contract.UnpackLog(event, "GlobalApprovalSet", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LazyMint1155OwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the LazyMint1155 contract.
+type LazyMint1155OwnershipTransferredIterator struct {
+	Event *LazyMint1155OwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LazyMint1155OwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LazyMint1155OwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LazyMint1155OwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LazyMint1155OwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LazyMint1155OwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LazyMint1155OwnershipTransferred represents a OwnershipTransferred event raised by the LazyMint1155 contract.
+type LazyMint1155OwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_LazyMint1155 *LazyMint1155Filterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*LazyMint1155OwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _LazyMint1155.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LazyMint1155OwnershipTransferredIterator{contract: _LazyMint1155.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_LazyMint1155 *LazyMint1155Filterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *LazyMint1155OwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _LazyMint1155.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LazyMint1155OwnershipTransferred)
+				if err := _LazyMint1155.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_LazyMint1155 *LazyMint1155Filterer) ParseOwnershipTransferred(log types.Log) (*LazyMint1155OwnershipTransferred, error) {
+	event := new(LazyMint1155OwnershipTransferred)
+	if err := _LazyMint1155.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LazyMint1155TransferBatchIterator is returned from FilterTransferBatch and is used to iterate over the raw logs and unpacked data for TransferBatch events raised by the LazyMint1155 contract.
+type LazyMint1155TransferBatchIterator struct {
+	Event *LazyMint1155TransferBatch // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LazyMint1155TransferBatchIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LazyMint1155TransferBatch)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LazyMint1155TransferBatch)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LazyMint1155TransferBatchIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LazyMint1155TransferBatchIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LazyMint1155TransferBatch represents a TransferBatch event raised by the LazyMint1155 contract.
+type LazyMint1155TransferBatch struct {
+	Operator common.Address
+	From     common.Address
+	To       common.Address
+	Ids      []*big.Int
+	Values   []*big.Int
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterTransferBatch is a free log retrieval operation binding the contract event 0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb.
+//
+// Solidity: event TransferBatch(address indexed operator, address indexed from, address indexed to, uint256[] ids, uint256[] values)
+func (_LazyMint1155 *LazyMint1155Filterer) FilterTransferBatch(opts *bind.FilterOpts, operator []common.Address, from []common.Address, to []common.Address) (*LazyMint1155TransferBatchIterator, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _LazyMint1155.contract.FilterLogs(opts, "TransferBatch", operatorRule, fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LazyMint1155TransferBatchIterator{contract: _LazyMint1155.contract, event: "TransferBatch", logs: logs, sub: sub}, nil
+}
+
+// WatchTransferBatch is a free log subscription operation binding the contract event 0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb.
+//
+// Solidity: event TransferBatch(address indexed operator, address indexed from, address indexed to, uint256[] ids, uint256[] values)
+func (_LazyMint1155 *LazyMint1155Filterer) WatchTransferBatch(opts *bind.WatchOpts, sink chan<- *LazyMint1155TransferBatch, operator []common.Address, from []common.Address, to []common.Address) (event.Subscription, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _LazyMint1155.contract.WatchLogs(opts, "TransferBatch", operatorRule, fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LazyMint1155TransferBatch)
+				if err := _LazyMint1155.contract.UnpackLog(event, "TransferBatch", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTransferBatch is a log parse operation binding the contract event 0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb.
+//
+// Solidity: event TransferBatch(address indexed operator, address indexed from, address indexed to, uint256[] ids, uint256[] values)
+func (_LazyMint1155 *LazyMint1155Filterer) ParseTransferBatch(log types.Log) (*LazyMint1155TransferBatch, error) {
+	event := new(LazyMint1155TransferBatch)
+	if err := _LazyMint1155.contract.UnpackLog(event, "TransferBatch", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LazyMint1155TransferSingleIterator is returned from FilterTransferSingle and is used to iterate over the raw logs and unpacked data for TransferSingle events raised by the LazyMint1155 contract.
+type LazyMint1155TransferSingleIterator struct {
+	Event *LazyMint1155TransferSingle // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LazyMint1155TransferSingleIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LazyMint1155TransferSingle)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LazyMint1155TransferSingle)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LazyMint1155TransferSingleIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LazyMint1155TransferSingleIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LazyMint1155TransferSingle represents a TransferSingle event raised by the LazyMint1155 contract.
+type LazyMint1155TransferSingle struct {
+	Operator common.Address
+	From     common.Address
+	To       common.Address
+	Id       *big.Int
+	Value    *big.Int
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterTransferSingle is a free log retrieval operation binding the contract event 0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62.
+//
+// Solidity: event TransferSingle(address indexed operator, address indexed from, address indexed to, uint256 id, uint256 value)
+func (_LazyMint1155 *LazyMint1155Filterer) FilterTransferSingle(opts *bind.FilterOpts, operator []common.Address, from []common.Address, to []common.Address) (*LazyMint1155TransferSingleIterator, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _LazyMint1155.contract.FilterLogs(opts, "TransferSingle", operatorRule, fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LazyMint1155TransferSingleIterator{contract: _LazyMint1155.contract, event: "TransferSingle", logs: logs, sub: sub}, nil
+}
+
+// WatchTransferSingle is a free log subscription operation binding the contract event 0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62.
+//
+// Solidity: event TransferSingle(address indexed operator, address indexed from, address indexed to, uint256 id, uint256 value)
+func (_LazyMint1155 *LazyMint1155Filterer) WatchTransferSingle(opts *bind.WatchOpts, sink chan<- *LazyMint1155TransferSingle, operator []common.Address, from []common.Address, to []common.Address) (event.Subscription, error) {
+
+	var operatorRule []interface{}
+	for _, operatorItem := range operator {
+		operatorRule = append(operatorRule, operatorItem)
+	}
+	var fromRule []interface{}
+	for _, fromItem := range from {
+		fromRule = append(fromRule, fromItem)
+	}
+	var toRule []interface{}
+	for _, toItem := range to {
+		toRule = append(toRule, toItem)
+	}
+
+	logs, sub, err := _LazyMint1155.contract.WatchLogs(opts, "TransferSingle", operatorRule, fromRule, toRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LazyMint1155TransferSingle)
+				if err := _LazyMint1155.contract.UnpackLog(event, "TransferSingle", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTransferSingle is a log parse operation binding the contract event 0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62.
+//
+// Solidity: event TransferSingle(address indexed operator, address indexed from, address indexed to, uint256 id, uint256 value)
+func (_LazyMint1155 *LazyMint1155Filterer) ParseTransferSingle(log types.Log) (*LazyMint1155TransferSingle, error) {
+	event := new(LazyMint1155TransferSingle)
+	if err := _LazyMint1155.contract.UnpackLog(event, "TransferSingle", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LazyMint1155URIIterator is returned from FilterURI and is used to iterate over the raw logs and unpacked data for URI events raised by the LazyMint1155 contract.
+type LazyMint1155URIIterator struct {
+	Event *LazyMint1155URI // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LazyMint1155URIIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LazyMint1155URI)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LazyMint1155URI)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LazyMint1155URIIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LazyMint1155URIIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LazyMint1155URI represents a URI event raised by the LazyMint1155 contract.
+type LazyMint1155URI struct {
+	Value string
+	Id    *big.Int
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterURI is a free log retrieval operation binding the contract event 0x6bb7ff708619ba0610cba295a58592e0451dee2622938c8755667688daf3529b.
+//
+// Solidity: event URI(string value, uint256 indexed id)
+func (_LazyMint1155 *LazyMint1155Filterer) FilterURI(opts *bind.FilterOpts, id []*big.Int) (*LazyMint1155URIIterator, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+
+	logs, sub, err := _LazyMint1155.contract.FilterLogs(opts, "URI", idRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LazyMint1155URIIterator{contract: _LazyMint1155.contract, event: "URI", logs: logs, sub: sub}, nil
+}
+
+// WatchURI is a free log subscription operation binding the contract event 0x6bb7ff708619ba0610cba295a58592e0451dee2622938c8755667688daf3529b.
+//
+// Solidity: event URI(string value, uint256 indexed id)
+func (_LazyMint1155 *LazyMint1155Filterer) WatchURI(opts *bind.WatchOpts, sink chan<- *LazyMint1155URI, id []*big.Int) (event.Subscription, error) {
+
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+
+	logs, sub, err := _LazyMint1155.contract.WatchLogs(opts, "URI", idRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LazyMint1155URI)
+				if err := _LazyMint1155.contract.UnpackLog(event, "URI", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseURI is a log parse operation binding the contract event 0x6bb7ff708619ba0610cba295a58592e0451dee2622938c8755667688daf3529b.
+//
+// Solidity: event URI(string value, uint256 indexed id)
+func (_LazyMint1155 *LazyMint1155Filterer) ParseURI(log types.Log) (*LazyMint1155URI, error) {
+	event := new(LazyMint1155URI)
+	if err := _LazyMint1155.contract.UnpackLog(event, "URI", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
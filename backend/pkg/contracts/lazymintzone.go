@@ -0,0 +1,646 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// ReceivedItem is an auto generated low-level Go binding around an user-defined struct.
+type ReceivedItem struct {
+	ItemType   uint8
+	Token      common.Address
+	Identifier *big.Int
+	Amount     *big.Int
+	Recipient  common.Address
+}
+
+// Schema is an auto generated low-level Go binding around an user-defined struct.
+type Schema struct {
+	Id       *big.Int
+	Metadata []byte
+}
+
+// SpentItem is an auto generated low-level Go binding around an user-defined struct.
+type SpentItem struct {
+	ItemType   uint8
+	Token      common.Address
+	Identifier *big.Int
+	Amount     *big.Int
+}
+
+// ZoneParameters is an auto generated low-level Go binding around an user-defined struct.
+type ZoneParameters struct {
+	OrderHash     [32]byte
+	Fulfiller     common.Address
+	Offerer       common.Address
+	Offer         []SpentItem
+	Consideration []ReceivedItem
+	ExtraData     []byte
+	OrderHashes   [][32]byte
+	StartTime     *big.Int
+	EndTime       *big.Int
+	ZoneHash      [32]byte
+}
+
+// LazyMintZoneMetaData contains all meta data concerning the LazyMintZone contract.
+var LazyMintZoneMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_signer\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_nft\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"OwnableInvalidOwner\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"OwnableUnauthorizedAccount\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"inputs\":[{\"components\":[{\"internalType\":\"bytes32\",\"name\":\"orderHash\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"fulfiller\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"offerer\",\"type\":\"address\"},{\"components\":[{\"internalType\":\"enumItemType\",\"name\":\"itemType\",\"type\":\"uint8\"},{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"identifier\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"internalType\":\"structSpentItem[]\",\"name\":\"offer\",\"type\":\"tuple[]\"},{\"components\":[{\"internalType\":\"enumItemType\",\"name\":\"itemType\",\"type\":\"uint8\"},{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"identifier\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"addresspayable\",\"name\":\"recipient\",\"type\":\"address\"}],\"internalType\":\"structReceivedItem[]\",\"name\":\"consideration\",\"type\":\"tuple[]\"},{\"internalType\":\"bytes\",\"name\":\"extraData\",\"type\":\"bytes\"},{\"internalType\":\"bytes32[]\",\"name\":\"orderHashes\",\"type\":\"bytes32[]\"},{\"internalType\":\"uint256\",\"name\":\"startTime\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"endTime\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"zoneHash\",\"type\":\"bytes32\"}],\"internalType\":\"structZoneParameters\",\"name\":\"zoneParameters\",\"type\":\"tuple\"}],\"name\":\"authorizeOrder\",\"outputs\":[{\"internalType\":\"bytes4\",\"name\":\"\",\"type\":\"bytes4\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getSeaportMetadata\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"components\":[{\"internalType\":\"uint256\",\"name\":\"id\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"metadata\",\"type\":\"bytes\"}],\"internalType\":\"structSchema[]\",\"name\":\"schemas\",\"type\":\"tuple[]\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"nft\",\"outputs\":[{\"internalType\":\"contractLazyMint1155\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"renounceOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_nft\",\"type\":\"address\"}],\"name\":\"setNftAddress\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes4\",\"name\":\"interfaceId\",\"type\":\"bytes4\"}],\"name\":\"supportsInterface\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"bytes32\",\"name\":\"orderHash\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"fulfiller\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"offerer\",\"type\":\"address\"},{\"components\":[{\"internalType\":\"enumItemType\",\"name\":\"itemType\",\"type\":\"uint8\"},{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"identifier\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"internalType\":\"structSpentItem[]\",\"name\":\"offer\",\"type\":\"tuple[]\"},{\"components\":[{\"internalType\":\"enumItemType\",\"name\":\"itemType\",\"type\":\"uint8\"},{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"identifier\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"addresspayable\",\"name\":\"recipient\",\"type\":\"address\"}],\"internalType\":\"structReceivedItem[]\",\"name\":\"consideration\",\"type\":\"tuple[]\"},{\"internalType\":\"bytes\",\"name\":\"extraData\",\"type\":\"bytes\"},{\"internalType\":\"bytes32[]\",\"name\":\"orderHashes\",\"type\":\"bytes32[]\"},{\"internalType\":\"uint256\",\"name\":\"startTime\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"endTime\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"zoneHash\",\"type\":\"bytes32\"}],\"internalType\":\"structZoneParameters\",\"name\":\"\",\"type\":\"tuple\"}],\"name\":\"validateOrder\",\"outputs\":[{\"internalType\":\"bytes4\",\"name\":\"\",\"type\":\"bytes4\"}],\"stateMutability\":\"pure\",\"type\":\"function\"}]",
+	Bin: "0x608060405234801561001057600080fd5b5060405161149938038061149983398181016040528101906100329190610224565b81600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff16036100a55760006040517f1e4fbdf700000000000000000000000000000000000000000000000000000000815260040161009c9190610273565b60405180910390fd5b6100b4816100fd60201b60201c565b5080600160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550505061028e565b60008060009054906101000a900473ffffffffffffffffffffffffffffffffffffffff169050816000806101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff1602179055508173ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff167f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e060405160405180910390a35050565b600080fd5b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b60006101f1826101c6565b9050919050565b610201816101e6565b811461020c57600080fd5b50565b60008151905061021e816101f8565b92915050565b6000806040838503121561023b5761023a6101c1565b5b60006102498582860161020f565b925050602061025a8582860161020f565b9150509250929050565b61026d816101e6565b82525050565b60006020820190506102886000830184610264565b92915050565b6111fc8061029d6000396000f3fe608060405234801561001057600080fd5b50600436106100935760003560e01c80632e778efc116100665780632e778efc1461014457806347ccca0214610163578063715018a6146101815780638da5cb5b1461018b578063f2fde38b146101a957610093565b806301e4d72a1461009857806301ffc9a7146100c85780630b102d1a146100f857806317b1f94214610114575b600080fd5b6100b260048036038101906100ad9190610809565b6101c5565b6040516100bf919061088d565b60405180910390f35b6100e260048036038101906100dd91906108d4565b610298565b6040516100ef919061091c565b60405180910390f35b610112600480360381019061010d9190610995565b610312565b005b61012e60048036038101906101299190610809565b6103cd565b60405161013b919061088d565b60405180910390f35b61014c6103de565b60405161015a929190610bbf565b60405180910390f35b61016b610510565b6040516101789190610c55565b60405180910390f35b610189610536565b005b61019361054a565b6040516101a09190610c7f565b60405180910390f35b6101c360048036038101906101be9190610995565b610573565b005b600080828060a001906101d89190610ca9565b8101906101e59190610fdc565b9050600160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff166353086991828560400160208101906102399190610995565b6040518363ffffffff1660e01b81526004016102569291906110fb565b600060405180830381600087803b15801561027057600080fd5b505af1158015610284573d6000803e3d6000fd5b505050506301e4d72a60e01b915050919050565b60007f39dd6933000000000000000000000000000000000000000000000000000000007bffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916827bffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916148061030b575061030a826105f9565b5b9050919050565b61031a610663565b600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff1603610389576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040161038090611177565b60405180910390fd5b80600160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555050565b60006301e4d72a60e01b9050919050565b606080600167ffffffffffffffff8111156103fc576103fb610d11565b5b60405190808252806020026020018201604052801561043557816020015b6104226107b6565b81526020019060019003908161041a5790505b509050610bbb8160008151811061044f5761044e611197565b5b60200260200101516000018181525050600067ffffffffffffffff81111561047a57610479610d11565b5b6040519080825280601f01601f1916602001820160405280156104ac5781602001600182028036833780820191505090505b50816000815181106104c1576104c0611197565b5b602002602001015160200181905250806040518060400160405280600b81526020017f417274696172745a6f6e6500000000000000000000000000000000000000000081525090915091509091565b600160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b61053e610663565b61054860006106ea565b565b60008060009054906101000a900473ffffffffffffffffffffffffffffffffffffffff16905090565b61057b610663565b600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff16036105ed5760006040517f1e4fbdf70000000000000000000000000000000000000000000000000000000081526004016105e49190610c7f565b60405180910390fd5b6105f6816106ea565b50565b60007f01ffc9a7000000000000000000000000000000000000000000000000000000007bffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916827bffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916149050919050565b61066b6107ae565b73ffffffffffffffffffffffffffffffffffffffff1661068961054a565b73ffffffffffffffffffffffffffffffffffffffff16146106e8576106ac6107ae565b6040517f118cdaa70000000000000000000000000000000000000000000000000000000081526004016106df9190610c7f565b60405180910390fd5b565b60008060009054906101000a900473ffffffffffffffffffffffffffffffffffffffff169050816000806101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff1602179055508173ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff167f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e060405160405180910390a35050565b600033905090565b604051806040016040528060008152602001606081525090565b6000604051905090565b600080fd5b600080fd5b600080fd5b60006101408284031215610800576107ff6107e4565b5b81905092915050565b60006020828403121561081f5761081e6107da565b5b600082013567ffffffffffffffff81111561083d5761083c6107df565b5b610849848285016107e9565b91505092915050565b60007fffffffff0000000000000000000000000000000000000000000000000000000082169050919050565b61088781610852565b82525050565b60006020820190506108a2600083018461087e565b92915050565b6108b181610852565b81146108bc57600080fd5b50565b6000813590506108ce816108a8565b92915050565b6000602082840312156108ea576108e96107da565b5b60006108f8848285016108bf565b91505092915050565b60008115159050919050565b61091681610901565b82525050565b6000602082019050610931600083018461090d565b92915050565b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b600061096282610937565b9050919050565b61097281610957565b811461097d57600080fd5b50565b60008135905061098f81610969565b92915050565b6000602082840312156109ab576109aa6107da565b5b60006109b984828501610980565b91505092915050565b600081519050919050565b600082825260208201905092915050565b60005b838110156109fc5780820151818401526020810190506109e1565b60008484015250505050565b6000601f19601f8301169050919050565b6000610a24826109c2565b610a2e81856109cd565b9350610a3e8185602086016109de565b610a4781610a08565b840191505092915050565b600081519050919050565b600082825260208201905092915050565b6000819050602082019050919050565b6000819050919050565b610a9181610a7e565b82525050565b600081519050919050565b600082825260208201905092915050565b6000610abe82610a97565b610ac88185610aa2565b9350610ad88185602086016109de565b610ae181610a08565b840191505092915050565b6000604083016000830151610b046000860182610a88565b5060208301518482036020860152610b1c8282610ab3565b9150508091505092915050565b6000610b358383610aec565b905092915050565b6000602082019050919050565b6000610b5582610a52565b610b5f8185610a5d565b935083602082028501610b7185610a6e565b8060005b85811015610bad5784840389528151610b8e8582610b29565b9450610b9983610b3d565b925060208a01995050600181019050610b75565b50829750879550505050505092915050565b60006040820190508181036000830152610bd98185610a19565b90508181036020830152610bed8184610b4a565b90509392505050565b6000819050919050565b6000610c1b610c16610c1184610937565b610bf6565b610937565b9050919050565b6000610c2d82610c00565b9050919050565b6000610c3f82610c22565b9050919050565b610c4f81610c34565b82525050565b6000602082019050610c6a6000830184610c46565b92915050565b610c7981610957565b82525050565b6000602082019050610c946000830184610c70565b92915050565b600080fd5b600080fd5b600080fd5b60008083356001602003843603038112610cc657610cc5610c9a565b5b80840192508235915067ffffffffffffffff821115610ce857610ce7610c9f565b5b602083019250600182023603831315610d0457610d03610ca4565b5b509250929050565b600080fd5b7f4e487b7100000000000000000000000000000000000000000000000000000000600052604160045260246000fd5b610d4982610a08565b810181811067ffffffffffffffff82111715610d6857610d67610d11565b5b80604052505050565b6000610d7b6107d0565b9050610d878282610d40565b919050565b600080fd5b610d9a81610a7e565b8114610da557600080fd5b50565b600081359050610db781610d91565b92915050565b600080fd5b600080fd5b600067ffffffffffffffff821115610de257610de1610d11565b5b610deb82610a08565b9050602081019050919050565b82818337600083830152505050565b6000610e1a610e1584610dc7565b610d71565b905082815260208101848484011115610e3657610e35610dc2565b5b610e41848285610df8565b509392505050565b600082601f830112610e5e57610e5d610dbd565b5b8135610e6e848260208601610e07565b91505092915050565b600067ffffffffffffffff821115610e9257610e91610d11565b5b610e9b82610a08565b9050602081019050919050565b6000610ebb610eb684610e77565b610d71565b905082815260208101848484011115610ed757610ed6610dc2565b5b610ee2848285610df8565b509392505050565b600082601f830112610eff57610efe610dbd565b5b8135610f0f848260208601610ea8565b91505092915050565b600060a08284031215610f2e57610f2d610d0c565b5b610f3860a0610d71565b90506000610f4884828501610980565b6000830152506020610f5c84828501610da8565b6020830152506040610f7084828501610da8565b604083015250606082013567ffffffffffffffff811115610f9457610f93610d8c565b5b610fa084828501610e49565b606083015250608082013567ffffffffffffffff811115610fc457610fc3610d8c565b5b610fd084828501610eea565b60808301525092915050565b600060208284031215610ff257610ff16107da565b5b600082013567ffffffffffffffff8111156110105761100f6107df565b5b61101c84828501610f18565b91505092915050565b61102e81610957565b82525050565b600082825260208201905092915050565b6000611050826109c2565b61105a8185611034565b935061106a8185602086016109de565b61107381610a08565b840191505092915050565b600060a0830160008301516110966000860182611025565b5060208301516110a96020860182610a88565b5060408301516110bc6040860182610a88565b50606083015184820360608601526110d48282611045565b915050608083015184820360808601526110ee8282610ab3565b9150508091505092915050565b60006040820190508181036000830152611115818561107e565b90506111246020830184610c70565b9392505050565b7f496e76616c6964204e4654206164647265737300000000000000000000000000600082015250565b60006111616013836109cd565b915061116c8261112b565b602082019050919050565b6000602082019050818103600083015261119081611154565b9050919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052603260045260246000fdfea26469706673582212203aaf6ec4593b90feaf8fd3ccccb7e0d5135018429804aa454729843d6d21cfa964736f6c634300081c0033",
+}
+
+// LazyMintZoneABI is the input ABI used to generate the binding from.
+// Deprecated: Use LazyMintZoneMetaData.ABI instead.
+var LazyMintZoneABI = LazyMintZoneMetaData.ABI
+
+// LazyMintZoneBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use LazyMintZoneMetaData.Bin instead.
+var LazyMintZoneBin = LazyMintZoneMetaData.Bin
+
+// DeployLazyMintZone deploys a new Ethereum contract, binding an instance of LazyMintZone to it.
+func DeployLazyMintZone(auth *bind.TransactOpts, backend bind.ContractBackend, _signer common.Address, _nft common.Address) (common.Address, *types.Transaction, *LazyMintZone, error) {
+	parsed, err := LazyMintZoneMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(LazyMintZoneBin), backend, _signer, _nft)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &LazyMintZone{LazyMintZoneCaller: LazyMintZoneCaller{contract: contract}, LazyMintZoneTransactor: LazyMintZoneTransactor{contract: contract}, LazyMintZoneFilterer: LazyMintZoneFilterer{contract: contract}}, nil
+}
+
+// LazyMintZone is an auto generated Go binding around an Ethereum contract.
+type LazyMintZone struct {
+	LazyMintZoneCaller     // Read-only binding to the contract
+	LazyMintZoneTransactor // Write-only binding to the contract
+	LazyMintZoneFilterer   // Log filterer for contract events
+}
+
+// LazyMintZoneCaller is an auto generated read-only Go binding around an Ethereum contract.
+type LazyMintZoneCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// LazyMintZoneTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type LazyMintZoneTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// LazyMintZoneFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type LazyMintZoneFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// LazyMintZoneSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type LazyMintZoneSession struct {
+	Contract     *LazyMintZone     // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// LazyMintZoneCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type LazyMintZoneCallerSession struct {
+	Contract *LazyMintZoneCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts       // Call options to use throughout this session
+}
+
+// LazyMintZoneTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type LazyMintZoneTransactorSession struct {
+	Contract     *LazyMintZoneTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// LazyMintZoneRaw is an auto generated low-level Go binding around an Ethereum contract.
+type LazyMintZoneRaw struct {
+	Contract *LazyMintZone // Generic contract binding to access the raw methods on
+}
+
+// LazyMintZoneCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type LazyMintZoneCallerRaw struct {
+	Contract *LazyMintZoneCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// LazyMintZoneTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type LazyMintZoneTransactorRaw struct {
+	Contract *LazyMintZoneTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewLazyMintZone creates a new instance of LazyMintZone, bound to a specific deployed contract.
+func NewLazyMintZone(address common.Address, backend bind.ContractBackend) (*LazyMintZone, error) {
+	contract, err := bindLazyMintZone(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &LazyMintZone{LazyMintZoneCaller: LazyMintZoneCaller{contract: contract}, LazyMintZoneTransactor: LazyMintZoneTransactor{contract: contract}, LazyMintZoneFilterer: LazyMintZoneFilterer{contract: contract}}, nil
+}
+
+// NewLazyMintZoneCaller creates a new read-only instance of LazyMintZone, bound to a specific deployed contract.
+func NewLazyMintZoneCaller(address common.Address, caller bind.ContractCaller) (*LazyMintZoneCaller, error) {
+	contract, err := bindLazyMintZone(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &LazyMintZoneCaller{contract: contract}, nil
+}
+
+// NewLazyMintZoneTransactor creates a new write-only instance of LazyMintZone, bound to a specific deployed contract.
+func NewLazyMintZoneTransactor(address common.Address, transactor bind.ContractTransactor) (*LazyMintZoneTransactor, error) {
+	contract, err := bindLazyMintZone(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &LazyMintZoneTransactor{contract: contract}, nil
+}
+
+// NewLazyMintZoneFilterer creates a new log filterer instance of LazyMintZone, bound to a specific deployed contract.
+func NewLazyMintZoneFilterer(address common.Address, filterer bind.ContractFilterer) (*LazyMintZoneFilterer, error) {
+	contract, err := bindLazyMintZone(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &LazyMintZoneFilterer{contract: contract}, nil
+}
+
+// bindLazyMintZone binds a generic wrapper to an already deployed contract.
+func bindLazyMintZone(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := LazyMintZoneMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_LazyMintZone *LazyMintZoneRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _LazyMintZone.Contract.LazyMintZoneCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_LazyMintZone *LazyMintZoneRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _LazyMintZone.Contract.LazyMintZoneTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_LazyMintZone *LazyMintZoneRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _LazyMintZone.Contract.LazyMintZoneTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_LazyMintZone *LazyMintZoneCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _LazyMintZone.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_LazyMintZone *LazyMintZoneTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _LazyMintZone.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_LazyMintZone *LazyMintZoneTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _LazyMintZone.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetSeaportMetadata is a free data retrieval call binding the contract method 0x2e778efc.
+//
+// Solidity: function getSeaportMetadata() pure returns(string name, (uint256,bytes)[] schemas)
+func (_LazyMintZone *LazyMintZoneCaller) GetSeaportMetadata(opts *bind.CallOpts) (struct {
+	Name    string
+	Schemas []Schema
+}, error) {
+	var out []interface{}
+	err := _LazyMintZone.contract.Call(opts, &out, "getSeaportMetadata")
+
+	outstruct := new(struct {
+		Name    string
+		Schemas []Schema
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Name = *abi.ConvertType(out[0], new(string)).(*string)
+	outstruct.Schemas = *abi.ConvertType(out[1], new([]Schema)).(*[]Schema)
+
+	return *outstruct, err
+
+}
+
+// GetSeaportMetadata is a free data retrieval call binding the contract method 0x2e778efc.
+//
+// Solidity: function getSeaportMetadata() pure returns(string name, (uint256,bytes)[] schemas)
+func (_LazyMintZone *LazyMintZoneSession) GetSeaportMetadata() (struct {
+	Name    string
+	Schemas []Schema
+}, error) {
+	return _LazyMintZone.Contract.GetSeaportMetadata(&_LazyMintZone.CallOpts)
+}
+
+// GetSeaportMetadata is a free data retrieval call binding the contract method 0x2e778efc.
+//
+// Solidity: function getSeaportMetadata() pure returns(string name, (uint256,bytes)[] schemas)
+func (_LazyMintZone *LazyMintZoneCallerSession) GetSeaportMetadata() (struct {
+	Name    string
+	Schemas []Schema
+}, error) {
+	return _LazyMintZone.Contract.GetSeaportMetadata(&_LazyMintZone.CallOpts)
+}
+
+// Nft is a free data retrieval call binding the contract method 0x47ccca02.
+//
+// Solidity: function nft() view returns(address)
+func (_LazyMintZone *LazyMintZoneCaller) Nft(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _LazyMintZone.contract.Call(opts, &out, "nft")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Nft is a free data retrieval call binding the contract method 0x47ccca02.
+//
+// Solidity: function nft() view returns(address)
+func (_LazyMintZone *LazyMintZoneSession) Nft() (common.Address, error) {
+	return _LazyMintZone.Contract.Nft(&_LazyMintZone.CallOpts)
+}
+
+// Nft is a free data retrieval call binding the contract method 0x47ccca02.
+//
+// Solidity: function nft() view returns(address)
+func (_LazyMintZone *LazyMintZoneCallerSession) Nft() (common.Address, error) {
+	return _LazyMintZone.Contract.Nft(&_LazyMintZone.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_LazyMintZone *LazyMintZoneCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _LazyMintZone.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_LazyMintZone *LazyMintZoneSession) Owner() (common.Address, error) {
+	return _LazyMintZone.Contract.Owner(&_LazyMintZone.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_LazyMintZone *LazyMintZoneCallerSession) Owner() (common.Address, error) {
+	return _LazyMintZone.Contract.Owner(&_LazyMintZone.CallOpts)
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_LazyMintZone *LazyMintZoneCaller) SupportsInterface(opts *bind.CallOpts, interfaceId [4]byte) (bool, error) {
+	var out []interface{}
+	err := _LazyMintZone.contract.Call(opts, &out, "supportsInterface", interfaceId)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_LazyMintZone *LazyMintZoneSession) SupportsInterface(interfaceId [4]byte) (bool, error) {
+	return _LazyMintZone.Contract.SupportsInterface(&_LazyMintZone.CallOpts, interfaceId)
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_LazyMintZone *LazyMintZoneCallerSession) SupportsInterface(interfaceId [4]byte) (bool, error) {
+	return _LazyMintZone.Contract.SupportsInterface(&_LazyMintZone.CallOpts, interfaceId)
+}
+
+// ValidateOrder is a free data retrieval call binding the contract method 0x17b1f942.
+//
+// Solidity: function validateOrder((bytes32,address,address,(uint8,address,uint256,uint256)[],(uint8,address,uint256,uint256,address)[],bytes,bytes32[],uint256,uint256,bytes32) ) pure returns(bytes4)
+func (_LazyMintZone *LazyMintZoneCaller) ValidateOrder(opts *bind.CallOpts, arg0 ZoneParameters) ([4]byte, error) {
+	var out []interface{}
+	err := _LazyMintZone.contract.Call(opts, &out, "validateOrder", arg0)
+
+	if err != nil {
+		return *new([4]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([4]byte)).(*[4]byte)
+
+	return out0, err
+
+}
+
+// ValidateOrder is a free data retrieval call binding the contract method 0x17b1f942.
+//
+// Solidity: function validateOrder((bytes32,address,address,(uint8,address,uint256,uint256)[],(uint8,address,uint256,uint256,address)[],bytes,bytes32[],uint256,uint256,bytes32) ) pure returns(bytes4)
+func (_LazyMintZone *LazyMintZoneSession) ValidateOrder(arg0 ZoneParameters) ([4]byte, error) {
+	return _LazyMintZone.Contract.ValidateOrder(&_LazyMintZone.CallOpts, arg0)
+}
+
+// ValidateOrder is a free data retrieval call binding the contract method 0x17b1f942.
+//
+// Solidity: function validateOrder((bytes32,address,address,(uint8,address,uint256,uint256)[],(uint8,address,uint256,uint256,address)[],bytes,bytes32[],uint256,uint256,bytes32) ) pure returns(bytes4)
+func (_LazyMintZone *LazyMintZoneCallerSession) ValidateOrder(arg0 ZoneParameters) ([4]byte, error) {
+	return _LazyMintZone.Contract.ValidateOrder(&_LazyMintZone.CallOpts, arg0)
+}
+
+// AuthorizeOrder is a paid mutator transaction binding the contract method 0x01e4d72a.
+//
+// Solidity: function authorizeOrder((bytes32,address,address,(uint8,address,uint256,uint256)[],(uint8,address,uint256,uint256,address)[],bytes,bytes32[],uint256,uint256,bytes32) zoneParameters) returns(bytes4)
+func (_LazyMintZone *LazyMintZoneTransactor) AuthorizeOrder(opts *bind.TransactOpts, zoneParameters ZoneParameters) (*types.Transaction, error) {
+	return _LazyMintZone.contract.Transact(opts, "authorizeOrder", zoneParameters)
+}
+
+// AuthorizeOrder is a paid mutator transaction binding the contract method 0x01e4d72a.
+//
+// Solidity: function authorizeOrder((bytes32,address,address,(uint8,address,uint256,uint256)[],(uint8,address,uint256,uint256,address)[],bytes,bytes32[],uint256,uint256,bytes32) zoneParameters) returns(bytes4)
+func (_LazyMintZone *LazyMintZoneSession) AuthorizeOrder(zoneParameters ZoneParameters) (*types.Transaction, error) {
+	return _LazyMintZone.Contract.AuthorizeOrder(&_LazyMintZone.TransactOpts, zoneParameters)
+}
+
+// AuthorizeOrder is a paid mutator transaction binding the contract method 0x01e4d72a.
+//
+// Solidity: function authorizeOrder((bytes32,address,address,(uint8,address,uint256,uint256)[],(uint8,address,uint256,uint256,address)[],bytes,bytes32[],uint256,uint256,bytes32) zoneParameters) returns(bytes4)
+func (_LazyMintZone *LazyMintZoneTransactorSession) AuthorizeOrder(zoneParameters ZoneParameters) (*types.Transaction, error) {
+	return _LazyMintZone.Contract.AuthorizeOrder(&_LazyMintZone.TransactOpts, zoneParameters)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_LazyMintZone *LazyMintZoneTransactor) RenounceOwnership(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _LazyMintZone.contract.Transact(opts, "renounceOwnership")
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_LazyMintZone *LazyMintZoneSession) RenounceOwnership() (*types.Transaction, error) {
+	return _LazyMintZone.Contract.RenounceOwnership(&_LazyMintZone.TransactOpts)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_LazyMintZone *LazyMintZoneTransactorSession) RenounceOwnership() (*types.Transaction, error) {
+	return _LazyMintZone.Contract.RenounceOwnership(&_LazyMintZone.TransactOpts)
+}
+
+// SetNftAddress is a paid mutator transaction binding the contract method 0x0b102d1a.
+//
+// Solidity: function setNftAddress(address _nft) returns()
+func (_LazyMintZone *LazyMintZoneTransactor) SetNftAddress(opts *bind.TransactOpts, _nft common.Address) (*types.Transaction, error) {
+	return _LazyMintZone.contract.Transact(opts, "setNftAddress", _nft)
+}
+
+// SetNftAddress is a paid mutator transaction binding the contract method 0x0b102d1a.
+//
+// Solidity: function setNftAddress(address _nft) returns()
+func (_LazyMintZone *LazyMintZoneSession) SetNftAddress(_nft common.Address) (*types.Transaction, error) {
+	return _LazyMintZone.Contract.SetNftAddress(&_LazyMintZone.TransactOpts, _nft)
+}
+
+// SetNftAddress is a paid mutator transaction binding the contract method 0x0b102d1a.
+//
+// Solidity: function setNftAddress(address _nft) returns()
+func (_LazyMintZone *LazyMintZoneTransactorSession) SetNftAddress(_nft common.Address) (*types.Transaction, error) {
+	return _LazyMintZone.Contract.SetNftAddress(&_LazyMintZone.TransactOpts, _nft)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_LazyMintZone *LazyMintZoneTransactor) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return _LazyMintZone.contract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_LazyMintZone *LazyMintZoneSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _LazyMintZone.Contract.TransferOwnership(&_LazyMintZone.TransactOpts, newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_LazyMintZone *LazyMintZoneTransactorSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _LazyMintZone.Contract.TransferOwnership(&_LazyMintZone.TransactOpts, newOwner)
+}
+
+// LazyMintZoneOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the LazyMintZone contract.
+type LazyMintZoneOwnershipTransferredIterator struct {
+	Event *LazyMintZoneOwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *LazyMintZoneOwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(LazyMintZoneOwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(LazyMintZoneOwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *LazyMintZoneOwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *LazyMintZoneOwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// LazyMintZoneOwnershipTransferred represents a OwnershipTransferred event raised by the LazyMintZone contract.
+type LazyMintZoneOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_LazyMintZone *LazyMintZoneFilterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*LazyMintZoneOwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _LazyMintZone.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &LazyMintZoneOwnershipTransferredIterator{contract: _LazyMintZone.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_LazyMintZone *LazyMintZoneFilterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *LazyMintZoneOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _LazyMintZone.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(LazyMintZoneOwnershipTransferred)
+				if err := _LazyMintZone.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_LazyMintZone *LazyMintZoneFilterer) ParseOwnershipTransferred(log types.Log) (*LazyMintZoneOwnershipTransferred, error) {
+	event := new(LazyMintZoneOwnershipTransferred)
+	if err := _LazyMintZone.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,125 @@
+package contracts
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// EIP-712 domain LazyMint1155 passes to its EIP712 constructor
+const (
+	VoucherDomainName    = "LazyMint1155"
+	VoucherDomainVersion = "1"
+)
+
+var (
+	eip712DomainTypeHash = crypto.Keccak256Hash([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+	voucherTypeHash      = crypto.Keccak256Hash([]byte("Voucher(address owner,uint256 tokenId,uint256 amount,string uri)"))
+
+	bytes32Type, _ = abi.NewType("bytes32", "", nil)
+	uint256Type, _ = abi.NewType("uint256", "", nil)
+	addressType, _ = abi.NewType("address", "", nil)
+)
+
+// VoucherDomainSeparator mirrors EIP712._domainSeparatorV4 of a LazyMint1155
+// deployed at verifyingContract
+func VoucherDomainSeparator(chainID *big.Int, verifyingContract common.Address) common.Hash {
+	encoded, _ := abi.Arguments{{Type: bytes32Type}, {Type: bytes32Type}, {Type: bytes32Type}, {Type: uint256Type}, {Type: addressType}}.Pack(
+		eip712DomainTypeHash,
+		crypto.Keccak256Hash([]byte(VoucherDomainName)),
+		crypto.Keccak256Hash([]byte(VoucherDomainVersion)),
+		chainID,
+		verifyingContract,
+	)
+	return crypto.Keccak256Hash(encoded)
+}
+
+// VoucherStructHash mirrors the struct hash built in LazyMint1155._hash.
+// The signature field is not part of the hash.
+func VoucherStructHash(v LazyMint1155Voucher) common.Hash {
+	encoded, _ := abi.Arguments{{Type: bytes32Type}, {Type: addressType}, {Type: uint256Type}, {Type: uint256Type}, {Type: bytes32Type}}.Pack(
+		voucherTypeHash,
+		v.Owner,
+		v.TokenId,
+		v.Amount,
+		crypto.Keccak256Hash([]byte(v.Uri)),
+	)
+	return crypto.Keccak256Hash(encoded)
+}
+
+// VoucherDigest is the hash the contract's signer must sign for v
+func VoucherDigest(chainID *big.Int, verifyingContract common.Address, v LazyMint1155Voucher) common.Hash {
+	domain := VoucherDomainSeparator(chainID, verifyingContract)
+	structHash := VoucherStructHash(v)
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, domain.Bytes(), structHash.Bytes())
+}
+
+// VoucherTypedData describes v as eth_signTypedData_v4 input, for clients
+// that want to display or re-verify what was signed
+func VoucherTypedData(chainID *big.Int, verifyingContract common.Address, v LazyMint1155Voucher) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"Voucher": {
+				{Name: "owner", Type: "address"},
+				{Name: "tokenId", Type: "uint256"},
+				{Name: "amount", Type: "uint256"},
+				{Name: "uri", Type: "string"},
+			},
+		},
+		PrimaryType: "Voucher",
+		Domain: apitypes.TypedDataDomain{
+			Name:              VoucherDomainName,
+			Version:           VoucherDomainVersion,
+			ChainId:           (*math.HexOrDecimal256)(chainID),
+			VerifyingContract: verifyingContract.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"owner":   v.Owner.Hex(),
+			"tokenId": v.TokenId.String(),
+			"amount":  v.Amount.String(),
+			"uri":     v.Uri,
+		},
+	}
+}
+
+// EncodeVoucher ABI-encodes v the way LazyMintZone decodes it from
+// zoneParameters.extraData
+func EncodeVoucher(v LazyMint1155Voucher) ([]byte, error) {
+	parsed, err := LazyMint1155MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	method, ok := parsed.Methods["mintIfNotExists"]
+	if !ok || len(method.Inputs) == 0 {
+		return nil, fmt.Errorf("LazyMint1155 abi has no mintIfNotExists(Voucher,address)")
+	}
+	return abi.Arguments{method.Inputs[0]}.Pack(v)
+}
+
+// DecodeVoucher reverses EncodeVoucher, as LazyMintZone's abi.decode does
+func DecodeVoucher(data []byte) (LazyMint1155Voucher, error) {
+	parsed, err := LazyMint1155MetaData.GetAbi()
+	if err != nil {
+		return LazyMint1155Voucher{}, err
+	}
+	method, ok := parsed.Methods["mintIfNotExists"]
+	if !ok || len(method.Inputs) == 0 {
+		return LazyMint1155Voucher{}, fmt.Errorf("LazyMint1155 abi has no mintIfNotExists(Voucher,address)")
+	}
+	values, err := abi.Arguments{method.Inputs[0]}.Unpack(data)
+	if err != nil {
+		return LazyMint1155Voucher{}, err
+	}
+	return *abi.ConvertType(values[0], new(LazyMint1155Voucher)).(*LazyMint1155Voucher), nil
+}
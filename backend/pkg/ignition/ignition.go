@@ -0,0 +1,232 @@
+// Package ignition reads Hardhat Ignition deployment folders.
+//
+// A deployment folder, ignition/deployments/chain-<id>, holds
+// deployed_addresses.json mapping future IDs such as
+// "LazyMint1155ModuleV1#LazyMint1155" to addresses, one Hardhat artifact per
+// future under artifacts/, and journal.jsonl, the log Ignition appends to as
+// it deploys. The journal gives the chain and the order futures completed in,
+// so when a module is redeployed under a new future ID the latest one wins.
+package ignition
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const chainDirPrefix = "chain-"
+
+// Contract is a contract Ignition deployed
+type Contract struct {
+	FutureID         string
+	Name             string
+	Address          common.Address
+	ABI              abi.ABI
+	DeployedBytecode []byte // runtime code, immutables left as zeros
+}
+
+// Deployment is the deployment folder of one chain
+type Deployment struct {
+	ChainID int64
+	Dir     string
+	// Contracts in the order they were deployed
+	Contracts []*Contract
+}
+
+// Latest returns the contract named name deployed last, or nil
+func (d *Deployment) Latest(name string) *Contract {
+	for i := len(d.Contracts) - 1; i >= 0; i-- {
+		if d.Contracts[i].Name == name {
+			return d.Contracts[i]
+		}
+	}
+	return nil
+}
+
+// MatchesCode reports whether code, read from the chain at c.Address, is the
+// runtime code of the artifact. Immutables are zeros in the artifact and set
+// by the constructor on chain, so only the artifact's non-zero bytes must
+// agree; the compiler metadata hash at the end still pins the exact build.
+func (c *Contract) MatchesCode(code []byte) bool {
+	if len(code) == 0 || len(code) != len(c.DeployedBytecode) {
+		return false
+	}
+	for i, b := range c.DeployedBytecode {
+		if b != 0 && code[i] != b {
+			return false
+		}
+	}
+	return true
+}
+
+type artifact struct {
+	ContractName     string          `json:"contractName"`
+	ABI              json.RawMessage `json:"abi"`
+	DeployedBytecode string          `json:"deployedBytecode"`
+}
+
+type journalEntry struct {
+	Type     string `json:"type"`
+	ChainID  int64  `json:"chainId"`
+	FutureID string `json:"futureId"`
+	Result   *struct {
+		Type string `json:"type"`
+	} `json:"result"`
+}
+
+// LoadAll reads every chain-<id> folder under root, such as
+// contracts/ignition/deployments
+func LoadAll(root string) ([]*Deployment, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deployments: %w", err)
+	}
+	var deployments []*Deployment
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), chainDirPrefix) {
+			continue
+		}
+		deployment, err := Load(filepath.Join(root, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		deployments = append(deployments, deployment)
+	}
+	if len(deployments) == 0 {
+		return nil, fmt.Errorf("no %s* deployments in %s", chainDirPrefix, root)
+	}
+	return deployments, nil
+}
+
+// Load reads one deployment folder
+func Load(dir string) (*Deployment, error) {
+	name, ok := strings.CutPrefix(filepath.Base(dir), chainDirPrefix)
+	chainID, err := strconv.ParseInt(name, 10, 64)
+	if !ok || err != nil || chainID <= 0 {
+		return nil, fmt.Errorf("%s is not named %s<chain id>", dir, chainDirPrefix)
+	}
+	deployment := &Deployment{ChainID: chainID, Dir: dir}
+
+	raw, err := os.ReadFile(filepath.Join(dir, "deployed_addresses.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deployed addresses of chain %d: %w", chainID, err)
+	}
+	var addresses map[string]string
+	if err := json.Unmarshal(raw, &addresses); err != nil {
+		return nil, fmt.Errorf("failed to decode deployed addresses of chain %d: %w", chainID, err)
+	}
+
+	order, err := completionOrder(filepath.Join(dir, "journal.jsonl"), chainID)
+	if err != nil {
+		return nil, err
+	}
+	futureIDs := make([]string, 0, len(addresses))
+	for futureID := range addresses {
+		futureIDs = append(futureIDs, futureID)
+	}
+	// futures missing from the journal go last, in a stable order
+	slices.SortFunc(futureIDs, func(a, b string) int {
+		ia, oka := order[a]
+		ib, okb := order[b]
+		switch {
+		case oka && okb:
+			return ia - ib
+		case oka:
+			return -1
+		case okb:
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+
+	for _, futureID := range futureIDs {
+		address := addresses[futureID]
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("%s on chain %d has invalid address %q", futureID, chainID, address)
+		}
+		contract, err := loadArtifact(filepath.Join(dir, "artifacts", futureID+".json"))
+		if err != nil {
+			return nil, fmt.Errorf("%s on chain %d: %w", futureID, chainID, err)
+		}
+		contract.FutureID = futureID
+		contract.Address = common.HexToAddress(address)
+		deployment.Contracts = append(deployment.Contracts, contract)
+	}
+	return deployment, nil
+}
+
+// completionOrder maps the futures that deployed successfully to their
+// position in the journal, checking the journal is for chainID
+func completionOrder(path string, chainID int64) (map[string]int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]int{}, nil
+		}
+		return nil, fmt.Errorf("failed to read journal of chain %d: %w", chainID, err)
+	}
+	defer f.Close()
+
+	order := make(map[string]int)
+	scanner := bufio.NewScanner(f)
+	// entries carry whole creation transactions
+	scanner.Buffer(make([]byte, 0, 1<<20), 16<<20)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var entry journalEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, fmt.Errorf("failed to decode journal of chain %d: %w", chainID, err)
+		}
+		switch entry.Type {
+		case "DEPLOYMENT_INITIALIZE":
+			if entry.ChainID != chainID {
+				return nil, fmt.Errorf("journal of chain %d was written for chain %d", chainID, entry.ChainID)
+			}
+		case "DEPLOYMENT_EXECUTION_STATE_COMPLETE":
+			if entry.Result != nil && entry.Result.Type == "SUCCESS" {
+				order[entry.FutureID] = len(order)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read journal of chain %d: %w", chainID, err)
+	}
+	return order, nil
+}
+
+func loadArtifact(path string) (*Contract, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifact: %w", err)
+	}
+	var a artifact
+	if err := json.Unmarshal(raw, &a); err != nil {
+		return nil, fmt.Errorf("failed to decode artifact: %w", err)
+	}
+	if a.ContractName == "" || len(a.ABI) == 0 {
+		return nil, fmt.Errorf("%s is not a Hardhat contract artifact", path)
+	}
+	parsed, err := abi.JSON(bytes.NewReader(a.ABI))
+	if err != nil {
+		return nil, fmt.Errorf("invalid abi: %w", err)
+	}
+	code, err := hexutil.Decode(a.DeployedBytecode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deployed bytecode: %w", err)
+	}
+	return &Contract{Name: a.ContractName, ABI: parsed, DeployedBytecode: code}, nil
+}
@@ -0,0 +1,187 @@
+package ignition
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/igwedaniel/artizan/pkg/contracts"
+)
+
+// the Hardhat artifacts the bindings of pkg/contracts are generated from
+const artifactsDir = "../../../contracts/ignition/deployments/chain-97/artifacts"
+
+const (
+	collectionFuture = "LazyMint1155ModuleV1#LazyMint1155"
+	zoneFuture       = "lazyMintZoneV3#LazyMintZone"
+	// an earlier build of the zone, whose code differs
+	oldZoneFuture = "lazyMintZone#LazyMintZone"
+)
+
+// writeDeployment writes a chain-<chainID> folder deploying the futures of
+// addresses with the artifacts of artifactsDir, journaling completed in
+// order
+func writeDeployment(t *testing.T, chainID int64, addresses map[string]common.Address, completed ...string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), fmt.Sprintf("chain-%d", chainID))
+	if err := os.MkdirAll(filepath.Join(dir, "artifacts"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	deployed := make(map[string]string, len(addresses))
+	for futureID, address := range addresses {
+		deployed[futureID] = address.Hex()
+		raw, err := os.ReadFile(filepath.Join(artifactsDir, futureID+".json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "artifacts", futureID+".json"), raw, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	raw, err := json.Marshal(deployed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "deployed_addresses.json"), raw, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	journal := []string{fmt.Sprintf(`{"chainId":%d,"type":"DEPLOYMENT_INITIALIZE"}`, chainID)}
+	for _, futureID := range completed {
+		journal = append(journal,
+			fmt.Sprintf(`{"futureId":%q,"type":"DEPLOYMENT_EXECUTION_STATE_INITIALIZE"}`, futureID),
+			fmt.Sprintf(`{"futureId":%q,"result":{"type":"SUCCESS"},"type":"DEPLOYMENT_EXECUTION_STATE_COMPLETE"}`, futureID))
+	}
+	if err := os.WriteFile(filepath.Join(dir, "journal.jsonl"), []byte(strings.Join(journal, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// deployOnSimulated deploys the collection and zone bindings and returns
+// the backend with their addresses
+func deployOnSimulated(t *testing.T) (*simulated.Backend, common.Address, common.Address) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	deployer := crypto.PubkeyToAddress(key.PublicKey)
+	backend := simulated.NewBackend(types.GenesisAlloc{deployer: {Balance: new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18))}})
+	t.Cleanup(func() { backend.Close() })
+
+	opts := &bind.TransactOpts{
+		From: deployer,
+		Signer: func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return types.SignTx(tx, types.LatestSignerForChainID(big.NewInt(1337)), key)
+		},
+	}
+	collection, _, _, err := contracts.DeployLazyMint1155(opts, backend.Client(), deployer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zone, _, _, err := contracts.DeployLazyMintZone(opts, backend.Client(), deployer, collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend.Commit()
+	return backend, collection, zone
+}
+
+func TestMatchesCodeOnChain(t *testing.T) {
+	backend, collection, zone := deployOnSimulated(t)
+	deployment, err := Load(writeDeployment(t, 1337, map[string]common.Address{
+		collectionFuture: collection,
+		zoneFuture:       zone,
+		oldZoneFuture:    zone,
+	}, collectionFuture, oldZoneFuture, zoneFuture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	code := func(address common.Address) []byte {
+		t.Helper()
+		code, err := backend.Client().CodeAt(ctx, address, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return code
+	}
+
+	byFuture := make(map[string]*Contract)
+	for _, c := range deployment.Contracts {
+		byFuture[c.FutureID] = c
+	}
+	// the collection's immutables, such as its EIP-712 domain, are set on
+	// chain and zeros in the artifact
+	if !byFuture[collectionFuture].MatchesCode(code(collection)) {
+		t.Fatal("the collection's code does not match its artifact")
+	}
+	if !byFuture[zoneFuture].MatchesCode(code(zone)) {
+		t.Fatal("the zone's code does not match its artifact")
+	}
+	if byFuture[oldZoneFuture].MatchesCode(code(zone)) {
+		t.Fatal("the zone's code matches the artifact of another build")
+	}
+	if byFuture[collectionFuture].MatchesCode(code(zone)) {
+		t.Fatal("the zone's code matches the collection's artifact")
+	}
+	if byFuture[collectionFuture].MatchesCode(nil) {
+		t.Fatal("an address without code matches the collection's artifact")
+	}
+}
+
+func TestLatestFollowsTheJournal(t *testing.T) {
+	addresses := map[string]common.Address{
+		collectionFuture: common.HexToAddress("0x01"),
+		zoneFuture:       common.HexToAddress("0x02"),
+		oldZoneFuture:    common.HexToAddress("0x03"),
+	}
+	for _, tt := range []struct {
+		completed []string
+		want      string
+	}{
+		{[]string{collectionFuture, oldZoneFuture, zoneFuture}, zoneFuture},
+		{[]string{collectionFuture, zoneFuture, oldZoneFuture}, oldZoneFuture},
+		// futures missing from the journal go after the journaled ones
+		{[]string{collectionFuture, zoneFuture}, oldZoneFuture},
+	} {
+		deployment, err := Load(writeDeployment(t, 97, addresses, tt.completed...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		latest := deployment.Latest("LazyMintZone")
+		if latest == nil || latest.FutureID != tt.want || latest.Address != addresses[tt.want] {
+			t.Fatalf("journal %v: latest zone = %+v, want %s", tt.completed, latest, tt.want)
+		}
+	}
+
+	deployment, err := Load(writeDeployment(t, 97, map[string]common.Address{collectionFuture: common.HexToAddress("0x01")}, collectionFuture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest := deployment.Latest("LazyMintZone"); latest != nil {
+		t.Fatalf("latest zone = %s, want none deployed", latest.FutureID)
+	}
+}
+
+func TestLoadRejectsAJournalOfAnotherChain(t *testing.T) {
+	dir := writeDeployment(t, 97, map[string]common.Address{collectionFuture: common.HexToAddress("0x01")}, collectionFuture)
+	renamed := filepath.Join(filepath.Dir(dir), "chain-56")
+	if err := os.Rename(dir, renamed); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(renamed); err == nil || !strings.Contains(err.Error(), "written for chain 97") {
+		t.Fatalf("err = %v, want the journal's chain rejected", err)
+	}
+}
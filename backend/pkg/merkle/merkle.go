@@ -0,0 +1,105 @@
+// Package merkle builds Merkle trees the way OpenZeppelin's
+// StandardMerkleTree does, so roots and proofs agree with
+// @openzeppelin/merkle-tree and verify with MerkleProof.verify.
+//
+// Leaves are double hashed, keccak256(keccak256(abi.encode(values))), which
+// keeps a leaf from ever being mistaken for an inner node. Inner nodes hash
+// their children in sorted order, and the tree is laid out in an array with
+// the leaves, sorted by hash, filling its end in reverse.
+package merkle
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	ErrEmptyTree     = errors.New("merkle tree needs at least one leaf")
+	ErrDuplicateLeaf = errors.New("merkle tree has the same leaf twice")
+	ErrUnknownLeaf   = errors.New("leaf is not in the merkle tree")
+)
+
+// Tree is a built Merkle tree
+type Tree struct {
+	nodes []common.Hash
+	// position of each leaf in nodes
+	index map[common.Hash]int
+}
+
+// AddressAmountLeaf is the leaf of an [address, uint256] value, the
+// encoding StandardMerkleTree.of(values, ["address", "uint256"]) uses
+func AddressAmountLeaf(account common.Address, amount *big.Int) common.Hash {
+	encoded := make([]byte, 0, 64)
+	encoded = append(encoded, common.LeftPadBytes(account.Bytes(), 32)...)
+	encoded = append(encoded, common.LeftPadBytes(amount.Bytes(), 32)...)
+	inner := crypto.Keccak256(encoded)
+	return crypto.Keccak256Hash(inner)
+}
+
+// New builds the tree of leaves
+func New(leaves []common.Hash) (*Tree, error) {
+	if len(leaves) == 0 {
+		return nil, ErrEmptyTree
+	}
+	sorted := slices.Clone(leaves)
+	slices.SortFunc(sorted, func(a, b common.Hash) int { return bytes.Compare(a[:], b[:]) })
+
+	n := len(sorted)
+	t := &Tree{nodes: make([]common.Hash, 2*n-1), index: make(map[common.Hash]int, n)}
+	for i, leaf := range sorted {
+		if _, ok := t.index[leaf]; ok {
+			return nil, ErrDuplicateLeaf
+		}
+		pos := len(t.nodes) - 1 - i
+		t.nodes[pos] = leaf
+		t.index[leaf] = pos
+	}
+	for i := len(t.nodes) - 1 - n; i >= 0; i-- {
+		t.nodes[i] = hashPair(t.nodes[2*i+1], t.nodes[2*i+2])
+	}
+	return t, nil
+}
+
+// Root is the hash contracts verify proofs against
+func (t *Tree) Root() common.Hash {
+	return t.nodes[0]
+}
+
+// Proof returns the sibling hashes from leaf up to the root
+func (t *Tree) Proof(leaf common.Hash) ([]common.Hash, error) {
+	i, ok := t.index[leaf]
+	if !ok {
+		return nil, ErrUnknownLeaf
+	}
+	proof := []common.Hash{}
+	for i > 0 {
+		sibling := i - 1
+		if i%2 == 1 {
+			sibling = i + 1
+		}
+		proof = append(proof, t.nodes[sibling])
+		i = (i - 1) / 2
+	}
+	return proof, nil
+}
+
+// Verify reports whether proof leads from leaf to root, as MerkleProof.verify does
+func Verify(root, leaf common.Hash, proof []common.Hash) bool {
+	computed := leaf
+	for _, sibling := range proof {
+		computed = hashPair(computed, sibling)
+	}
+	return computed == root
+}
+
+func hashPair(a, b common.Hash) common.Hash {
+	if bytes.Compare(a[:], b[:]) > 0 {
+		a, b = b, a
+	}
+	return crypto.Keccak256Hash(a[:], b[:])
+}
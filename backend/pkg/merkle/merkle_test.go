@@ -0,0 +1,97 @@
+package merkle
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The example tree of the @openzeppelin/merkle-tree README, built by
+// StandardMerkleTree.of(values, ["address", "uint256"]) and dumped with
+// tree.dump()
+var (
+	ozValues = []struct {
+		account common.Address
+		amount  string
+	}{
+		{common.HexToAddress("0x1111111111111111111111111111111111111111"), "5000000000000000000"},
+		{common.HexToAddress("0x2222222222222222222222222222222222222222"), "2500000000000000000"},
+	}
+	ozTree = []common.Hash{
+		common.HexToHash("0xd4dee0beab2d53f2cc83e567171bd2820e49898130a22622b10ead383e90bd77"),
+		common.HexToHash("0xeb02c421cfa48976e66dfb29120745909ea3a0f843456c263cf8f1253483e283"),
+		common.HexToHash("0xb92c48e9d7abe27fd8dfd6b5dfdbfb1c9a463f80c712b66f3a5180a090cccafc"),
+	}
+	// tree index of each value
+	ozTreeIndex = []int{1, 2}
+)
+
+func TestMatchesOpenZeppelinStandardMerkleTree(t *testing.T) {
+	leaves := make([]common.Hash, len(ozValues))
+	for i, v := range ozValues {
+		amount, _ := new(big.Int).SetString(v.amount, 10)
+		leaves[i] = AddressAmountLeaf(v.account, amount)
+		if want := ozTree[ozTreeIndex[i]]; leaves[i] != want {
+			t.Fatalf("leaf of value %d = %s, want %s", i, leaves[i].Hex(), want.Hex())
+		}
+	}
+
+	tree, err := New(leaves)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(tree.nodes, ozTree) {
+		t.Fatalf("tree = %v, want %v", tree.nodes, ozTree)
+	}
+	if tree.Root() != ozTree[0] {
+		t.Fatalf("root = %s, want %s", tree.Root().Hex(), ozTree[0].Hex())
+	}
+	for i, leaf := range leaves {
+		proof, err := tree.Proof(leaf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the only sibling of either leaf is the other one
+		want := []common.Hash{ozTree[3-ozTreeIndex[i]]}
+		if !slices.Equal(proof, want) {
+			t.Fatalf("proof of value %d = %v, want %v", i, proof, want)
+		}
+	}
+}
+
+func TestProofsVerifyInUnbalancedTrees(t *testing.T) {
+	for n := 1; n <= 9; n++ {
+		leaves := make([]common.Hash, n)
+		for i := range leaves {
+			leaves[i] = AddressAmountLeaf(common.BigToAddress(big.NewInt(int64(i+1))), big.NewInt(int64(i+1)))
+		}
+		tree, err := New(leaves)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, leaf := range leaves {
+			proof, err := tree.Proof(leaf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !Verify(tree.Root(), leaf, proof) {
+				t.Fatalf("proof of leaf %d of %d does not verify", i, n)
+			}
+			if Verify(tree.Root(), AddressAmountLeaf(common.BigToAddress(big.NewInt(int64(i+1))), big.NewInt(0)), proof) {
+				t.Fatalf("proof of leaf %d of %d verifies another amount", i, n)
+			}
+		}
+	}
+}
+
+func TestRejectsEmptyAndDuplicateTrees(t *testing.T) {
+	if _, err := New(nil); err != ErrEmptyTree {
+		t.Fatalf("err = %v, want %v", err, ErrEmptyTree)
+	}
+	leaf := AddressAmountLeaf(common.HexToAddress("0x1111111111111111111111111111111111111111"), big.NewInt(1))
+	if _, err := New([]common.Hash{leaf, leaf}); err != ErrDuplicateLeaf {
+		t.Fatalf("err = %v, want %v", err, ErrDuplicateLeaf)
+	}
+}
@@ -0,0 +1,155 @@
+package seaport
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// the Seaport 1.6 events the backend indexes
+const eventsABI = `[
+	{"type":"event","name":"OrderFulfilled","anonymous":false,"inputs":[
+		{"name":"orderHash","type":"bytes32","indexed":false},
+		{"name":"offerer","type":"address","indexed":true},
+		{"name":"zone","type":"address","indexed":true},
+		{"name":"recipient","type":"address","indexed":false},
+		{"name":"offer","type":"tuple[]","indexed":false,"components":[
+			{"name":"itemType","type":"uint8"},
+			{"name":"token","type":"address"},
+			{"name":"identifier","type":"uint256"},
+			{"name":"amount","type":"uint256"}]},
+		{"name":"consideration","type":"tuple[]","indexed":false,"components":[
+			{"name":"itemType","type":"uint8"},
+			{"name":"token","type":"address"},
+			{"name":"identifier","type":"uint256"},
+			{"name":"amount","type":"uint256"},
+			{"name":"recipient","type":"address"}]}]},
+	{"type":"event","name":"OrderCancelled","anonymous":false,"inputs":[
+		{"name":"orderHash","type":"bytes32","indexed":false},
+		{"name":"offerer","type":"address","indexed":true},
+		{"name":"zone","type":"address","indexed":true}]},
+	{"type":"event","name":"CounterIncremented","anonymous":false,"inputs":[
+		{"name":"newCounter","type":"uint256","indexed":false},
+		{"name":"offerer","type":"address","indexed":true}]}
+]`
+
+var (
+	events, _ = abi.JSON(strings.NewReader(eventsABI))
+
+	OrderFulfilledTopic     = events.Events["OrderFulfilled"].ID
+	OrderCancelledTopic     = events.Events["OrderCancelled"].ID
+	CounterIncrementedTopic = events.Events["CounterIncremented"].ID
+
+	errWrongEvent = errors.New("log is not the expected seaport event")
+)
+
+// SpentItem is an item an order's offerer gave up
+type SpentItem struct {
+	ItemType   ItemType
+	Token      common.Address
+	Identifier *big.Int
+	Amount     *big.Int
+}
+
+// ReceivedItem is an item paid to Recipient
+type ReceivedItem struct {
+	ItemType   ItemType
+	Token      common.Address
+	Identifier *big.Int
+	Amount     *big.Int
+	Recipient  common.Address
+}
+
+// OrderFulfilled is emitted for every order filled, fully or in part.
+// Recipient is who received the offer items, the buyer of a listing.
+type OrderFulfilled struct {
+	OrderHash     common.Hash
+	Offerer       common.Address
+	Zone          common.Address
+	Recipient     common.Address
+	Offer         []SpentItem
+	Consideration []ReceivedItem
+}
+
+type OrderCancelled struct {
+	OrderHash common.Hash
+	Offerer   common.Address
+	Zone      common.Address
+}
+
+// CounterIncremented cancels every order the offerer signed under an older counter
+type CounterIncremented struct {
+	NewCounter *big.Int
+	Offerer    common.Address
+}
+
+func ParseOrderFulfilled(entry types.Log) (*OrderFulfilled, error) {
+	var raw struct {
+		OrderHash [32]byte
+		Recipient common.Address
+		Offer     []struct {
+			ItemType   uint8
+			Token      common.Address
+			Identifier *big.Int
+			Amount     *big.Int
+		}
+		Consideration []struct {
+			ItemType   uint8
+			Token      common.Address
+			Identifier *big.Int
+			Amount     *big.Int
+			Recipient  common.Address
+		}
+	}
+	if err := unpack(&raw, "OrderFulfilled", entry, 3); err != nil {
+		return nil, err
+	}
+	event := &OrderFulfilled{
+		OrderHash: raw.OrderHash,
+		Offerer:   common.BytesToAddress(entry.Topics[1].Bytes()),
+		Zone:      common.BytesToAddress(entry.Topics[2].Bytes()),
+		Recipient: raw.Recipient,
+	}
+	for _, item := range raw.Offer {
+		event.Offer = append(event.Offer, SpentItem{ItemType(item.ItemType), item.Token, item.Identifier, item.Amount})
+	}
+	for _, item := range raw.Consideration {
+		event.Consideration = append(event.Consideration, ReceivedItem{ItemType(item.ItemType), item.Token, item.Identifier, item.Amount, item.Recipient})
+	}
+	return event, nil
+}
+
+func ParseOrderCancelled(entry types.Log) (*OrderCancelled, error) {
+	var raw struct {
+		OrderHash [32]byte
+	}
+	if err := unpack(&raw, "OrderCancelled", entry, 3); err != nil {
+		return nil, err
+	}
+	return &OrderCancelled{
+		OrderHash: raw.OrderHash,
+		Offerer:   common.BytesToAddress(entry.Topics[1].Bytes()),
+		Zone:      common.BytesToAddress(entry.Topics[2].Bytes()),
+	}, nil
+}
+
+func ParseCounterIncremented(entry types.Log) (*CounterIncremented, error) {
+	var raw struct {
+		NewCounter *big.Int
+	}
+	if err := unpack(&raw, "CounterIncremented", entry, 2); err != nil {
+		return nil, err
+	}
+	return &CounterIncremented{NewCounter: raw.NewCounter, Offerer: common.BytesToAddress(entry.Topics[1].Bytes())}, nil
+}
+
+func unpack(out interface{}, name string, entry types.Log, topics int) error {
+	if len(entry.Topics) != topics || entry.Topics[0] != events.Events[name].ID {
+		return errWrongEvent
+	}
+	return events.UnpackIntoInterface(out, name, entry.Data)
+}
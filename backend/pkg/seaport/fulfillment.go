@@ -0,0 +1,69 @@
+package seaport
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Side is Seaport's enum Side
+type Side uint8
+
+const (
+	SideOffer Side = iota
+	SideConsideration
+)
+
+// CriteriaResolver names the token a fulfiller supplies for a criteria item.
+// CriteriaProof is empty for items whose criteria is zero.
+type CriteriaResolver struct {
+	OrderIndex    uint64        `json:"orderIndex"`
+	Side          Side          `json:"side"`
+	Index         uint64        `json:"index"`
+	Identifier    string        `json:"identifier"`
+	CriteriaProof []common.Hash `json:"criteriaProof"`
+}
+
+var conduitABI, _ = abi.JSON(strings.NewReader(`[
+	{"type":"function","name":"information","stateMutability":"view","inputs":[],"outputs":[
+		{"name":"version","type":"string"},{"name":"domainSeparator","type":"bytes32"},{"name":"conduitController","type":"address"}]},
+	{"type":"function","name":"getConduit","stateMutability":"view","inputs":[{"name":"conduitKey","type":"bytes32"}],"outputs":[
+		{"name":"conduit","type":"address"},{"name":"exists","type":"bool"}]}
+]`))
+
+// GetConduit returns the account that moves tokens for orders and
+// fulfillments using conduitKey, the one to approve: Seaport itself for the
+// zero key, otherwise the conduit its controller deployed for the key.
+// Conduits that do not exist yet come back as the zero address.
+func GetConduit(ctx context.Context, caller bind.ContractCaller, seaport common.Address, conduitKey common.Hash) (common.Address, error) {
+	if conduitKey == (common.Hash{}) {
+		return seaport, nil
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	var out []interface{}
+	if err := bind.NewBoundContract(seaport, conduitABI, caller, nil, nil).Call(opts, &out, "information"); err != nil {
+		return common.Address{}, err
+	}
+	controller := *abi.ConvertType(out[2], new(common.Address)).(*common.Address)
+	out = nil
+	if err := bind.NewBoundContract(controller, conduitABI, caller, nil, nil).Call(opts, &out, "getConduit", conduitKey); err != nil {
+		return common.Address{}, err
+	}
+	if exists, _ := out[1].(bool); !exists {
+		return common.Address{}, nil
+	}
+	return *abi.ConvertType(out[0], new(common.Address)).(*common.Address), nil
+}
+
+// Fraction is the share of an order a partial fill takes, as the numerator
+// and denominator of Seaport's AdvancedOrder
+func Fraction(quantity, total uint64) (*big.Int, *big.Int) {
+	if quantity == total {
+		return big.NewInt(1), big.NewInt(1)
+	}
+	return new(big.Int).SetUint64(quantity), new(big.Int).SetUint64(total)
+}
@@ -0,0 +1,176 @@
+package seaport
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// orderJSON is OrderComponents as seaport-js and wallets write it. Numbers
+// may be JSON numbers or decimal or 0x hex strings.
+type orderJSON struct {
+	Offerer string `json:"offerer"`
+	Zone    string `json:"zone"`
+	Offer   []struct {
+		ItemType             number `json:"itemType"`
+		Token                string `json:"token"`
+		IdentifierOrCriteria number `json:"identifierOrCriteria"`
+		StartAmount          number `json:"startAmount"`
+		EndAmount            number `json:"endAmount"`
+	} `json:"offer"`
+	Consideration []struct {
+		ItemType             number `json:"itemType"`
+		Token                string `json:"token"`
+		IdentifierOrCriteria number `json:"identifierOrCriteria"`
+		StartAmount          number `json:"startAmount"`
+		EndAmount            number `json:"endAmount"`
+		Recipient            string `json:"recipient"`
+	} `json:"consideration"`
+	OrderType  number `json:"orderType"`
+	StartTime  number `json:"startTime"`
+	EndTime    number `json:"endTime"`
+	ZoneHash   string `json:"zoneHash"`
+	Salt       number `json:"salt"`
+	ConduitKey string `json:"conduitKey"`
+	Counter    number `json:"counter"`
+}
+
+type number struct {
+	v *big.Int
+}
+
+func (n *number) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		s = string(data)
+	}
+	s = strings.TrimSpace(s)
+	v, ok := new(big.Int), false
+	if hex, found := strings.CutPrefix(strings.ToLower(s), "0x"); found {
+		v, ok = v.SetString(hex, 16)
+	} else {
+		v, ok = v.SetString(s, 10)
+	}
+	if !ok || v.Sign() < 0 || v.BitLen() > 256 {
+		return fmt.Errorf("invalid uint256 %s", data)
+	}
+	n.v = v
+	return nil
+}
+
+// UnmarshalJSON reads the format MarshalJSON writes, rejecting malformed
+// addresses, hashes and numbers rather than zeroing them
+func (o *OrderComponents) UnmarshalJSON(data []byte) error {
+	var raw orderJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	var err error
+	field := func(name string, n number) *big.Int {
+		if n.v == nil && err == nil {
+			err = fmt.Errorf("%s is required", name)
+		}
+		if n.v == nil {
+			return new(big.Int)
+		}
+		return n.v
+	}
+	address := func(name, s string) common.Address {
+		if !common.IsHexAddress(s) && err == nil {
+			err = fmt.Errorf("%s is not an address", name)
+		}
+		return common.HexToAddress(s)
+	}
+	hash := func(name, s string) common.Hash {
+		if s == "" {
+			return common.Hash{}
+		}
+		b, decodeErr := hexutil.Decode(s)
+		if (decodeErr != nil || len(b) != common.HashLength) && err == nil {
+			err = fmt.Errorf("%s is not a bytes32", name)
+		}
+		return common.BytesToHash(b)
+	}
+	enum := func(name string, n number) uint8 {
+		v := field(name, n)
+		if !v.IsUint64() || v.Uint64() > 255 {
+			if err == nil {
+				err = fmt.Errorf("%s is not a uint8", name)
+			}
+			return 0
+		}
+		return uint8(v.Uint64())
+	}
+
+	parsed := OrderComponents{
+		Offerer:    address("offerer", raw.Offerer),
+		Zone:       address("zone", raw.Zone),
+		OrderType:  OrderType(enum("orderType", raw.OrderType)),
+		StartTime:  field("startTime", raw.StartTime),
+		EndTime:    field("endTime", raw.EndTime),
+		ZoneHash:   hash("zoneHash", raw.ZoneHash),
+		Salt:       field("salt", raw.Salt),
+		ConduitKey: hash("conduitKey", raw.ConduitKey),
+		Counter:    field("counter", raw.Counter),
+	}
+	for i, item := range raw.Offer {
+		name := fmt.Sprintf("offer[%d]", i)
+		parsed.Offer = append(parsed.Offer, OfferItem{
+			ItemType:             ItemType(enum(name+".itemType", item.ItemType)),
+			Token:                address(name+".token", item.Token),
+			IdentifierOrCriteria: field(name+".identifierOrCriteria", item.IdentifierOrCriteria),
+			StartAmount:          field(name+".startAmount", item.StartAmount),
+			EndAmount:            field(name+".endAmount", item.EndAmount),
+		})
+	}
+	for i, item := range raw.Consideration {
+		name := fmt.Sprintf("consideration[%d]", i)
+		parsed.Consideration = append(parsed.Consideration, ConsiderationItem{
+			ItemType:             ItemType(enum(name+".itemType", item.ItemType)),
+			Token:                address(name+".token", item.Token),
+			IdentifierOrCriteria: field(name+".identifierOrCriteria", item.IdentifierOrCriteria),
+			StartAmount:          field(name+".startAmount", item.StartAmount),
+			EndAmount:            field(name+".endAmount", item.EndAmount),
+			Recipient:            address(name+".recipient", item.Recipient),
+		})
+	}
+	if err != nil {
+		return err
+	}
+	*o = parsed
+	return nil
+}
+
+// RecoverSigner returns the address that signed digest. Like Seaport and
+// OpenZeppelin's ECDSA it accepts 65 byte signatures only with v as 27 or 28,
+// and 64 byte EIP-2098 compact signatures.
+func RecoverSigner(digest common.Hash, signature []byte) (common.Address, error) {
+	sig := make([]byte, crypto.SignatureLength)
+	switch len(signature) {
+	case crypto.SignatureLength:
+		copy(sig, signature)
+		v := sig[crypto.RecoveryIDOffset]
+		if v != 27 && v != 28 {
+			return common.Address{}, fmt.Errorf("signature v must be 27 or 28, got %d", v)
+		}
+		sig[crypto.RecoveryIDOffset] = v - 27
+	case crypto.SignatureLength - 1:
+		// the top bit of s holds the recovery id
+		copy(sig, signature)
+		sig[crypto.RecoveryIDOffset] = sig[32] >> 7
+		sig[32] &= 0x7f
+	default:
+		return common.Address{}, errors.New("signature must be 64 or 65 bytes")
+	}
+	pub, err := crypto.SigToPub(digest.Bytes(), sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
@@ -0,0 +1,81 @@
+package seaport
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// signWithRecoveryID signs successive digests until the recovery id of the
+// signature is id, so both values of v are covered
+func signWithRecoveryID(t *testing.T, id byte) (common.Hash, []byte, common.Address) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; ; i++ {
+		digest := crypto.Keccak256Hash([]byte{byte(i)})
+		sig, err := crypto.Sign(digest.Bytes(), key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sig[crypto.RecoveryIDOffset] == id {
+			return digest, sig, crypto.PubkeyToAddress(key.PublicKey)
+		}
+	}
+}
+
+func withV(sig []byte, v byte) []byte {
+	out := append([]byte(nil), sig...)
+	out[crypto.RecoveryIDOffset] = v
+	return out
+}
+
+// compact encodes sig as an EIP-2098 signature with the recovery id in the
+// top bit of s
+func compact(sig []byte) []byte {
+	out := append([]byte(nil), sig[:crypto.SignatureLength-1]...)
+	out[32] |= sig[crypto.RecoveryIDOffset] << 7
+	return out
+}
+
+func TestRecoverSigner(t *testing.T) {
+	digest0, sig0, signer0 := signWithRecoveryID(t, 0)
+	digest1, sig1, signer1 := signWithRecoveryID(t, 1)
+
+	tests := []struct {
+		name      string
+		digest    common.Hash
+		signature []byte
+		want      common.Address
+		wantErr   bool
+	}{
+		{name: "v 0", digest: digest0, signature: withV(sig0, 0), wantErr: true},
+		{name: "v 1", digest: digest1, signature: withV(sig1, 1), wantErr: true},
+		{name: "v 27", digest: digest0, signature: withV(sig0, 27), want: signer0},
+		{name: "v 28", digest: digest1, signature: withV(sig1, 28), want: signer1},
+		{name: "v 29", digest: digest0, signature: withV(sig0, 29), wantErr: true},
+		{name: "EIP-2098 with an even y", digest: digest0, signature: compact(sig0), want: signer0},
+		{name: "EIP-2098 with an odd y", digest: digest1, signature: compact(sig1), want: signer1},
+		{name: "short", digest: digest0, signature: sig0[:63], wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RecoverSigner(tt.digest, tt.signature)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("recovered %s, want an error", got.Hex())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("recovered %s, want %s", got.Hex(), tt.want.Hex())
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+// Package seaport builds and hashes Seaport 1.6 orders.
+//
+// Hashes mirror Seaport's own: OrderHash is the EIP-712 struct hash of the
+// order components, which Seaport returns from getOrderHash and emits in its
+// events, and Digest is what the offerer signs.
+package seaport
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Canonical Seaport 1.6 deployment, at the same address on every chain
+const DefaultAddress = "0x0000000000000068F116a894984e2DB1123eB395"
+
+// EIP-712 domain Seaport 1.6 signs orders under
+const (
+	DomainName    = "Seaport"
+	DomainVersion = "1.6"
+)
+
+// ItemType is Seaport's enum ItemType
+type ItemType uint8
+
+const (
+	ItemTypeNative ItemType = iota
+	ItemTypeERC20
+	ItemTypeERC721
+	ItemTypeERC1155
+	// criteria items match any token whose ID is in the merkle root in
+	// IdentifierOrCriteria, or every token of the contract when it is zero;
+	// fulfillers name the token with a CriteriaResolver
+	ItemTypeERC721WithCriteria
+	ItemTypeERC1155WithCriteria
+)
+
+// OrderType is Seaport's enum OrderType
+type OrderType uint8
+
+const (
+	OrderTypeFullOpen OrderType = iota
+	OrderTypePartialOpen
+	// restricted orders call the zone's authorizeOrder before any transfer
+	OrderTypeFullRestricted
+	OrderTypePartialRestricted
+)
+
+type OfferItem struct {
+	ItemType             ItemType
+	Token                common.Address
+	IdentifierOrCriteria *big.Int
+	StartAmount          *big.Int
+	EndAmount            *big.Int
+}
+
+type ConsiderationItem struct {
+	ItemType             ItemType
+	Token                common.Address
+	IdentifierOrCriteria *big.Int
+	StartAmount          *big.Int
+	EndAmount            *big.Int
+	Recipient            common.Address
+}
+
+// OrderComponents is the signed part of an order. Fulfillers rebuild
+// OrderParameters from it, with totalOriginalConsiderationItems set to
+// len(Consideration).
+type OrderComponents struct {
+	Offerer       common.Address
+	Zone          common.Address
+	Offer         []OfferItem
+	Consideration []ConsiderationItem
+	OrderType     OrderType
+	StartTime     *big.Int
+	EndTime       *big.Int
+	ZoneHash      common.Hash
+	Salt          *big.Int
+	ConduitKey    common.Hash
+	Counter       *big.Int
+}
+
+const (
+	offerItemType         = "OfferItem(uint8 itemType,address token,uint256 identifierOrCriteria,uint256 startAmount,uint256 endAmount)"
+	considerationItemType = "ConsiderationItem(uint8 itemType,address token,uint256 identifierOrCriteria,uint256 startAmount,uint256 endAmount,address recipient)"
+	orderComponentsType   = "OrderComponents(address offerer,address zone,OfferItem[] offer,ConsiderationItem[] consideration,uint8 orderType,uint256 startTime,uint256 endTime,bytes32 zoneHash,uint256 salt,bytes32 conduitKey,uint256 counter)"
+)
+
+var (
+	eip712DomainTypeHash      = crypto.Keccak256Hash([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+	offerItemTypeHash         = crypto.Keccak256Hash([]byte(offerItemType))
+	considerationItemTypeHash = crypto.Keccak256Hash([]byte(considerationItemType))
+	// referenced structs follow the primary type in alphabetical order
+	orderComponentsTypeHash = crypto.Keccak256Hash([]byte(orderComponentsType + considerationItemType + offerItemType))
+
+	bytes32Type, _ = abi.NewType("bytes32", "", nil)
+	uint8Type, _   = abi.NewType("uint8", "", nil)
+	uint256Type, _ = abi.NewType("uint256", "", nil)
+	addressType, _ = abi.NewType("address", "", nil)
+
+	counterABI, _ = abi.JSON(strings.NewReader(`[{"type":"function","name":"getCounter","stateMutability":"view","inputs":[{"name":"offerer","type":"address"}],"outputs":[{"name":"counter","type":"uint256"}]}]`))
+)
+
+// DomainSeparator is the EIP-712 domain separator of the Seaport at verifyingContract
+func DomainSeparator(chainID *big.Int, verifyingContract common.Address) common.Hash {
+	encoded, _ := abi.Arguments{{Type: bytes32Type}, {Type: bytes32Type}, {Type: bytes32Type}, {Type: uint256Type}, {Type: addressType}}.Pack(
+		eip712DomainTypeHash,
+		crypto.Keccak256Hash([]byte(DomainName)),
+		crypto.Keccak256Hash([]byte(DomainVersion)),
+		chainID,
+		verifyingContract,
+	)
+	return crypto.Keccak256Hash(encoded)
+}
+
+// OrderHash mirrors Seaport's _deriveOrderHash
+func OrderHash(o OrderComponents) common.Hash {
+	offerHashes := make([]byte, 0, len(o.Offer)*common.HashLength)
+	for _, item := range o.Offer {
+		encoded, _ := abi.Arguments{{Type: bytes32Type}, {Type: uint8Type}, {Type: addressType}, {Type: uint256Type}, {Type: uint256Type}, {Type: uint256Type}}.Pack(
+			offerItemTypeHash, uint8(item.ItemType), item.Token, item.IdentifierOrCriteria, item.StartAmount, item.EndAmount,
+		)
+		offerHashes = append(offerHashes, crypto.Keccak256(encoded)...)
+	}
+	considerationHashes := make([]byte, 0, len(o.Consideration)*common.HashLength)
+	for _, item := range o.Consideration {
+		encoded, _ := abi.Arguments{{Type: bytes32Type}, {Type: uint8Type}, {Type: addressType}, {Type: uint256Type}, {Type: uint256Type}, {Type: uint256Type}, {Type: addressType}}.Pack(
+			considerationItemTypeHash, uint8(item.ItemType), item.Token, item.IdentifierOrCriteria, item.StartAmount, item.EndAmount, item.Recipient,
+		)
+		considerationHashes = append(considerationHashes, crypto.Keccak256(encoded)...)
+	}
+	encoded, _ := abi.Arguments{
+		{Type: bytes32Type}, {Type: addressType}, {Type: addressType}, {Type: bytes32Type}, {Type: bytes32Type},
+		{Type: uint8Type}, {Type: uint256Type}, {Type: uint256Type}, {Type: bytes32Type}, {Type: uint256Type}, {Type: bytes32Type}, {Type: uint256Type},
+	}.Pack(
+		orderComponentsTypeHash,
+		o.Offerer,
+		o.Zone,
+		crypto.Keccak256Hash(offerHashes),
+		crypto.Keccak256Hash(considerationHashes),
+		uint8(o.OrderType),
+		o.StartTime,
+		o.EndTime,
+		o.ZoneHash,
+		o.Salt,
+		o.ConduitKey,
+		o.Counter,
+	)
+	return crypto.Keccak256Hash(encoded)
+}
+
+// Digest is the hash the offerer signs for an order with orderHash
+func Digest(chainID *big.Int, verifyingContract common.Address, orderHash common.Hash) common.Hash {
+	domain := DomainSeparator(chainID, verifyingContract)
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, domain.Bytes(), orderHash.Bytes())
+}
+
+// TypedData describes o as eth_signTypedData_v4 input
+func TypedData(chainID *big.Int, verifyingContract common.Address, o OrderComponents) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"OrderComponents": {
+				{Name: "offerer", Type: "address"},
+				{Name: "zone", Type: "address"},
+				{Name: "offer", Type: "OfferItem[]"},
+				{Name: "consideration", Type: "ConsiderationItem[]"},
+				{Name: "orderType", Type: "uint8"},
+				{Name: "startTime", Type: "uint256"},
+				{Name: "endTime", Type: "uint256"},
+				{Name: "zoneHash", Type: "bytes32"},
+				{Name: "salt", Type: "uint256"},
+				{Name: "conduitKey", Type: "bytes32"},
+				{Name: "counter", Type: "uint256"},
+			},
+			"OfferItem": {
+				{Name: "itemType", Type: "uint8"},
+				{Name: "token", Type: "address"},
+				{Name: "identifierOrCriteria", Type: "uint256"},
+				{Name: "startAmount", Type: "uint256"},
+				{Name: "endAmount", Type: "uint256"},
+			},
+			"ConsiderationItem": {
+				{Name: "itemType", Type: "uint8"},
+				{Name: "token", Type: "address"},
+				{Name: "identifierOrCriteria", Type: "uint256"},
+				{Name: "startAmount", Type: "uint256"},
+				{Name: "endAmount", Type: "uint256"},
+				{Name: "recipient", Type: "address"},
+			},
+		},
+		PrimaryType: "OrderComponents",
+		Domain: apitypes.TypedDataDomain{
+			Name:              DomainName,
+			Version:           DomainVersion,
+			ChainId:           (*math.HexOrDecimal256)(chainID),
+			VerifyingContract: verifyingContract.Hex(),
+		},
+		Message: o.message(),
+	}
+}
+
+// MarshalJSON writes the components the way seaport-js and the typed data
+// message spell them, with amounts as decimal strings
+func (o OrderComponents) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.message())
+}
+
+// message uses float64 for the enums, the type JSON decodes numbers to,
+// which apitypes accepts for uint8
+func (o OrderComponents) message() apitypes.TypedDataMessage {
+	offer := make([]interface{}, len(o.Offer))
+	for i, item := range o.Offer {
+		offer[i] = map[string]interface{}{
+			"itemType":             float64(item.ItemType),
+			"token":                item.Token.Hex(),
+			"identifierOrCriteria": item.IdentifierOrCriteria.String(),
+			"startAmount":          item.StartAmount.String(),
+			"endAmount":            item.EndAmount.String(),
+		}
+	}
+	consideration := make([]interface{}, len(o.Consideration))
+	for i, item := range o.Consideration {
+		consideration[i] = map[string]interface{}{
+			"itemType":             float64(item.ItemType),
+			"token":                item.Token.Hex(),
+			"identifierOrCriteria": item.IdentifierOrCriteria.String(),
+			"startAmount":          item.StartAmount.String(),
+			"endAmount":            item.EndAmount.String(),
+			"recipient":            item.Recipient.Hex(),
+		}
+	}
+	return apitypes.TypedDataMessage{
+		"offerer":       o.Offerer.Hex(),
+		"zone":          o.Zone.Hex(),
+		"offer":         offer,
+		"consideration": consideration,
+		"orderType":     float64(o.OrderType),
+		"startTime":     o.StartTime.String(),
+		"endTime":       o.EndTime.String(),
+		"zoneHash":      o.ZoneHash.Hex(),
+		"salt":          o.Salt.String(),
+		"conduitKey":    o.ConduitKey.Hex(),
+		"counter":       o.Counter.String(),
+	}
+}
+
+// GetCounter reads the offerer's current counter; orders signed under an
+// older counter were cancelled by incrementCounter
+func GetCounter(ctx context.Context, caller bind.ContractCaller, seaport, offerer common.Address) (*big.Int, error) {
+	contract := bind.NewBoundContract(seaport, counterABI, caller, nil, nil)
+	var out []interface{}
+	if err := contract.Call(&bind.CallOpts{Context: ctx}, &out, "getCounter", offerer); err != nil {
+		return nil, err
+	}
+	return abi.ConvertType(out[0], new(big.Int)).(*big.Int), nil
+}
@@ -0,0 +1,16 @@
+#!/usr/bin/env bash
+# Regenerates the contract bindings from the Hardhat artifacts and fails if
+# the result differs from what is committed. Run from anywhere in the repo.
+set -euo pipefail
+
+cd "$(dirname "$0")/.."
+
+go generate ./pkg/contracts
+go build ./pkg/contracts
+
+if ! git diff --quiet --exit-code -- pkg/contracts || [ -n "$(git status --porcelain -- pkg/contracts)" ]; then
+	echo "contract bindings are out of date; run 'go generate ./pkg/contracts' and commit the result" >&2
+	git status --short -- pkg/contracts >&2
+	exit 1
+fi
+echo "contract bindings are up to date"